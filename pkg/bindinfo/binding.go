@@ -48,6 +48,8 @@ const (
 	Builtin = "builtin"
 	// History indicate the binding is created from statement summary by plan digest
 	History = "history"
+	// Evolve indicates the binding is promoted by the baseline evolution.
+	Evolve = "evolve"
 )
 
 // Binding stores the basic bind hint info.
@@ -0,0 +1,332 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/bindinfo/internal/logutil"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// evolveTaskPending is the status of the evolve task which is not verified yet.
+	evolveTaskPending = "pending"
+	// evolveTaskAccepted is the status of the evolve task whose plan is promoted to the binding.
+	evolveTaskAccepted = "accepted"
+	// evolveTaskRejected is the status of the evolve task whose plan is not faster than the binding.
+	evolveTaskRejected = "rejected"
+	// evolveTaskFailed is the status of the evolve task which can't be verified.
+	evolveTaskFailed = "failed"
+
+	// acceptFactor is the factor to decide should we accept the pending verified plan.
+	// A pending verified plan will be accepted if it performs at least `acceptFactor` times better than the accepted plans.
+	acceptFactor = 1.5
+	// evolveRunTimes is the times a plan is run in the verification, the shortest execution time is used.
+	evolveRunTimes = 3
+	// minEvolvePlanTime is the min execution time of the binding to verify an evolve task. The difference of
+	// faster plans can't be told apart from the noise.
+	minEvolvePlanTime = 100 * time.Millisecond
+)
+
+// evolveTaskCache is used to store the evolve tasks temporarily.
+type evolveTaskCache struct {
+	mu sync.Mutex
+	m  map[string]Binding // key: sqlDigest + planDigest
+}
+
+func newEvolveTaskCache() *evolveTaskCache {
+	return &evolveTaskCache{
+		m: make(map[string]Binding),
+	}
+}
+
+func (c *evolveTaskCache) add(task Binding) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Keep the first one like the storage does.
+	if _, ok := c.m[task.SQLDigest+task.PlanDigest]; !ok {
+		c.m[task.SQLDigest+task.PlanDigest] = task
+	}
+}
+
+// take returns all the tasks in the cache and resets it.
+func (c *evolveTaskCache) take() Bindings {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tasks := make(Bindings, 0, len(c.m))
+	for _, task := range c.m {
+		tasks = append(tasks, task)
+	}
+	c.m = make(map[string]Binding)
+	return tasks
+}
+
+// AddEvolveTask adds a plan to be verified against the binding of the statement. The BindSQL of the task is the
+// statement with the hints of the plan, and the PlanDigest is the digest of the plan.
+func (h *globalBindingHandle) AddEvolveTask(task Binding) {
+	task.SQLDigest = parser.DigestNormalized(task.OriginalSQL).String()
+	h.evolveTasks.add(task)
+}
+
+// SaveEvolveTasksToStorage saves the evolve tasks in memory to mysql.tidb_binding_evolve_tasks. The tasks already
+// in the storage are kept, so a plan is only verified once for a statement.
+func (h *globalBindingHandle) SaveEvolveTasksToStorage() {
+	tasks := h.evolveTasks.take()
+	if len(tasks) == 0 {
+		return
+	}
+	now := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3).String()
+	err := h.callWithSCtx(false, func(sctx sessionctx.Context) error {
+		for _, task := range tasks {
+			_, err := exec(sctx, `INSERT IGNORE INTO mysql.tidb_binding_evolve_tasks (sql_digest, plan_digest, original_sql,
+				bind_sql, default_db, charset, collation, status, create_time, update_time) VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+				task.SQLDigest, task.PlanDigest, task.OriginalSQL, task.BindSQL, task.Db, task.Charset, task.Collation,
+				evolveTaskPending, now, now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logutil.BindLogger().Warn("save evolve tasks failed", zap.Error(err))
+	}
+}
+
+// HandleEvolveTasks verifies the pending evolve tasks. Only one task is verified each time unless it's called
+// by ADMIN EVOLVE BINDINGS, which also ignores the time window of the evolution.
+func (h *globalBindingHandle) HandleEvolveTasks(adminEvolve bool) error {
+	// Save the tasks of this instance first, so ADMIN EVOLVE BINDINGS verifies them right away.
+	h.SaveEvolveTasksToStorage()
+	for {
+		handled, err := h.handleEvolveTask(adminEvolve)
+		if err != nil || !handled || !adminEvolve {
+			return err
+		}
+	}
+}
+
+// handleEvolveTask verifies the earliest pending evolve task, and returns whether there is a task verified.
+func (h *globalBindingHandle) handleEvolveTask(adminEvolve bool) (handled bool, err error) {
+	err = h.callWithSCtx(false, func(sctx sessionctx.Context) error {
+		params, err := getEvolveParameters(sctx)
+		if err != nil {
+			return err
+		}
+		if params.maxTime == 0 || (!adminEvolve && !timeutil.WithinDayTimePeriod(params.startTime, params.endTime, time.Now())) {
+			return nil
+		}
+		rows, _, err := execRows(sctx, `SELECT sql_digest, plan_digest, original_sql, bind_sql, default_db, charset, collation
+			FROM mysql.tidb_binding_evolve_tasks WHERE status = %? ORDER BY create_time LIMIT 1`, evolveTaskPending)
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+		handled = true
+		task := Binding{
+			SQLDigest:   rows[0].GetString(0),
+			PlanDigest:  rows[0].GetString(1),
+			OriginalSQL: rows[0].GetString(2),
+			BindSQL:     rows[0].GetString(3),
+			Db:          rows[0].GetString(4),
+			Charset:     rows[0].GetString(5),
+			Collation:   rows[0].GetString(6),
+			Status:      Enabled,
+			Source:      Evolve,
+		}
+		status, currentTime, candidateTime, message := h.verifyEvolveTask(sctx, &task, params)
+		if status == evolveTaskAccepted {
+			// We don't need to pass the `sctx` because the BindSQL has been run by the verification.
+			if err := h.CreateGlobalBinding(nil, task); err != nil {
+				status, message = evolveTaskFailed, err.Error()
+			}
+		}
+		logutil.BindLogger().Info("evolve task verified", zap.String("sqlDigest", task.SQLDigest),
+			zap.String("planDigest", task.PlanDigest), zap.String("status", status),
+			zap.Duration("currentPlanTime", currentTime), zap.Duration("candidatePlanTime", candidateTime), zap.String("message", message))
+
+		updateTs := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3).String()
+		_, err = exec(sctx, `UPDATE mysql.tidb_binding_evolve_tasks SET status = %?, current_plan_time = %?,
+			candidate_plan_time = %?, message = %?, update_time = %? WHERE sql_digest = %? AND plan_digest = %?`,
+			status, planTimeForStorage(currentTime), planTimeForStorage(candidateTime), message, updateTs,
+			task.SQLDigest, task.PlanDigest)
+		return err
+	})
+	return handled, err
+}
+
+// verifyEvolveTask runs the statement of the task with the plan of the binding and the plan of the task, and
+// decides whether the plan of the task is accepted. The execution time is -1 if the statement times out, and 0 if
+// it's not run.
+func (h *globalBindingHandle) verifyEvolveTask(sctx sessionctx.Context, task *Binding, params *evolveParameters) (status string, currentTime, candidateTime time.Duration, message string) {
+	hasEnabledBinding := false
+	for _, binding := range h.getCache().GetBinding(task.SQLDigest) {
+		hasEnabledBinding = hasEnabledBinding || binding.IsBindingEnabled()
+	}
+	if !hasEnabledBinding {
+		return evolveTaskFailed, 0, 0, "the binding of the statement is dropped or disabled"
+	}
+
+	vars := sctx.GetSessionVars()
+	origUsePlanBaselines, origEvolvePlanBaselines := vars.UsePlanBaselines, vars.EvolvePlanBaselines
+	origResourceGroupName, origCurrentDB := vars.ResourceGroupName, vars.CurrentDB
+	defer func() {
+		vars.UsePlanBaselines, vars.EvolvePlanBaselines = origUsePlanBaselines, origEvolvePlanBaselines
+		vars.ResourceGroupName, vars.CurrentDB = origResourceGroupName, origCurrentDB
+	}()
+	// The statements are run in the resource group of the evolution, so they don't take up the resources of
+	// the user workload.
+	vars.ResourceGroupName = params.resourceGroup
+	vars.EvolvePlanBaselines = false
+	vars.CurrentDB = task.Db
+
+	// The hints in the BindSQL are replaced by the binding when the bindings are used.
+	vars.UsePlanBaselines = true
+	currentTime, err := measureEvolveSQL(sctx, task.BindSQL, params.maxTime)
+	if err != nil {
+		return evolveTaskFailed, 0, 0, err.Error()
+	}
+	failpoint.Inject("mockCandidatePlanFaster", func() {
+		currentTime = max(currentTime, minEvolvePlanTime)
+	})
+	if currentTime > 0 && currentTime < minEvolvePlanTime {
+		return evolveTaskRejected, currentTime, 0, "the binding runs too fast to compare the plans"
+	}
+	// If the plan of the binding times out, the plan of the task is accepted if it finishes in the max time.
+	maxTime := params.maxTime
+	if currentTime > 0 {
+		maxTime = time.Duration(float64(currentTime) / acceptFactor)
+	}
+	vars.UsePlanBaselines = false
+	candidateTime, err = measureEvolveSQL(sctx, task.BindSQL, maxTime)
+	if err != nil {
+		return evolveTaskFailed, currentTime, 0, err.Error()
+	}
+	failpoint.Inject("mockCandidatePlanFaster", func(val failpoint.Value) {
+		if val.(bool) {
+			candidateTime = time.Duration(float64(currentTime) / acceptFactor / 2)
+		} else {
+			candidateTime = -1
+		}
+	})
+	if candidateTime < 0 {
+		return evolveTaskRejected, currentTime, candidateTime, "the plan is not faster than the binding"
+	}
+	return evolveTaskAccepted, currentTime, candidateTime, ""
+}
+
+// measureEvolveSQL runs the statement evolveRunTimes times and returns the shortest execution time. It returns -1
+// once a run doesn't finish in maxTime.
+func measureEvolveSQL(sctx sessionctx.Context, sql string, maxTime time.Duration) (time.Duration, error) {
+	var minTime time.Duration
+	for i := 0; i < evolveRunTimes; i++ {
+		d, err := runEvolveSQL(sctx, sql, maxTime)
+		if err != nil || d < 0 {
+			return d, err
+		}
+		if i == 0 || d < minTime {
+			minTime = d
+		}
+	}
+	return minTime, nil
+}
+
+// runEvolveSQL runs the statement to the end and returns the execution time. It returns -1 if the statement
+// doesn't finish in maxTime, and a negative maxTime means no limit.
+func runEvolveSQL(sctx sessionctx.Context, sql string, maxTime time.Duration) (time.Duration, error) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	if maxTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, maxTime)
+		defer cancel()
+	}
+	start := time.Now()
+	rs, err := sctx.GetSQLExecutor().ExecuteInternal(ctx, sql)
+	if rs != nil {
+		chk := rs.NewChunk(nil)
+		for err == nil {
+			if err = rs.Next(ctx, chk); chk.NumRows() == 0 {
+				break
+			}
+		}
+		terror.Call(rs.Close)
+	}
+	// The error of the statement is kept unless it's caused by the timeout.
+	if ctx.Err() != nil && (err == nil || terror.ErrorEqual(err, context.DeadlineExceeded) || terror.ErrorEqual(err, context.Canceled)) {
+		return -1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// planTimeForStorage converts the execution time of a plan to the seconds saved in mysql.tidb_binding_evolve_tasks.
+func planTimeForStorage(d time.Duration) any {
+	if d == 0 {
+		return nil
+	}
+	if d < 0 {
+		return -1
+	}
+	return d.Seconds()
+}
+
+type evolveParameters struct {
+	// maxTime is the max execution time of the statements, 0 means the evolution is disabled, and a negative value
+	// means no limit.
+	maxTime            time.Duration
+	startTime, endTime time.Time
+	resourceGroup      string
+}
+
+func getEvolveParameters(sctx sessionctx.Context) (*evolveParameters, error) {
+	globalVars := sctx.GetSessionVars().GlobalVarsAccessor
+	maxTimeStr, err := globalVars.GetGlobalSysVar(variable.TiDBEvolvePlanTaskMaxTime)
+	if err != nil {
+		return nil, err
+	}
+	maxTime, err := strconv.ParseInt(maxTimeStr, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	params := &evolveParameters{maxTime: time.Duration(maxTime) * time.Second}
+	for name, t := range map[string]*time.Time{
+		variable.TiDBEvolvePlanTaskStartTime: &params.startTime,
+		variable.TiDBEvolvePlanTaskEndTime:   &params.endTime,
+	} {
+		val, err := globalVars.GetGlobalSysVar(name)
+		if err != nil {
+			return nil, err
+		}
+		if *t, err = time.ParseInLocation(variable.FullDayTimeFormat, val, time.UTC); err != nil {
+			return nil, err
+		}
+	}
+	params.resourceGroup, err = globalVars.GetGlobalSysVar(variable.TiDBEvolvePlanTaskResourceGroup)
+	return params, err
+}
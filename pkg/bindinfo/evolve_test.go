@@ -0,0 +1,101 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvolveBindings(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, key ia(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("create global binding for select * from t where a = 1 using select * from t ignore index(ia) where a = 1")
+
+	// the task is not added if the evolution is disabled.
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 1"))
+	dom.BindHandle().SaveEvolveTasksToStorage()
+	tk.MustQuery("show binding evolve tasks").Check(testkit.Rows())
+
+	// the evolution is not generally available.
+	tk.MustGetErrMsg("set @@tidb_evolve_plan_baselines = 1", "Cannot enable baseline evolution feature, it is not generally available now")
+	originalVal := config.CheckTableBeforeDrop
+	config.CheckTableBeforeDrop = true
+	defer func() {
+		config.CheckTableBeforeDrop = originalVal
+	}()
+	tk.MustExec("set @@tidb_evolve_plan_baselines = 1")
+	// the task is not added for the locking read.
+	tk.MustExec("create global binding for select * from t where a = 1 for update using select * from t ignore index(ia) where a = 1 for update")
+	tk.MustQuery("select * from t where a = 1 for update").Check(testkit.Rows("1 1"))
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	dom.BindHandle().SaveEvolveTasksToStorage()
+	tk.MustQuery("show binding evolve tasks").Check(testkit.Rows())
+	tk.MustExec("drop global binding for select * from t where a = 1 for update")
+
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 1"))
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	// the plan of the same digest is only added once.
+	tk.MustQuery("select * from t where a = 2").Check(testkit.Rows("2 2"))
+	dom.BindHandle().SaveEvolveTasksToStorage()
+	candidate := "SELECT /*+ use_index(@`sel_1` `test`.`t` `ia`), no_order_index(@`sel_1` `test`.`t` `ia`)*/ * FROM `test`.`t` WHERE `a` = 1"
+	tk.MustQuery("select original_sql, default_db, bind_sql, status from mysql.tidb_binding_evolve_tasks").Check(testkit.Rows(
+		"select * from `test` . `t` where `a` = ? test " + candidate + " pending"))
+
+	// the task is not verified if the max time is 0.
+	tk.MustExec("set global tidb_evolve_plan_task_max_time = 0")
+	tk.MustExec("admin evolve bindings")
+	tk.MustQuery("select status from mysql.tidb_binding_evolve_tasks").Check(testkit.Rows("pending"))
+	tk.MustExec("set global tidb_evolve_plan_task_max_time = default")
+
+	// the task is rejected if the binding runs too fast to compare the plans.
+	tk.MustExec("admin evolve bindings")
+	tk.MustQuery("show binding evolve tasks").CheckAt([]int{3, 6}, [][]any{
+		{"rejected", "the binding runs too fast to compare the plans"}})
+	tk.MustExec("update mysql.tidb_binding_evolve_tasks set status = 'pending'")
+
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/bindinfo/mockCandidatePlanFaster", "return(false)"))
+	tk.MustExec("admin evolve bindings")
+	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/bindinfo/mockCandidatePlanFaster"))
+	tk.MustQuery("show binding evolve tasks").CheckAt([]int{2, 3, 5, 6}, [][]any{
+		{candidate, "rejected", "-1", "the plan is not faster than the binding"}})
+	tk.MustQuery("select bind_sql, source from mysql.bind_info where source != 'builtin' and status = 'enabled'").Check(testkit.Rows(
+		"SELECT * FROM `test`.`t` IGNORE INDEX (`ia`) WHERE `a` = 1 manual"))
+
+	tk.MustExec("update mysql.tidb_binding_evolve_tasks set status = 'pending'")
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/bindinfo/mockCandidatePlanFaster", "return(true)"))
+	tk.MustExec("admin evolve bindings")
+	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/bindinfo/mockCandidatePlanFaster"))
+	tk.MustQuery("show binding evolve tasks where status = 'accepted'").CheckAt([]int{2, 3, 6}, [][]any{{candidate, "accepted", ""}})
+	tk.MustQuery("select bind_sql, source from mysql.bind_info where source != 'builtin' and status = 'enabled'").Check(testkit.Rows(
+		candidate + " evolve"))
+	tk.MustExec("set @@tidb_evolve_plan_baselines = 0")
+	tk.MustQuery("explain format='brief' select * from t where a = 3").CheckContain("IndexLookUp")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+
+	// the task fails if the binding is dropped.
+	tk.MustExec("update mysql.tidb_binding_evolve_tasks set status = 'pending'")
+	tk.MustExec("drop global binding for select * from t where a = 1")
+	tk.MustExec("admin evolve bindings")
+	tk.MustQuery("show binding evolve tasks").CheckAt([]int{3, 6}, [][]any{
+		{"failed", "the binding of the statement is dropped or disabled"}})
+}
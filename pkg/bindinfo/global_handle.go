@@ -104,6 +104,19 @@ type GlobalBindingHandle interface {
 	// CaptureBaselines is used to automatically capture plan baselines.
 	CaptureBaselines()
 
+	// Methods for Baseline Evolution.
+
+	// AddEvolveTask adds a plan to be verified against the binding of the statement, the binding is promoted
+	// if the plan runs faster. The tasks are kept in memory until SaveEvolveTasksToStorage is called.
+	AddEvolveTask(task Binding)
+
+	// SaveEvolveTasksToStorage saves the evolve tasks in memory to mysql.tidb_binding_evolve_tasks.
+	SaveEvolveTasksToStorage()
+
+	// HandleEvolveTasks verifies the pending evolve tasks. Only one task is verified each time unless it's called
+	// by ADMIN EVOLVE BINDINGS, which also ignores the time window of the evolution.
+	HandleEvolveTasks(adminEvolve bool) error
+
 	variable.Statistics
 }
 
@@ -123,6 +136,9 @@ type globalBindingHandle struct {
 
 	// syncBindingSingleflight is used to synchronize the execution of `LoadFromStorageToCache` method.
 	syncBindingSingleflight singleflight.Group
+
+	// evolveTasks indicates the evolve tasks added on this instance, which are not saved to the storage yet.
+	evolveTasks *evolveTaskCache
 }
 
 // Lease influences the duration of loading bind info and handling invalid bind.
@@ -168,6 +184,7 @@ func (h *globalBindingHandle) setCache(c FuzzyBindingCache) {
 func (h *globalBindingHandle) Reset() {
 	h.lastUpdateTime.Store(types.ZeroTimestamp)
 	h.invalidBindings = newInvalidBindingCache()
+	h.evolveTasks = newEvolveTaskCache()
 	h.setCache(newFuzzyBindingCache(h.LoadBindingsFromStorage))
 	variable.RegisterStatistics(h)
 }
@@ -637,6 +654,7 @@ func (h *globalBindingHandle) Clear() {
 	h.setCache(newFuzzyBindingCache(h.LoadBindingsFromStorage))
 	h.setLastUpdateTime(types.ZeroTimestamp)
 	h.invalidBindings.reset()
+	h.evolveTasks.take()
 }
 
 // FlushGlobalBindings flushes the Bindings in temp maps to storage and loads them into cache.
@@ -85,6 +85,8 @@ const (
 	DefMaxOfStatsLoadQueueSizeLimit = 100000
 	// DefDDLSlowOprThreshold sets log DDL operations whose execution time exceeds the threshold value.
 	DefDDLSlowOprThreshold = 300
+	// DefSlowPrefixOpThreshold sets log scanning or deleting meta keys with a prefix whose execution time exceeds the threshold value.
+	DefSlowPrefixOpThreshold = 1000
 	// DefExpensiveQueryTimeThreshold indicates the time threshold of expensive query.
 	DefExpensiveQueryTimeThreshold = 60
 	// DefExpensiveTxnTimeThreshold indicates the time threshold of expensive txn.
@@ -494,6 +496,11 @@ type Log struct {
 
 	GeneralLogFile string `toml:"general-log-file" json:"general-log-file"`
 
+	// SlowPrefixOpThreshold is the threshold in milliseconds after which
+	// scanning or deleting meta keys with a prefix is logged as slow, 0 means
+	// the log is disabled.
+	SlowPrefixOpThreshold uint64 `toml:"slow-prefix-op-threshold" json:"slow-prefix-op-threshold"`
+
 	// The following items are deprecated. We need to keep them here temporarily
 	// to support the upgrade process. They can be removed in future.
 
@@ -939,19 +946,20 @@ var defaultConf = Config{
 	VersionComment:               "",
 	TiDBReleaseVersion:           "",
 	Log: Log{
-		Level:               "info",
-		Format:              "text",
-		File:                logutil.NewFileLogConfig(logutil.DefaultLogMaxSize),
-		SlowQueryFile:       "tidb-slow.log",
-		SlowThreshold:       logutil.DefaultSlowThreshold,
-		ExpensiveThreshold:  10000, // ExpensiveThreshold is deprecated.
-		DisableErrorStack:   nbUnset,
-		EnableErrorStack:    nbUnset, // If both options are nbUnset, getDisableErrorStack() returns true
-		EnableTimestamp:     nbUnset,
-		DisableTimestamp:    nbUnset, // If both options are nbUnset, getDisableTimestamp() returns false
-		QueryLogMaxLen:      logutil.DefaultQueryLogMaxLen,
-		RecordPlanInSlowLog: logutil.DefaultRecordPlanInSlowLog,
-		EnableSlowLog:       *NewAtomicBool(logutil.DefaultTiDBEnableSlowLog),
+		Level:                 "info",
+		Format:                "text",
+		File:                  logutil.NewFileLogConfig(logutil.DefaultLogMaxSize),
+		SlowQueryFile:         "tidb-slow.log",
+		SlowThreshold:         logutil.DefaultSlowThreshold,
+		ExpensiveThreshold:    10000, // ExpensiveThreshold is deprecated.
+		DisableErrorStack:     nbUnset,
+		EnableErrorStack:      nbUnset, // If both options are nbUnset, getDisableErrorStack() returns true
+		EnableTimestamp:       nbUnset,
+		DisableTimestamp:      nbUnset, // If both options are nbUnset, getDisableTimestamp() returns false
+		QueryLogMaxLen:        logutil.DefaultQueryLogMaxLen,
+		RecordPlanInSlowLog:   logutil.DefaultRecordPlanInSlowLog,
+		EnableSlowLog:         *NewAtomicBool(logutil.DefaultTiDBEnableSlowLog),
+		SlowPrefixOpThreshold: DefSlowPrefixOpThreshold,
 	},
 	Instance: Instance{
 		TiDBGeneralLog:              false,
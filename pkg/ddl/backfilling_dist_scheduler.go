@@ -46,6 +46,18 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// BackfillSubtaskMaxRetry is the max retry count of failed backfill subtasks.
+	// The index KVs written by a subtask are the same each time it runs, so it's
+	// safe to retry it on another node, such as when it fails because the node
+	// runs out of memory or disk.
+	BackfillSubtaskMaxRetry = 3
+	// BackfillSubtaskTimeout is the timeout of running backfill subtasks. It's
+	// large enough for a subtask of a huge table to finish, and only catches
+	// the subtasks which hang, such as when the node is stuck on the disk.
+	BackfillSubtaskTimeout = 24 * time.Hour
+)
+
 // BackfillingSchedulerExt is an extension of litBackfillScheduler, exported for test.
 type BackfillingSchedulerExt struct {
 	d          *ddl
@@ -197,6 +197,7 @@ func TestBackfillingSchedulerGlobalSortMode(t *testing.T) {
 	sortStepMetaBytes, err := json.Marshal(sortStepMeta)
 	require.NoError(t, err)
 	for _, s := range gotSubtasks {
+		require.NoError(t, mgr.StartSubtask(ctx, s.ID, s.ExecID))
 		require.NoError(t, mgr.FinishSubtask(ctx, s.ExecID, s.ID, sortStepMetaBytes))
 	}
 	// 2. to merge-sort stage.
@@ -237,6 +238,7 @@ func TestBackfillingSchedulerGlobalSortMode(t *testing.T) {
 	mergeSortStepMetaBytes, err := json.Marshal(mergeSortStepMeta)
 	require.NoError(t, err)
 	for _, s := range gotSubtasks {
+		require.NoError(t, mgr.StartSubtask(ctx, s.ID, s.ExecID))
 		require.NoError(t, mgr.FinishSubtask(ctx, s.ExecID, s.ID, mergeSortStepMetaBytes))
 	}
 	// 3. to write&ingest stage.
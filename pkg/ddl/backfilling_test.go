@@ -27,6 +27,7 @@ import (
 	contextutil "github.com/pingcap/tidb/pkg/util/context"
 	"github.com/pingcap/tidb/pkg/util/mock"
 	"github.com/stretchr/testify/require"
+	kvutil "github.com/tikv/client-go/v2/util"
 )
 
 func TestDoneTaskKeeper(t *testing.T) {
@@ -190,3 +191,12 @@ func TestReorgDistSQLCtx(t *testing.T) {
 
 	require.Equal(t, ctx1, ctx2)
 }
+
+func TestReorgSessCtxRequestSourceType(t *testing.T) {
+	sctx := newReorgSessCtx(&mockStorage{client: &mock.Client{}})
+	restore := restoreSessCtx(sctx)
+	require.NoError(t, initSessCtx(sctx, &model.DDLReorgMeta{}))
+	require.Equal(t, kvutil.ExplicitTypeDDL, sctx.GetSessionVars().ExplicitRequestSourceType)
+	restore(sctx)
+	require.Empty(t, sctx.GetSessionVars().ExplicitRequestSourceType)
+}
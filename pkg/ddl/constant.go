@@ -90,6 +90,7 @@ const (
 		ordinal int,
 		error BLOB,
 		summary json,
+		retry_count int default 0,
 		key idx_task_key(task_key),
 		key idx_exec_id(exec_id),
 		unique uk_task_key_step_ordinal(task_key, step, ordinal)
@@ -115,6 +116,7 @@ const (
 		ordinal int,
 		error BLOB,
 		summary json,
+		retry_count int default 0,
 		key idx_task_key(task_key),
 		key idx_state_update_time(state_update_time))`
 )
@@ -768,11 +768,15 @@ func newDDL(ctx context.Context, options ...Option) *ddl {
 		},
 	)
 
-	scheduler.RegisterSchedulerFactory(proto.Backfill,
+	if err := scheduler.RegisterSchedulerFactory(proto.Backfill,
 		func(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
 			return newLitBackfillScheduler(ctx, d, task, param)
-		})
+		}); err != nil {
+		logutil.DDLLogger().Error("register backfill scheduler factory failed", zap.Error(err))
+	}
 	scheduler.RegisterSchedulerCleanUpFactory(proto.Backfill, newBackfillCleanUpS3)
+	scheduler.RegisterSubtaskMaxRetry(proto.Backfill, BackfillSubtaskMaxRetry)
+	scheduler.RegisterSubtaskTimeout(proto.Backfill, BackfillSubtaskTimeout)
 	// Register functions for enable/disable ddl when changing system variable `tidb_enable_ddl`.
 	variable.EnableDDL = d.EnableDDL
 	variable.DisableDDL = d.DisableDDL
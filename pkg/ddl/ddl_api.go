@@ -3575,6 +3575,15 @@ func SetDirectResourceGroupSettings(groupInfo *model.ResourceGroupInfo, opt *ast
 				return err
 			}
 		}
+	case ast.ResourceGroupAdmissionTimeout:
+		dur, err := time.ParseDuration(opt.StrValue)
+		if err != nil {
+			return err
+		}
+		if dur < 0 {
+			return errors.Errorf("invalid admission timeout %s", opt.StrValue)
+		}
+		resourceGroupSettings.AdmissionTimeoutMs = uint64(dur.Milliseconds())
 	default:
 		return errors.Trace(errors.New("unknown resource unit type"))
 	}
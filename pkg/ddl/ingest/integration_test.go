@@ -17,7 +17,9 @@ package ingest_test
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/ddl"
@@ -25,10 +27,13 @@ import (
 	ingesttestutil "github.com/pingcap/tidb/pkg/ddl/ingest/testutil"
 	"github.com/pingcap/tidb/pkg/ddl/testutil"
 	"github.com/pingcap/tidb/pkg/ddl/util/callback"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/testkit/testfailpoint"
 	"github.com/pingcap/tidb/tests/realtikvtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -375,3 +380,52 @@ func TestMultiSchemaAddIndexMerge(t *testing.T) {
 		tk.MustExec("admin check table t;")
 	}
 }
+
+func TestAddIndexIngestRetrySubtask(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	defer ingesttestutil.InjectMockBackendMgr(t, store)()
+	bak := scheduler.RetrySubtaskInterval
+	scheduler.RetrySubtaskInterval = 100 * time.Millisecond
+	t.Cleanup(func() {
+		scheduler.RetrySubtaskInterval = bak
+	})
+
+	tk.MustExec("create table t (a int primary key, b int);")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3);")
+	// the failed backfill subtask is retried, so the job succeeds.
+	testfailpoint.Enable(t, "github.com/pingcap/tidb/pkg/ddl/injectPanicForIndexIngest", "1*return()")
+	tk.MustExec("alter table t add index idx(b);")
+	tk.MustExec("admin check table t;")
+	tk.MustQuery(`select retry_count from mysql.tidb_background_subtask
+		union all select retry_count from mysql.tidb_background_subtask_history`).Sort().Check(testkit.Rows("1"))
+}
+
+func TestAddIndexIngestSubtaskTimeout(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	defer ingesttestutil.InjectMockBackendMgr(t, store)()
+	bak := scheduler.RetrySubtaskInterval
+	scheduler.RetrySubtaskInterval = 100 * time.Millisecond
+	scheduler.RegisterSubtaskTimeout(proto.Backfill, time.Second)
+	t.Cleanup(func() {
+		scheduler.RetrySubtaskInterval = bak
+		scheduler.RegisterSubtaskTimeout(proto.Backfill, ddl.BackfillSubtaskTimeout)
+	})
+
+	tk.MustExec("create table t (a int primary key, b int);")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3);")
+	// the first run of the subtask hangs and times out, then it's retried.
+	var hung atomic.Bool
+	testfailpoint.EnableCall(t, "github.com/pingcap/tidb/pkg/ddl/writeLocalExec", func(bool) {
+		if hung.CompareAndSwap(false, true) {
+			time.Sleep(5 * time.Second)
+		}
+	})
+	tk.MustExec("alter table t add index idx(b);")
+	tk.MustExec("admin check table t;")
+	tk.MustQuery(`select retry_count from mysql.tidb_background_subtask
+		union all select retry_count from mysql.tidb_background_subtask_history`).Sort().Check(testkit.Rows("1"))
+}
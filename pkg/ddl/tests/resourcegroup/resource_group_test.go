@@ -27,12 +27,16 @@ import (
 	"github.com/pingcap/tidb/pkg/ddl/util/callback"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
+	rg "github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	mysql "github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
 	"github.com/stretchr/testify/require"
+	tikvutil "github.com/tikv/client-go/v2/util"
 )
 
 func TestResourceGroupBasic(t *testing.T) {
@@ -80,6 +84,33 @@ func TestResourceGroupBasic(t *testing.T) {
 	// Check the group is correctly reloaded in the information schema.
 	g := testResourceGroupNameFromIS(t, tk.Session(), "x")
 	checkFunc(g)
+	tk.MustQuery("select name, ru_per_sec, burstable, consumed_ru, consumed_ru_per_sec from information_schema.resource_groups_runtime where name in ('default', 'x') order by name").
+		Check(testkit.Rows("default 1000 YES <nil> <nil>", "x 1000 NO <nil> <nil>"))
+	// the RU consumed by internal SQLs is reported as a pseudo resource group.
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/session/mockInternalRRU", "return(10)"))
+	exec := tk.Session().GetRestrictedSQLExecutor()
+	internalCtx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	rruBefore, _ := rg.InternalRU()
+	_, _, err := exec.ExecRestrictedSQL(internalCtx, nil, "select 1")
+	require.NoError(t, err)
+	rruAfter, _ := rg.InternalRU()
+	require.GreaterOrEqual(t, rruAfter-rruBefore, float64(10))
+	tk.MustQuery("select name, ru_per_sec, burstable, tokens, consumed_ru >= 10 from information_schema.resource_groups_runtime where name = 'internal'").
+		Check(testkit.Rows("internal <nil> <nil> <nil> 1"))
+	// the internal SQL run in the current session is accounted to the user statement.
+	userRUDetails := tikvutil.NewRUDetailsWith(80, 40, 0)
+	userCtx := context.WithValue(internalCtx, tikvutil.RUDetailsCtxKey, userRUDetails)
+	_, _, err = exec.ExecRestrictedSQL(userCtx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession}, "select 1")
+	require.NoError(t, err)
+	require.Same(t, userRUDetails, tk.Session().GetSessionVars().StmtCtx.RUDetails)
+	// the internal SQL run in the current session without a user statement, like auto analyze, is accounted to
+	// the internal group.
+	rruBefore, _ = rg.InternalRU()
+	_, _, err = exec.ExecRestrictedSQL(internalCtx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession}, "select 1")
+	require.NoError(t, err)
+	rruAfter, _ = rg.InternalRU()
+	require.GreaterOrEqual(t, rruAfter-rruBefore, float64(10))
+	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/session/mockInternalRRU"))
 
 	// test create if not exists
 	tk.MustExec("create resource group if not exists x RU_PER_SEC=10000")
@@ -363,6 +394,48 @@ func TestResourceGroupRunaway(t *testing.T) {
 	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/store/copr/sleepCoprAfterReq"))
 }
 
+func TestResourceGroupAdmission(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "localhost"}, nil, nil, nil))
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int)")
+	tk.MustExec("insert into t values(1)")
+
+	tk.MustExec("set global tidb_enable_resource_control='on'")
+	tk.MustExec("create resource group rg1 RU_PER_SEC=1000 ADMISSION_TIMEOUT='100ms'")
+	tk.MustQuery("show create resource group rg1").Check(testkit.Rows("rg1 CREATE RESOURCE GROUP `rg1` RU_PER_SEC=1000, PRIORITY=MEDIUM, ADMISSION_TIMEOUT=\"100ms\""))
+	tk.MustGetErrMsg("alter resource group rg1 ADMISSION_TIMEOUT='-1s'", "invalid admission timeout -1s")
+	tk.MustExec("set resource group rg1")
+
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/executor/mockRUWaitDuration", `return(true)`))
+	// the group starts to be throttled.
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+	time.Sleep(200 * time.Millisecond)
+	tk.MustGetErrCode("select * from t", mysql.ErrResourceGroupAdmissionRejected)
+	tk.MustGetErrCode("insert into t values(2)", mysql.ErrResourceGroupAdmissionRejected)
+	// other statements are not rejected.
+	tk.MustExec("set @a = 1")
+	tk.MustGetErrCode("select * from t", mysql.ErrResourceGroupAdmissionRejected)
+
+	// the group is admitted again once a statement finishes without waiting for tokens.
+	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/executor/mockRUWaitDuration"))
+	tk.MustExec("set @a = 2")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+
+	// statements are always admitted without ADMISSION_TIMEOUT.
+	tk.MustExec("alter resource group rg1 ADMISSION_TIMEOUT='0s'")
+	tk.MustQuery("show create resource group rg1").Check(testkit.Rows("rg1 CREATE RESOURCE GROUP `rg1` RU_PER_SEC=1000, PRIORITY=MEDIUM"))
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/executor/mockRUWaitDuration", `return(true)`))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/executor/mockRUWaitDuration"))
+	}()
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+	time.Sleep(200 * time.Millisecond)
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+}
+
 func TestAlreadyExistsDefaultResourceGroup(t *testing.T) {
 	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/domain/infosync/managerAlreadyCreateSomeGroups", `return(true)`))
 	defer func() {
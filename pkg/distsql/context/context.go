@@ -66,6 +66,7 @@ type DistSQLContext struct {
 	TiFlashQuerySpillRatio               float64
 
 	DistSQLConcurrency            int
+	AdaptiveConcurrency           bool
 	ReplicaReadType               kv.ReplicaReadType
 	WeakConsistency               bool
 	RCCheckTS                     bool
@@ -83,6 +84,7 @@ type DistSQLContext struct {
 	LoadBasedReplicaReadThreshold time.Duration
 	RunawayChecker                *resourcegroup.RunawayChecker
 	TiKVClientReadTimeout         uint64
+	StmtRUQuota                   uint64
 
 	ReplicaClosestReadThreshold int64
 	ConnectionID                uint64
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/store/copr"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/codec"
@@ -151,6 +152,16 @@ func TestSelectResultRuntimeStats(t *testing.T) {
 	s1.procKeys.Add(100)
 	expect = "cop_task: {num: 1, max: 1s, proc_keys: 100, tot_proc: 1s, tot_wait: 1s, copr_cache_hit_ratio: 0.00}, backoff{RegionMiss: 1ms}"
 	require.Equal(t, expect, s1.String())
+
+	s1.distSQLConcurrency = 15
+	s1.concurrencyHistory = []copr.ConcurrencyChange{
+		{Concurrency: 2},
+		{Elapsed: time.Millisecond, Concurrency: 3},
+		{Elapsed: 30 * time.Millisecond, Concurrency: 1},
+	}
+	expect = "cop_task: {num: 1, max: 1s, proc_keys: 100, tot_proc: 1s, tot_wait: 1s, copr_cache_hit_ratio: 0.00, max_distsql_concurrency: 15, adaptive_concurrency: [0s:2, 1ms:3, 30ms:1]}, backoff{RegionMiss: 1ms}"
+	require.Equal(t, expect, s1.String())
+	require.Equal(t, expect, s1.Clone().String())
 }
 
 func TestAnalyze(t *testing.T) {
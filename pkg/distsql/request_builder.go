@@ -276,7 +276,7 @@ func (*RequestBuilder) getKVPriority(dctx *distsqlctx.DistSQLContext) int {
 }
 
 // SetFromSessionVars sets the following fields for "kv.Request" from session variables:
-// "Concurrency", "IsolationLevel", "NotFillCache", "TaskID", "Priority", "ReplicaRead",
+// "Concurrency", "AdaptiveConcurrency", "IsolationLevel", "NotFillCache", "TaskID", "Priority", "ReplicaRead",
 // "ResourceGroupTagger", "ResourceGroupName"
 func (builder *RequestBuilder) SetFromSessionVars(dctx *distsqlctx.DistSQLContext) *RequestBuilder {
 	distsqlConcurrency := dctx.DistSQLConcurrency
@@ -287,6 +287,7 @@ func (builder *RequestBuilder) SetFromSessionVars(dctx *distsqlctx.DistSQLContex
 		// Concurrency is set in SetDAGRequest, check the upper limit.
 		builder.Request.Concurrency = distsqlConcurrency
 	}
+	builder.Request.AdaptiveConcurrency = dctx.AdaptiveConcurrency
 	replicaReadType := dctx.ReplicaReadType
 	if dctx.WeakConsistency {
 		builder.Request.IsolationLevel = kv.RC
@@ -314,6 +315,7 @@ func (builder *RequestBuilder) SetFromSessionVars(dctx *distsqlctx.DistSQLContex
 	builder.Request.StoreBusyThreshold = dctx.LoadBasedReplicaReadThreshold
 	builder.Request.RunawayChecker = dctx.RunawayChecker
 	builder.Request.TiKVClientReadTimeout = dctx.TiKVClientReadTimeout
+	builder.Request.StmtRUQuota = dctx.StmtRUQuota
 	return builder
 }
 
@@ -19,6 +19,7 @@ import (
 	"container/heap"
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -615,6 +616,7 @@ func (r *selectResult) Close() error {
 		defer func() {
 			if ci, ok := r.resp.(copr.CopInfo); ok {
 				r.stats.buildTaskDuration = ci.GetBuildTaskElapsed()
+				r.stats.concurrencyHistory = ci.GetConcurrencyHistory()
 				batched, fallback := ci.GetStoreBatchInfo()
 				if batched != 0 || fallback != 0 {
 					r.stats.storeBatchedNum, r.stats.storeBatchedFallbackNum = batched, fallback
@@ -645,6 +647,8 @@ type selectResultRuntimeStats struct {
 	storeBatchedNum         uint64
 	storeBatchedFallbackNum uint64
 	buildTaskDuration       time.Duration
+	// concurrencyHistory is the changes of the adaptive distsql concurrency.
+	concurrencyHistory []copr.ConcurrencyChange
 }
 
 func (s *selectResultRuntimeStats) mergeCopRuntimeStats(copStats *copr.CopRuntimeStats, respTime time.Duration) {
@@ -675,6 +679,7 @@ func (s *selectResultRuntimeStats) Clone() execdetails.RuntimeStats {
 		storeBatchedNum:         s.storeBatchedNum,
 		storeBatchedFallbackNum: s.storeBatchedFallbackNum,
 		buildTaskDuration:       s.buildTaskDuration,
+		concurrencyHistory:      slices.Clone(s.concurrencyHistory),
 	}
 	newRs.copRespTime.MergePercentile(&s.copRespTime)
 	newRs.procKeys.MergePercentile(&s.procKeys)
@@ -711,6 +716,9 @@ func (s *selectResultRuntimeStats) Merge(rs execdetails.RuntimeStats) {
 	s.storeBatchedNum += other.storeBatchedNum
 	s.storeBatchedFallbackNum += other.storeBatchedFallbackNum
 	s.buildTaskDuration += other.buildTaskDuration
+	if len(s.concurrencyHistory) == 0 {
+		s.concurrencyHistory = slices.Clone(other.concurrencyHistory)
+	}
 }
 
 func (s *selectResultRuntimeStats) String() string {
@@ -764,6 +772,18 @@ func (s *selectResultRuntimeStats) String() string {
 			buf.WriteString(", max_extra_concurrency: ")
 			buf.WriteString(strconv.FormatInt(int64(s.extraConcurrency), 10))
 		}
+		if len(s.concurrencyHistory) > 0 {
+			buf.WriteString(", adaptive_concurrency: [")
+			for i, change := range s.concurrencyHistory {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteString(execdetails.FormatDuration(change.Elapsed))
+				buf.WriteString(":")
+				buf.WriteString(strconv.Itoa(change.Concurrency))
+			}
+			buf.WriteString("]")
+		}
 		if s.storeBatchedNum > 0 {
 			buf.WriteString(", store_batch_num: ")
 			buf.WriteString(strconv.FormatInt(int64(s.storeBatchedNum), 10))
@@ -797,6 +817,17 @@ func (s *selectResultRuntimeStats) String() string {
 	return buf.String()
 }
 
+// StorageTime implements the execdetails.StorageTimeStats interface.
+func (s *selectResultRuntimeStats) StorageTime() execdetails.StorageTime {
+	rpc := time.Duration(s.copRespTime.Sum())
+	return execdetails.StorageTime{
+		RPC:     rpc,
+		Process: s.totalProcessTime,
+		Wait:    s.totalWaitTime,
+		Blocked: rpc / time.Duration(max(s.distSQLConcurrency, 1)),
+	}
+}
+
 // Tp implements the RuntimeStats interface.
 func (*selectResultRuntimeStats) Tp() int {
 	return execdetails.TpSelectResultRuntimeStats
@@ -0,0 +1,40 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+)
+
+// TaskKey returns the task key of analyzing the table, startTS is the start
+// ts of the analyze statement to distinguish analyzing the same table again.
+func TaskKey(tableID int64, startTS uint64) string {
+	return fmt.Sprintf("analyze/%d/%d", tableID, startTS)
+}
+
+// SubmitTask submits a distributed analyze task, and returns the task.
+func SubmitTask(ctx context.Context, taskMeta *TaskMeta, startTS uint64, concurrency int) (*proto.Task, error) {
+	bs, err := json.Marshal(taskMeta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return handle.SubmitTask(ctx, TaskKey(taskMeta.TableID, startTS), proto.Analyze, concurrency, "", bs)
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+)
+
+// TaskMeta is the task meta of distributed analyze.
+// the task analyzes every partition of a partitioned table in a separate
+// subtask, and merges the partition stats into global stats at last.
+type TaskMeta struct {
+	DBName     string          `json:"db_name"`
+	TableName  string          `json:"table_name"`
+	TableID    int64           `json:"table_id"`
+	Partitions []PartitionMeta `json:"partitions"`
+	// Options are the analyze options specified by the user or saved for the
+	// table, they're used when analyzing partitions.
+	Options map[ast.AnalyzeOptionType]uint64 `json:"options,omitempty"`
+	// FilledOptions are Options filled with default values, they're used when
+	// merging global stats.
+	FilledOptions map[ast.AnalyzeOptionType]uint64 `json:"filled_options"`
+	StatsVersion  int                              `json:"stats_version"`
+	// SessionContext is the context of the session which submits the task, it's
+	// applied when analyzing partitions. it's nil for tasks submitted by old
+	// versions.
+	SessionContext *handle.SessionContext `json:"session_context,omitempty"`
+}
+
+// PartitionMeta is the meta of a partition to analyze, it's also the subtask
+// meta of proto.AnalyzeStepPartition.
+type PartitionMeta struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// MergeStepMeta is the subtask meta of proto.AnalyzeStepMergeGlobalStats.
+type MergeStepMeta struct {
+	TableID int64 `json:"table_id"`
+}
+
+// NewTaskMeta creates the task meta to analyze all partitions of the table,
+// the analyze related variables of the session are kept in the task meta.
+func NewTaskMeta(vars *variable.SessionVars, dbName string, tblInfo *model.TableInfo,
+	opts, filledOpts map[ast.AnalyzeOptionType]uint64) (*TaskMeta, error) {
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil || len(pi.Definitions) == 0 {
+		return nil, errors.Errorf("table %s.%s is not partitioned", dbName, tblInfo.Name.O)
+	}
+	partitions := make([]PartitionMeta, 0, len(pi.Definitions))
+	for _, def := range pi.Definitions {
+		partitions = append(partitions, PartitionMeta{ID: def.ID, Name: def.Name.O})
+	}
+	sessCtx, err := handle.NewSessionContext(vars, variable.TiDBAnalyzeVersion, variable.TiDBAnalyzeSkipColumnTypes)
+	if err != nil {
+		return nil, err
+	}
+	return &TaskMeta{
+		DBName:         dbName,
+		TableName:      tblInfo.Name.O,
+		TableID:        tblInfo.ID,
+		Partitions:     partitions,
+		Options:        opts,
+		FilledOptions:  filledOpts,
+		StatsVersion:   vars.AnalyzeVersion,
+		SessionContext: sessCtx,
+	}, nil
+}
+
+// optionsClause returns the WITH clause of the analyze statement for the
+// options, it's empty if there is no option.
+func optionsClause(opts map[ast.AnalyzeOptionType]uint64) string {
+	items := make([]string, 0, len(opts))
+	// iterate in the order of option type to make the clause stable.
+	for tp := ast.AnalyzeOptionType(ast.AnalyzeOptNumBuckets); tp <= ast.AnalyzeOptSampleRate; tp++ {
+		v, ok := opts[tp]
+		// zero samples and non-positive sample rate mean the option is not set.
+		if !ok || (tp == ast.AnalyzeOptNumSamples && v == 0) ||
+			(tp == ast.AnalyzeOptSampleRate && math.Float64frombits(v) <= 0) {
+			continue
+		}
+		if tp == ast.AnalyzeOptSampleRate {
+			items = append(items, fmt.Sprintf("%v %s", math.Float64frombits(v), ast.AnalyzeOptionString[tp]))
+		} else {
+			items = append(items, fmt.Sprintf("%d %s", v, ast.AnalyzeOptionString[tp]))
+		}
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return " WITH " + strings.Join(items, ", ")
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// SchedulerExt is the scheduler extension of distributed analyze, exported for test.
+type SchedulerExt struct{}
+
+var _ scheduler.Extension = (*SchedulerExt)(nil)
+
+// OnTick implements scheduler.Extension interface.
+func (*SchedulerExt) OnTick(context.Context, *proto.Task) {}
+
+// OnNextSubtasksBatch implements scheduler.Extension interface.
+// each partition is analyzed in its own subtask, so they can run on different
+// nodes, and there is only one subtask to merge global stats.
+func (*SchedulerExt) OnNextSubtasksBatch(
+	_ context.Context,
+	_ storage.TaskHandle,
+	task *proto.Task,
+	_ []string,
+	nextStep proto.Step,
+) ([][]byte, error) {
+	taskMeta := &TaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch nextStep {
+	case proto.AnalyzeStepPartition:
+		metas := make([][]byte, 0, len(taskMeta.Partitions))
+		for _, p := range taskMeta.Partitions {
+			bs, err := json.Marshal(&p)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			metas = append(metas, bs)
+		}
+		return metas, nil
+	case proto.AnalyzeStepMergeGlobalStats:
+		bs, err := json.Marshal(&MergeStepMeta{TableID: taskMeta.TableID})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return [][]byte{bs}, nil
+	default:
+		return nil, errors.Errorf("unknown step %d for analyze task %d", nextStep, task.ID)
+	}
+}
+
+// OnDone implements scheduler.Extension interface.
+func (*SchedulerExt) OnDone(_ context.Context, _ storage.TaskHandle, task *proto.Task) error {
+	logutil.BgLogger().Info("analyze task done",
+		zap.Int64("task-id", task.ID),
+		zap.Stringer("state", task.State),
+		zap.Error(task.Error))
+	return nil
+}
+
+// GetEligibleInstances implements scheduler.Extension interface.
+func (*SchedulerExt) GetEligibleInstances(context.Context, *proto.Task) ([]string, error) {
+	return nil, nil
+}
+
+// IsRetryableErr implements scheduler.Extension interface.
+func (*SchedulerExt) IsRetryableErr(error) bool {
+	return false
+}
+
+// GetNextStep implements scheduler.Extension interface.
+func (*SchedulerExt) GetNextStep(task *proto.TaskBase) proto.Step {
+	switch task.Step {
+	case proto.StepInit:
+		return proto.AnalyzeStepPartition
+	case proto.AnalyzeStepPartition:
+		return proto.AnalyzeStepMergeGlobalStats
+	default:
+		return proto.StepDone
+	}
+}
+
+// NewScheduler creates a new scheduler for distributed analyze.
+func NewScheduler(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+	sch := scheduler.NewBaseScheduler(ctx, task, param)
+	sch.Extension = &SchedulerExt{}
+	return sch
+}
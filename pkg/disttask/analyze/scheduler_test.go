@@ -0,0 +1,129 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaskMeta(t *testing.T) {
+	vars := variable.NewSessionVars(nil)
+	vars.GlobalVarsAccessor = variable.NewMockGlobalAccessor4Tests()
+	require.NoError(t, vars.SetSystemVar(variable.TimeZone, "+08:00"))
+	tblInfo := &model.TableInfo{ID: 100, Name: model.NewCIStr("t")}
+	_, err := NewTaskMeta(vars, "test", tblInfo, nil, nil)
+	require.ErrorContains(t, err, "table test.t is not partitioned")
+
+	tblInfo.Partition = &model.PartitionInfo{
+		Enable: true,
+		Definitions: []model.PartitionDefinition{
+			{ID: 101, Name: model.NewCIStr("p0")},
+			{ID: 102, Name: model.NewCIStr("p1")},
+		},
+	}
+	opts := map[ast.AnalyzeOptionType]uint64{ast.AnalyzeOptNumTopN: 10}
+	filledOpts := map[ast.AnalyzeOptionType]uint64{ast.AnalyzeOptNumTopN: 10, ast.AnalyzeOptNumBuckets: 256}
+	vars.AnalyzeVersion = 2
+	taskMeta, err := NewTaskMeta(vars, "test", tblInfo, opts, filledOpts)
+	require.NoError(t, err)
+	require.NotNil(t, taskMeta.SessionContext)
+	require.Equal(t, "+08:00", taskMeta.SessionContext.SysVars[variable.TimeZone])
+	require.Contains(t, taskMeta.SessionContext.SysVars, variable.TiDBAnalyzeVersion)
+	taskMeta.SessionContext = nil
+	require.Equal(t, &TaskMeta{
+		DBName:    "test",
+		TableName: "t",
+		TableID:   100,
+		Partitions: []PartitionMeta{
+			{ID: 101, Name: "p0"},
+			{ID: 102, Name: "p1"},
+		},
+		Options:       opts,
+		FilledOptions: filledOpts,
+		StatsVersion:  2,
+	}, taskMeta)
+}
+
+func TestOptionsClause(t *testing.T) {
+	require.Equal(t, "", optionsClause(nil))
+	require.Equal(t, " WITH 64 BUCKETS, 10 TOPN, 0.5 SAMPLERATE", optionsClause(map[ast.AnalyzeOptionType]uint64{
+		ast.AnalyzeOptSampleRate: math.Float64bits(0.5),
+		ast.AnalyzeOptNumTopN:    10,
+		ast.AnalyzeOptNumBuckets: 64,
+		ast.AnalyzeOptNumSamples: 0,
+	}))
+	require.Equal(t, " WITH 1000 SAMPLES", optionsClause(map[ast.AnalyzeOptionType]uint64{
+		ast.AnalyzeOptSampleRate: math.Float64bits(-1),
+		ast.AnalyzeOptNumSamples: 1000,
+	}))
+}
+
+func TestSchedulerExtGetNextStep(t *testing.T) {
+	ext := &SchedulerExt{}
+	task := &proto.TaskBase{Step: proto.StepInit}
+	for _, nextStep := range []proto.Step{
+		proto.AnalyzeStepPartition,
+		proto.AnalyzeStepMergeGlobalStats,
+		proto.StepDone,
+	} {
+		task.Step = ext.GetNextStep(task)
+		require.Equal(t, nextStep, task.Step)
+	}
+}
+
+func TestSchedulerExtOnNextSubtasksBatch(t *testing.T) {
+	ctx := context.Background()
+	ext := &SchedulerExt{}
+	taskMeta := &TaskMeta{
+		DBName:    "test",
+		TableName: "t",
+		TableID:   100,
+		Partitions: []PartitionMeta{
+			{ID: 101, Name: "p0"},
+			{ID: 102, Name: "p1"},
+		},
+	}
+	bs, err := json.Marshal(taskMeta)
+	require.NoError(t, err)
+	task := &proto.Task{TaskBase: proto.TaskBase{ID: 1, Type: proto.Analyze}, Meta: bs}
+
+	metas, err := ext.OnNextSubtasksBatch(ctx, nil, task, nil, proto.AnalyzeStepPartition)
+	require.NoError(t, err)
+	require.Len(t, metas, 2)
+	for i, m := range metas {
+		p := PartitionMeta{}
+		require.NoError(t, json.Unmarshal(m, &p))
+		require.Equal(t, taskMeta.Partitions[i], p)
+	}
+
+	metas, err = ext.OnNextSubtasksBatch(ctx, nil, task, nil, proto.AnalyzeStepMergeGlobalStats)
+	require.NoError(t, err)
+	require.Len(t, metas, 1)
+	stepMeta := MergeStepMeta{}
+	require.NoError(t, json.Unmarshal(metas[0], &stepMeta))
+	require.Equal(t, int64(100), stepMeta.TableID)
+
+	_, err = ext.OnNextSubtasksBatch(ctx, nil, task, nil, proto.StepDone)
+	require.ErrorContains(t, err, "unknown step")
+}
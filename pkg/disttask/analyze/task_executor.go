@@ -0,0 +1,186 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	statstypes "github.com/pingcap/tidb/pkg/statistics/handle/types"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+func withNewSession(fn func(se sessionctx.Context) error) error {
+	taskMgr, err := storage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	return taskMgr.WithNewSession(fn)
+}
+
+// partitionStepExecutor analyzes one partition per subtask.
+type partitionStepExecutor struct {
+	taskexecutor.EmptyStepExecutor
+	taskMeta *TaskMeta
+	logger   *zap.Logger
+}
+
+var _ execute.StepExecutor = &partitionStepExecutor{}
+
+func (e *partitionStepExecutor) RunSubtask(ctx context.Context, subtask *proto.Subtask) error {
+	partition := PartitionMeta{}
+	if err := json.Unmarshal(subtask.Meta, &partition); err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Info("analyze partition", zap.Int64("subtask-id", subtask.ID),
+		zap.String("partition", partition.Name))
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnStats)
+	return withNewSession(func(se sessionctx.Context) error {
+		// in static prune mode, analyze partition won't merge global stats,
+		// it's done in a separate step after all partitions are analyzed.
+		vars := se.GetSessionVars()
+		if e.taskMeta.SessionContext != nil {
+			restore, err := e.taskMeta.SessionContext.Apply(vars)
+			if err != nil {
+				return err
+			}
+			defer restore()
+		}
+		origin := vars.PartitionPruneMode.Load()
+		vars.PartitionPruneMode.Store(string(variable.Static))
+		defer vars.PartitionPruneMode.Store(origin)
+		_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), "analyze table %n.%n partition %n"+optionsClause(e.taskMeta.Options),
+			e.taskMeta.DBName, e.taskMeta.TableName, partition.Name)
+		return err
+	})
+}
+
+// mergeStepExecutor merges partition stats into global stats.
+type mergeStepExecutor struct {
+	taskexecutor.EmptyStepExecutor
+	taskMeta *TaskMeta
+	logger   *zap.Logger
+}
+
+var _ execute.StepExecutor = &mergeStepExecutor{}
+
+func (e *mergeStepExecutor) RunSubtask(_ context.Context, subtask *proto.Subtask) error {
+	stepMeta := MergeStepMeta{}
+	if err := json.Unmarshal(subtask.Meta, &stepMeta); err != nil {
+		return errors.Trace(err)
+	}
+	return withNewSession(func(se sessionctx.Context) error {
+		dom := domain.GetDomain(se)
+		is := dom.InfoSchema()
+		tbl, ok := is.TableByID(stepMeta.TableID)
+		if !ok {
+			return errors.Errorf("table %d not found", stepMeta.TableID)
+		}
+		infos := globalStatsInfos(tbl.Meta(), e.taskMeta.StatsVersion)
+		e.logger.Info("merge global stats", zap.Int64("subtask-id", subtask.ID),
+			zap.Int64("table-id", stepMeta.TableID), zap.Int("count", len(infos)))
+		for _, info := range infos {
+			if err := dom.StatsHandle().MergePartitionStats2GlobalStatsByTableID(
+				se, e.taskMeta.FilledOptions, is, info, stepMeta.TableID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// globalStatsInfos returns the global stats to merge, all columns are merged
+// together, and each index is merged separately.
+func globalStatsInfos(tblInfo *model.TableInfo, statsVersion int) []*statstypes.GlobalStatsInfo {
+	infos := make([]*statstypes.GlobalStatsInfo, 0, len(tblInfo.Indices)+1)
+	colIDs := make([]int64, 0, len(tblInfo.Columns))
+	for _, col := range tblInfo.Columns {
+		if col.State == model.StatePublic {
+			colIDs = append(colIDs, col.ID)
+		}
+	}
+	infos = append(infos, &statstypes.GlobalStatsInfo{
+		HistIDs:      colIDs,
+		IsIndex:      0,
+		StatsVersion: statsVersion,
+	})
+	for _, idx := range tblInfo.Indices {
+		if idx.State != model.StatePublic {
+			continue
+		}
+		infos = append(infos, &statstypes.GlobalStatsInfo{
+			HistIDs:      []int64{idx.ID},
+			IsIndex:      1,
+			StatsVersion: statsVersion,
+		})
+	}
+	return infos
+}
+
+type analyzeExecutor struct {
+	*taskexecutor.BaseTaskExecutor
+}
+
+// NewTaskExecutor creates a new task executor for distributed analyze.
+func NewTaskExecutor(ctx context.Context, id string, task *proto.Task, taskTable taskexecutor.TaskTable) taskexecutor.TaskExecutor {
+	e := &analyzeExecutor{
+		BaseTaskExecutor: taskexecutor.NewBaseTaskExecutor(ctx, id, task, taskTable),
+	}
+	e.BaseTaskExecutor.Extension = e
+	return e
+}
+
+func (*analyzeExecutor) IsIdempotent(*proto.Subtask) bool {
+	// analyze overwrites the stats of the partition or table, it's ok to run
+	// it again.
+	return true
+}
+
+func (*analyzeExecutor) IsRetryableError(error) bool {
+	return false
+}
+
+func (*analyzeExecutor) GetStepExecutor(task *proto.Task) (execute.StepExecutor, error) {
+	taskMeta := TaskMeta{}
+	if err := json.Unmarshal(task.Meta, &taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger := logutil.BgLogger().With(
+		zap.Stringer("type", proto.Analyze),
+		zap.Int64("task-id", task.ID),
+		zap.String("step", proto.Step2Str(task.Type, task.Step)),
+	)
+
+	switch task.Step {
+	case proto.AnalyzeStepPartition:
+		return &partitionStepExecutor{taskMeta: &taskMeta, logger: logger}, nil
+	case proto.AnalyzeStepMergeGlobalStats:
+		return &mergeStepExecutor{taskMeta: &taskMeta, logger: logger}, nil
+	default:
+		return nil, errors.Errorf("unknown step %d for analyze task %d", task.Step, task.ID)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+)
+
+// TaskKey returns the task key of checksum the table on the snapshot of startTS.
+func TaskKey(tableID int64, startTS uint64) string {
+	return fmt.Sprintf("checksum/%d/%d", tableID, startTS)
+}
+
+// SubmitTask submits a distributed checksum task, and returns the task.
+func SubmitTask(ctx context.Context, taskMeta *TaskMeta, concurrency int) (*proto.Task, error) {
+	bs, err := json.Marshal(taskMeta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return handle.SubmitTask(ctx, TaskKey(taskMeta.TableID, taskMeta.StartTS), proto.Checksum, concurrency, "", bs)
+}
+
+// GetResult returns the checksum result of a succeeded task.
+func GetResult(ctx context.Context, taskID int64) (*Result, error) {
+	taskMgr, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	task, err := taskMgr.GetTaskByIDWithHistory(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.State != proto.TaskStateSucceed {
+		return nil, errors.Errorf("checksum task %d is %s", taskID, task.State)
+	}
+	taskMeta := &TaskMeta{}
+	if err = json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if taskMeta.Result == nil {
+		return nil, errors.Errorf("checksum result of task %d is missing", taskID)
+	}
+	return taskMeta.Result, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum
+
+import (
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+// TaskMeta is the task meta of distributed checksum table.
+// the table is split into key ranges, the checksum of each range is computed
+// in a separate subtask, and the scheduler aggregates them into Result when
+// the task is done.
+type TaskMeta struct {
+	DBName    string `json:"db_name"`
+	TableName string `json:"table_name"`
+	TableID   int64  `json:"table_id"`
+	// PhysicalTableIDs contains the table ID and all partition IDs.
+	PhysicalTableIDs []int64 `json:"physical_table_ids"`
+	// IndexIDs contains the IDs of public indexes.
+	IndexIDs []int64 `json:"index_ids"`
+	// StartTS is the snapshot all subtasks compute checksum on.
+	StartTS uint64 `json:"start_ts"`
+	// Result is set when the task is done.
+	Result *Result `json:"result,omitempty"`
+}
+
+// SubtaskMeta is the subtask meta of proto.ChecksumStepCompute.
+type SubtaskMeta struct {
+	PhysicalTableID int64 `json:"physical_table_id"`
+	// IndexID is -1 for the record range.
+	IndexID  int64  `json:"index_id"`
+	StartKey kv.Key `json:"start_key"`
+	EndKey   kv.Key `json:"end_key"`
+	// Result is set when the subtask is finished.
+	Result *Result `json:"result,omitempty"`
+}
+
+// Result is the checksum result of a key range or the whole table.
+type Result struct {
+	Checksum   uint64 `json:"checksum"`
+	TotalKvs   uint64 `json:"total_kvs"`
+	TotalBytes uint64 `json:"total_bytes"`
+}
+
+// Merge merges the checksum of another key range into r.
+func (r *Result) Merge(other *Result) {
+	r.Checksum ^= other.Checksum
+	r.TotalKvs += other.TotalKvs
+	r.TotalBytes += other.TotalBytes
+}
+
+// NewTaskMeta creates the task meta to checksum the table on the snapshot of startTS.
+func NewTaskMeta(dbName string, tblInfo *model.TableInfo, startTS uint64) *TaskMeta {
+	physicalIDs := []int64{tblInfo.ID}
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			physicalIDs = append(physicalIDs, def.ID)
+		}
+	}
+	indexIDs := make([]int64, 0, len(tblInfo.Indices))
+	for _, idx := range tblInfo.Indices {
+		if idx.State == model.StatePublic {
+			indexIDs = append(indexIDs, idx.ID)
+		}
+	}
+	return &TaskMeta{
+		DBName:           dbName,
+		TableName:        tblInfo.Name.O,
+		TableID:          tblInfo.ID,
+		PhysicalTableIDs: physicalIDs,
+		IndexIDs:         indexIDs,
+		StartTS:          startTS,
+	}
+}
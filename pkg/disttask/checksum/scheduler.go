@@ -0,0 +1,166 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// SchedulerExt is the scheduler extension of distributed checksum, exported for test.
+type SchedulerExt struct{}
+
+var _ scheduler.Extension = (*SchedulerExt)(nil)
+
+// OnTick implements scheduler.Extension interface.
+func (*SchedulerExt) OnTick(context.Context, *proto.Task) {}
+
+// OnNextSubtasksBatch implements scheduler.Extension interface.
+// there is one subtask for the record range and each index range of every
+// physical table.
+func (*SchedulerExt) OnNextSubtasksBatch(
+	_ context.Context,
+	_ storage.TaskHandle,
+	task *proto.Task,
+	_ []string,
+	nextStep proto.Step,
+) ([][]byte, error) {
+	if nextStep != proto.ChecksumStepCompute {
+		return nil, errors.Errorf("unknown step %d for checksum task %d", nextStep, task.ID)
+	}
+	taskMeta := &TaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buildSubtaskMetas(taskMeta)
+}
+
+func buildSubtaskMetas(taskMeta *TaskMeta) ([][]byte, error) {
+	metas := make([][]byte, 0, len(taskMeta.PhysicalTableIDs)*(len(taskMeta.IndexIDs)+1))
+	appendMeta := func(m *SubtaskMeta) error {
+		bs, err := json.Marshal(m)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		metas = append(metas, bs)
+		return nil
+	}
+	for _, pid := range taskMeta.PhysicalTableIDs {
+		startKey, endKey := tablecodec.GetTableHandleKeyRange(pid)
+		if err := appendMeta(&SubtaskMeta{
+			PhysicalTableID: pid,
+			IndexID:         -1,
+			StartKey:        startKey,
+			EndKey:          endKey,
+		}); err != nil {
+			return nil, err
+		}
+		for _, idxID := range taskMeta.IndexIDs {
+			startKey, endKey = tablecodec.GetTableIndexKeyRange(pid, idxID)
+			if err := appendMeta(&SubtaskMeta{
+				PhysicalTableID: pid,
+				IndexID:         idxID,
+				StartKey:        startKey,
+				EndKey:          endKey,
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return metas, nil
+}
+
+// aggregateResult merges the partial checksums in subtask metas.
+func aggregateResult(subtaskMetas [][]byte) (*Result, error) {
+	res := &Result{}
+	for _, bs := range subtaskMetas {
+		m := &SubtaskMeta{}
+		if err := json.Unmarshal(bs, m); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m.Result == nil {
+			return nil, errors.Errorf("checksum of range [%s, %s) is missing", m.StartKey, m.EndKey)
+		}
+		res.Merge(m.Result)
+	}
+	return res, nil
+}
+
+// OnDone implements scheduler.Extension interface.
+// on success, the partial checksums are aggregated and saved into task meta.
+func (*SchedulerExt) OnDone(ctx context.Context, h storage.TaskHandle, task *proto.Task) error {
+	logger := logutil.BgLogger().With(zap.Int64("task-id", task.ID))
+	if task.Error != nil {
+		logger.Info("checksum task done", zap.Stringer("state", task.State), zap.Error(task.Error))
+		return nil
+	}
+	taskMeta := &TaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return errors.Trace(err)
+	}
+	subtaskMetas, err := h.GetPreviousSubtaskMetas(task.ID, proto.ChecksumStepCompute)
+	if err != nil {
+		return err
+	}
+	if taskMeta.Result, err = aggregateResult(subtaskMetas); err != nil {
+		return err
+	}
+	bs, err := json.Marshal(taskMeta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Info("checksum task done",
+		zap.Uint64("checksum", taskMeta.Result.Checksum),
+		zap.Uint64("total-kvs", taskMeta.Result.TotalKvs),
+		zap.Uint64("total-bytes", taskMeta.Result.TotalBytes))
+	taskMgr, err := storage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	return taskMgr.UpdateTaskMeta(ctx, task.ID, bs)
+}
+
+// GetEligibleInstances implements scheduler.Extension interface.
+func (*SchedulerExt) GetEligibleInstances(context.Context, *proto.Task) ([]string, error) {
+	return nil, nil
+}
+
+// IsRetryableErr implements scheduler.Extension interface.
+func (*SchedulerExt) IsRetryableErr(error) bool {
+	return false
+}
+
+// GetNextStep implements scheduler.Extension interface.
+func (*SchedulerExt) GetNextStep(task *proto.TaskBase) proto.Step {
+	if task.Step == proto.StepInit {
+		return proto.ChecksumStepCompute
+	}
+	return proto.StepDone
+}
+
+// NewScheduler creates a new scheduler for distributed checksum.
+func NewScheduler(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+	sch := scheduler.NewBaseScheduler(ctx, task, param)
+	sch.Extension = &SchedulerExt{}
+	return sch
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaskMeta(t *testing.T) {
+	tblInfo := &model.TableInfo{
+		ID:   100,
+		Name: model.NewCIStr("t"),
+		Indices: []*model.IndexInfo{
+			{ID: 1, State: model.StatePublic},
+			{ID: 2, State: model.StateWriteReorganization},
+		},
+		Partition: &model.PartitionInfo{
+			Enable: true,
+			Definitions: []model.PartitionDefinition{
+				{ID: 101, Name: model.NewCIStr("p0")},
+				{ID: 102, Name: model.NewCIStr("p1")},
+			},
+		},
+	}
+	require.Equal(t, &TaskMeta{
+		DBName:           "test",
+		TableName:        "t",
+		TableID:          100,
+		PhysicalTableIDs: []int64{100, 101, 102},
+		IndexIDs:         []int64{1},
+		StartTS:          123,
+	}, NewTaskMeta("test", tblInfo, 123))
+}
+
+func TestSchedulerExtOnNextSubtasksBatch(t *testing.T) {
+	ext := &SchedulerExt{}
+	taskMeta := &TaskMeta{
+		TableID:          100,
+		PhysicalTableIDs: []int64{101, 102},
+		IndexIDs:         []int64{1},
+	}
+	bs, err := json.Marshal(taskMeta)
+	require.NoError(t, err)
+	task := &proto.Task{TaskBase: proto.TaskBase{ID: 1, Type: proto.Checksum, Step: proto.StepInit}, Meta: bs}
+
+	require.Equal(t, proto.ChecksumStepCompute, ext.GetNextStep(&task.TaskBase))
+	metas, err := ext.OnNextSubtasksBatch(context.Background(), nil, task, nil, proto.ChecksumStepCompute)
+	require.NoError(t, err)
+	require.Len(t, metas, 4)
+	expected := []struct{ pid, idxID int64 }{{101, -1}, {101, 1}, {102, -1}, {102, 1}}
+	for i, m := range metas {
+		stepMeta := SubtaskMeta{}
+		require.NoError(t, json.Unmarshal(m, &stepMeta))
+		require.Equal(t, expected[i].pid, stepMeta.PhysicalTableID)
+		require.Equal(t, expected[i].idxID, stepMeta.IndexID)
+		startKey, endKey := tablecodec.GetTableHandleKeyRange(stepMeta.PhysicalTableID)
+		if stepMeta.IndexID != -1 {
+			startKey, endKey = tablecodec.GetTableIndexKeyRange(stepMeta.PhysicalTableID, stepMeta.IndexID)
+		}
+		require.EqualValues(t, startKey, stepMeta.StartKey)
+		require.EqualValues(t, endKey, stepMeta.EndKey)
+	}
+	task.Step = proto.ChecksumStepCompute
+	require.Equal(t, proto.StepDone, ext.GetNextStep(&task.TaskBase))
+}
+
+func TestAggregateResult(t *testing.T) {
+	metas := make([][]byte, 0, 2)
+	for _, r := range []*Result{
+		{Checksum: 0b0101, TotalKvs: 1, TotalBytes: 10},
+		{Checksum: 0b0011, TotalKvs: 2, TotalBytes: 20},
+	} {
+		bs, err := json.Marshal(&SubtaskMeta{Result: r})
+		require.NoError(t, err)
+		metas = append(metas, bs)
+	}
+	res, err := aggregateResult(metas)
+	require.NoError(t, err)
+	require.Equal(t, &Result{Checksum: 0b0110, TotalKvs: 3, TotalBytes: 30}, res)
+
+	bs, err := json.Marshal(&SubtaskMeta{})
+	require.NoError(t, err)
+	_, err = aggregateResult(append(metas, bs))
+	require.ErrorContains(t, err, "is missing")
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksum
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/distsql"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tipb/go-tipb"
+	"go.uber.org/zap"
+)
+
+// computeStepExecutor computes the checksum of the key range of a subtask.
+type computeStepExecutor struct {
+	taskexecutor.EmptyStepExecutor
+	taskMeta *TaskMeta
+	store    kv.Storage
+	logger   *zap.Logger
+	result   *Result
+}
+
+var _ execute.StepExecutor = &computeStepExecutor{}
+
+func (e *computeStepExecutor) RunSubtask(ctx context.Context, subtask *proto.Subtask) (err error) {
+	stepMeta := SubtaskMeta{}
+	if err = json.Unmarshal(subtask.Meta, &stepMeta); err != nil {
+		return errors.Trace(err)
+	}
+	scanOn := tipb.ChecksumScanOn_Table
+	if stepMeta.IndexID != -1 {
+		scanOn = tipb.ChecksumScanOn_Index
+	}
+	var builder distsql.RequestBuilder
+	req, err := builder.SetKeyRanges([]kv.KeyRange{{StartKey: stepMeta.StartKey, EndKey: stepMeta.EndKey}}).
+		SetChecksumRequest(&tipb.ChecksumRequest{
+			ScanOn:    scanOn,
+			Algorithm: tipb.ChecksumAlgorithm_Crc64_Xor,
+		}).
+		SetStartTS(e.taskMeta.StartTS).
+		SetConcurrency(int(e.GetResource().CPU.Capacity())).
+		Build()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	killed := uint32(0)
+	res, err := distsql.Checksum(ctx, e.store.GetClient(), req, kv.NewVariables(&killed))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := res.Close(); err1 != nil && err == nil {
+			err = err1
+		}
+	}()
+
+	result := &Result{}
+	for {
+		data, err2 := res.NextRaw(ctx)
+		if err2 != nil {
+			return err2
+		}
+		if data == nil {
+			break
+		}
+		resp := &tipb.ChecksumResponse{}
+		if err = resp.Unmarshal(data); err != nil {
+			return errors.Trace(err)
+		}
+		result.Merge(&Result{
+			Checksum:   resp.Checksum,
+			TotalKvs:   resp.TotalKvs,
+			TotalBytes: resp.TotalBytes,
+		})
+	}
+	e.logger.Info("checksum range done",
+		zap.Int64("subtask-id", subtask.ID),
+		zap.Int64("physical-table-id", stepMeta.PhysicalTableID),
+		zap.Int64("index-id", stepMeta.IndexID),
+		zap.Uint64("checksum", result.Checksum),
+		zap.Uint64("total-kvs", result.TotalKvs))
+	e.result = result
+	return nil
+}
+
+func (e *computeStepExecutor) OnFinished(_ context.Context, subtask *proto.Subtask) error {
+	stepMeta := SubtaskMeta{}
+	if err := json.Unmarshal(subtask.Meta, &stepMeta); err != nil {
+		return errors.Trace(err)
+	}
+	stepMeta.Result = e.result
+	bs, err := json.Marshal(&stepMeta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	subtask.Meta = bs
+	return nil
+}
+
+type checksumExecutor struct {
+	*taskexecutor.BaseTaskExecutor
+	store kv.Storage
+}
+
+// NewTaskExecutor creates a new task executor for distributed checksum.
+func NewTaskExecutor(
+	ctx context.Context,
+	id string,
+	task *proto.Task,
+	taskTable taskexecutor.TaskTable,
+	store kv.Storage,
+) taskexecutor.TaskExecutor {
+	e := &checksumExecutor{
+		BaseTaskExecutor: taskexecutor.NewBaseTaskExecutor(ctx, id, task, taskTable),
+		store:            store,
+	}
+	e.BaseTaskExecutor.Extension = e
+	return e
+}
+
+func (*checksumExecutor) IsIdempotent(*proto.Subtask) bool {
+	// checksum is read only.
+	return true
+}
+
+func (*checksumExecutor) IsRetryableError(error) bool {
+	return false
+}
+
+func (e *checksumExecutor) GetStepExecutor(task *proto.Task) (execute.StepExecutor, error) {
+	taskMeta := TaskMeta{}
+	if err := json.Unmarshal(task.Meta, &taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if task.Step != proto.ChecksumStepCompute {
+		return nil, errors.Errorf("unknown step %d for checksum task %d", task.Step, task.ID)
+	}
+	return &computeStepExecutor{
+		taskMeta: &taskMeta,
+		store:    e.store,
+		logger: logutil.BgLogger().With(
+			zap.Stringer("type", proto.Checksum),
+			zap.Int64("task-id", task.ID),
+			zap.String("step", proto.Step2Str(task.Type, task.Step)),
+		),
+	}, nil
+}
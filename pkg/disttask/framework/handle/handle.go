@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/util/backoff"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -56,6 +57,20 @@ func GetCPUCountOfNode(ctx context.Context) (int, error) {
 
 // SubmitTask submits a task.
 func SubmitTask(ctx context.Context, taskKey string, taskType proto.TaskType, concurrency int, targetScope string, taskMeta []byte) (*proto.Task, error) {
+	return SubmitTaskWithDependencies(ctx, taskKey, taskType, concurrency, targetScope, taskMeta, nil)
+}
+
+// SubmitTaskWithDependencies submits a task which is kept pending until all
+// tasks in dependencies succeed, and it fails if any of them doesn't succeed.
+func SubmitTaskWithDependencies(
+	ctx context.Context,
+	taskKey string,
+	taskType proto.TaskType,
+	concurrency int,
+	targetScope string,
+	taskMeta []byte,
+	dependencies []int64,
+) (*proto.Task, error) {
 	taskManager, err := storage.GetTaskManager()
 	if err != nil {
 		return nil, err
@@ -68,7 +83,7 @@ func SubmitTask(ctx context.Context, taskKey string, taskType proto.TaskType, co
 		return nil, storage.ErrTaskAlreadyExists
 	}
 
-	taskID, err := taskManager.CreateTask(ctx, taskKey, taskType, concurrency, targetScope, taskMeta)
+	taskID, err := taskManager.CreateTaskWithDependencies(ctx, taskKey, taskType, concurrency, targetScope, taskMeta, dependencies)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +98,32 @@ func SubmitTask(ctx context.Context, taskKey string, taskType proto.TaskType, co
 	return task, nil
 }
 
+// SubmitTaskIdempotent submits a task like SubmitTask, but if a task with the
+// same key exists, either unfinished or in history, it's returned instead of
+// ErrTaskAlreadyExists, so the same logical job can be resubmitted safely, such
+// as after the submitter restarts. existed is true if the task is submitted
+// before.
+func SubmitTaskIdempotent(ctx context.Context, taskKey string, taskType proto.TaskType, concurrency int, targetScope string, taskMeta []byte) (task *proto.Task, existed bool, err error) {
+	task, err = SubmitTask(ctx, taskKey, taskType, concurrency, targetScope, taskMeta)
+	// the task might be submitted concurrently after the check in SubmitTask,
+	// the unique key of task_key fails the insert in this case.
+	if err == nil || (err != storage.ErrTaskAlreadyExists && !kv.ErrKeyExists.Equal(err)) {
+		return task, false, err
+	}
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, false, err
+	}
+	task, err = taskManager.GetTaskByKeyWithHistory(ctx, taskKey)
+	if err != nil {
+		return nil, false, err
+	}
+	if task.Type != taskType {
+		return nil, false, errors.Errorf("task key %s is used by task %d of type %s", taskKey, task.ID, task.Type)
+	}
+	return task, true, nil
+}
+
 // WaitTaskDoneOrPaused waits for a task done or paused.
 // this API returns error if task failed or cancelled.
 func WaitTaskDoneOrPaused(ctx context.Context, id int64) error {
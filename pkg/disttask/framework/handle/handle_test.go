@@ -16,6 +16,7 @@ package handle_test
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"sync/atomic"
 	"testing"
@@ -27,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/testkit/testfailpoint"
 	"github.com/pingcap/tidb/pkg/util/backoff"
@@ -91,6 +93,24 @@ func TestHandle(t *testing.T) {
 	task, err = handle.SubmitTask(ctx, "3", proto.TaskTypeExample, 2, "", proto.EmptyMeta)
 	require.Nil(t, task)
 	require.Error(t, storage.ErrTaskAlreadyExists, err)
+
+	// idempotent submission returns the existing task.
+	task, existed, err := handle.SubmitTaskIdempotent(ctx, "4", proto.TaskTypeExample, 2, "", proto.EmptyMeta)
+	require.NoError(t, err)
+	require.False(t, existed)
+	taskID := task.ID
+	task, existed, err = handle.SubmitTaskIdempotent(ctx, "4", proto.TaskTypeExample, 2, "", proto.EmptyMeta)
+	require.NoError(t, err)
+	require.True(t, existed)
+	require.Equal(t, taskID, task.ID)
+	// task in history.
+	task, existed, err = handle.SubmitTaskIdempotent(ctx, "3", proto.TaskTypeExample, 2, "", proto.EmptyMeta)
+	require.NoError(t, err)
+	require.True(t, existed)
+	require.Equal(t, "3", task.Key)
+	// key used by another task type.
+	_, _, err = handle.SubmitTaskIdempotent(ctx, "4", proto.ImportInto, 2, "", proto.EmptyMeta)
+	require.ErrorContains(t, err, fmt.Sprintf("task key 4 is used by task %d of type Example", taskID))
 }
 
 func TestRunWithRetry(t *testing.T) {
@@ -149,3 +169,50 @@ func TestRunWithRetry(t *testing.T) {
 	)
 	require.ErrorIs(t, err, context.Canceled)
 }
+
+func TestSessionContext(t *testing.T) {
+	newVars := func() *variable.SessionVars {
+		vars := variable.NewSessionVars(nil)
+		vars.GlobalVarsAccessor = variable.NewMockGlobalAccessor4Tests()
+		return vars
+	}
+	submitVars := newVars()
+	require.NoError(t, submitVars.SetSystemVar(variable.TimeZone, "Asia/Shanghai"))
+	require.NoError(t, submitVars.SetSystemVar(variable.SQLModeVar, "NO_ZERO_DATE"))
+	require.NoError(t, submitVars.SetSystemVar(variable.DivPrecisionIncrement, "8"))
+	sessCtx, err := handle.NewSessionContext(submitVars, variable.DivPrecisionIncrement)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		variable.TimeZone:              "Asia/Shanghai",
+		variable.SQLModeVar:            "NO_ZERO_DATE",
+		variable.DivPrecisionIncrement: "8",
+	}, sessCtx.SysVars)
+	_, err = handle.NewSessionContext(submitVars, "unknown_var")
+	require.ErrorContains(t, err, "Unknown system variable")
+
+	vars := newVars()
+	require.NoError(t, vars.SetSystemVar(variable.TimeZone, "+00:00"))
+	require.NoError(t, vars.SetSystemVar(variable.SQLModeVar, "STRICT_TRANS_TABLES"))
+	originSQLMode := vars.SQLMode
+	timeZone := func() string {
+		tz, ok := vars.GetSystemVar(variable.TimeZone)
+		require.True(t, ok)
+		return tz
+	}
+	restore, err := sessCtx.Apply(vars)
+	require.NoError(t, err)
+	require.Equal(t, "Asia/Shanghai", vars.Location().String())
+	require.True(t, vars.SQLMode.HasNoZeroDateMode())
+	require.Equal(t, 8, vars.DivPrecisionIncrement)
+	restore()
+	require.Equal(t, "+00:00", timeZone())
+	require.Equal(t, originSQLMode, vars.SQLMode)
+	require.Equal(t, variable.DefDivPrecisionIncrement, vars.DivPrecisionIncrement)
+
+	// invalid value, the applied variables are restored.
+	sessCtx.SysVars[variable.DivPrecisionIncrement] = "invalid"
+	_, err = sessCtx.Apply(vars)
+	require.Error(t, err)
+	require.Equal(t, "+00:00", timeZone())
+	require.Equal(t, originSQLMode, vars.SQLMode)
+}
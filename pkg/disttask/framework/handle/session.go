@@ -0,0 +1,74 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handle
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+)
+
+// sessionContextVars are the variables always captured in SessionContext.
+var sessionContextVars = []string{variable.TimeZone, variable.SQLModeVar}
+
+// SessionContext is the context of the session which submits the task. Subtasks
+// run in internal sessions, so task types which depend on the session, such as
+// evaluating time or casting values, can persist it in the task meta and apply
+// it to the sessions which run the subtasks to behave the same as the submitting
+// session.
+type SessionContext struct {
+	// SysVars contains time_zone, sql_mode and the variables passed to
+	// NewSessionContext.
+	SysVars map[string]string `json:"sys_vars"`
+}
+
+// NewSessionContext captures the time zone, SQL mode and the extra system
+// variables of the session.
+func NewSessionContext(vars *variable.SessionVars, extraVars ...string) (*SessionContext, error) {
+	names := make([]string, 0, len(sessionContextVars)+len(extraVars))
+	names = append(names, sessionContextVars...)
+	names = append(names, extraVars...)
+	sysVars := make(map[string]string, len(names))
+	for _, name := range names {
+		val, err := vars.GetSessionOrGlobalSystemVar(context.Background(), name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sysVars[name] = val
+	}
+	return &SessionContext{SysVars: sysVars}, nil
+}
+
+// Apply sets the captured variables to the session, and returns a function to
+// restore them, as internal sessions are reused.
+func (c *SessionContext) Apply(vars *variable.SessionVars) (restore func(), err error) {
+	origin := make(map[string]string, len(c.SysVars))
+	restore = func() {
+		for name, val := range origin {
+			// the value is got from the session, it's always valid.
+			_ = vars.SetSystemVar(name, val)
+		}
+	}
+	for name, val := range c.SysVars {
+		originVal, err := vars.SetSystemVarWithOldValAsRet(name, val)
+		if err != nil {
+			restore()
+			return nil, errors.Trace(err)
+		}
+		origin[name] = originVal
+	}
+	return restore, nil
+}
@@ -23,13 +23,13 @@ import (
 
 	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
-	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
 	mockDispatch "github.com/pingcap/tidb/pkg/disttask/framework/scheduler/mock"
 	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
 	"github.com/pingcap/tidb/pkg/disttask/framework/testutil"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/session"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/store/driver"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/testkit/testfailpoint"
@@ -39,9 +39,9 @@ import (
 )
 
 var (
-	maxConcurrentTask       = flag.Int("max-concurrent-task", proto.MaxConcurrentTask, "max concurrent task")
+	maxConcurrentTask       = flag.Int("max-concurrent-task", variable.DefTiDBDistTaskDispatchConcurrency, "max concurrent task")
 	waitDuration            = flag.Duration("task-wait-duration", 2*time.Minute, "task wait duration")
-	schedulerInterval       = flag.Duration("scheduler-interval", scheduler.CheckTaskFinishedInterval, "scheduler interval")
+	schedulerInterval       = flag.Duration("scheduler-interval", variable.DefTiDBDistTaskCheckInterval, "scheduler interval")
 	taskExecutorMgrInterval = flag.Duration("task-executor-mgr-interval", taskexecutor.TaskCheckInterval, "task executor mgr interval")
 	taskMetaSize            = flag.Int("task-meta-size", 1<<10, "task meta size")
 	noTask                  = flag.Bool("no-task", false, "no task")
@@ -61,45 +61,45 @@ func BenchmarkSchedulerOverhead(b *testing.B) {
 		cancel()
 		statusWG.Wait()
 	}()
-	schIntervalBak := scheduler.CheckTaskFinishedInterval
+	schIntervalBak := variable.DistTaskCheckInterval.Load()
 	exeMgrIntervalBak := taskexecutor.TaskCheckInterval
-	bak := proto.MaxConcurrentTask
+	bak := variable.DistTaskDispatchConcurrency.Load()
 	b.Cleanup(func() {
-		proto.MaxConcurrentTask = bak
-		scheduler.CheckTaskFinishedInterval = schIntervalBak
+		variable.DistTaskDispatchConcurrency.Store(bak)
+		variable.DistTaskCheckInterval.Store(schIntervalBak)
 		taskexecutor.TaskCheckInterval = exeMgrIntervalBak
 	})
-	proto.MaxConcurrentTask = *maxConcurrentTask
-	scheduler.CheckTaskFinishedInterval = *schedulerInterval
+	variable.DistTaskDispatchConcurrency.Store(int32(*maxConcurrentTask))
+	variable.DistTaskCheckInterval.Store(*schedulerInterval)
 	taskexecutor.TaskCheckInterval = *taskExecutorMgrInterval
 
-	b.Logf("max concurrent task: %d", proto.MaxConcurrentTask)
+	b.Logf("max concurrent task: %d", *maxConcurrentTask)
 	b.Logf("taks wait duration: %s", *waitDuration)
 	b.Logf("task meta size: %d", *taskMetaSize)
-	b.Logf("scheduler interval: %s", scheduler.CheckTaskFinishedInterval)
+	b.Logf("scheduler interval: %s", *schedulerInterval)
 	b.Logf("task executor mgr interval: %s", taskexecutor.TaskCheckInterval)
 
 	prepareForBenchTest(b)
-	c := testutil.NewTestDXFContext(b, 1, 2*proto.MaxConcurrentTask, false)
+	c := testutil.NewTestDXFContext(b, 1, 2**maxConcurrentTask, false)
 
 	registerTaskTypeForBench(c)
 
 	if *noTask {
 		time.Sleep(*waitDuration)
 	} else {
-		// in this test, we will start 4*proto.MaxConcurrentTask tasks, but only
-		// proto.MaxConcurrentTask will be scheduled at the same time, for other
+		// in this test, we will start 4*maxConcurrentTask tasks, but only
+		// maxConcurrentTask will be scheduled at the same time, for other
 		// tasks will be in queue only to check the performance of querying them.
-		for i := 0; i < 4*proto.MaxConcurrentTask; i++ {
+		for i := 0; i < 4**maxConcurrentTask; i++ {
 			taskKey := fmt.Sprintf("task-%03d", i)
 			taskMeta := make([]byte, *taskMetaSize)
 			_, err := handle.SubmitTask(c.Ctx, taskKey, proto.TaskTypeExample, 1, "", taskMeta)
 			require.NoError(c.T, err)
 		}
 		// task has 2 steps, each step has 1 subtask，wait in serial to reduce WaitTask check overhead.
-		// only wait first proto.MaxConcurrentTask and exit
+		// only wait first maxConcurrentTask and exit
 		time.Sleep(2 * *waitDuration)
-		for i := 0; i < proto.MaxConcurrentTask; i++ {
+		for i := 0; i < *maxConcurrentTask; i++ {
 			taskKey := fmt.Sprintf("task-%03d", i)
 			testutil.WaitTaskDoneOrPaused(c.Ctx, c.T, taskKey)
 		}
@@ -173,3 +173,30 @@ func TestTargetScope(t *testing.T) {
 		})
 	}
 }
+
+func TestSubtaskAffinity(t *testing.T) {
+	c := testutil.NewTestDXFContext(t, 3, 16, true)
+	schedulerExt := testutil.GetMockSchedulerExt(c.MockCtrl, testutil.SchedulerInfo{
+		AllErrorRetryable: true,
+		StepInfos: []testutil.StepInfo{
+			{Step: proto.StepOne, SubtaskCnt: 1},
+			{Step: proto.StepTwo, SubtaskCnt: 3},
+		},
+	})
+	testutil.RegisterTaskMeta(t, c.MockCtrl, schedulerExt, c.TestContext, nil)
+	subtaskCnts := map[proto.Step]int{proto.StepOne: 1, proto.StepTwo: 3}
+
+	// subtasks of step two are spread over all nodes by default.
+	taskID := submitTaskAndCheckSuccess(c.Ctx, t, "key1", "", c.TestContext, subtaskCnts)
+	checkSubtaskOnNodes(c.Ctx, t, taskID, []string{":4000", ":4001", ":4002"})
+
+	// subtasks of step two stay on the node which runs step one.
+	scheduler.RegisterSubtaskAffinity(proto.TaskTypeExample, true)
+	t.Cleanup(scheduler.ClearSubtaskAffinity)
+	taskID = submitTaskAndCheckSuccess(c.Ctx, t, "key2", "", c.TestContext, subtaskCnts)
+	mgr, err := storage.GetTaskManager()
+	require.NoError(t, err)
+	nodes, err := testutil.GetSubtaskNodes(c.Ctx, mgr, taskID)
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
 	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
@@ -134,6 +135,91 @@ func TestFrameworkCancelTask(t *testing.T) {
 	require.Equal(t, proto.TaskStateReverted, task.State)
 }
 
+func TestFrameworkCancelTaskBySQL(t *testing.T) {
+	c := testutil.NewTestDXFContext(t, 2, 16, true)
+
+	testutil.RegisterTaskMeta(t, c.MockCtrl, testutil.GetMockBasicSchedulerExt(c.MockCtrl), c.TestContext, nil)
+	tk := testkit.NewTestKit(t, c.Store)
+
+	tk.MustQuery("admin cancel task 1000").Check(testkit.Rows("1000 error: task 1000 not found"))
+
+	var once sync.Once
+	testfailpoint.EnableCall(t, "github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/syncAfterSubtaskFinish", func() {
+		once.Do(func() {
+			taskMgr, err := storage.GetTaskManager()
+			require.NoError(t, err)
+			task, err := taskMgr.GetTaskByKey(c.Ctx, "key1")
+			require.NoError(t, err)
+			id := fmt.Sprintf("%d", task.ID)
+			testkit.NewTestKit(t, c.Store).MustQuery("admin cancel task " + id).Check(testkit.Rows(id + " successful"))
+		})
+	})
+	task := testutil.SubmitAndWaitTask(c.Ctx, t, "key1", "", 1)
+	require.Equal(t, proto.TaskStateReverted, task.State)
+	id := fmt.Sprintf("%d", task.ID)
+	tk.MustQuery("admin cancel task " + id).Check(testkit.Rows(
+		id + " error: task " + id + " is in reverted state, only pending or running task can be cancelled"))
+}
+
+func TestFrameworkInfoSchemaTables(t *testing.T) {
+	c := testutil.NewTestDXFContext(t, 2, 16, true)
+
+	testutil.RegisterTaskMeta(t, c.MockCtrl, testutil.GetMockBasicSchedulerExt(c.MockCtrl), c.TestContext, nil)
+	tk := testkit.NewTestKit(t, c.Store)
+	tk.MustQuery("select * from information_schema.tidb_global_tasks").Check(testkit.Rows())
+	tk.MustQuery("select * from information_schema.tidb_background_subtasks").Check(testkit.Rows())
+
+	var once sync.Once
+	testfailpoint.EnableCall(t, "github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/syncAfterSubtaskFinish", func() {
+		once.Do(func() {
+			tk := testkit.NewTestKit(t, c.Store)
+			tk.MustQuery("select task_key, type, state, concurrency, error is null from information_schema.tidb_global_tasks").
+				Check(testkit.Rows("key1 Example running 1 1"))
+			tk.MustQuery("select row_count, bytes, progress between 0 and 100 from information_schema.tidb_global_tasks").
+				Check(testkit.Rows("0 0 1"))
+			rs := tk.MustQuery("select distinct task_id, type from information_schema.tidb_background_subtasks").Rows()
+			require.Len(t, rs, 1)
+			require.Equal(t, "Example", rs[0][1])
+		})
+	})
+	task := testutil.SubmitAndWaitTask(c.Ctx, t, "key1", "", 1)
+	require.Equal(t, proto.TaskStateSucceed, task.State)
+}
+
+func TestFrameworkTaskDependencies(t *testing.T) {
+	c := testutil.NewTestDXFContext(t, 1, 16, true)
+
+	testutil.RegisterTaskMeta(t, c.MockCtrl, testutil.GetMockBasicSchedulerExt(c.MockCtrl), c.TestContext, nil)
+	_, err := handle.SubmitTaskWithDependencies(c.Ctx, "key0", proto.TaskTypeExample, 1, "", nil, []int64{1000})
+	require.ErrorContains(t, err, "dependency task 1000 not found")
+
+	// key2 is scheduled after key1 succeed.
+	task1, err := handle.SubmitTask(c.Ctx, "key1", proto.TaskTypeExample, 1, "", nil)
+	require.NoError(t, err)
+	task2, err := handle.SubmitTaskWithDependencies(c.Ctx, "key2", proto.TaskTypeExample, 1, "", nil, []int64{task1.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int64{task1.ID}, task2.Dependencies)
+	require.Equal(t, proto.TaskStateSucceed, testutil.WaitTaskDone(c.Ctx, t, "key2").State)
+	task1, err = c.TaskMgr.GetTaskByIDWithHistory(c.Ctx, task1.ID)
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateSucceed, task1.State)
+	task2, err = c.TaskMgr.GetTaskByIDWithHistory(c.Ctx, task2.ID)
+	require.NoError(t, err)
+	require.False(t, task2.StartTime.Before(task1.StateUpdateTime))
+
+	// key4 fails when key3 is reverted.
+	testfailpoint.Enable(t, "github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/MockExecutorRunErr", "1*return(true)")
+	task3, err := handle.SubmitTask(c.Ctx, "key3", proto.TaskTypeExample, 1, "", nil)
+	require.NoError(t, err)
+	task4, err := handle.SubmitTaskWithDependencies(c.Ctx, "key4", proto.TaskTypeExample, 1, "", nil, []int64{task3.ID})
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateFailed, testutil.WaitTaskDone(c.Ctx, t, "key4").State)
+	require.Equal(t, proto.TaskStateReverted, testutil.WaitTaskDone(c.Ctx, t, "key3").State)
+	task4, err = c.TaskMgr.GetTaskByIDWithHistory(c.Ctx, task4.ID)
+	require.NoError(t, err)
+	require.ErrorContains(t, task4.Error, fmt.Sprintf("dependency task %d is reverted", task3.ID))
+}
+
 func TestFrameworkSubTaskFailed(t *testing.T) {
 	c := testutil.NewTestDXFContext(t, 1, 16, true)
 
@@ -143,6 +229,35 @@ func TestFrameworkSubTaskFailed(t *testing.T) {
 	require.Equal(t, proto.TaskStateReverted, task.State)
 }
 
+func TestFrameworkSubTaskFailedRetry(t *testing.T) {
+	c := testutil.NewTestDXFContext(t, 2, 16, true)
+
+	testutil.RegisterTaskMeta(t, c.MockCtrl, testutil.GetMockBasicSchedulerExt(c.MockCtrl), c.TestContext, nil)
+	scheduler.RegisterSubtaskMaxRetry(proto.TaskTypeExample, 1)
+	t.Cleanup(scheduler.ClearSubtaskMaxRetry)
+	bak := scheduler.RetrySubtaskInterval
+	scheduler.RetrySubtaskInterval = 100 * time.Millisecond
+	t.Cleanup(func() {
+		scheduler.RetrySubtaskInterval = bak
+	})
+
+	// the failed subtask is retried and succeeds.
+	testfailpoint.Enable(t, "github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/MockExecutorRunErr", "1*return(true)")
+	task := testutil.SubmitAndWaitTask(c.Ctx, t, "key1", "", 1)
+	require.Equal(t, proto.TaskStateSucceed, task.State)
+	rs, err := c.TaskMgr.ExecuteSQLWithNewSession(c.Ctx, `select cast(sum(retry_count) as signed) from (
+		select retry_count from mysql.tidb_background_subtask where task_key = %?
+		union all
+		select retry_count from mysql.tidb_background_subtask_history where task_key = %?) t`, task.ID, task.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, rs[0].GetInt64(0))
+
+	// the subtask fails again after reaching the max retry count.
+	testfailpoint.Enable(t, "github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/MockExecutorRunErr", "return(true)")
+	task = testutil.SubmitAndWaitTask(c.Ctx, t, "key2", "", 1)
+	require.Equal(t, proto.TaskStateReverted, task.State)
+}
+
 func TestFrameworkSubTaskInitEnvFailed(t *testing.T) {
 	c := testutil.NewTestDXFContext(t, 1, 16, true)
 	testutil.RegisterTaskMeta(t, c.MockCtrl, testutil.GetMockBasicSchedulerExt(c.MockCtrl), c.TestContext, nil)
@@ -199,6 +314,65 @@ func TestGC(t *testing.T) {
 	}, 10*time.Second, 500*time.Millisecond)
 }
 
+func TestAdminCleanupTaskHistory(t *testing.T) {
+	c := testutil.NewTestDXFContext(t, 1, 16, true)
+
+	testutil.RegisterTaskMeta(t, c.MockCtrl, testutil.GetMockBasicSchedulerExt(c.MockCtrl), c.TestContext, nil)
+	submitTaskAndCheckSuccessForBasic(c.Ctx, t, "key1", c.TestContext)
+
+	tk := testkit.NewTestKit(t, c.Store)
+	require.Eventually(t, func() bool {
+		rs := tk.MustQuery("select count(1) from mysql.tidb_global_task_history").Rows()
+		return rs[0][0] == "1"
+	}, 10*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select count(1) > 0 from mysql.tidb_background_subtask_history").Check(testkit.Rows("1"))
+
+	tk.MustExec("admin cleanup task history")
+	tk.MustQuery("select count(1) from mysql.tidb_global_task_history").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(1) from mysql.tidb_background_subtask_history").Check(testkit.Rows("0"))
+}
+
+func TestShowTaskHistory(t *testing.T) {
+	c := testutil.NewTestDXFContext(t, 1, 16, true)
+
+	testutil.RegisterTaskMeta(t, c.MockCtrl, testutil.GetMockBasicSchedulerExt(c.MockCtrl), c.TestContext, nil)
+	taskID := submitTaskAndCheckSuccessForBasic(c.Ctx, t, "key1", c.TestContext)
+
+	tk := testkit.NewTestKit(t, c.Store)
+	rs := tk.MustQuery(fmt.Sprintf("show task %d history", taskID)).Rows()
+	events := make([]string, 0, len(rs))
+	for _, r := range rs {
+		events = append(events, fmt.Sprintf("%s: %s", r[1], r[3]))
+	}
+	require.Equal(t, []string{
+		"state-change: pending -> running, step init -> one",
+		"assign: step one: 3 subtasks on :4000",
+		"state-change: running -> running, step one -> two",
+		"assign: step two: 1 subtasks on :4000",
+		"state-change: running -> succeed",
+	}, events)
+	require.ErrorContains(t, tk.QueryToErr("show task 12345 history"), "task 12345 not found")
+}
+
+func TestAdminShowTasks(t *testing.T) {
+	c := testutil.NewTestDXFContext(t, 1, 16, true)
+
+	testutil.RegisterTaskMeta(t, c.MockCtrl, testutil.GetMockBasicSchedulerExt(c.MockCtrl), c.TestContext, nil)
+	taskID1 := submitTaskAndCheckSuccessForBasic(c.Ctx, t, "key1", c.TestContext)
+	taskID2 := submitTaskAndCheckSuccessForBasic(c.Ctx, t, "key2", c.TestContext)
+
+	tk := testkit.NewTestKit(t, c.Store)
+	// tasks are moved to history after finished, they are shown too.
+	tk.MustQuery("admin show tasks").CheckAt([]int{0, 1, 2, 3}, testkit.Rows(
+		fmt.Sprintf("%d key2 Example succeed", taskID2),
+		fmt.Sprintf("%d key1 Example succeed", taskID1),
+	))
+	tk.MustQuery("admin show background tasks where task_key = 'key1'").CheckAt([]int{0, 1}, testkit.Rows(
+		fmt.Sprintf("%d key1", taskID1),
+	))
+	tk.MustQuery("admin show tasks where state = 'running'").Check(testkit.Rows())
+}
+
 func TestFrameworkSubtaskFinishedCancel(t *testing.T) {
 	c := testutil.NewTestDXFContext(t, 3, 16, true)
 
@@ -131,6 +131,20 @@ func (mr *MockStepExecutorMockRecorder) RunSubtask(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunSubtask", reflect.TypeOf((*MockStepExecutor)(nil).RunSubtask), arg0, arg1)
 }
 
+// UpdateCheckpoint mocks base method.
+func (m *MockStepExecutor) UpdateCheckpoint(arg0 context.Context, arg1 int64, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCheckpoint", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCheckpoint indicates an expected call of UpdateCheckpoint.
+func (mr *MockStepExecutorMockRecorder) UpdateCheckpoint(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCheckpoint", reflect.TypeOf((*MockStepExecutor)(nil).UpdateCheckpoint), arg0, arg1, arg2)
+}
+
 // restricted mocks base method.
 func (m *MockStepExecutor) restricted() {
 	m.ctrl.T.Helper()
@@ -281,6 +281,20 @@ func (mr *MockTaskManagerMockRecorder) DeleteDeadNodes(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeadNodes", reflect.TypeOf((*MockTaskManager)(nil).DeleteDeadNodes), arg0, arg1)
 }
 
+// FailRunningSubtask mocks base method.
+func (m *MockTaskManager) FailRunningSubtask(arg0 context.Context, arg1 int64, arg2 error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailRunningSubtask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailRunningSubtask indicates an expected call of FailRunningSubtask.
+func (mr *MockTaskManagerMockRecorder) FailRunningSubtask(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailRunningSubtask", reflect.TypeOf((*MockTaskManager)(nil).FailRunningSubtask), arg0, arg1, arg2)
+}
+
 // FailTask mocks base method.
 func (m *MockTaskManager) FailTask(arg0 context.Context, arg1 int64, arg2 proto.TaskState, arg3 error) error {
 	m.ctrl.T.Helper()
@@ -295,18 +309,18 @@ func (mr *MockTaskManagerMockRecorder) FailTask(arg0, arg1, arg2, arg3 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailTask", reflect.TypeOf((*MockTaskManager)(nil).FailTask), arg0, arg1, arg2, arg3)
 }
 
-// GCSubtasks mocks base method.
-func (m *MockTaskManager) GCSubtasks(arg0 context.Context) error {
+// GCHistory mocks base method.
+func (m *MockTaskManager) GCHistory(arg0 context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GCSubtasks", arg0)
+	ret := m.ctrl.Call(m, "GCHistory", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GCSubtasks indicates an expected call of GCSubtasks.
-func (mr *MockTaskManagerMockRecorder) GCSubtasks(arg0 any) *gomock.Call {
+// GCHistory indicates an expected call of GCHistory.
+func (mr *MockTaskManagerMockRecorder) GCHistory(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GCSubtasks", reflect.TypeOf((*MockTaskManager)(nil).GCSubtasks), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GCHistory", reflect.TypeOf((*MockTaskManager)(nil).GCHistory), arg0)
 }
 
 // GetActiveSubtasks mocks base method.
@@ -429,6 +443,21 @@ func (mr *MockTaskManagerMockRecorder) GetTaskByID(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTaskManager)(nil).GetTaskByID), arg0, arg1)
 }
 
+// GetTaskStatesWithHistory mocks base method.
+func (m *MockTaskManager) GetTaskStatesWithHistory(arg0 context.Context, arg1 []int64) (map[int64]proto.TaskState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskStatesWithHistory", arg0, arg1)
+	ret0, _ := ret[0].(map[int64]proto.TaskState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskStatesWithHistory indicates an expected call of GetTaskStatesWithHistory.
+func (mr *MockTaskManagerMockRecorder) GetTaskStatesWithHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStatesWithHistory", reflect.TypeOf((*MockTaskManager)(nil).GetTaskStatesWithHistory), arg0, arg1)
+}
+
 // GetTasksInStates mocks base method.
 func (m *MockTaskManager) GetTasksInStates(arg0 context.Context, arg1 ...any) ([]*proto.Task, error) {
 	m.ctrl.T.Helper()
@@ -479,6 +508,21 @@ func (mr *MockTaskManagerMockRecorder) GetUsedSlotsOnNodes(arg0 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedSlotsOnNodes", reflect.TypeOf((*MockTaskManager)(nil).GetUsedSlotsOnNodes), arg0)
 }
 
+// ModifyTaskConcurrency mocks base method.
+func (m *MockTaskManager) ModifyTaskConcurrency(arg0 context.Context, arg1 int64, arg2 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyTaskConcurrency", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyTaskConcurrency indicates an expected call of ModifyTaskConcurrency.
+func (mr *MockTaskManagerMockRecorder) ModifyTaskConcurrency(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyTaskConcurrency", reflect.TypeOf((*MockTaskManager)(nil).ModifyTaskConcurrency), arg0, arg1, arg2)
+}
+
 // PauseTask mocks base method.
 func (m *MockTaskManager) PauseTask(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -536,6 +580,20 @@ func (mr *MockTaskManagerMockRecorder) ResumedTask(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumedTask", reflect.TypeOf((*MockTaskManager)(nil).ResumedTask), arg0, arg1)
 }
 
+// RetrySubtask mocks base method.
+func (m *MockTaskManager) RetrySubtask(arg0 context.Context, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrySubtask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetrySubtask indicates an expected call of RetrySubtask.
+func (mr *MockTaskManagerMockRecorder) RetrySubtask(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrySubtask", reflect.TypeOf((*MockTaskManager)(nil).RetrySubtask), arg0, arg1, arg2)
+}
+
 // RevertTask mocks base method.
 func (m *MockTaskManager) RevertTask(arg0 context.Context, arg1 int64, arg2 proto.TaskState, arg3 error) error {
 	m.ctrl.T.Helper()
@@ -61,6 +61,20 @@ func (mr *MockTaskTableMockRecorder) CancelSubtask(arg0, arg1, arg2 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSubtask", reflect.TypeOf((*MockTaskTable)(nil).CancelSubtask), arg0, arg1, arg2)
 }
 
+// DeleteDeadNodes mocks base method.
+func (m *MockTaskTable) DeleteDeadNodes(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeadNodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeadNodes indicates an expected call of DeleteDeadNodes.
+func (mr *MockTaskTableMockRecorder) DeleteDeadNodes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeadNodes", reflect.TypeOf((*MockTaskTable)(nil).DeleteDeadNodes), arg0, arg1)
+}
+
 // FailSubtask mocks base method.
 func (m *MockTaskTable) FailSubtask(arg0 context.Context, arg1 string, arg2 int64, arg3 error) error {
 	m.ctrl.T.Helper()
@@ -284,6 +298,20 @@ func (mr *MockTaskTableMockRecorder) StartSubtask(arg0, arg1, arg2 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSubtask", reflect.TypeOf((*MockTaskTable)(nil).StartSubtask), arg0, arg1, arg2)
 }
 
+// UpdateSubtaskCheckpoint mocks base method.
+func (m *MockTaskTable) UpdateSubtaskCheckpoint(arg0 context.Context, arg1 string, arg2 int64, arg3 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubtaskCheckpoint", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubtaskCheckpoint indicates an expected call of UpdateSubtaskCheckpoint.
+func (mr *MockTaskTableMockRecorder) UpdateSubtaskCheckpoint(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubtaskCheckpoint", reflect.TypeOf((*MockTaskTable)(nil).UpdateSubtaskCheckpoint), arg0, arg1, arg2, arg3)
+}
+
 // UpdateSubtaskStateAndError mocks base method.
 func (m *MockTaskTable) UpdateSubtaskStateAndError(arg0 context.Context, arg1 string, arg2 int64, arg3 proto.SubtaskState, arg4 error) error {
 	m.ctrl.T.Helper()
@@ -430,6 +458,18 @@ func (mr *MockTaskExecutorMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockTaskExecutor)(nil).Close))
 }
 
+// Drain mocks base method.
+func (m *MockTaskExecutor) Drain() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Drain")
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockTaskExecutorMockRecorder) Drain() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockTaskExecutor)(nil).Drain))
+}
+
 // GetTaskBase mocks base method.
 func (m *MockTaskExecutor) GetTaskBase() *proto.TaskBase {
 	m.ctrl.T.Helper()
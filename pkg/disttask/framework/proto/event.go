@@ -0,0 +1,49 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto
+
+import "time"
+
+// event types of task, they are appended to the event history of the task for
+// post-mortem debugging.
+const (
+	// TaskEventStateChange is the event when the task state or step changes.
+	TaskEventStateChange TaskEventType = "state-change"
+	// TaskEventAssign is the event when subtasks are assigned to nodes.
+	TaskEventAssign TaskEventType = "assign"
+	// TaskEventError is the event when the task or its subtask meets error.
+	TaskEventError TaskEventType = "error"
+	// TaskEventRetry is the event when a failed subtask is retried.
+	TaskEventRetry TaskEventType = "retry"
+)
+
+// TaskEventType is the type of task event.
+type TaskEventType string
+
+func (t TaskEventType) String() string {
+	return string(t)
+}
+
+// TaskEvent is an event in the history of a task.
+type TaskEvent struct {
+	ID     int64
+	TaskID int64
+	Type   TaskEventType
+	// ExecID is the node where the event happens, it's empty for events
+	// happen on the scheduler side.
+	ExecID     string
+	Message    string
+	CreateTime time.Time
+}
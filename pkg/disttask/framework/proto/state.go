@@ -0,0 +1,97 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto
+
+import "slices"
+
+// taskStateTransforms defines the legal state transitions of task, see the
+// state diagram of TaskState.
+// any non-final state can be transformed to failed, as the task might be failed
+// directly by the framework, such as when the task type is unknown.
+var taskStateTransforms = map[TaskState][]TaskState{
+	TaskStatePending: {
+		TaskStateRunning,
+		TaskStateSucceed,
+		TaskStateReverting,
+		TaskStateFailed,
+		TaskStateCancelling,
+		TaskStatePausing,
+	},
+	TaskStateRunning: {
+		TaskStateSucceed,
+		TaskStateReverting,
+		TaskStateFailed,
+		TaskStateCancelling,
+		TaskStatePausing,
+	},
+	TaskStateSucceed: {},
+	TaskStateReverting: {
+		TaskStateReverted,
+		TaskStateFailed,
+	},
+	TaskStateReverted: {},
+	TaskStateFailed:   {},
+	TaskStateCancelling: {
+		TaskStateReverting,
+		TaskStateFailed,
+	},
+	TaskStatePausing: {
+		TaskStatePaused,
+		TaskStateFailed,
+	},
+	TaskStatePaused: {
+		TaskStateResuming,
+		TaskStateFailed,
+	},
+	TaskStateResuming: {
+		TaskStateRunning,
+		TaskStateFailed,
+	},
+}
+
+// subtaskStateTransforms defines the legal state transitions of subtask, see
+// the state diagram of SubtaskState.
+var subtaskStateTransforms = map[SubtaskState][]SubtaskState{
+	SubtaskStatePending: {
+		SubtaskStateRunning,
+		SubtaskStateFailed,
+		SubtaskStateCanceled,
+		SubtaskStatePaused,
+	},
+	SubtaskStateRunning: {
+		SubtaskStatePending,
+		SubtaskStateSucceed,
+		SubtaskStateFailed,
+		SubtaskStateCanceled,
+		SubtaskStatePaused,
+	},
+	SubtaskStateSucceed: {},
+	// failed subtask can be retried.
+	SubtaskStateFailed:   {SubtaskStatePending},
+	SubtaskStateCanceled: {},
+	SubtaskStatePaused:   {SubtaskStatePending},
+}
+
+// VerifyTaskStateTransform verifies whether the task state transform is valid,
+// transform to the same state is always valid.
+func VerifyTaskStateTransform(from, to TaskState) bool {
+	return from == to || slices.Contains(taskStateTransforms[from], to)
+}
+
+// VerifySubtaskStateTransform verifies whether the subtask state transform is
+// valid, transform to the same state is always valid.
+func VerifySubtaskStateTransform(from, to SubtaskState) bool {
+	return from == to || slices.Contains(subtaskStateTransforms[from], to)
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyTaskStateTransform(t *testing.T) {
+	testCases := []struct {
+		from   TaskState
+		to     TaskState
+		expect bool
+	}{
+		{TaskStateRunning, TaskStateRunning, true},
+		{TaskStatePending, TaskStateRunning, true},
+		{TaskStatePending, TaskStateReverting, true},
+		{TaskStateRunning, TaskStateReverting, true},
+		{TaskStateReverting, TaskStateReverted, true},
+		{TaskStateReverting, TaskStateSucceed, false},
+		{TaskStateRunning, TaskStatePausing, true},
+		{TaskStateRunning, TaskStateResuming, false},
+		{TaskStateCancelling, TaskStateRunning, false},
+		{TaskStatePaused, TaskStateFailed, true},
+		{TaskStateSucceed, TaskStateFailed, false},
+		{TaskStateReverted, TaskStateRunning, false},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expect, VerifyTaskStateTransform(tc.from, tc.to), "%s -> %s", tc.from, tc.to)
+	}
+}
+
+func TestVerifySubtaskStateTransform(t *testing.T) {
+	testCases := []struct {
+		from   SubtaskState
+		to     SubtaskState
+		expect bool
+	}{
+		{SubtaskStatePending, SubtaskStatePending, true},
+		{SubtaskStatePending, SubtaskStateRunning, true},
+		{SubtaskStatePending, SubtaskStateSucceed, false},
+		{SubtaskStateRunning, SubtaskStatePending, true},
+		{SubtaskStateRunning, SubtaskStateSucceed, true},
+		{SubtaskStateFailed, SubtaskStatePending, true},
+		{SubtaskStatePaused, SubtaskStatePending, true},
+		{SubtaskStateSucceed, SubtaskStateRunning, false},
+		{SubtaskStateCanceled, SubtaskStatePending, false},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expect, VerifySubtaskStateTransform(tc.from, tc.to), "%s -> %s", tc.from, tc.to)
+	}
+}
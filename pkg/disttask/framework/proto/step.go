@@ -41,6 +41,12 @@ func Step2Str(t TaskType, s Step) string {
 		return backfillStep2Str(s)
 	case ImportInto:
 		return importIntoStep2Str(s)
+	case Analyze:
+		return analyzeStep2Str(s)
+	case Checksum:
+		return checksumStep2Str(s)
+	case TTL:
+		return ttlStep2Str(s)
 	case TaskTypeExample:
 		return exampleStep2Str(s)
 	}
@@ -136,3 +142,64 @@ func backfillStep2Str(s Step) string {
 		return fmt.Sprintf("unknown step %d", s)
 	}
 }
+
+// Steps of distributed analyze.
+// the initial step is StepInit(-1)
+// steps are processed in the following order:
+// StepInit -> AnalyzeStepPartition -> AnalyzeStepMergeGlobalStats -> StepDone
+const (
+	// AnalyzeStepPartition analyzes each partition of the table, one subtask
+	// per partition.
+	AnalyzeStepPartition Step = 1
+	// AnalyzeStepMergeGlobalStats merges partition stats into global stats.
+	AnalyzeStepMergeGlobalStats Step = 2
+)
+
+func analyzeStep2Str(s Step) string {
+	switch s {
+	case AnalyzeStepPartition:
+		return "analyze-partition"
+	case AnalyzeStepMergeGlobalStats:
+		return "merge-global-stats"
+	default:
+		return fmt.Sprintf("unknown step %d", s)
+	}
+}
+
+// Steps of distributed checksum table.
+// the initial step is StepInit(-1)
+// steps are processed in the following order:
+// StepInit -> ChecksumStepCompute -> StepDone
+const (
+	// ChecksumStepCompute computes the checksum of each key range of the table,
+	// the partial checksums are aggregated by the scheduler when the task is done.
+	ChecksumStepCompute Step = 1
+)
+
+func checksumStep2Str(s Step) string {
+	switch s {
+	case ChecksumStepCompute:
+		return "compute"
+	default:
+		return fmt.Sprintf("unknown step %d", s)
+	}
+}
+
+// Steps of TTL.
+// the initial step is StepInit(-1)
+// steps are processed in the following order:
+// StepInit -> TTLStepDeleteExpired -> StepDone
+const (
+	// TTLStepDeleteExpired scans and deletes the expired rows, one subtask
+	// per scan range.
+	TTLStepDeleteExpired Step = 1
+)
+
+func ttlStep2Str(s Step) string {
+	switch s {
+	case TTLStepDeleteExpired:
+		return "delete-expired"
+	default:
+		return fmt.Sprintf("unknown step %d", s)
+	}
+}
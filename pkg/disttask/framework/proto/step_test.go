@@ -39,6 +39,25 @@ func TestStep(t *testing.T) {
 	require.Equal(t, "done", Step2Str(ImportInto, StepDone))
 	require.Equal(t, "unknown step 123", Step2Str(ImportInto, 123))
 
+	// analyze type
+	require.Equal(t, "init", Step2Str(Analyze, StepInit))
+	require.Equal(t, "analyze-partition", Step2Str(Analyze, AnalyzeStepPartition))
+	require.Equal(t, "merge-global-stats", Step2Str(Analyze, AnalyzeStepMergeGlobalStats))
+	require.Equal(t, "done", Step2Str(Analyze, StepDone))
+	require.Equal(t, "unknown step 444", Step2Str(Analyze, 444))
+
+	// checksum type
+	require.Equal(t, "init", Step2Str(Checksum, StepInit))
+	require.Equal(t, "compute", Step2Str(Checksum, ChecksumStepCompute))
+	require.Equal(t, "done", Step2Str(Checksum, StepDone))
+	require.Equal(t, "unknown step 555", Step2Str(Checksum, 555))
+
+	// ttl type
+	require.Equal(t, "init", Step2Str(TTL, StepInit))
+	require.Equal(t, "delete-expired", Step2Str(TTL, TTLStepDeleteExpired))
+	require.Equal(t, "done", Step2Str(TTL, StepDone))
+	require.Equal(t, "unknown step 666", Step2Str(TTL, 666))
+
 	// example type
 	require.Equal(t, "init", Step2Str(TaskTypeExample, StepInit))
 	require.Equal(t, "one", Step2Str(TaskTypeExample, StepOne))
@@ -112,6 +112,13 @@ type Subtask struct {
 	// On other code path, this field should be read-only.
 	Meta    []byte
 	Summary string
+	// RetryCount is the number of times the subtask has been retried after
+	// it failed, see scheduler.RegisterSubtaskMaxRetry.
+	RetryCount int
+	// Checkpoint is the last checkpoint saved by StepExecutor during RunSubtask,
+	// it's nil if no checkpoint is saved. When the subtask is run again, such
+	// as after the node restarts, StepExecutor can resume from it.
+	Checkpoint []byte
 }
 
 // NewSubtask create a new subtask.
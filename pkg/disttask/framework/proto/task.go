@@ -73,12 +73,12 @@ const (
 	TaskIDLabelName = "task_id"
 	// NormalPriority represents the normal priority of task.
 	NormalPriority = 512
+	// MinPriority is the min valid priority of task, it's the highest priority.
+	MinPriority = 1
+	// MaxPriority is the max valid priority of task, it's the lowest priority.
+	MaxPriority = 1024
 )
 
-// MaxConcurrentTask is the max concurrency of task.
-// TODO: remove this limit later.
-var MaxConcurrentTask = 16
-
 // TaskBase contains the basic information of a task.
 // we define this to avoid load task meta which might be very large into memory.
 type TaskBase struct {
@@ -99,6 +99,9 @@ type TaskBase struct {
 	// if there is no such nodes, will try nodes of "" scope.
 	TargetScope string
 	CreateTime  time.Time
+	// Dependencies are IDs of the tasks which must succeed before this task
+	// can be scheduled.
+	Dependencies []int64
 }
 
 // IsDone checks if the task is done.
@@ -158,6 +161,24 @@ type Task struct {
 	Error error
 }
 
+// TaskProgress is the progress of a task on its current step, it's aggregated
+// from the summary of the subtasks of the step.
+type TaskProgress struct {
+	Step        Step
+	SubtaskCnt  int64
+	FinishedCnt int64
+	RowCount    int64
+	Bytes       int64
+}
+
+// Percent returns the percentage of succeed subtasks of the step.
+func (p *TaskProgress) Percent() float64 {
+	if p.SubtaskCnt == 0 {
+		return 0
+	}
+	return float64(p.FinishedCnt) * 100 / float64(p.SubtaskCnt)
+}
+
 var (
 	// EmptyMeta is the empty meta of task/subtask.
 	EmptyMeta = []byte("{}")
@@ -72,3 +72,10 @@ func TestTaskCompare(t *testing.T) {
 	taskB.ID = taskA.ID + 10
 	require.Less(t, taskA.CompareTask(&taskB), 0)
 }
+
+func TestTaskProgressPercent(t *testing.T) {
+	require.Equal(t, float64(0), (&TaskProgress{}).Percent())
+	require.Equal(t, float64(0), (&TaskProgress{SubtaskCnt: 4}).Percent())
+	require.Equal(t, float64(25), (&TaskProgress{SubtaskCnt: 4, FinishedCnt: 1}).Percent())
+	require.Equal(t, float64(100), (&TaskProgress{SubtaskCnt: 4, FinishedCnt: 4}).Percent())
+}
@@ -21,6 +21,12 @@ const (
 	ImportInto TaskType = "ImportInto"
 	// Backfill is TaskType of add index Backfilling process.
 	Backfill TaskType = "backfill"
+	// Analyze is TaskType of distributed analyze.
+	Analyze TaskType = "analyze"
+	// Checksum is TaskType of distributed admin checksum table.
+	Checksum TaskType = "checksum"
+	// TTL is TaskType of deleting expired rows of a TTL table.
+	TTL TaskType = "ttl"
 )
 
 // Type2Int converts task type to int.
@@ -32,6 +38,12 @@ func Type2Int(t TaskType) int {
 		return 2
 	case Backfill:
 		return 3
+	case Analyze:
+		return 4
+	case Checksum:
+		return 5
+	case TTL:
+		return 6
 	default:
 		return 0
 	}
@@ -46,6 +58,12 @@ func Int2Type(i int) TaskType {
 		return ImportInto
 	case 3:
 		return Backfill
+	case 4:
+		return Analyze
+	case 5:
+		return Checksum
+	case 6:
+		return TTL
 	default:
 		return ""
 	}
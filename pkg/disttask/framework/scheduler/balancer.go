@@ -22,13 +22,14 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	llog "github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"go.uber.org/zap"
 )
 
 var (
 	// balanceCheckInterval is the interval to check whether we need to balance the subtasks.
-	balanceCheckInterval = 3 * CheckTaskFinishedInterval
+	balanceCheckInterval = 3 * variable.DefTiDBDistTaskCheckInterval
 )
 
 // balancer is used to balance subtasks on managed nodes
@@ -100,10 +101,10 @@ func (b *balancer) balanceSubtasks(ctx context.Context, sch Scheduler, managedNo
 	if len(eligibleNodes) == 0 {
 		return errors.New("no eligible nodes to balance subtasks")
 	}
-	return b.doBalanceSubtasks(ctx, task.ID, eligibleNodes)
+	return b.doBalanceSubtasks(ctx, task.ID, eligibleNodes, hasSubtaskAffinity(task.Type))
 }
 
-func (b *balancer) doBalanceSubtasks(ctx context.Context, taskID int64, eligibleNodes []string) (err error) {
+func (b *balancer) doBalanceSubtasks(ctx context.Context, taskID int64, eligibleNodes []string, affinity bool) (err error) {
 	subtasks, err := b.taskMgr.GetActiveSubtasks(ctx, taskID)
 	if err != nil {
 		return err
@@ -121,6 +122,15 @@ func (b *balancer) doBalanceSubtasks(ctx context.Context, taskID int64, eligible
 		// update used slots.
 		return nil
 	}
+	if affinity {
+		// subtasks are only balanced among the nodes they are assigned to,
+		// unless all of those nodes are dead or don't have enough slots.
+		usedNodes := make([]string, 0, len(subtasks))
+		for _, subtask := range subtasks {
+			usedNodes = append(usedNodes, subtask.ExecID)
+		}
+		adjustedNodes = PreferNodes(adjustedNodes, usedNodes)
+	}
 	adjustedNodeMap := make(map[string]struct{}, len(adjustedNodes))
 	for _, n := range adjustedNodes {
 		adjustedNodeMap[n] = struct{}{}
@@ -308,6 +308,86 @@ func TestBalanceOneTask(t *testing.T) {
 	})
 }
 
+func TestBalanceWithAffinity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	RegisterSubtaskAffinity(proto.TaskTypeExample, true)
+	t.Cleanup(ClearSubtaskAffinity)
+
+	testCases := []balanceTestCase{
+		// subtasks are balanced among the nodes they are assigned to only.
+		{
+			subtasks: []*proto.SubtaskBase{
+				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
+				{ID: 2, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
+				{ID: 3, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
+				{ID: 4, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
+			},
+			eligibleNodes: []string{"tidb1", "tidb2", "tidb3"},
+			initUsedSlots: map[string]int{"tidb1": 0, "tidb2": 0, "tidb3": 0},
+			expectedSubtasks: []*proto.SubtaskBase{
+				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
+				{ID: 2, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
+				{ID: 3, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
+				{ID: 4, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
+			},
+			expectedUsedSlots: map[string]int{"tidb1": 16, "tidb2": 16, "tidb3": 0},
+		},
+		// subtasks on dead node are moved to the other node used.
+		{
+			subtasks: []*proto.SubtaskBase{
+				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
+				{ID: 2, ExecID: "tidb4", Concurrency: 16, State: proto.SubtaskStatePending},
+			},
+			eligibleNodes: []string{"tidb1", "tidb2"},
+			initUsedSlots: map[string]int{"tidb1": 0, "tidb2": 0},
+			expectedSubtasks: []*proto.SubtaskBase{
+				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
+				{ID: 2, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
+			},
+			expectedUsedSlots: map[string]int{"tidb1": 16, "tidb2": 0},
+		},
+		// all nodes used are dead, fall back to other eligible nodes.
+		{
+			subtasks: []*proto.SubtaskBase{
+				{ID: 1, ExecID: "tidb3", Concurrency: 16, State: proto.SubtaskStatePending},
+				{ID: 2, ExecID: "tidb3", Concurrency: 16, State: proto.SubtaskStatePending},
+			},
+			eligibleNodes: []string{"tidb1", "tidb2"},
+			initUsedSlots: map[string]int{"tidb1": 0, "tidb2": 0},
+			expectedSubtasks: []*proto.SubtaskBase{
+				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
+				{ID: 2, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
+			},
+			expectedUsedSlots: map[string]int{"tidb1": 16, "tidb2": 16},
+		},
+	}
+	for i, c := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			mockTaskMgr := mock.NewMockTaskManager(ctrl)
+			mockTaskMgr.EXPECT().GetActiveSubtasks(gomock.Any(), gomock.Any()).Return(c.subtasks, nil)
+			mockTaskMgr.EXPECT().UpdateSubtasksExecIDs(gomock.Any(), gomock.Any()).Return(nil)
+			mockScheduler := mock.NewMockScheduler(ctrl)
+			mockScheduler.EXPECT().GetTask().Return(&proto.Task{TaskBase: proto.TaskBase{ID: 1, Type: proto.TaskTypeExample}}).Times(2)
+			mockScheduler.EXPECT().GetEligibleInstances(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+			slotMgr := newSlotManager()
+			slotMgr.updateCapacity(16)
+			b := newBalancer(Param{
+				taskMgr: mockTaskMgr,
+				nodeMgr: newNodeManager(""),
+				slotMgr: slotMgr,
+			})
+			b.currUsedSlots = c.initUsedSlots
+			require.NoError(t, b.balanceSubtasks(ctx, mockScheduler, c.eligibleNodes))
+			require.Equal(t, c.expectedUsedSlots, b.currUsedSlots)
+			require.Equal(t, c.expectedSubtasks, c.subtasks)
+			require.True(t, ctrl.Satisfied())
+		})
+	}
+}
+
 func TestBalanceMultipleTasks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
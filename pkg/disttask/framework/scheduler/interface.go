@@ -16,7 +16,11 @@ package scheduler
 
 import (
 	"context"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/sessionctx"
@@ -25,8 +29,9 @@ import (
 
 // TaskManager defines the interface to access task table.
 type TaskManager interface {
-	// GetTopUnfinishedTasks returns unfinished tasks, limited by MaxConcurrentTask*2,
+	// GetTopUnfinishedTasks returns unfinished tasks, limited by tidb_dist_task_dispatch_concurrency*2,
 	// to make sure lower rank tasks can be scheduled if resource is enough.
+	// Pending tasks with dependencies are not counted toward the limit.
 	// The returned tasks are sorted by task order, see proto.Task.
 	GetTopUnfinishedTasks(ctx context.Context) ([]*proto.TaskBase, error)
 	// GetAllSubtasks gets all subtasks with basic columns.
@@ -34,7 +39,10 @@ type TaskManager interface {
 	GetTasksInStates(ctx context.Context, states ...any) (task []*proto.Task, err error)
 	GetTaskByID(ctx context.Context, taskID int64) (task *proto.Task, err error)
 	GetTaskBaseByID(ctx context.Context, taskID int64) (task *proto.TaskBase, err error)
-	GCSubtasks(ctx context.Context) error
+	// GetTaskStatesWithHistory returns the states of the tasks in both task and
+	// task history table, tasks not found are not included.
+	GetTaskStatesWithHistory(ctx context.Context, taskIDs []int64) (map[int64]proto.TaskState, error)
+	GCHistory(ctx context.Context) error
 	GetAllNodes(ctx context.Context) ([]proto.ManagedNode, error)
 	DeleteDeadNodes(ctx context.Context, nodes []string) error
 	// TransferTasks2History transfer tasks, and it's related subtasks to history tables.
@@ -47,6 +55,8 @@ type TaskManager interface {
 	RevertTask(ctx context.Context, taskID int64, taskState proto.TaskState, taskErr error) error
 	// RevertedTask updates task state to reverted.
 	RevertedTask(ctx context.Context, taskID int64) error
+	// ModifyTaskConcurrency modifies the concurrency of a pending task.
+	ModifyTaskConcurrency(ctx context.Context, taskID int64, concurrency int) (bool, error)
 	// PauseTask updated task state to pausing.
 	PauseTask(ctx context.Context, taskKey string) (bool, error)
 	// PausedTask updated task state to paused.
@@ -79,6 +89,12 @@ type TaskManager interface {
 	ResumeSubtasks(ctx context.Context, taskID int64) error
 	GetSubtaskErrors(ctx context.Context, taskID int64) ([]error, error)
 	UpdateSubtasksExecIDs(ctx context.Context, subtasks []*proto.SubtaskBase) error
+	// RetrySubtask moves the failed subtask back to pending state on the node
+	// execID, and increases its retry count.
+	RetrySubtask(ctx context.Context, subtaskID int64, execID string) error
+	// FailRunningSubtask updates the subtask to failed state with the error if
+	// it's still running.
+	FailRunningSubtask(ctx context.Context, subtaskID int64, err error) error
 
 	// GetAllSubtasksByStepAndState gets all subtasks by given states for one step.
 	GetAllSubtasksByStepAndState(ctx context.Context, taskID int64, step proto.Step, state proto.SubtaskState) ([]*proto.Subtask, error)
@@ -141,11 +157,102 @@ type Param struct {
 // schedulerFactoryFn is used to create a scheduler.
 type schedulerFactoryFn func(ctx context.Context, task *proto.Task, param Param) Scheduler
 
+// ErrUnknownTaskType is the error when there is no scheduler factory registered
+// for the task type.
+var ErrUnknownTaskType = errors.New("unknown task type")
+
+type schedulerFactoryOptions struct {
+	// version is the version of the registered factory.
+	version int
+	// getVersion gets the version of the task from its meta.
+	getVersion func(meta []byte) (int, error)
+	// compatibleFactories are factories of older versions, key is version.
+	compatibleFactories map[int]schedulerFactoryFn
+}
+
+// SchedulerFactoryOption is the option of RegisterSchedulerFactory.
+type SchedulerFactoryOption func(opts *schedulerFactoryOptions)
+
+// WithVersion sets the version of the registered scheduler factory, and the
+// function to get the version of a task from its meta. Tasks of the version
+// are scheduled by the registered factory, tasks of older versions, such as
+// tasks persisted before upgrade, are scheduled by the factory registered by
+// WithCompatibleFactory.
+// Without this option, all tasks of the type are scheduled by the registered
+// factory.
+func WithVersion(version int, getVersion func(meta []byte) (int, error)) SchedulerFactoryOption {
+	return func(opts *schedulerFactoryOptions) {
+		opts.version = version
+		opts.getVersion = getVersion
+	}
+}
+
+// WithCompatibleFactory registers the scheduler factory for tasks of an older
+// version, see WithVersion.
+func WithCompatibleFactory(version int, ctor schedulerFactoryFn) SchedulerFactoryOption {
+	return func(opts *schedulerFactoryOptions) {
+		if opts.compatibleFactories == nil {
+			opts.compatibleFactories = make(map[int]schedulerFactoryFn)
+		}
+		if _, ok := opts.compatibleFactories[version]; ok {
+			// mark it as duplicated, checked in validate.
+			opts.compatibleFactories[version] = nil
+			return
+		}
+		opts.compatibleFactories[version] = ctor
+	}
+}
+
+type schedulerRegistration struct {
+	schedulerFactoryOptions
+	factory schedulerFactoryFn
+}
+
+func (r *schedulerRegistration) validate(taskType proto.TaskType) error {
+	if taskType == "" {
+		return errors.New("task type is empty")
+	}
+	if r.factory == nil {
+		return errors.Errorf("scheduler factory of task type %s is nil", taskType)
+	}
+	if len(r.compatibleFactories) > 0 && r.getVersion == nil {
+		return errors.Errorf("compatible scheduler factories of task type %s are registered without version", taskType)
+	}
+	if r.getVersion == nil {
+		return nil
+	}
+	if r.version <= 0 {
+		return errors.Errorf("invalid version %d of task type %s", r.version, taskType)
+	}
+	for version, ctor := range r.compatibleFactories {
+		if version <= 0 || version >= r.version {
+			return errors.Errorf("compatible version %d of task type %s should be in range [1, %d)",
+				version, taskType, r.version)
+		}
+		if ctor == nil {
+			return errors.Errorf("compatible scheduler factory of version %d of task type %s is nil or duplicated",
+				version, taskType)
+		}
+	}
+	return nil
+}
+
+// supportedVersions returns the sorted versions which can be scheduled.
+func (r *schedulerRegistration) supportedVersions() []int {
+	versions := make([]int, 0, len(r.compatibleFactories)+1)
+	for version := range r.compatibleFactories {
+		versions = append(versions, version)
+	}
+	versions = append(versions, r.version)
+	slices.Sort(versions)
+	return versions
+}
+
 var schedulerFactoryMap = struct {
 	syncutil.RWMutex
-	m map[proto.TaskType]schedulerFactoryFn
+	m map[proto.TaskType]*schedulerRegistration
 }{
-	m: make(map[proto.TaskType]schedulerFactoryFn),
+	m: make(map[proto.TaskType]*schedulerRegistration),
 }
 
 // RegisterSchedulerFactory is used to register the scheduler factory.
@@ -154,24 +261,263 @@ var schedulerFactoryMap = struct {
 // after the server start, there's should be no write to the map.
 // but for index backfill, the register call stack is so deep, not sure
 // if it's safe to do so, so we use a lock here.
-func RegisterSchedulerFactory(taskType proto.TaskType, ctor schedulerFactoryFn) {
+// Registering a task type again replaces the previous one, as DDL registers
+// backfill on each start.
+func RegisterSchedulerFactory(taskType proto.TaskType, ctor schedulerFactoryFn, opts ...SchedulerFactoryOption) error {
+	r := &schedulerRegistration{factory: ctor}
+	for _, opt := range opts {
+		opt(&r.schedulerFactoryOptions)
+	}
+	if err := r.validate(taskType); err != nil {
+		return err
+	}
 	schedulerFactoryMap.Lock()
 	defer schedulerFactoryMap.Unlock()
-	schedulerFactoryMap.m[taskType] = ctor
+	schedulerFactoryMap.m[taskType] = r
+	return nil
+}
+
+// isTaskTypeRegistered checks whether the scheduler factory of the task type
+// is registered.
+func isTaskTypeRegistered(taskType proto.TaskType) bool {
+	schedulerFactoryMap.RLock()
+	defer schedulerFactoryMap.RUnlock()
+	_, ok := schedulerFactoryMap.m[taskType]
+	return ok
 }
 
-// getSchedulerFactory is used to get the scheduler factory.
-func getSchedulerFactory(taskType proto.TaskType) schedulerFactoryFn {
+// getSchedulerFactory is used to get the scheduler factory which is compatible
+// with the version of the task.
+func getSchedulerFactory(task *proto.Task) (schedulerFactoryFn, error) {
 	schedulerFactoryMap.RLock()
 	defer schedulerFactoryMap.RUnlock()
-	return schedulerFactoryMap.m[taskType]
+	r, ok := schedulerFactoryMap.m[task.Type]
+	if !ok {
+		registered := make([]string, 0, len(schedulerFactoryMap.m))
+		for tp := range schedulerFactoryMap.m {
+			registered = append(registered, tp.String())
+		}
+		slices.Sort(registered)
+		return nil, errors.Annotatef(ErrUnknownTaskType, "task type %s is not registered, registered types: [%s]",
+			task.Type, strings.Join(registered, ", "))
+	}
+	if r.getVersion == nil {
+		return r.factory, nil
+	}
+	version, err := r.getVersion(task.Meta)
+	if err != nil {
+		return nil, errors.Annotatef(err, "get version of task %d of type %s", task.ID, task.Type)
+	}
+	if version == r.version {
+		return r.factory, nil
+	}
+	if ctor, ok := r.compatibleFactories[version]; ok {
+		return ctor, nil
+	}
+	return nil, errors.Errorf("unsupported version %d of task type %s, supported versions: %v",
+		version, task.Type, r.supportedVersions())
 }
 
 // ClearSchedulerFactory is only used in test.
 func ClearSchedulerFactory() {
 	schedulerFactoryMap.Lock()
 	defer schedulerFactoryMap.Unlock()
-	schedulerFactoryMap.m = make(map[proto.TaskType]schedulerFactoryFn)
+	schedulerFactoryMap.m = make(map[proto.TaskType]*schedulerRegistration)
+}
+
+var subtaskMaxRetryMap = struct {
+	syncutil.RWMutex
+	m map[proto.TaskType]int
+}{
+	m: make(map[proto.TaskType]int),
+}
+
+// RegisterSubtaskMaxRetry is used to register the max retry count of failed
+// subtasks of the task type. A failed subtask is moved back to pending state,
+// possibly on another node, with exponential backoff until it has been retried
+// maxRetry times, then the task is reverted. By default, failed subtasks are
+// not retried.
+func RegisterSubtaskMaxRetry(taskType proto.TaskType, maxRetry int) {
+	subtaskMaxRetryMap.Lock()
+	defer subtaskMaxRetryMap.Unlock()
+	subtaskMaxRetryMap.m[taskType] = maxRetry
+}
+
+// getSubtaskMaxRetry is used to get the max retry count of failed subtasks.
+func getSubtaskMaxRetry(taskType proto.TaskType) int {
+	subtaskMaxRetryMap.RLock()
+	defer subtaskMaxRetryMap.RUnlock()
+	return subtaskMaxRetryMap.m[taskType]
+}
+
+// ClearSubtaskMaxRetry is only used in test.
+func ClearSubtaskMaxRetry() {
+	subtaskMaxRetryMap.Lock()
+	defer subtaskMaxRetryMap.Unlock()
+	subtaskMaxRetryMap.m = make(map[proto.TaskType]int)
+}
+
+var maxConcurrentTaskOfTypeMap = struct {
+	syncutil.RWMutex
+	m map[proto.TaskType]int
+}{
+	m: make(map[proto.TaskType]int),
+}
+
+// RegisterMaxConcurrentTaskOfType is used to limit the number of tasks of the
+// task type which can be scheduled at the same time, so tasks of this type can't
+// starve tasks of other types. 0 means no limit, which is the default.
+func RegisterMaxConcurrentTaskOfType(taskType proto.TaskType, maxCnt int) {
+	maxConcurrentTaskOfTypeMap.Lock()
+	defer maxConcurrentTaskOfTypeMap.Unlock()
+	maxConcurrentTaskOfTypeMap.m[taskType] = maxCnt
+}
+
+// getMaxConcurrentTaskOfType is used to get the max number of scheduled tasks of the task type.
+func getMaxConcurrentTaskOfType(taskType proto.TaskType) int {
+	maxConcurrentTaskOfTypeMap.RLock()
+	defer maxConcurrentTaskOfTypeMap.RUnlock()
+	return maxConcurrentTaskOfTypeMap.m[taskType]
+}
+
+// ClearMaxConcurrentTaskOfType is only used in test.
+func ClearMaxConcurrentTaskOfType() {
+	maxConcurrentTaskOfTypeMap.Lock()
+	defer maxConcurrentTaskOfTypeMap.Unlock()
+	maxConcurrentTaskOfTypeMap.m = make(map[proto.TaskType]int)
+}
+
+var subtaskTimeoutMap = struct {
+	syncutil.RWMutex
+	m map[proto.TaskType]time.Duration
+}{
+	m: make(map[proto.TaskType]time.Duration),
+}
+
+// RegisterSubtaskTimeout is used to register the timeout of running subtasks of
+// the task type. A subtask which has been running longer than timeout is marked
+// as failed, and the task executor running it will abort it. The failed subtask
+// is retried if RegisterSubtaskMaxRetry is set, else the task is reverted.
+// By default, subtasks never time out.
+func RegisterSubtaskTimeout(taskType proto.TaskType, timeout time.Duration) {
+	subtaskTimeoutMap.Lock()
+	defer subtaskTimeoutMap.Unlock()
+	subtaskTimeoutMap.m[taskType] = timeout
+}
+
+// getSubtaskTimeout is used to get the timeout of running subtasks.
+func getSubtaskTimeout(taskType proto.TaskType) time.Duration {
+	subtaskTimeoutMap.RLock()
+	defer subtaskTimeoutMap.RUnlock()
+	return subtaskTimeoutMap.m[taskType]
+}
+
+// ClearSubtaskTimeout is only used in test.
+func ClearSubtaskTimeout() {
+	subtaskTimeoutMap.Lock()
+	defer subtaskTimeoutMap.Unlock()
+	subtaskTimeoutMap.m = make(map[proto.TaskType]time.Duration)
+}
+
+var subtaskAffinityMap = struct {
+	syncutil.RWMutex
+	m map[proto.TaskType]bool
+}{
+	m: make(map[proto.TaskType]bool),
+}
+
+// RegisterSubtaskAffinity is used to make the subtasks of the task type stay on
+// the nodes used before, so they can reuse the local data left there, such as
+// temp files and sort buffers. Subtasks of the next step are scheduled to the
+// nodes which run the subtasks of the previous step, and the balancer only moves
+// subtasks among the nodes they are assigned to. Other nodes are used only when
+// all of those nodes are gone or busy. By default, subtasks are spread over all
+// eligible nodes.
+func RegisterSubtaskAffinity(taskType proto.TaskType, enabled bool) {
+	subtaskAffinityMap.Lock()
+	defer subtaskAffinityMap.Unlock()
+	subtaskAffinityMap.m[taskType] = enabled
+}
+
+// hasSubtaskAffinity is used to check whether subtasks of the task type prefer
+// the nodes used before.
+func hasSubtaskAffinity(taskType proto.TaskType) bool {
+	subtaskAffinityMap.RLock()
+	defer subtaskAffinityMap.RUnlock()
+	return subtaskAffinityMap.m[taskType]
+}
+
+// ClearSubtaskAffinity is only used in test.
+func ClearSubtaskAffinity() {
+	subtaskAffinityMap.Lock()
+	defer subtaskAffinityMap.Unlock()
+	subtaskAffinityMap.m = make(map[proto.TaskType]bool)
+}
+
+// ResourceEstimator returns the estimated resource each subtask of the step of
+// the task requires, it's called before subtasks of the step are scheduled.
+type ResourceEstimator func(ctx context.Context, task *proto.Task, step proto.Step) (SubtaskResource, error)
+
+var resourceEstimatorMap = struct {
+	syncutil.RWMutex
+	m map[proto.TaskType]ResourceEstimator
+}{
+	m: make(map[proto.TaskType]ResourceEstimator),
+}
+
+// RegisterResourceEstimator is used to register the resource estimator of the
+// task type. If the estimated resource of the subtasks of a step exceeds the
+// capacity of the nodes, the task is reverted with ErrResourceNotEnough before
+// scheduling them, instead of running subtasks which will run out of memory or
+// disk. By default, resource is not checked.
+func RegisterResourceEstimator(taskType proto.TaskType, fn ResourceEstimator) {
+	resourceEstimatorMap.Lock()
+	defer resourceEstimatorMap.Unlock()
+	resourceEstimatorMap.m[taskType] = fn
+}
+
+// getResourceEstimator is used to get the resource estimator of the task type.
+func getResourceEstimator(taskType proto.TaskType) ResourceEstimator {
+	resourceEstimatorMap.RLock()
+	defer resourceEstimatorMap.RUnlock()
+	return resourceEstimatorMap.m[taskType]
+}
+
+// ClearResourceEstimator is only used in test.
+func ClearResourceEstimator() {
+	resourceEstimatorMap.Lock()
+	defer resourceEstimatorMap.Unlock()
+	resourceEstimatorMap.m = make(map[proto.TaskType]ResourceEstimator)
+}
+
+// ResourceHeadroomGetter returns the ratio of the cluster resource that isn't
+// consumed recently, the result should be in [0, 1].
+type ResourceHeadroomGetter func(ctx context.Context) (float64, error)
+
+var resourceHeadroomGetter = struct {
+	syncutil.RWMutex
+	fn ResourceHeadroomGetter
+}{}
+
+// RegisterResourceHeadroomGetter registers the getter used to scale down the
+// concurrency of pending tasks before they are scheduled, so tasks submitted
+// to a busy cluster use less slots. The concurrency is never scaled up, by
+// default, the concurrency specified on submit is used.
+func RegisterResourceHeadroomGetter(fn ResourceHeadroomGetter) {
+	resourceHeadroomGetter.Lock()
+	defer resourceHeadroomGetter.Unlock()
+	resourceHeadroomGetter.fn = fn
+}
+
+func getResourceHeadroomGetter() ResourceHeadroomGetter {
+	resourceHeadroomGetter.RLock()
+	defer resourceHeadroomGetter.RUnlock()
+	return resourceHeadroomGetter.fn
+}
+
+// ClearResourceHeadroomGetter is only used in test.
+func ClearResourceHeadroomGetter() {
+	RegisterResourceHeadroomGetter(nil)
 }
 
 // CleanUpRoutine is used for the framework to do some clean up work if the task is finished.
@@ -17,6 +17,7 @@ package scheduler
 import (
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/testkit/testsetup"
 	"go.uber.org/goleak"
 )
@@ -49,7 +50,7 @@ func TestMain(m *testing.M) {
 
 	// Make test more fast.
 	CheckTaskRunningInterval /= 10
-	CheckTaskFinishedInterval /= 10
+	variable.DistTaskCheckInterval.Store(variable.DefTiDBDistTaskCheckInterval / 10)
 	RetrySQLInterval /= 20
 
 	opts := []goleak.Option{
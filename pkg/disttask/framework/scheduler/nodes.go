@@ -23,13 +23,14 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	llog "github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"go.uber.org/zap"
 )
 
 var (
 	// liveNodesCheckInterval is the tick interval of fetching all server infos from etcs.
-	nodesCheckInterval = 2 * CheckTaskFinishedInterval
+	nodesCheckInterval = 2 * variable.DefTiDBDistTaskCheckInterval
 )
 
 // NodeManager maintains live TiDB nodes in the cluster, and maintains the nodes
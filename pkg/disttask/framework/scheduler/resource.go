@@ -0,0 +1,83 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/docker/go-units"
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	sysstorage "github.com/pingcap/tidb/pkg/util/sys/storage"
+	"go.uber.org/zap"
+)
+
+// ErrResourceNotEnough is the error when the estimated resource of a subtask
+// exceeds the capacity of the nodes, the task is reverted with it.
+var ErrResourceNotEnough = dbterror.ClassExecutor.NewStd(errno.ErrDistTaskResourceNotEnough)
+
+// SubtaskResource is the estimated resource a subtask needs on the node which
+// runs it. Zero means unknown, and it's not checked.
+type SubtaskResource struct {
+	// Mem is the memory in bytes.
+	Mem int64
+	// Disk is the local disk space in bytes, such as the temp files for sorting.
+	Disk int64
+}
+
+// nodeResource is the resource capacity of a node managed by the framework.
+// nodes are assumed to have the same spec, like what we do for slots, so we
+// use the resource of current node. Zero means unknown.
+type nodeResource struct {
+	mem  int64
+	disk int64
+}
+
+// getNodeResource returns the resource of current node, the disk space is the
+// available space of the temp dir.
+func getNodeResource() nodeResource {
+	var res nodeResource
+	if mem, err := memory.MemTotal(); err != nil {
+		logutil.BgLogger().Warn("get total memory failed", zap.Error(err))
+	} else {
+		res.mem = int64(mem)
+	}
+	if disk, err := sysstorage.GetTargetDirectoryCapacity(config.GetGlobalConfig().TempDir); err != nil {
+		logutil.BgLogger().Warn("get disk capacity of temp dir failed", zap.Error(err))
+	} else {
+		res.disk = int64(disk)
+	}
+	return res
+}
+
+// check returns ErrResourceNotEnough if the node can't run a subtask which
+// requires res.
+func (r nodeResource) check(taskType proto.TaskType, step proto.Step, res SubtaskResource) error {
+	if res.Mem > 0 && r.mem > 0 && res.Mem > r.mem {
+		return ErrResourceNotEnough.GenWithStackByArgs(fmt.Sprintf(
+			"subtask of step %s requires %s memory, but the node only has %s",
+			proto.Step2Str(taskType, step), units.BytesSize(float64(res.Mem)), units.BytesSize(float64(r.mem))))
+	}
+	if res.Disk > 0 && r.disk > 0 && res.Disk > r.disk {
+		return ErrResourceNotEnough.GenWithStackByArgs(fmt.Sprintf(
+			"subtask of step %s requires %s disk, but the node only has %s available",
+			proto.Step2Str(taskType, step), units.BytesSize(float64(res.Disk)), units.BytesSize(float64(r.disk))))
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeResourceCheck(t *testing.T) {
+	node := nodeResource{mem: 16 << 30, disk: 100 << 30}
+	require.NoError(t, node.check(proto.TaskTypeExample, proto.StepOne, SubtaskResource{}))
+	require.NoError(t, node.check(proto.TaskTypeExample, proto.StepOne, SubtaskResource{Mem: 16 << 30, Disk: 100 << 30}))
+
+	err := node.check(proto.TaskTypeExample, proto.StepOne, SubtaskResource{Mem: 17 << 30})
+	require.ErrorIs(t, err, ErrResourceNotEnough)
+	require.EqualError(t, err, "[executor:8264]Resource of the node is not enough to run the task: subtask of step one requires 17GiB memory, but the node only has 16GiB")
+	err = node.check(proto.TaskTypeExample, proto.StepTwo, SubtaskResource{Disk: 200 << 30})
+	require.ErrorIs(t, err, ErrResourceNotEnough)
+	require.EqualError(t, err, "[executor:8264]Resource of the node is not enough to run the task: subtask of step two requires 200GiB disk, but the node only has 100GiB available")
+
+	// unknown capacity is not checked.
+	require.NoError(t, nodeResource{}.check(proto.TaskTypeExample, proto.StepOne, SubtaskResource{Mem: 17 << 30, Disk: 200 << 30}))
+
+	require.Greater(t, getNodeResource().mem, int64(0))
+}
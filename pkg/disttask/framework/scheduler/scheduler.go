@@ -16,6 +16,7 @@ package scheduler
 
 import (
 	"context"
+	goerrors "errors"
 	"math/rand"
 	"strings"
 	"sync/atomic"
@@ -31,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/backoff"
 	disttaskutil "github.com/pingcap/tidb/pkg/util/disttask"
 	"github.com/pingcap/tidb/pkg/util/intest"
@@ -46,15 +48,26 @@ const (
 )
 
 var (
-	// CheckTaskFinishedInterval is the interval for scheduler.
-	// exported for testing.
-	CheckTaskFinishedInterval = 500 * time.Millisecond
 	// RetrySQLTimes is the max retry times when executing SQL.
 	RetrySQLTimes = 30
 	// RetrySQLInterval is the initial interval between two SQL retries.
 	RetrySQLInterval = 3 * time.Second
 	// RetrySQLMaxInterval is the max interval between two SQL retries.
 	RetrySQLMaxInterval = 30 * time.Second
+	// RetryStorageTimes is the max consecutive times the scheduler retries the
+	// failed task storage operations, such as refreshing the task or updating
+	// its state, the task is marked failed after that. the interval between
+	// retries backs off from RetrySQLInterval to RetrySQLMaxInterval.
+	RetryStorageTimes = 30
+	// RetrySubtaskInterval is the initial interval before retrying a failed subtask,
+	// it's doubled on each retry of the subtask.
+	RetrySubtaskInterval = 5 * time.Second
+	// RetrySubtaskMaxInterval is the max interval before retrying a failed subtask.
+	RetrySubtaskMaxInterval = 5 * time.Minute
+
+	// ErrSubtaskTimeout is the error when the subtask runs longer than the
+	// timeout registered by RegisterSubtaskTimeout.
+	ErrSubtaskTimeout = errors.New("subtask timeout")
 )
 
 // Scheduler manages the lifetime of a task
@@ -88,6 +101,45 @@ type BaseScheduler struct {
 	balanceSubtaskTick int
 	// rand is for generating random selection of nodes.
 	rand *rand.Rand
+
+	// storageFailures is the consecutive count of failed task storage
+	// operations, we won't schedule the task again before storageRetryAfter.
+	storageFailures   int
+	storageRetryAfter time.Time
+}
+
+// storageErr is the error of a task storage operation in scheduler, it's
+// retried with backoff in the schedule loop.
+type storageErr struct {
+	op  string
+	err error
+}
+
+func (e *storageErr) Error() string {
+	return e.op + ": " + e.err.Error()
+}
+
+// Cause implements the causer interface of pingcap/errors.
+func (e *storageErr) Cause() error {
+	return e.err
+}
+
+// Unwrap implements the Wrapper interface of errors.
+func (e *storageErr) Unwrap() error {
+	return e.err
+}
+
+// wrapStorageErr marks err as a retryable error of task storage operation op.
+func wrapStorageErr(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	switch errors.Cause(err) {
+	case storage.ErrTaskNotFound, storage.ErrInvalidTaskStateTransform,
+		storage.ErrUnstableSubtasks, context.Canceled:
+		return err
+	}
+	return &storageErr{op: op, err: err}
 }
 
 // NewBaseScheduler creates a new BaseScheduler.
@@ -135,7 +187,7 @@ func (s *BaseScheduler) refreshTaskIfNeeded() error {
 	// are refreshed when needed.
 	newTaskBase, err := s.taskMgr.GetTaskBaseByID(s.ctx, task.ID)
 	if err != nil {
-		return err
+		return wrapStorageErr("get-task", err)
 	}
 	// state might be changed by user to pausing/resuming/cancelling, or
 	// in case of network partition, state/step/meta might be changed by other scheduler,
@@ -148,7 +200,7 @@ func (s *BaseScheduler) refreshTaskIfNeeded() error {
 			zap.String("new-step", proto.Step2Str(task.Type, newTaskBase.Step)))
 		newTask, err := s.taskMgr.GetTaskByID(s.ctx, task.ID)
 		if err != nil {
-			return err
+			return wrapStorageErr("get-task", err)
 		}
 		s.task.Store(newTask)
 	}
@@ -157,7 +209,8 @@ func (s *BaseScheduler) refreshTaskIfNeeded() error {
 
 // scheduleTask schedule the task execution step by step.
 func (s *BaseScheduler) scheduleTask() {
-	ticker := time.NewTicker(CheckTaskFinishedInterval)
+	checkInterval := variable.DistTaskCheckInterval.Load()
+	ticker := time.NewTicker(checkInterval)
 	defer ticker.Stop()
 	for {
 		select {
@@ -165,6 +218,13 @@ func (s *BaseScheduler) scheduleTask() {
 			s.logger.Info("schedule task exits")
 			return
 		case <-ticker.C:
+			if interval := variable.DistTaskCheckInterval.Load(); interval != checkInterval {
+				checkInterval = interval
+				ticker.Reset(checkInterval)
+			}
+			if time.Now().Before(s.storageRetryAfter) {
+				continue
+			}
 			err := s.refreshTaskIfNeeded()
 			if err != nil {
 				if errors.Cause(err) == storage.ErrTaskNotFound {
@@ -173,6 +233,7 @@ func (s *BaseScheduler) scheduleTask() {
 					return
 				}
 				s.logger.Error("refresh task failed", zap.Error(err))
+				s.onStorageErr(err)
 				continue
 			}
 			task := *s.GetTask()
@@ -260,6 +321,7 @@ func (s *BaseScheduler) scheduleTask() {
 			if err != nil {
 				s.logger.Info("schedule task meet err, reschedule it", zap.Error(err))
 			}
+			s.onStorageErr(err)
 
 			failpoint.InjectCall("mockOwnerChange")
 		}
@@ -281,7 +343,7 @@ func (s *BaseScheduler) onPausing() error {
 	cntByStates, err := s.taskMgr.GetSubtaskCntGroupByStates(s.ctx, task.ID, task.Step)
 	if err != nil {
 		s.logger.Warn("check task failed", zap.Error(err))
-		return err
+		return wrapStorageErr("get-subtask-cnt", err)
 	}
 	runningPendingCnt := cntByStates[proto.SubtaskStateRunning] + cntByStates[proto.SubtaskStatePending]
 	if runningPendingCnt > 0 {
@@ -291,7 +353,7 @@ func (s *BaseScheduler) onPausing() error {
 
 	s.logger.Info("all running subtasks paused, update the task to paused state")
 	if err = s.taskMgr.PausedTask(s.ctx, task.ID); err != nil {
-		return err
+		return wrapStorageErr("paused-task", err)
 	}
 	task.State = proto.TaskStatePaused
 	s.task.Store(&task)
@@ -313,20 +375,20 @@ func (s *BaseScheduler) onResuming() error {
 	cntByStates, err := s.taskMgr.GetSubtaskCntGroupByStates(s.ctx, task.ID, task.Step)
 	if err != nil {
 		s.logger.Warn("check task failed", zap.Error(err))
-		return err
+		return wrapStorageErr("get-subtask-cnt", err)
 	}
 	if cntByStates[proto.SubtaskStatePaused] == 0 {
 		// Finish the resuming process.
 		s.logger.Info("all paused tasks converted to pending state, update the task to running state")
 		if err = s.taskMgr.ResumedTask(s.ctx, task.ID); err != nil {
-			return err
+			return wrapStorageErr("resumed-task", err)
 		}
 		task.State = proto.TaskStateRunning
 		s.task.Store(&task)
 		return nil
 	}
 
-	return s.taskMgr.ResumeSubtasks(s.ctx, task.ID)
+	return wrapStorageErr("resume-subtasks", s.taskMgr.ResumeSubtasks(s.ctx, task.ID))
 }
 
 // handle task in reverting state, check all revert subtasks finishes.
@@ -336,7 +398,7 @@ func (s *BaseScheduler) onReverting() error {
 	cntByStates, err := s.taskMgr.GetSubtaskCntGroupByStates(s.ctx, task.ID, task.Step)
 	if err != nil {
 		s.logger.Warn("check task failed", zap.Error(err))
-		return err
+		return wrapStorageErr("get-subtask-cnt", err)
 	}
 	runnableSubtaskCnt := cntByStates[proto.SubtaskStatePending] + cntByStates[proto.SubtaskStateRunning]
 	if runnableSubtaskCnt == 0 {
@@ -344,7 +406,7 @@ func (s *BaseScheduler) onReverting() error {
 			return errors.Trace(err)
 		}
 		if err = s.taskMgr.RevertedTask(s.ctx, task.ID); err != nil {
-			return errors.Trace(err)
+			return wrapStorageErr("reverted-task", err)
 		}
 		task.State = proto.TaskStateReverted
 		s.task.Store(&task)
@@ -370,13 +432,28 @@ func (s *BaseScheduler) onRunning() error {
 	s.logger.Debug("on running state",
 		zap.Stringer("state", task.State),
 		zap.String("step", proto.Step2Str(task.Type, task.Step)))
+	if err := s.failTimeoutSubtasks(task); err != nil {
+		s.logger.Warn("fail timeout subtasks failed", zap.Error(err))
+		return err
+	}
 	// check current step finishes.
 	cntByStates, err := s.taskMgr.GetSubtaskCntGroupByStates(s.ctx, task.ID, task.Step)
 	if err != nil {
 		s.logger.Warn("check task failed", zap.Error(err))
-		return err
+		return wrapStorageErr("get-subtask-cnt", err)
 	}
 	if cntByStates[proto.SubtaskStateFailed] > 0 || cntByStates[proto.SubtaskStateCanceled] > 0 {
+		if cntByStates[proto.SubtaskStateCanceled] == 0 {
+			retrying, err := s.retryFailedSubtasks(task)
+			if err != nil {
+				s.logger.Warn("retry failed subtasks failed", zap.Error(err))
+				return err
+			}
+			if retrying {
+				s.OnTick(s.ctx, task)
+				return nil
+			}
+		}
 		subTaskErrs, err := s.taskMgr.GetSubtaskErrors(s.ctx, task.ID)
 		if err != nil {
 			s.logger.Warn("collect subtask error failed", zap.Error(err))
@@ -397,6 +474,132 @@ func (s *BaseScheduler) onRunning() error {
 	return nil
 }
 
+// failTimeoutSubtasks marks the running subtasks of current step which run
+// longer than the registered timeout as failed, the task executor running it
+// will find it's not running anymore and abort it.
+func (s *BaseScheduler) failTimeoutSubtasks(task *proto.Task) error {
+	timeout := getSubtaskTimeout(task.Type)
+	if timeout <= 0 {
+		return nil
+	}
+	subtasks, err := s.taskMgr.GetAllSubtasksByStepAndState(s.ctx, task.ID, task.Step, proto.SubtaskStateRunning)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, subtask := range subtasks {
+		if subtask.StartTime.IsZero() || now.Sub(subtask.StartTime) <= timeout {
+			continue
+		}
+		if err = s.taskMgr.FailRunningSubtask(s.ctx, subtask.ID, ErrSubtaskTimeout); err != nil {
+			return err
+		}
+		s.logger.Info("subtask timeout, mark it as failed",
+			zap.Int64("subtask-id", subtask.ID),
+			zap.String("exec-id", subtask.ExecID),
+			zap.Time("start-time", subtask.StartTime),
+			zap.Duration("timeout", timeout))
+	}
+	return nil
+}
+
+// retryFailedSubtasks moves the failed subtasks of current step back to pending
+// state if they haven't reached the max retry count, the retried subtask is
+// preferred to run on another node. It returns false if any failed subtask
+// can't be retried, and the task should be reverted.
+func (s *BaseScheduler) retryFailedSubtasks(task *proto.Task) (bool, error) {
+	maxRetry := getSubtaskMaxRetry(task.Type)
+	if maxRetry <= 0 {
+		return false, nil
+	}
+	subtasks, err := s.taskMgr.GetAllSubtasksByStepAndState(s.ctx, task.ID, task.Step, proto.SubtaskStateFailed)
+	if err != nil {
+		return false, err
+	}
+	for _, subtask := range subtasks {
+		if subtask.RetryCount >= maxRetry {
+			return false, nil
+		}
+	}
+	nodeIDs := filterByScope(s.nodeMgr.getNodes(), task.TargetScope)
+	if len(nodeIDs) == 0 {
+		return false, nil
+	}
+	now := time.Now()
+	for _, subtask := range subtasks {
+		if now.Before(subtask.UpdateTime.Add(subtaskRetryBackoff(subtask.RetryCount))) {
+			continue
+		}
+		execID := pickRetryNode(nodeIDs, subtask)
+		if err = s.taskMgr.RetrySubtask(s.ctx, subtask.ID, execID); err != nil {
+			return false, err
+		}
+		metrics.DistTaskSubtaskRetryCounter.WithLabelValues(task.Type.String()).Inc()
+		s.logger.Info("retry failed subtask",
+			zap.Int64("subtask-id", subtask.ID),
+			zap.Int("retry-count", subtask.RetryCount+1),
+			zap.String("from", subtask.ExecID),
+			zap.String("to", execID))
+	}
+	return true, nil
+}
+
+// subtaskRetryBackoff returns the interval to wait before retrying a subtask
+// which has been retried retryCount times.
+func subtaskRetryBackoff(retryCount int) time.Duration {
+	backoff := RetrySubtaskInterval
+	for i := 0; i < retryCount && backoff < RetrySubtaskMaxInterval; i++ {
+		backoff *= 2
+	}
+	return min(backoff, RetrySubtaskMaxInterval)
+}
+
+// pickRetryNode picks a node to retry the subtask, the node which the subtask
+// failed on is avoided if there are other nodes.
+func pickRetryNode(nodeIDs []string, subtask *proto.Subtask) string {
+	candidates := make([]string, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if nodeID != subtask.ExecID {
+			candidates = append(candidates, nodeID)
+		}
+	}
+	if len(candidates) == 0 {
+		return subtask.ExecID
+	}
+	return candidates[int(subtask.ID)%len(candidates)]
+}
+
+// onStorageErr backs off the next schedule of the task if err is a failed task
+// storage operation, and marks the task failed after RetryStorageTimes
+// consecutive failures. the failure count is reset on other results.
+func (s *BaseScheduler) onStorageErr(err error) {
+	var sErr *storageErr
+	if !goerrors.As(err, &sErr) {
+		s.storageFailures = 0
+		s.storageRetryAfter = time.Time{}
+		return
+	}
+	task := *s.GetTask()
+	metrics.DistTaskStorageFailureCounter.WithLabelValues(task.Type.String(), sErr.op).Inc()
+	s.storageFailures++
+	if s.storageFailures < RetryStorageTimes {
+		backoffer := backoff.NewExponential(RetrySQLInterval, 2, RetrySQLMaxInterval)
+		s.storageRetryAfter = time.Now().Add(backoffer.Backoff(s.storageFailures - 1))
+		return
+	}
+	s.logger.Warn("task storage operation failed too many times, fail the task",
+		zap.Int("retry-times", s.storageFailures), zap.Error(err))
+	if err2 := s.taskMgr.FailTask(s.ctx, task.ID, task.State, err); err2 != nil {
+		s.logger.Warn("fail task failed", zap.Error(err2))
+		return
+	}
+	task.State = proto.TaskStateFailed
+	task.Error = err
+	s.task.Store(&task)
+	s.storageFailures = 0
+	s.storageRetryAfter = time.Time{}
+}
+
 func (s *BaseScheduler) onFinished() {
 	task := s.GetTask()
 	metrics.UpdateMetricsForFinishTask(task)
@@ -415,7 +618,7 @@ func (s *BaseScheduler) switch2NextStep() error {
 			return errors.Trace(err)
 		}
 		if err := s.taskMgr.SucceedTask(s.ctx, task.ID); err != nil {
-			return errors.Trace(err)
+			return wrapStorageErr("succeed-task", err)
 		}
 		task.Step = nextStep
 		task.State = proto.TaskStateSucceed
@@ -423,6 +626,14 @@ func (s *BaseScheduler) switch2NextStep() error {
 		return nil
 	}
 
+	if err := s.checkResource(&task, nextStep); err != nil {
+		if goerrors.Is(err, ErrResourceNotEnough) {
+			s.logger.Warn("resource not enough to run subtasks", zap.Error(err))
+			return s.revertTask(err)
+		}
+		return s.handlePlanErr(err)
+	}
+
 	nodes := s.nodeMgr.getNodes()
 	nodeIDs := filterByScope(nodes, task.TargetScope)
 	eligibleNodes, err := getEligibleNodes(s.ctx, s, nodeIDs)
@@ -434,6 +645,9 @@ func (s *BaseScheduler) switch2NextStep() error {
 	if len(eligibleNodes) == 0 {
 		return errors.New("no available TiDB node to dispatch subtasks")
 	}
+	if eligibleNodes, err = s.getAffinityNodes(&task, eligibleNodes); err != nil {
+		return wrapStorageErr("get-affinity-nodes", err)
+	}
 
 	metas, err := s.OnNextSubtasksBatch(s.ctx, s, &task, eligibleNodes, nextStep)
 	if err != nil {
@@ -442,7 +656,7 @@ func (s *BaseScheduler) switch2NextStep() error {
 	}
 
 	if err = s.scheduleSubTask(&task, nextStep, metas, eligibleNodes); err != nil {
-		return err
+		return wrapStorageErr("switch-task-step", err)
 	}
 	task.Step = nextStep
 	task.State = proto.TaskStateRunning
@@ -451,6 +665,61 @@ func (s *BaseScheduler) switch2NextStep() error {
 	return nil
 }
 
+// checkResource checks whether the nodes can run the subtasks of the step,
+// according to the resource estimated by the registered ResourceEstimator.
+func (s *BaseScheduler) checkResource(task *proto.Task, step proto.Step) error {
+	estimator := getResourceEstimator(task.Type)
+	if estimator == nil {
+		return nil
+	}
+	res, err := estimator(s.ctx, task, step)
+	if err != nil {
+		return err
+	}
+	return getNodeResource().check(task.Type, step, res)
+}
+
+// getAffinityNodes returns the eligible nodes which run the subtasks of current
+// step, if the task type registers subtask affinity, so subtasks of the next
+// step stay on them. eligibleNodes is returned as is if there is no such node,
+// such as all of them are gone, or it's the first step.
+func (s *BaseScheduler) getAffinityNodes(task *proto.Task, eligibleNodes []string) ([]string, error) {
+	if !hasSubtaskAffinity(task.Type) || task.Step == proto.StepInit {
+		return eligibleNodes, nil
+	}
+	subtasks, err := s.taskMgr.GetAllSubtasksByStepAndState(s.ctx, task.ID, task.Step, proto.SubtaskStateSucceed)
+	if err != nil {
+		return nil, err
+	}
+	usedNodes := make([]string, 0, len(subtasks))
+	for _, subtask := range subtasks {
+		usedNodes = append(usedNodes, subtask.ExecID)
+	}
+	nodes := PreferNodes(eligibleNodes, usedNodes)
+	s.logger.Info("subtask affinity nodes", zap.Strings("nodes", nodes))
+	return nodes, nil
+}
+
+// PreferNodes returns the nodes of eligibleNodes which are also in preferredNodes,
+// in the order of eligibleNodes. If none of preferredNodes is eligible, such as
+// they are all gone, eligibleNodes is returned.
+func PreferNodes(eligibleNodes, preferredNodes []string) []string {
+	preferred := make(map[string]struct{}, len(preferredNodes))
+	for _, node := range preferredNodes {
+		preferred[node] = struct{}{}
+	}
+	nodes := make([]string, 0, len(eligibleNodes))
+	for _, node := range eligibleNodes {
+		if _, ok := preferred[node]; ok {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return eligibleNodes
+	}
+	return nodes
+}
+
 func (s *BaseScheduler) scheduleSubTask(
 	task *proto.Task,
 	subtaskStep proto.Step,
@@ -504,7 +773,8 @@ func (s *BaseScheduler) scheduleSubTask(
 	return handle.RunWithRetry(s.ctx, RetrySQLTimes, backoffer, s.logger,
 		func(context.Context) (bool, error) {
 			err := fn(s.ctx, task, proto.TaskStateRunning, subtaskStep, subTasks)
-			if errors.Cause(err) == storage.ErrUnstableSubtasks {
+			if errors.Cause(err) == storage.ErrUnstableSubtasks ||
+				errors.Cause(err) == storage.ErrInvalidTaskStateTransform {
 				return false, err
 			}
 			return true, err
@@ -524,7 +794,7 @@ func (s *BaseScheduler) handlePlanErr(err error) error {
 func (s *BaseScheduler) revertTask(taskErr error) error {
 	task := *s.GetTask()
 	if err := s.taskMgr.RevertTask(s.ctx, task.ID, task.State, taskErr); err != nil {
-		return err
+		return wrapStorageErr("revert-task", err)
 	}
 	task.State = proto.TaskStateReverting
 	task.Error = taskErr
@@ -16,6 +16,7 @@ package scheduler
 
 import (
 	"context"
+	"math"
 	"slices"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	tidbutil "github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/syncutil"
@@ -42,6 +44,12 @@ var (
 	defaultCollectMetricsInterval = 5 * time.Second
 )
 
+// maxConcurrentTask returns the max number of tasks scheduled concurrently,
+// see tidb_dist_task_dispatch_concurrency.
+func maxConcurrentTask() int {
+	return int(variable.DistTaskDispatchConcurrency.Load())
+}
+
 // WaitTaskFinished is used to sync the test.
 var WaitTaskFinished = make(chan struct{})
 
@@ -51,6 +59,18 @@ func (sm *Manager) getSchedulerCount() int {
 	return len(sm.mu.schedulerMap)
 }
 
+func (sm *Manager) getSchedulerCountOfType(taskType proto.TaskType) int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	cnt := 0
+	for _, scheduler := range sm.mu.schedulers {
+		if scheduler.GetTask().Type == taskType {
+			cnt++
+		}
+	}
+	return cnt
+}
+
 func (sm *Manager) addScheduler(taskID int64, scheduler Scheduler) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -114,6 +134,13 @@ type Manager struct {
 	logger   *zap.Logger
 
 	finishCh chan struct{}
+	// tunedTasks records the pending tasks whose concurrency has been tuned,
+	// so each task is tuned at most once. the entry is deleted when the task
+	// leaves the pending state.
+	tunedTasks struct {
+		syncutil.Mutex
+		m map[int64]struct{}
+	}
 
 	mu struct {
 		syncutil.RWMutex
@@ -121,6 +148,33 @@ type Manager struct {
 		// in task order
 		schedulers []Scheduler
 	}
+
+	status struct {
+		syncutil.Mutex
+		lastScheduleTime   time.Time
+		lastStorageErr     error
+		lastStorageErrTime time.Time
+	}
+}
+
+// ManagerStatus is the status of the scheduler manager, it's used to monitor
+// the framework.
+type ManagerStatus struct {
+	// RunningTasks is the number of tasks which have a scheduler running.
+	RunningTasks int
+	// LastScheduleTime is the last time the manager checks and schedules
+	// tasks successfully.
+	LastScheduleTime time.Time
+	// LastStorageErr is the last error when accessing the task storage, it's
+	// nil if there is no error after the manager starts.
+	LastStorageErr     error
+	LastStorageErrTime time.Time
+}
+
+// Healthy returns whether the manager is able to schedule tasks, i.e. it has
+// scheduled tasks after the last storage error.
+func (s *ManagerStatus) Healthy() bool {
+	return s.LastStorageErr == nil || s.LastScheduleTime.After(s.LastStorageErrTime)
 }
 
 // NewManager creates a scheduler struct.
@@ -146,9 +200,10 @@ func NewManager(ctx context.Context, taskMgr TaskManager, serverID string) *Mana
 			serverID: serverID,
 		}),
 		logger:   logger,
-		finishCh: make(chan struct{}, proto.MaxConcurrentTask),
+		finishCh: make(chan struct{}, maxConcurrentTask()),
 	}
 	schedulerManager.mu.schedulerMap = make(map[int64]Scheduler)
+	schedulerManager.tunedTasks.m = make(map[int64]struct{})
 
 	return schedulerManager
 }
@@ -195,6 +250,31 @@ func (sm *Manager) Initialized() bool {
 	return sm.initialized
 }
 
+// Status returns the status of the manager.
+func (sm *Manager) Status() ManagerStatus {
+	sm.status.Lock()
+	defer sm.status.Unlock()
+	return ManagerStatus{
+		RunningTasks:       sm.getSchedulerCount(),
+		LastScheduleTime:   sm.status.lastScheduleTime,
+		LastStorageErr:     sm.status.lastStorageErr,
+		LastStorageErrTime: sm.status.lastStorageErrTime,
+	}
+}
+
+func (sm *Manager) onScheduled() {
+	sm.status.Lock()
+	defer sm.status.Unlock()
+	sm.status.lastScheduleTime = time.Now()
+}
+
+func (sm *Manager) onStorageErr(err error) {
+	sm.status.Lock()
+	defer sm.status.Unlock()
+	sm.status.lastStorageErr = err
+	sm.status.lastStorageErrTime = time.Now()
+}
+
 // scheduleTaskLoop schedules the tasks.
 func (sm *Manager) scheduleTaskLoop() {
 	sm.logger.Info("schedule task loop start")
@@ -210,21 +290,25 @@ func (sm *Manager) scheduleTaskLoop() {
 		}
 
 		taskCnt := sm.getSchedulerCount()
-		if taskCnt >= proto.MaxConcurrentTask {
+		if maxTaskCnt := maxConcurrentTask(); taskCnt >= maxTaskCnt {
 			sm.logger.Debug("scheduled tasks reached limit",
-				zap.Int("current", taskCnt), zap.Int("max", proto.MaxConcurrentTask))
+				zap.Int("current", taskCnt), zap.Int("max", maxTaskCnt))
+			sm.onScheduled()
 			continue
 		}
 
 		schedulableTasks, err := sm.getSchedulableTasks()
 		if err != nil {
+			sm.onStorageErr(err)
 			continue
 		}
 
 		err = sm.startSchedulers(schedulableTasks)
 		if err != nil {
+			sm.onStorageErr(err)
 			continue
 		}
+		sm.onScheduled()
 	}
 }
 
@@ -235,19 +319,32 @@ func (sm *Manager) getSchedulableTasks() ([]*proto.TaskBase, error) {
 		return nil, err
 	}
 
-	schedulableTasks := make([]*proto.TaskBase, 0, len(tasks))
+	candidates := make([]*proto.TaskBase, 0, len(tasks))
 	for _, task := range tasks {
+		if task.State != proto.TaskStatePending {
+			// the pending task might be cancelled or paused before it's scheduled.
+			sm.forgetTunedTasks(task.ID)
+		}
 		if sm.hasScheduler(task.ID) {
 			continue
 		}
-		// we check it before start scheduler, so no need to check it again.
-		// see startScheduler.
+		// we check it before start scheduler, the version of the task is
+		// checked in startScheduler.
 		// this should not happen normally, unless user modify system table
 		// directly.
-		if getSchedulerFactory(task.Type) == nil {
+		if !isTaskTypeRegistered(task.Type) {
 			sm.logger.Warn("unknown task type", zap.Int64("task-id", task.ID),
 				zap.Stringer("task-type", task.Type))
-			sm.failTask(task.ID, task.State, errors.New("unknown task type"))
+			sm.failTask(task.ID, task.State, errors.Annotatef(ErrUnknownTaskType, "task type %s", task.Type))
+			continue
+		}
+		candidates = append(candidates, task)
+	}
+
+	depStates, depStatesOK := sm.getDependencyStates(candidates)
+	schedulableTasks := make([]*proto.TaskBase, 0, len(candidates))
+	for _, task := range candidates {
+		if waitDependencies(task) && (!depStatesOK || !sm.dependenciesSucceed(task, depStates)) {
 			continue
 		}
 		schedulableTasks = append(schedulableTasks, task)
@@ -255,6 +352,120 @@ func (sm *Manager) getSchedulableTasks() ([]*proto.TaskBase, error) {
 	return schedulableTasks, nil
 }
 
+func waitDependencies(task *proto.TaskBase) bool {
+	return task.State == proto.TaskStatePending && len(task.Dependencies) > 0
+}
+
+// getDependencyStates gets the states of the dependencies of all pending tasks
+// in one query, ok is false if the query fails.
+func (sm *Manager) getDependencyStates(tasks []*proto.TaskBase) (states map[int64]proto.TaskState, ok bool) {
+	ids := make([]int64, 0)
+	seen := make(map[int64]struct{})
+	for _, task := range tasks {
+		if !waitDependencies(task) {
+			continue
+		}
+		for _, id := range task.Dependencies {
+			if _, exists := seen[id]; !exists {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, true
+	}
+	states, err := sm.taskMgr.GetTaskStatesWithHistory(sm.ctx, ids)
+	if err != nil {
+		sm.logger.Warn("get states of dependency tasks failed", zap.Error(err))
+		return nil, false
+	}
+	return states, true
+}
+
+// dependenciesSucceed checks whether all dependencies of the pending task have
+// succeeded, states contains the states of the dependencies. If any of them is
+// done but not succeed, the task will never be schedulable, so we fail it.
+// dependencies must exist when the task is created, and the history tasks
+// which unfinished tasks depend on are kept by GC, so a dependency which is
+// not found in either table is removed unexpectedly, we fail the task too.
+func (sm *Manager) dependenciesSucceed(task *proto.TaskBase, states map[int64]proto.TaskState) bool {
+	for _, id := range task.Dependencies {
+		state, ok := states[id]
+		switch {
+		case !ok:
+			sm.failTask(task.ID, task.State, errors.Errorf("dependency task %d not found", id))
+			return false
+		case state == proto.TaskStateSucceed:
+		case state == proto.TaskStateFailed || state == proto.TaskStateReverted:
+			sm.failTask(task.ID, task.State, errors.Errorf("dependency task %d is %s", id, state))
+			return false
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// getResourceHeadroomFn returns a function which gets the resource headroom
+// at most once, it's used to avoid querying it for each task in one round of
+// scheduling. returns nil if no getter is registered.
+func (sm *Manager) getResourceHeadroomFn() func() (float64, bool) {
+	getter := getResourceHeadroomGetter()
+	if getter == nil {
+		return nil
+	}
+	var (
+		fetched bool
+		ratio   float64
+		err     error
+	)
+	return func() (float64, bool) {
+		if !fetched {
+			fetched = true
+			if ratio, err = getter(sm.ctx); err != nil {
+				sm.logger.Warn("get resource headroom failed", zap.Error(err))
+			}
+		}
+		return ratio, err == nil
+	}
+}
+
+// tuneConcurrency scales down the concurrency of a pending task by the
+// resource headroom of the cluster, it's best effort, the task keeps its
+// original concurrency on error. Each task is tuned at most once, and the
+// concurrency is never scaled up, so the concurrency specified by user, such
+// as the THREAD option of IMPORT INTO, is the upper limit.
+func (sm *Manager) tuneConcurrency(task *proto.TaskBase, getHeadroom func() (float64, bool)) {
+	if getHeadroom == nil {
+		return
+	}
+	sm.tunedTasks.Lock()
+	_, tuned := sm.tunedTasks.m[task.ID]
+	sm.tunedTasks.m[task.ID] = struct{}{}
+	sm.tunedTasks.Unlock()
+	if tuned {
+		return
+	}
+	ratio, ok := getHeadroom()
+	if !ok {
+		return
+	}
+	concurrency := max(1, int(math.Ceil(ratio*float64(task.Concurrency))))
+	if concurrency >= task.Concurrency {
+		return
+	}
+	found, err := sm.taskMgr.ModifyTaskConcurrency(sm.ctx, task.ID, concurrency)
+	if err != nil || !found {
+		sm.logger.Warn("modify task concurrency failed", zap.Int64("task-id", task.ID),
+			zap.Bool("found", found), zap.Error(err))
+		return
+	}
+	sm.logger.Info("tuned task concurrency", zap.Int64("task-id", task.ID),
+		zap.Int("old", task.Concurrency), zap.Int("new", concurrency))
+	task.Concurrency = concurrency
+}
+
 func (sm *Manager) startSchedulers(schedulableTasks []*proto.TaskBase) error {
 	if len(schedulableTasks) == 0 {
 		return nil
@@ -263,11 +474,22 @@ func (sm *Manager) startSchedulers(schedulableTasks []*proto.TaskBase) error {
 		sm.logger.Warn("update used slot failed", zap.Error(err))
 		return err
 	}
+	getHeadroom := sm.getResourceHeadroomFn()
 	for _, task := range schedulableTasks {
 		taskCnt := sm.getSchedulerCount()
-		if taskCnt >= proto.MaxConcurrentTask {
+		if taskCnt >= maxConcurrentTask() {
 			break
 		}
+		// we only limit the start of new tasks, tasks which have been started
+		// before owner change are not affected.
+		if task.State == proto.TaskStatePending {
+			if maxCnt := getMaxConcurrentTaskOfType(task.Type); maxCnt > 0 &&
+				sm.getSchedulerCountOfType(task.Type) >= maxCnt {
+				// task of other types might be able to be scheduled.
+				continue
+			}
+			sm.tuneConcurrency(task, getHeadroom)
+		}
 		var reservedExecID string
 		allocateSlots := true
 		var ok bool
@@ -286,13 +508,24 @@ func (sm *Manager) startSchedulers(schedulableTasks []*proto.TaskBase) error {
 		}
 
 		metrics.DistTaskGauge.WithLabelValues(task.Type.String(), metrics.SchedulingStatus).Inc()
-		metrics.UpdateMetricsForScheduleTask(task.ID, task.Type)
+		metrics.UpdateMetricsForScheduleTask(task)
 		sm.startScheduler(task, allocateSlots, reservedExecID)
 	}
 	return nil
 }
 
+// forgetTunedTasks deletes the tasks which leave the pending state from
+// tunedTasks.
+func (sm *Manager) forgetTunedTasks(ids ...int64) {
+	sm.tunedTasks.Lock()
+	defer sm.tunedTasks.Unlock()
+	for _, id := range ids {
+		delete(sm.tunedTasks.m, id)
+	}
+}
+
 func (sm *Manager) failTask(id int64, currState proto.TaskState, err error) {
+	sm.forgetTunedTasks(id)
 	if err2 := sm.taskMgr.FailTask(sm.ctx, id, currState, err); err2 != nil {
 		sm.logger.Warn("failed to update task state to failed",
 			zap.Int64("task-id", id), zap.Error(err2))
@@ -309,33 +542,41 @@ func (sm *Manager) gcSubtaskHistoryTableLoop() {
 		<-WaitTaskFinished
 	})
 
-	sm.logger.Info("subtask table gc loop start")
+	sm.logger.Info("history table gc loop start")
 	ticker := time.NewTicker(historySubtaskTableGcInterval)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-sm.ctx.Done():
-			sm.logger.Info("subtask history table gc loop exits")
+			sm.logger.Info("history table gc loop exits")
 			return
 		case <-ticker.C:
-			err := sm.taskMgr.GCSubtasks(sm.ctx)
+			err := sm.taskMgr.GCHistory(sm.ctx)
 			if err != nil {
-				sm.logger.Warn("subtask history table gc failed", zap.Error(err))
+				sm.onStorageErr(err)
+				sm.logger.Warn("history table gc failed", zap.Error(err))
 			} else {
-				sm.logger.Info("subtask history table gc success")
+				sm.logger.Info("history table gc success")
 			}
 		}
 	}
 }
 
 func (sm *Manager) startScheduler(basicTask *proto.TaskBase, allocateSlots bool, reservedExecID string) {
+	// only the tasks not started yet need to be tuned.
+	sm.forgetTunedTasks(basicTask.ID)
 	task, err := sm.taskMgr.GetTaskByID(sm.ctx, basicTask.ID)
 	if err != nil {
 		sm.logger.Error("get task failed", zap.Int64("task-id", basicTask.ID), zap.Error(err))
 		return
 	}
 
-	schedulerFactory := getSchedulerFactory(task.Type)
+	schedulerFactory, err := getSchedulerFactory(task)
+	if err != nil {
+		sm.logger.Error("get scheduler factory failed", zap.Int64("task-id", task.ID), zap.Error(err))
+		sm.failTask(task.ID, task.State, err)
+		return
+	}
 	scheduler := schedulerFactory(sm.ctx, task, Param{
 		taskMgr:        sm.taskMgr,
 		nodeMgr:        sm.nodeMgr,
@@ -365,7 +606,13 @@ func (sm *Manager) startScheduler(basicTask *proto.TaskBase, allocateSlots bool,
 		}()
 		metrics.UpdateMetricsForRunTask(task)
 		scheduler.ScheduleTask()
-		sm.finishCh <- struct{}{}
+		// tidb_dist_task_dispatch_concurrency might be enlarged after finishCh
+		// is created, so don't block on a full channel, the pending signal
+		// already triggers a cleanup which handles all finished tasks.
+		select {
+		case sm.finishCh <- struct{}{}:
+		default:
+		}
 	})
 }
 
@@ -446,7 +693,15 @@ func (sm *Manager) cleanupFinishedTasks(tasks []*proto.Task) error {
 		failpoint.Return(errors.New("transfer err"))
 	})
 
-	return sm.taskMgr.TransferTasks2History(sm.ctx, cleanedTasks)
+	if err := sm.taskMgr.TransferTasks2History(sm.ctx, cleanedTasks); err != nil {
+		return err
+	}
+	ids := make([]int64, 0, len(cleanedTasks))
+	for _, task := range cleanedTasks {
+		ids = append(ids, task.ID)
+	}
+	sm.forgetTunedTasks(ids...)
+	return nil
 }
 
 func (sm *Manager) collectLoop() {
@@ -120,12 +120,13 @@ func TestManagerSchedulerNotAllocateSlots(t *testing.T) {
 
 	taskMgr := mock.NewMockTaskManager(ctrl)
 	mgr := NewManager(context.Background(), taskMgr, "1")
-	RegisterSchedulerFactory(proto.TaskTypeExample,
+	t.Cleanup(ClearSchedulerFactory)
+	require.NoError(t, RegisterSchedulerFactory(proto.TaskTypeExample,
 		func(ctx context.Context, task *proto.Task, param Param) Scheduler {
 			mockScheduler := NewBaseScheduler(ctx, task, param)
 			mockScheduler.Extension = GetTestSchedulerExt(ctrl)
 			return mockScheduler
-		})
+		}))
 	taskMgr.EXPECT().GetUsedSlotsOnNodes(gomock.Any()).Return(nil, nil).AnyTimes()
 	tasks := []*proto.TaskBase{
 		{
@@ -162,3 +163,185 @@ func TestManagerSchedulerNotAllocateSlots(t *testing.T) {
 	mgr.schedulerWG.Wait()
 	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/disttask/framework/scheduler/exitScheduler"))
 }
+
+func TestManagerMaxConcurrentTaskOfType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	RegisterMaxConcurrentTaskOfType(proto.TaskTypeExample, 2)
+	t.Cleanup(ClearMaxConcurrentTaskOfType)
+
+	taskMgr := mock.NewMockTaskManager(ctrl)
+	mgr := NewManager(context.Background(), taskMgr, "1")
+	taskMgr.EXPECT().GetUsedSlotsOnNodes(gomock.Any()).Return(nil, nil).AnyTimes()
+	for i, tp := range []proto.TaskType{proto.TaskTypeExample, proto.ImportInto, proto.TaskTypeExample} {
+		task := &proto.Task{TaskBase: proto.TaskBase{ID: int64(i + 1), Type: tp}}
+		mockScheduler := mock.NewMockScheduler(ctrl)
+		mockScheduler.EXPECT().GetTask().Return(task).AnyTimes()
+		mgr.addScheduler(task.ID, mockScheduler)
+	}
+	require.Equal(t, 2, mgr.getSchedulerCountOfType(proto.TaskTypeExample))
+	require.Equal(t, 1, mgr.getSchedulerCountOfType(proto.ImportInto))
+	require.Equal(t, 0, mgr.getSchedulerCountOfType(proto.Backfill))
+
+	// pending task of the type isn't started when the limit is reached.
+	require.NoError(t, mgr.startSchedulers([]*proto.TaskBase{
+		{ID: 4, Type: proto.TaskTypeExample, State: proto.TaskStatePending, Concurrency: 1},
+	}))
+	require.Equal(t, 3, mgr.getSchedulerCount())
+	require.False(t, mgr.hasScheduler(4))
+}
+
+func TestManagerTuneConcurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	t.Cleanup(ClearResourceHeadroomGetter)
+
+	taskMgr := mock.NewMockTaskManager(ctrl)
+	mgr := NewManager(context.Background(), taskMgr, "1")
+
+	// no getter registered.
+	task := &proto.TaskBase{ID: 1, State: proto.TaskStatePending, Concurrency: 8}
+	mgr.tuneConcurrency(task, mgr.getResourceHeadroomFn())
+	require.Equal(t, 8, task.Concurrency)
+	require.Empty(t, mgr.tunedTasks.m)
+
+	var (
+		ratio    float64
+		getErr   error
+		getCalls int
+	)
+	RegisterResourceHeadroomGetter(func(context.Context) (float64, error) {
+		getCalls++
+		return ratio, getErr
+	})
+	// the headroom is only fetched once in one round, and each task is tuned
+	// only once.
+	ratio = 0.3
+	taskMgr.EXPECT().ModifyTaskConcurrency(gomock.Any(), int64(1), 3).Return(true, nil)
+	taskMgr.EXPECT().ModifyTaskConcurrency(gomock.Any(), int64(2), 2).Return(true, nil)
+	getHeadroom := mgr.getResourceHeadroomFn()
+	task2 := &proto.TaskBase{ID: 2, State: proto.TaskStatePending, Concurrency: 4}
+	mgr.tuneConcurrency(task, getHeadroom)
+	mgr.tuneConcurrency(task2, getHeadroom)
+	mgr.tuneConcurrency(task, getHeadroom)
+	require.Equal(t, 3, task.Concurrency)
+	require.Equal(t, 2, task2.Concurrency)
+	require.Equal(t, 1, getCalls)
+	ratio = 0.1
+	mgr.tuneConcurrency(task, mgr.getResourceHeadroomFn())
+	require.Equal(t, 3, task.Concurrency)
+	require.Equal(t, 1, getCalls)
+
+	// the concurrency is never scaled up, and is at least 1.
+	ratio = 1
+	task3 := &proto.TaskBase{ID: 3, State: proto.TaskStatePending, Concurrency: 4}
+	mgr.tuneConcurrency(task3, mgr.getResourceHeadroomFn())
+	require.Equal(t, 4, task3.Concurrency)
+	ratio = 0
+	taskMgr.EXPECT().ModifyTaskConcurrency(gomock.Any(), int64(4), 1).Return(true, nil)
+	task4 := &proto.TaskBase{ID: 4, State: proto.TaskStatePending, Concurrency: 4}
+	mgr.tuneConcurrency(task4, mgr.getResourceHeadroomFn())
+	require.Equal(t, 1, task4.Concurrency)
+
+	// task keeps its concurrency if it's not pending anymore or on error.
+	ratio = 0.5
+	taskMgr.EXPECT().ModifyTaskConcurrency(gomock.Any(), int64(5), 2).Return(false, nil)
+	task5 := &proto.TaskBase{ID: 5, State: proto.TaskStatePending, Concurrency: 4}
+	mgr.tuneConcurrency(task5, mgr.getResourceHeadroomFn())
+	require.Equal(t, 4, task5.Concurrency)
+	taskMgr.EXPECT().ModifyTaskConcurrency(gomock.Any(), int64(6), 2).Return(false, errors.New("mock err"))
+	task6 := &proto.TaskBase{ID: 6, State: proto.TaskStatePending, Concurrency: 4}
+	mgr.tuneConcurrency(task6, mgr.getResourceHeadroomFn())
+	require.Equal(t, 4, task6.Concurrency)
+	getErr = errors.New("mock err")
+	task7 := &proto.TaskBase{ID: 7, State: proto.TaskStatePending, Concurrency: 4}
+	getHeadroom = mgr.getResourceHeadroomFn()
+	mgr.tuneConcurrency(task7, getHeadroom)
+	task8 := &proto.TaskBase{ID: 8, State: proto.TaskStatePending, Concurrency: 4}
+	mgr.tuneConcurrency(task8, getHeadroom)
+	require.Equal(t, 4, task7.Concurrency)
+	require.Equal(t, 4, task8.Concurrency)
+	require.Len(t, mgr.tunedTasks.m, 8)
+
+	// the entry is deleted when the task leaves the pending state.
+	t.Cleanup(ClearSchedulerFactory)
+	require.NoError(t, RegisterSchedulerFactory(proto.TaskTypeExample,
+		func(ctx context.Context, task *proto.Task, param Param) Scheduler {
+			return NewBaseScheduler(ctx, task, param)
+		}))
+	taskMgr.EXPECT().GetTaskByID(gomock.Any(), int64(1)).Return(nil, errors.New("mock err"))
+	mgr.startScheduler(task, false, "")
+	taskMgr.EXPECT().FailTask(gomock.Any(), int64(2), proto.TaskStatePending, gomock.Any()).Return(nil)
+	mgr.failTask(2, proto.TaskStatePending, errors.New("mock err"))
+	taskMgr.EXPECT().GetTopUnfinishedTasks(gomock.Any()).
+		Return([]*proto.TaskBase{{ID: 3, Type: proto.TaskTypeExample, State: proto.TaskStateCancelling}}, nil)
+	_, err := mgr.getSchedulableTasks()
+	require.NoError(t, err)
+	taskMgr.EXPECT().TransferTasks2History(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, mgr.cleanupFinishedTasks([]*proto.Task{{TaskBase: proto.TaskBase{ID: 4}}}))
+	require.Len(t, mgr.tunedTasks.m, 4)
+	for _, id := range []int64{1, 2, 3, 4} {
+		require.NotContains(t, mgr.tunedTasks.m, id)
+	}
+}
+
+func TestManagerDependenciesSucceed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	taskMgr := mock.NewMockTaskManager(ctrl)
+	mgr := NewManager(context.Background(), taskMgr, "1")
+	task := &proto.TaskBase{ID: 10, State: proto.TaskStatePending, Dependencies: []int64{1, 2}}
+
+	require.True(t, mgr.dependenciesSucceed(task,
+		map[int64]proto.TaskState{1: proto.TaskStateSucceed, 2: proto.TaskStateSucceed}))
+	// wait until all dependencies succeed.
+	require.False(t, mgr.dependenciesSucceed(task,
+		map[int64]proto.TaskState{1: proto.TaskStateSucceed, 2: proto.TaskStateRunning}))
+	// task fails if any dependency doesn't succeed.
+	taskMgr.EXPECT().FailTask(gomock.Any(), int64(10), proto.TaskStatePending, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, _ proto.TaskState, err error) error {
+			require.ErrorContains(t, err, "dependency task 2 is reverted")
+			return nil
+		})
+	require.False(t, mgr.dependenciesSucceed(task,
+		map[int64]proto.TaskState{1: proto.TaskStateSucceed, 2: proto.TaskStateReverted}))
+	// the history tasks which are depended on are kept by GC, so the task
+	// fails if a dependency is missing.
+	taskMgr.EXPECT().FailTask(gomock.Any(), int64(10), proto.TaskStatePending, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, _ proto.TaskState, err error) error {
+			require.ErrorContains(t, err, "dependency task 2 not found")
+			return nil
+		})
+	require.False(t, mgr.dependenciesSucceed(task, map[int64]proto.TaskState{1: proto.TaskStateSucceed}))
+}
+
+func TestManagerGetSchedulableTasksWithDependencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	taskMgr := mock.NewMockTaskManager(ctrl)
+	mgr := NewManager(context.Background(), taskMgr, "1")
+	t.Cleanup(ClearSchedulerFactory)
+	require.NoError(t, RegisterSchedulerFactory(proto.TaskTypeExample,
+		func(ctx context.Context, task *proto.Task, param Param) Scheduler {
+			return NewBaseScheduler(ctx, task, param)
+		}))
+	tasks := []*proto.TaskBase{
+		{ID: 10, Type: proto.TaskTypeExample, State: proto.TaskStatePending, Dependencies: []int64{1, 2}},
+		{ID: 11, Type: proto.TaskTypeExample, State: proto.TaskStatePending, Dependencies: []int64{2, 3}},
+		{ID: 12, Type: proto.TaskTypeExample, State: proto.TaskStatePending},
+		// dependencies of a running task are not checked.
+		{ID: 13, Type: proto.TaskTypeExample, State: proto.TaskStateRunning, Dependencies: []int64{4}},
+	}
+	taskMgr.EXPECT().GetTopUnfinishedTasks(gomock.Any()).Return(tasks, nil).Times(2)
+	// the states of all dependencies are got in one query.
+	taskMgr.EXPECT().GetTaskStatesWithHistory(gomock.Any(), []int64{1, 2, 3}).
+		Return(map[int64]proto.TaskState{1: proto.TaskStateSucceed, 2: proto.TaskStateSucceed, 3: proto.TaskStateRunning}, nil)
+	schedulableTasks, err := mgr.getSchedulableTasks()
+	require.NoError(t, err)
+	require.Equal(t, []*proto.TaskBase{tasks[0], tasks[2], tasks[3]}, schedulableTasks)
+
+	taskMgr.EXPECT().GetTaskStatesWithHistory(gomock.Any(), []int64{1, 2, 3}).Return(nil, errors.New("mock err"))
+	schedulableTasks, err = mgr.getSchedulableTasks()
+	require.NoError(t, err)
+	require.Equal(t, []*proto.TaskBase{tasks[2], tasks[3]}, schedulableTasks)
+}
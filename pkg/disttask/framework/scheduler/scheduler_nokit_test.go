@@ -17,6 +17,7 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	schmock "github.com/pingcap/tidb/pkg/disttask/framework/scheduler/mock"
 	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage/memstore"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/client-go/v2/util"
@@ -144,6 +146,86 @@ func TestSchedulerOnNextStage(t *testing.T) {
 	require.True(t, ctrl.Satisfied())
 }
 
+func TestSchedulerSwitchStepWithMemStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	taskMgr := memstore.NewTaskManager()
+	schExt := schmock.NewMockExtension(ctrl)
+	id, err := taskMgr.CreateTask(ctx, "key1", proto.TaskTypeExample, 1, "", nil)
+	require.NoError(t, err)
+	task, err := taskMgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	sch := createScheduler(task, true, taskMgr, ctrl)
+	sch.Extension = schExt
+
+	serverNodes := []string{":4000"}
+	schExt.EXPECT().GetNextStep(gomock.Any()).Return(proto.StepOne)
+	schExt.EXPECT().GetEligibleInstances(gomock.Any(), gomock.Any()).Return(serverNodes, nil)
+	schExt.EXPECT().OnNextSubtasksBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([][]byte{[]byte("1"), []byte("2")}, nil)
+	require.NoError(t, sch.Switch2NextStep())
+	task, err = taskMgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateRunning, task.State)
+	require.Equal(t, proto.StepOne, task.Step)
+	subtasks, err := taskMgr.GetSubtasksByExecIDAndStepAndStates(ctx, ":4000", id, proto.StepOne, proto.SubtaskStatePending)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 2)
+
+	for _, subtask := range subtasks {
+		require.NoError(t, taskMgr.StartSubtask(ctx, subtask.ID, ":4000"))
+		require.NoError(t, taskMgr.FinishSubtask(ctx, ":4000", subtask.ID, nil))
+	}
+	schExt.EXPECT().GetNextStep(gomock.Any()).Return(proto.StepDone)
+	schExt.EXPECT().OnDone(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, sch.Switch2NextStep())
+	task, err = taskMgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateSucceed, task.State)
+	require.Equal(t, proto.StepDone, task.Step)
+}
+
+func TestSchedulerRevertTaskIfResourceNotEnough(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	taskMgr := memstore.NewTaskManager()
+	schExt := schmock.NewMockExtension(ctrl)
+	id, err := taskMgr.CreateTask(ctx, "key1", proto.TaskTypeExample, 1, "", nil)
+	require.NoError(t, err)
+	task, err := taskMgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	sch := createScheduler(task, true, taskMgr, ctrl)
+	sch.Extension = schExt
+	t.Cleanup(ClearResourceEstimator)
+
+	// estimate failed and it's retryable.
+	RegisterResourceEstimator(proto.TaskTypeExample, func(context.Context, *proto.Task, proto.Step) (SubtaskResource, error) {
+		return SubtaskResource{}, errors.New("mock estimate err")
+	})
+	schExt.EXPECT().GetNextStep(gomock.Any()).Return(proto.StepOne)
+	schExt.EXPECT().IsRetryableErr(gomock.Any()).Return(true)
+	require.ErrorContains(t, sch.Switch2NextStep(), "mock estimate err")
+	require.True(t, ctrl.Satisfied())
+
+	// subtask requires more memory than the node has.
+	RegisterResourceEstimator(proto.TaskTypeExample, func(context.Context, *proto.Task, proto.Step) (SubtaskResource, error) {
+		return SubtaskResource{Mem: math.MaxInt64}, nil
+	})
+	schExt.EXPECT().GetNextStep(gomock.Any()).Return(proto.StepOne)
+	require.NoError(t, sch.Switch2NextStep())
+	require.True(t, ctrl.Satisfied())
+	task, err = taskMgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateReverting, task.State)
+	require.ErrorIs(t, task.Error, ErrResourceNotEnough)
+	require.ErrorContains(t, task.Error, "subtask of step one requires")
+	cnt, err := taskMgr.GetSubtaskCntGroupByStates(ctx, id, proto.StepOne)
+	require.NoError(t, err)
+	require.Empty(t, cnt)
+}
+
 func TestGetEligibleNodes(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -490,3 +572,231 @@ func TestSchedulerMaintainTaskFields(t *testing.T) {
 		require.True(t, ctrl.Satisfied())
 	})
 }
+
+func TestSchedulerFailTaskAfterStorageRetries(t *testing.T) {
+	bak := RetryStorageTimes
+	t.Cleanup(func() {
+		RetryStorageTimes = bak
+	})
+	RetryStorageTimes = 3
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	taskMgr := mock.NewMockTaskManager(ctrl)
+
+	task := proto.Task{
+		TaskBase: proto.TaskBase{
+			ID:          int64(1),
+			Concurrency: 1,
+			Type:        proto.TaskTypeExample,
+			State:       proto.TaskStatePausing,
+			Step:        proto.StepOne,
+		},
+	}
+	cloneTask := task
+	sch := createScheduler(&cloneTask, true, taskMgr, ctrl)
+
+	// other errors reset the failure count.
+	sch.onStorageErr(wrapStorageErr("get-task", errors.New("mock err")))
+	require.Equal(t, 1, sch.storageFailures)
+	require.False(t, sch.storageRetryAfter.IsZero())
+	sch.onStorageErr(errors.New("plan err"))
+	require.Zero(t, sch.storageFailures)
+	require.True(t, sch.storageRetryAfter.IsZero())
+	// non-retryable storage errors are not wrapped.
+	require.ErrorIs(t, wrapStorageErr("get-task", storage.ErrTaskNotFound), storage.ErrTaskNotFound)
+	require.Nil(t, wrapStorageErr("get-task", nil))
+
+	// failures of different storage operations are counted together, the task
+	// is marked failed after all retries fail.
+	taskMgr.EXPECT().GetTaskBaseByID(gomock.Any(), task.ID).Return(nil, errors.New("get task err")).Times(2)
+	taskMgr.EXPECT().GetTaskBaseByID(gomock.Any(), task.ID).Return(&task.TaskBase, nil)
+	taskMgr.EXPECT().GetSubtaskCntGroupByStates(gomock.Any(), task.ID, task.Step).Return(nil, errors.New("cnt err"))
+	taskMgr.EXPECT().FailTask(gomock.Any(), task.ID, proto.TaskStatePausing, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, _ proto.TaskState, taskErr error) error {
+			require.ErrorContains(t, taskErr, "cnt err")
+			return nil
+		})
+	failedTask := task
+	failedTask.State = proto.TaskStateFailed
+	taskMgr.EXPECT().GetTaskBaseByID(gomock.Any(), task.ID).Return(&failedTask.TaskBase, nil)
+	sch.scheduleTask()
+	require.True(t, ctrl.Satisfied())
+	require.Equal(t, proto.TaskStateFailed, sch.GetTask().State)
+	require.ErrorContains(t, sch.GetTask().Error, "cnt err")
+}
+
+func TestSubtaskRetryBackoff(t *testing.T) {
+	bakInterval, bakMaxInterval := RetrySubtaskInterval, RetrySubtaskMaxInterval
+	t.Cleanup(func() {
+		RetrySubtaskInterval, RetrySubtaskMaxInterval = bakInterval, bakMaxInterval
+	})
+	RetrySubtaskInterval, RetrySubtaskMaxInterval = time.Second, 5*time.Second
+	require.Equal(t, time.Second, subtaskRetryBackoff(0))
+	require.Equal(t, 2*time.Second, subtaskRetryBackoff(1))
+	require.Equal(t, 4*time.Second, subtaskRetryBackoff(2))
+	require.Equal(t, 5*time.Second, subtaskRetryBackoff(3))
+	require.Equal(t, 5*time.Second, subtaskRetryBackoff(100))
+
+	subtask := &proto.Subtask{SubtaskBase: proto.SubtaskBase{ID: 1, ExecID: "a"}}
+	require.Equal(t, "a", pickRetryNode([]string{"a"}, subtask))
+	require.Equal(t, "b", pickRetryNode([]string{"a", "b"}, subtask))
+	for i := 0; i < 10; i++ {
+		subtask.ID = int64(i)
+		require.NotEqual(t, "a", pickRetryNode([]string{"a", "b", "c"}, subtask))
+	}
+}
+
+func TestSchedulerFailTimeoutSubtasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	taskMgr := mock.NewMockTaskManager(ctrl)
+	t.Cleanup(ClearSubtaskTimeout)
+
+	ctx := context.Background()
+	task := &proto.Task{TaskBase: proto.TaskBase{ID: 1, Type: proto.TaskTypeExample, State: proto.TaskStateRunning, Step: proto.StepOne}}
+	scheduler := NewBaseScheduler(ctx, task, Param{taskMgr: taskMgr})
+
+	// no timeout registered
+	require.NoError(t, scheduler.failTimeoutSubtasks(task))
+	require.True(t, ctrl.Satisfied())
+
+	RegisterSubtaskTimeout(proto.TaskTypeExample, time.Minute)
+	taskMgr.EXPECT().GetAllSubtasksByStepAndState(gomock.Any(), task.ID, task.Step, proto.SubtaskStateRunning).
+		Return(nil, errors.New("mock err"))
+	require.ErrorContains(t, scheduler.failTimeoutSubtasks(task), "mock err")
+	require.True(t, ctrl.Satisfied())
+
+	now := time.Now()
+	subtasks := []*proto.Subtask{
+		{SubtaskBase: proto.SubtaskBase{ID: 1, StartTime: now.Add(-2 * time.Minute)}},
+		{SubtaskBase: proto.SubtaskBase{ID: 2, StartTime: now}},
+		{SubtaskBase: proto.SubtaskBase{ID: 3}},
+	}
+	taskMgr.EXPECT().GetAllSubtasksByStepAndState(gomock.Any(), task.ID, task.Step, proto.SubtaskStateRunning).
+		Return(subtasks, nil)
+	taskMgr.EXPECT().FailRunningSubtask(gomock.Any(), int64(1), ErrSubtaskTimeout).Return(nil)
+	require.NoError(t, scheduler.failTimeoutSubtasks(task))
+	require.True(t, ctrl.Satisfied())
+}
+
+func TestSchedulerGetAffinityNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	taskMgr := mock.NewMockTaskManager(ctrl)
+	t.Cleanup(ClearSubtaskAffinity)
+
+	ctx := context.Background()
+	task := &proto.Task{TaskBase: proto.TaskBase{ID: 1, Type: proto.TaskTypeExample, State: proto.TaskStateRunning, Step: proto.StepOne}}
+	scheduler := NewBaseScheduler(ctx, task, Param{taskMgr: taskMgr})
+	eligibleNodes := []string{":4000", ":4001", ":4002"}
+
+	// no affinity registered
+	nodes, err := scheduler.getAffinityNodes(task, eligibleNodes)
+	require.NoError(t, err)
+	require.Equal(t, eligibleNodes, nodes)
+	require.True(t, ctrl.Satisfied())
+
+	RegisterSubtaskAffinity(proto.TaskTypeExample, true)
+	// first step
+	initTask := *task
+	initTask.Step = proto.StepInit
+	nodes, err = scheduler.getAffinityNodes(&initTask, eligibleNodes)
+	require.NoError(t, err)
+	require.Equal(t, eligibleNodes, nodes)
+	require.True(t, ctrl.Satisfied())
+
+	taskMgr.EXPECT().GetAllSubtasksByStepAndState(gomock.Any(), task.ID, task.Step, proto.SubtaskStateSucceed).
+		Return(nil, errors.New("mock err"))
+	_, err = scheduler.getAffinityNodes(task, eligibleNodes)
+	require.ErrorContains(t, err, "mock err")
+	require.True(t, ctrl.Satisfied())
+
+	subtasks := []*proto.Subtask{
+		{SubtaskBase: proto.SubtaskBase{ID: 1, ExecID: ":4002"}},
+		{SubtaskBase: proto.SubtaskBase{ID: 2, ExecID: ":4000"}},
+		{SubtaskBase: proto.SubtaskBase{ID: 3, ExecID: ":4002"}},
+	}
+	taskMgr.EXPECT().GetAllSubtasksByStepAndState(gomock.Any(), task.ID, task.Step, proto.SubtaskStateSucceed).
+		Return(subtasks, nil)
+	nodes, err = scheduler.getAffinityNodes(task, eligibleNodes)
+	require.NoError(t, err)
+	require.Equal(t, []string{":4000", ":4002"}, nodes)
+	require.True(t, ctrl.Satisfied())
+
+	// nodes used before are all gone
+	taskMgr.EXPECT().GetAllSubtasksByStepAndState(gomock.Any(), task.ID, task.Step, proto.SubtaskStateSucceed).
+		Return(subtasks, nil)
+	nodes, err = scheduler.getAffinityNodes(task, []string{":4001", ":4003"})
+	require.NoError(t, err)
+	require.Equal(t, []string{":4001", ":4003"}, nodes)
+	require.True(t, ctrl.Satisfied())
+}
+
+func TestRegisterSchedulerFactory(t *testing.T) {
+	t.Cleanup(ClearSchedulerFactory)
+	var created string
+	factoryOf := func(name string) schedulerFactoryFn {
+		return func(ctx context.Context, task *proto.Task, param Param) Scheduler {
+			created = name
+			return nil
+		}
+	}
+	getVersion := func(meta []byte) (int, error) {
+		if len(meta) == 0 {
+			return 0, errors.New("empty meta")
+		}
+		return int(meta[0] - '0'), nil
+	}
+
+	// validation
+	require.ErrorContains(t, RegisterSchedulerFactory("", factoryOf("v1")), "task type is empty")
+	require.ErrorContains(t, RegisterSchedulerFactory(proto.TaskTypeExample, nil), "is nil")
+	require.ErrorContains(t, RegisterSchedulerFactory(proto.TaskTypeExample, factoryOf("v2"),
+		WithCompatibleFactory(1, factoryOf("v1"))), "without version")
+	require.ErrorContains(t, RegisterSchedulerFactory(proto.TaskTypeExample, factoryOf("v2"),
+		WithVersion(0, getVersion)), "invalid version")
+	require.ErrorContains(t, RegisterSchedulerFactory(proto.TaskTypeExample, factoryOf("v2"),
+		WithVersion(2, getVersion), WithCompatibleFactory(2, factoryOf("v1"))), "should be in range [1, 2)")
+	require.ErrorContains(t, RegisterSchedulerFactory(proto.TaskTypeExample, factoryOf("v2"),
+		WithVersion(2, getVersion), WithCompatibleFactory(1, factoryOf("v1")),
+		WithCompatibleFactory(1, factoryOf("v1"))), "nil or duplicated")
+	require.False(t, isTaskTypeRegistered(proto.TaskTypeExample))
+
+	// unknown task type
+	task := &proto.Task{TaskBase: proto.TaskBase{ID: 1, Type: proto.TaskTypeExample}}
+	_, err := getSchedulerFactory(task)
+	require.ErrorIs(t, err, ErrUnknownTaskType)
+	require.ErrorContains(t, err, "task type Example is not registered, registered types: []")
+
+	// without version
+	require.NoError(t, RegisterSchedulerFactory(proto.TaskTypeExample, factoryOf("v1")))
+	require.True(t, isTaskTypeRegistered(proto.TaskTypeExample))
+	factory, err := getSchedulerFactory(task)
+	require.NoError(t, err)
+	factory(context.Background(), task, Param{})
+	require.Equal(t, "v1", created)
+
+	// with version
+	require.NoError(t, RegisterSchedulerFactory(proto.TaskTypeExample, factoryOf("v3"),
+		WithVersion(3, getVersion), WithCompatibleFactory(1, factoryOf("v1"))))
+	for _, c := range []struct {
+		meta    string
+		factory string
+		err     string
+	}{
+		{meta: "3", factory: "v3"},
+		{meta: "1", factory: "v1"},
+		{meta: "2", err: "unsupported version 2 of task type Example, supported versions: [1 3]"},
+		{meta: "", err: "empty meta"},
+	} {
+		task.Meta = []byte(c.meta)
+		factory, err = getSchedulerFactory(task)
+		if c.err != "" {
+			require.ErrorContains(t, err, c.err)
+			continue
+		}
+		require.NoError(t, err)
+		factory(context.Background(), task, Param{})
+		require.Equal(t, c.factory, created)
+	}
+}
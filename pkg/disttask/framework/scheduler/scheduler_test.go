@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/testkit/testfailpoint"
 	disttaskutil "github.com/pingcap/tidb/pkg/util/disttask"
@@ -93,12 +94,12 @@ func MockSchedulerManager(t *testing.T, ctrl *gomock.Controller, pool *pools.Res
 	mgr := storage.NewTaskManager(pool)
 	storage.SetTaskManager(mgr)
 	sch := scheduler.NewManager(util.WithInternalSourceType(ctx, "scheduler"), mgr, "host:port")
-	scheduler.RegisterSchedulerFactory(proto.TaskTypeExample,
+	require.NoError(t, scheduler.RegisterSchedulerFactory(proto.TaskTypeExample,
 		func(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
 			mockScheduler := scheduler.NewBaseScheduler(ctx, task, param)
 			mockScheduler.Extension = ext
 			return mockScheduler
-		})
+		}))
 	return sch, mgr
 }
 
@@ -120,12 +121,12 @@ func TestTaskFailInManager(t *testing.T) {
 	ctx = util.WithInternalSourceType(ctx, "handle_test")
 
 	schManager, mgr := MockSchedulerManager(t, ctrl, pool, scheduler.GetTestSchedulerExt(ctrl), nil)
-	scheduler.RegisterSchedulerFactory(proto.TaskTypeExample,
+	require.NoError(t, scheduler.RegisterSchedulerFactory(proto.TaskTypeExample,
 		func(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
 			mockScheduler := mock.NewMockScheduler(ctrl)
 			mockScheduler.EXPECT().Init().Return(errors.New("mock scheduler init error"))
 			return mockScheduler
-		})
+		}))
 	schManager.Start()
 	defer schManager.Stop()
 
@@ -154,10 +155,10 @@ func checkSchedule(t *testing.T, taskCnt int, isSucc, isCancel, isSubtaskCancel,
 	testfailpoint.Enable(t, "github.com/pingcap/tidb/pkg/domain/MockDisableDistTask", "return(true)")
 	// test scheduleTaskLoop
 	// test parallelism control
-	var originalConcurrency int
+	var originalConcurrency int32
 	if taskCnt == 1 {
-		originalConcurrency = proto.MaxConcurrentTask
-		proto.MaxConcurrentTask = 1
+		originalConcurrency = variable.DistTaskDispatchConcurrency.Load()
+		variable.DistTaskDispatchConcurrency.Store(1)
 	}
 
 	store := testkit.CreateMockStore(t)
@@ -179,7 +180,7 @@ func checkSchedule(t *testing.T, taskCnt int, isSucc, isCancel, isSubtaskCancel,
 		sch.Stop()
 		// make data race happy
 		if taskCnt == 1 {
-			proto.MaxConcurrentTask = originalConcurrency
+			variable.DistTaskDispatchConcurrency.Store(originalConcurrency)
 		}
 	}()
 
@@ -257,6 +258,7 @@ func checkSchedule(t *testing.T, taskCnt int, isSucc, isCancel, isSubtaskCancel,
 	if isSucc {
 		// Mock subtasks succeed.
 		for i := 1; i <= subtaskCnt*taskCnt; i++ {
+			require.NoError(t, mgr.StartSubtask(ctx, int64(i), ":4000"))
 			err = mgr.UpdateSubtaskStateAndError(ctx, ":4000", int64(i), proto.SubtaskStateSucceed, nil)
 			require.NoError(t, err)
 		}
@@ -288,9 +290,14 @@ func checkSchedule(t *testing.T, taskCnt int, isSucc, isCancel, isSubtaskCancel,
 			require.True(t, found)
 			require.NoError(t, err)
 		}
+		// Mock the task executor resuming the paused subtasks.
+		for _, taskID := range taskIDs {
+			require.NoError(t, mgr.ResumeSubtasks(ctx, taskID))
+		}
 
 		// Mock subtasks succeed.
 		for i := 1; i <= subtaskCnt*taskCnt; i++ {
+			require.NoError(t, mgr.StartSubtask(ctx, int64(i), ":4000"))
 			err = mgr.UpdateSubtaskStateAndError(ctx, ":4000", int64(i), proto.SubtaskStateSucceed, nil)
 			require.NoError(t, err)
 		}
@@ -375,7 +382,8 @@ func TestVerifyTaskStateTransform(t *testing.T) {
 	}{
 		{proto.TaskStateRunning, proto.TaskStateRunning, true},
 		{proto.TaskStatePending, proto.TaskStateRunning, true},
-		{proto.TaskStatePending, proto.TaskStateReverting, false},
+		// task is reverted if planning subtasks of the first step fails.
+		{proto.TaskStatePending, proto.TaskStateReverting, true},
 		{proto.TaskStateRunning, proto.TaskStateReverting, true},
 		{proto.TaskStateReverting, proto.TaskStateReverted, true},
 		{proto.TaskStateReverting, proto.TaskStateSucceed, false},
@@ -384,7 +392,7 @@ func TestVerifyTaskStateTransform(t *testing.T) {
 		{proto.TaskStateCancelling, proto.TaskStateRunning, false},
 	}
 	for _, tc := range testCases {
-		require.Equal(t, tc.expect, scheduler.VerifyTaskStateTransform(tc.oldState, tc.newState))
+		require.Equal(t, tc.expect, proto.VerifyTaskStateTransform(tc.oldState, tc.newState))
 	}
 }
 
@@ -426,7 +434,7 @@ func TestManagerScheduleLoop(t *testing.T) {
 	for i := 0; i < len(concurrencies); i++ {
 		waitChannels[fmt.Sprintf("key/%d", i)] = make(chan struct{})
 	}
-	scheduler.RegisterSchedulerFactory(proto.TaskTypeExample,
+	require.NoError(t, scheduler.RegisterSchedulerFactory(proto.TaskTypeExample,
 		func(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
 			mockScheduler = mock.NewMockScheduler(ctrl)
 			// below 2 are for balancer loop, it's async, cannot determine how
@@ -453,7 +461,7 @@ func TestManagerScheduleLoop(t *testing.T) {
 			mockScheduler.EXPECT().Close()
 			return mockScheduler
 		},
-	)
+	))
 	for i := 0; i < len(concurrencies); i++ {
 		_, err := taskMgr.CreateTask(ctx, fmt.Sprintf("key/%d", i), proto.TaskTypeExample, concurrencies[i], "", []byte("{}"))
 		require.NoError(t, err)
@@ -16,6 +16,7 @@ package storage
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -37,6 +38,9 @@ func row2TaskBasic(r chunk.Row) *proto.TaskBase {
 		TargetScope: r.GetString(8),
 	}
 	task.CreateTime, _ = r.GetTime(7).GoTime(time.Local)
+	if !r.IsNull(9) {
+		task.Dependencies = str2Dependencies(r.GetString(9))
+	}
 	return task
 }
 
@@ -45,28 +49,58 @@ func Row2Task(r chunk.Row) *proto.Task {
 	taskBase := row2TaskBasic(r)
 	task := &proto.Task{TaskBase: *taskBase}
 	var startTime, updateTime time.Time
-	if !r.IsNull(9) {
-		startTime, _ = r.GetTime(9).GoTime(time.Local)
-	}
 	if !r.IsNull(10) {
-		updateTime, _ = r.GetTime(10).GoTime(time.Local)
+		startTime, _ = r.GetTime(10).GoTime(time.Local)
+	}
+	if !r.IsNull(11) {
+		updateTime, _ = r.GetTime(11).GoTime(time.Local)
 	}
 	task.StartTime = startTime
 	task.StateUpdateTime = updateTime
-	task.Meta = r.GetBytes(11)
-	task.SchedulerID = r.GetString(12)
-	if !r.IsNull(13) {
-		errBytes := r.GetBytes(13)
-		stdErr := errors.Normalize("")
-		err := stdErr.UnmarshalJSON(errBytes)
+	task.Meta = mustDecodeMeta(r.GetBytes(12))
+	task.SchedulerID = r.GetString(13)
+	if !r.IsNull(14) {
+		task.Error = bytes2Error(r.GetBytes(14))
+	}
+	return task
+}
+
+// dependencies2Str converts the task dependencies to the comma separated string
+// stored in the task table.
+func dependencies2Str(dependencies []int64) string {
+	strs := make([]string, 0, len(dependencies))
+	for _, id := range dependencies {
+		strs = append(strs, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(strs, ",")
+}
+
+// str2Dependencies converts the dependencies stored in the task table back.
+func str2Dependencies(s string) []int64 {
+	if s == "" {
+		return nil
+	}
+	strs := strings.Split(s, ",")
+	dependencies := make([]int64, 0, len(strs))
+	for _, str := range strs {
+		id, err := strconv.ParseInt(str, 10, 64)
 		if err != nil {
-			logutil.BgLogger().Error("unmarshal task error", zap.Error(err))
-			task.Error = errors.New(string(errBytes))
-		} else {
-			task.Error = stdErr
+			logutil.BgLogger().Warn("unexpected task dependency", zap.String("dependencies", s))
+			continue
 		}
+		dependencies = append(dependencies, id)
 	}
-	return task
+	return dependencies
+}
+
+// bytes2Error converts the error stored in the task or subtask table to an error.
+func bytes2Error(errBytes []byte) error {
+	stdErr := errors.Normalize("")
+	if err := stdErr.UnmarshalJSON(errBytes); err != nil {
+		logutil.BgLogger().Error("unmarshal task error", zap.Error(err))
+		return errors.New(string(errBytes))
+	}
+	return stdErr
 }
 
 // row2BasicSubTask converts a row to a subtask with basic info
@@ -120,7 +154,15 @@ func Row2SubTask(r chunk.Row) *proto.Subtask {
 	}
 
 	subtask.UpdateTime = updateTime
-	subtask.Meta = r.GetBytes(11)
+	subtask.Meta = mustDecodeMeta(r.GetBytes(11))
 	subtask.Summary = r.GetJSON(12).String()
+	if !r.IsNull(13) {
+		subtask.RetryCount = int(r.GetInt64(13))
+	}
+	// checkpoint is initialized to '{}' when the subtask is inserted, which
+	// means there is no checkpoint yet.
+	if checkpoint := r.GetBytes(14); len(checkpoint) > 0 && string(checkpoint) != "{}" {
+		subtask.Checkpoint = checkpoint
+	}
 	return subtask
 }
@@ -0,0 +1,160 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// GetTaskEvents gets the event history of the task in the order they happen.
+func (mgr *TaskManager) GetTaskEvents(ctx context.Context, taskID int64) ([]*proto.TaskEvent, error) {
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx, `
+		select id, task_id, type, exec_id, message, create_time
+		from mysql.tidb_global_task_event
+		where task_id = %? order by id`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*proto.TaskEvent, 0, len(rs))
+	for _, r := range rs {
+		event := &proto.TaskEvent{
+			ID:      r.GetInt64(0),
+			TaskID:  r.GetInt64(1),
+			Type:    proto.TaskEventType(r.GetString(2)),
+			ExecID:  r.GetString(3),
+			Message: r.GetString(4),
+		}
+		event.CreateTime, _ = r.GetTime(5).GoTime(time.Local)
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// insertTaskEvent appends an event to the event history of the task, it should
+// be called in the same transaction which changes the task.
+func insertTaskEvent(ctx context.Context, se sessionctx.Context, taskID int64,
+	tp proto.TaskEventType, execID, msg string) error {
+	_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `
+		insert into mysql.tidb_global_task_event(task_id, type, exec_id, message)
+		values (%?, %?, %?, %?)`, taskID, tp, execID, msg)
+	return err
+}
+
+// insertTaskEventByKey is like insertTaskEvent, but the task is specified by
+// its key.
+func insertTaskEventByKey(ctx context.Context, se sessionctx.Context, taskKey string,
+	tp proto.TaskEventType, msg string) error {
+	_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `
+		insert into mysql.tidb_global_task_event(task_id, type, exec_id, message)
+		select id, %?, '', %? from mysql.tidb_global_task where task_key = %?`,
+		tp, msg, taskKey)
+	return err
+}
+
+// insertSubtaskEvent is like insertTaskEvent, but the task and node are taken
+// from the subtask.
+func insertSubtaskEvent(ctx context.Context, se sessionctx.Context, subtaskID int64,
+	tp proto.TaskEventType, msg string) error {
+	_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `
+		insert into mysql.tidb_global_task_event(task_id, type, exec_id, message)
+		select cast(task_key as signed), %?, exec_id, %? from mysql.tidb_background_subtask where id = %?`,
+		tp, msg, subtaskID)
+	return err
+}
+
+// updateTaskWithEvent executes the update SQL of the task in a new transaction,
+// and appends a state change event with msg if the task is updated, an error
+// event is appended too if taskErr is not nil.
+func (mgr *TaskManager) updateTaskWithEvent(ctx context.Context, taskID int64,
+	msg string, taskErr error, sql string, args ...any) (bool, error) {
+	found := false
+	err := mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		if _, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), sql, args...); err != nil {
+			return err
+		}
+		if se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+			return nil
+		}
+		found = true
+		if err := insertTaskEvent(ctx, se, taskID, proto.TaskEventStateChange, "", msg); err != nil {
+			return err
+		}
+		if taskErr == nil {
+			return nil
+		}
+		return insertTaskEvent(ctx, se, taskID, proto.TaskEventError, "", taskErr.Error())
+	})
+	return found, err
+}
+
+// stateChangeMsg describes the state change of task, such as "running -> reverting",
+// multiple from states are joined by "|".
+func stateChangeMsg(to proto.TaskState, from ...proto.TaskState) string {
+	var sb strings.Builder
+	for i, state := range from {
+		if i > 0 {
+			sb.WriteString("|")
+		}
+		sb.WriteString(state.String())
+	}
+	sb.WriteString(" -> ")
+	sb.WriteString(to.String())
+	return sb.String()
+}
+
+// assignSubtasksMsg describes how the subtasks of the step are assigned to
+// nodes, such as "step one: 3 subtasks on :4000, 2 subtasks on :4001".
+func assignSubtasksMsg(taskType proto.TaskType, step proto.Step, subtasks []*proto.Subtask) string {
+	execIDs := make([]string, 0, 4)
+	cnts := make(map[string]int, 4)
+	for _, st := range subtasks {
+		if _, ok := cnts[st.ExecID]; !ok {
+			execIDs = append(execIDs, st.ExecID)
+		}
+		cnts[st.ExecID]++
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "step %s:", proto.Step2Str(taskType, step))
+	for i, execID := range execIDs {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, " %d subtasks on %s", cnts[execID], execID)
+	}
+	return sb.String()
+}
+
+// insertSwitchStepEvents appends the state change and subtask assignment events
+// when the task switches to the next step.
+func insertSwitchStepEvents(ctx context.Context, se sessionctx.Context, task *proto.Task,
+	nextState proto.TaskState, nextStep proto.Step, subtasks []*proto.Subtask) error {
+	msg := fmt.Sprintf("%s, step %s -> %s", stateChangeMsg(nextState, task.State),
+		proto.Step2Str(task.Type, task.Step), proto.Step2Str(task.Type, nextStep))
+	if err := insertTaskEvent(ctx, se, task.ID, proto.TaskEventStateChange, "", msg); err != nil {
+		return err
+	}
+	if len(subtasks) == 0 {
+		return nil
+	}
+	return insertTaskEvent(ctx, se, task.ID, proto.TaskEventAssign, "",
+		assignSubtasksMsg(task.Type, nextStep, subtasks))
+}
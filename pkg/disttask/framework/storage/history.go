@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 )
 
@@ -50,10 +51,14 @@ func (mgr *TaskManager) TransferTasks2History(ctx context.Context, tasks []*prot
 		// sensitive data in meta might be redacted, need update first.
 		exec := se.GetSQLExecutor()
 		for _, t := range tasks {
+			encoded := encodeMeta(t.Meta)
+			if err := insertMetaChunks(ctx, se, t.ID, encoded); err != nil {
+				return err
+			}
 			_, err := sqlexec.ExecSQL(ctx, exec, `
 				update mysql.tidb_global_task
 				set meta= %?, state_update_time = CURRENT_TIMESTAMP()
-				where id = %?`, t.Meta, t.ID)
+				where id = %?`, encoded.data, t.ID)
 			if err != nil {
 				return err
 			}
@@ -80,17 +85,81 @@ func (mgr *TaskManager) TransferTasks2History(ctx context.Context, tasks []*prot
 	})
 }
 
-// GCSubtasks deletes the history subtask which is older than the given days.
-func (mgr *TaskManager) GCSubtasks(ctx context.Context) error {
-	subtaskHistoryKeepSeconds := defaultSubtaskKeepDays * 24 * 60 * 60
+// GCHistory deletes the history tasks, subtasks and task events which are
+// finished before the retention period, see tidb_dist_task_history_retention,
+// and the meta chunks of the deleted tasks.
+func (mgr *TaskManager) GCHistory(ctx context.Context) error {
+	historyKeepSeconds := int64(variable.DistTaskHistoryRetention.Load().Seconds())
 	failpoint.Inject("subtaskHistoryKeepSeconds", func(val failpoint.Value) {
 		if val, ok := val.(int); ok {
-			subtaskHistoryKeepSeconds = val
+			historyKeepSeconds = int64(val)
+		}
+	})
+	return mgr.WithNewSession(func(se sessionctx.Context) error {
+		exec := se.GetSQLExecutor()
+		// state_update_time of subtask is a unix timestamp in seconds.
+		_, err := sqlexec.ExecSQL(ctx, exec, `DELETE FROM mysql.tidb_background_subtask_history
+			WHERE state_update_time < UNIX_TIMESTAMP() - %?`, historyKeepSeconds)
+		if err != nil {
+			return err
+		}
+		// the history tasks which unfinished tasks depend on are kept, the
+		// dependent tasks need their states to be scheduled.
+		rs, err := sqlexec.ExecSQL(ctx, exec, `SELECT dependencies FROM mysql.tidb_global_task
+			WHERE dependencies != ''`)
+		if err != nil {
+			return err
+		}
+		sql, args := `DELETE FROM mysql.tidb_global_task_history
+			WHERE state_update_time < FROM_UNIXTIME(UNIX_TIMESTAMP() - %?)`, []any{historyKeepSeconds}
+		if len(rs) > 0 {
+			dependencies := make([]any, 0, len(rs))
+			for _, r := range rs {
+				for _, id := range str2Dependencies(r.GetString(0)) {
+					dependencies = append(dependencies, id)
+				}
+			}
+			sql += ` AND id NOT IN (` + strings.Repeat("%?,", len(dependencies)-1) + `%?)`
+			args = append(args, dependencies...)
+		}
+		if _, err = sqlexec.ExecSQL(ctx, exec, sql, args...); err != nil {
+			return err
+		}
+		_, err = sqlexec.ExecSQL(ctx, exec, `DELETE FROM mysql.tidb_global_task_event
+			WHERE create_time < FROM_UNIXTIME(UNIX_TIMESTAMP() - %?)
+			AND task_id NOT IN (SELECT id FROM mysql.tidb_global_task)`, historyKeepSeconds)
+		if err != nil {
+			return err
 		}
+		return deleteStaleMetaChunks(ctx, exec)
 	})
-	_, err := mgr.ExecuteSQLWithNewSession(
-		ctx,
-		fmt.Sprintf("DELETE FROM mysql.tidb_background_subtask_history WHERE state_update_time < UNIX_TIMESTAMP() - %d ;", subtaskHistoryKeepSeconds),
-	)
+}
+
+// PurgeHistory deletes all the history tasks and subtasks, and the events and
+// meta chunks of them.
+func (mgr *TaskManager) PurgeHistory(ctx context.Context) error {
+	return mgr.WithNewSession(func(se sessionctx.Context) error {
+		exec := se.GetSQLExecutor()
+		if _, err := sqlexec.ExecSQL(ctx, exec, `DELETE FROM mysql.tidb_background_subtask_history`); err != nil {
+			return err
+		}
+		if _, err := sqlexec.ExecSQL(ctx, exec, `DELETE FROM mysql.tidb_global_task_history`); err != nil {
+			return err
+		}
+		_, err := sqlexec.ExecSQL(ctx, exec, `DELETE FROM mysql.tidb_global_task_event
+			WHERE task_id NOT IN (SELECT id FROM mysql.tidb_global_task)`)
+		if err != nil {
+			return err
+		}
+		return deleteStaleMetaChunks(ctx, exec)
+	})
+}
+
+// deleteStaleMetaChunks deletes the meta chunks of the tasks which are deleted
+// from both the task and the task history table.
+func deleteStaleMetaChunks(ctx context.Context, exec sqlexec.SQLExecutor) error {
+	_, err := sqlexec.ExecSQL(ctx, exec, `DELETE FROM mysql.tidb_global_task_meta_chunk
+		WHERE task_id NOT IN (SELECT id FROM mysql.tidb_global_task)
+		AND task_id NOT IN (SELECT id FROM mysql.tidb_global_task_history)`)
 	return err
 }
@@ -0,0 +1,152 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+)
+
+// TaskFilter is the filter used when listing tasks, zero value fields are ignored.
+type TaskFilter struct {
+	States []proto.TaskState
+	Types  []proto.TaskType
+	// CreatedAfter and CreatedBefore filter the tasks by create_time, in range
+	// [CreatedAfter, CreatedBefore).
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// SubtaskFilter is the filter used when listing subtasks, zero value fields are ignored.
+type SubtaskFilter struct {
+	TaskID int64
+	Step   proto.Step
+	States []proto.SubtaskState
+	ExecID string
+	// CreatedAfter and CreatedBefore filter the subtasks by create_time, in range
+	// [CreatedAfter, CreatedBefore).
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+type whereBuilder struct {
+	conds []string
+	args  []any
+}
+
+func (b *whereBuilder) add(cond string, args ...any) {
+	b.conds = append(b.conds, cond)
+	b.args = append(b.args, args...)
+}
+
+func (b *whereBuilder) addStrings(column string, values []string) {
+	if len(values) > 0 {
+		b.add(column+" in (%?)", values)
+	}
+}
+
+func (b *whereBuilder) addTimeRange(column string, after, before time.Time) {
+	if !after.IsZero() {
+		b.add(column+" >= %?", after)
+	}
+	if !before.IsZero() {
+		b.add(column+" < %?", before)
+	}
+}
+
+// build returns the where clause along with the order by and limit clause.
+func (b *whereBuilder) build(orderBy string, limit, offset int) string {
+	var sb strings.Builder
+	if len(b.conds) > 0 {
+		sb.WriteString(" where ")
+		sb.WriteString(strings.Join(b.conds, " and "))
+	}
+	sb.WriteString(" order by ")
+	sb.WriteString(orderBy)
+	if limit > 0 {
+		sb.WriteString(" limit %? offset %?")
+		b.args = append(b.args, limit, max(offset, 0))
+	}
+	return sb.String()
+}
+
+// ListTasks lists the tasks which are not moved to history table and match the
+// filter, order by id. limit <= 0 means no limit.
+func (mgr *TaskManager) ListTasks(ctx context.Context, filter TaskFilter, limit, offset int) ([]*proto.Task, error) {
+	var b whereBuilder
+	states := make([]string, 0, len(filter.States))
+	for _, s := range filter.States {
+		states = append(states, string(s))
+	}
+	b.addStrings("t.state", states)
+	types := make([]string, 0, len(filter.Types))
+	for _, tp := range filter.Types {
+		types = append(types, string(tp))
+	}
+	b.addStrings("t.type", types)
+	b.addTimeRange("t.create_time", filter.CreatedAfter, filter.CreatedBefore)
+	sql := "select " + TaskColumns + " from mysql.tidb_global_task t" + b.build("t.id", limit, offset)
+
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx, sql, b.args...)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*proto.Task, 0, len(rs))
+	for _, r := range rs {
+		tasks = append(tasks, Row2Task(r))
+	}
+	if err = mgr.loadTaskMetaChunks(ctx, tasks...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListSubtasks lists the subtasks which are not moved to history table and
+// match the filter, order by id. limit <= 0 means no limit.
+func (mgr *TaskManager) ListSubtasks(ctx context.Context, filter SubtaskFilter, limit, offset int) ([]*proto.Subtask, error) {
+	var b whereBuilder
+	if filter.TaskID > 0 {
+		b.add("task_key = %?", filter.TaskID)
+	}
+	if filter.Step != 0 {
+		b.add("step = %?", filter.Step)
+	}
+	states := make([]string, 0, len(filter.States))
+	for _, s := range filter.States {
+		states = append(states, string(s))
+	}
+	b.addStrings("state", states)
+	if filter.ExecID != "" {
+		b.add("exec_id = %?", filter.ExecID)
+	}
+	b.addTimeRange("create_time", filter.CreatedAfter, filter.CreatedBefore)
+	sql := "select " + SubtaskColumns + " from mysql.tidb_background_subtask" + b.build("id", limit, offset)
+
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx, sql, b.args...)
+	if err != nil {
+		return nil, err
+	}
+	subtasks := make([]*proto.Subtask, 0, len(rs))
+	for _, r := range rs {
+		subtasks = append(subtasks, Row2SubTask(r))
+	}
+	if err = mgr.loadSubtaskMetaChunks(ctx, subtasks...); err != nil {
+		return nil, err
+	}
+	return subtasks, nil
+}
@@ -0,0 +1,973 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memstore provides an in-memory implementation of the task and subtask
+// tables, it can be used as scheduler.TaskManager and taskexecutor.TaskTable to
+// unit test the framework and task types without a store.
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/cpu"
+)
+
+// ErrSessionNotSupported is returned when the caller asks for a session, the
+// in-memory task manager doesn't have one.
+var ErrSessionNotSupported = errors.New("session is not supported by in-memory task manager")
+
+type subtaskEntry struct {
+	subtask *proto.Subtask
+	err     error
+}
+
+// TaskManager is an in-memory task manager, it mirrors the behavior of
+// storage.TaskManager, except that:
+//   - task concurrency is not checked against the cpu count of nodes.
+//   - WithNewSession and WithNewTxn return ErrSessionNotSupported.
+//
+// it's safe for concurrent use.
+type TaskManager struct {
+	mu sync.Mutex
+
+	lastTaskID    int64
+	lastSubtaskID int64
+	tasks         map[int64]*proto.Task
+	subtasks      map[int64]*subtaskEntry
+	// history tables, subtasks are only kept for GCHistory.
+	historyTasks    map[int64]*proto.Task
+	historySubtasks map[int64]*subtaskEntry
+	nodes           map[string]proto.ManagedNode
+}
+
+// NewTaskManager creates a new in-memory task manager.
+func NewTaskManager() *TaskManager {
+	return &TaskManager{
+		tasks:           make(map[int64]*proto.Task),
+		subtasks:        make(map[int64]*subtaskEntry),
+		historyTasks:    make(map[int64]*proto.Task),
+		historySubtasks: make(map[int64]*subtaskEntry),
+		nodes:           make(map[string]proto.ManagedNode),
+	}
+}
+
+func cloneTask(t *proto.Task) *proto.Task {
+	c := *t
+	c.Dependencies = slices.Clone(t.Dependencies)
+	c.Meta = slices.Clone(t.Meta)
+	return &c
+}
+
+func cloneTaskBase(t *proto.Task) *proto.TaskBase {
+	c := t.TaskBase
+	c.Dependencies = slices.Clone(t.Dependencies)
+	return &c
+}
+
+func cloneSubtask(s *proto.Subtask) *proto.Subtask {
+	c := *s
+	c.Meta = slices.Clone(s.Meta)
+	c.Checkpoint = slices.Clone(s.Checkpoint)
+	return &c
+}
+
+// sortedTasks returns the tasks which match the filter ordered by rank.
+func (m *TaskManager) sortedTasks(filter func(*proto.Task) bool) []*proto.Task {
+	res := make([]*proto.Task, 0, len(m.tasks))
+	for _, t := range m.tasks {
+		if filter(t) {
+			res = append(res, t)
+		}
+	}
+	slices.SortFunc(res, func(a, b *proto.Task) int {
+		return a.Compare(&b.TaskBase)
+	})
+	return res
+}
+
+// sortedSubtasks returns the subtasks which match the filter ordered by id.
+func (m *TaskManager) sortedSubtasks(filter func(*proto.Subtask) bool) []*subtaskEntry {
+	res := make([]*subtaskEntry, 0, len(m.subtasks))
+	for _, e := range m.subtasks {
+		if filter(e.subtask) {
+			res = append(res, e)
+		}
+	}
+	slices.SortFunc(res, func(a, b *subtaskEntry) int {
+		return int(a.subtask.ID - b.subtask.ID)
+	})
+	return res
+}
+
+// CreateTask adds a new task.
+func (m *TaskManager) CreateTask(ctx context.Context, key string, tp proto.TaskType, concurrency int, targetScope string, meta []byte) (int64, error) {
+	return m.CreateTaskWithDependencies(ctx, key, tp, concurrency, targetScope, meta, nil)
+}
+
+// CreateTaskWithDependencies adds a new task which will only be scheduled after
+// all the tasks in dependencies succeed.
+func (m *TaskManager) CreateTaskWithDependencies(
+	_ context.Context,
+	key string,
+	tp proto.TaskType,
+	concurrency int,
+	targetScope string,
+	meta []byte,
+	dependencies []int64,
+) (int64, error) {
+	if err := storage.CheckTaskDependencies(dependencies); err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range dependencies {
+		_, ok := m.tasks[id]
+		_, ok2 := m.historyTasks[id]
+		if !ok && !ok2 {
+			return 0, errors.Errorf("dependency task %d not found", id)
+		}
+	}
+	for _, t := range m.tasks {
+		if t.Key == key {
+			return 0, storage.ErrTaskAlreadyExists
+		}
+	}
+	m.lastTaskID++
+	now := time.Now()
+	m.tasks[m.lastTaskID] = &proto.Task{
+		TaskBase: proto.TaskBase{
+			ID:           m.lastTaskID,
+			Key:          key,
+			Type:         tp,
+			State:        proto.TaskStatePending,
+			Step:         proto.StepInit,
+			Priority:     proto.NormalPriority,
+			Concurrency:  concurrency,
+			TargetScope:  targetScope,
+			CreateTime:   now,
+			Dependencies: slices.Clone(dependencies),
+		},
+		StateUpdateTime: now,
+		Meta:            slices.Clone(meta),
+	}
+	return m.lastTaskID, nil
+}
+
+// GetTopUnfinishedTasks implements the scheduler.TaskManager interface.
+func (m *TaskManager) GetTopUnfinishedTasks(context.Context) ([]*proto.TaskBase, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tasks := m.sortedTasks(func(t *proto.Task) bool {
+		switch t.State {
+		case proto.TaskStatePending, proto.TaskStateRunning, proto.TaskStateReverting,
+			proto.TaskStateCancelling, proto.TaskStatePausing, proto.TaskStateResuming:
+			return true
+		}
+		return false
+	})
+	// pending tasks with dependencies are not counted toward the limit.
+	limit := int(variable.DistTaskDispatchConcurrency.Load() * 2)
+	res := make([]*proto.TaskBase, 0, min(len(tasks), limit))
+	var count int
+	for _, t := range tasks {
+		if t.State == proto.TaskStatePending && len(t.Dependencies) > 0 {
+			res = append(res, cloneTaskBase(t))
+			continue
+		}
+		if count >= limit {
+			continue
+		}
+		count++
+		res = append(res, cloneTaskBase(t))
+	}
+	return res, nil
+}
+
+// GetTaskExecInfoByExecID implements the taskexecutor.TaskTable interface.
+func (m *TaskManager) GetTaskExecInfoByExecID(_ context.Context, execID string) ([]*storage.TaskExecInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tasks := m.sortedTasks(func(t *proto.Task) bool {
+		return t.State == proto.TaskStateRunning || t.State == proto.TaskStateReverting ||
+			t.State == proto.TaskStatePausing
+	})
+	res := make([]*storage.TaskExecInfo, 0, len(tasks))
+	for _, t := range tasks {
+		subtaskConcurrency := 0
+		for _, e := range m.subtasks {
+			s := e.subtask
+			if s.TaskID == t.ID && s.Step == t.Step && s.ExecID == execID &&
+				(s.State == proto.SubtaskStatePending || s.State == proto.SubtaskStateRunning) {
+				subtaskConcurrency = max(subtaskConcurrency, s.Concurrency)
+			}
+		}
+		if subtaskConcurrency > 0 {
+			res = append(res, &storage.TaskExecInfo{
+				TaskBase:           cloneTaskBase(t),
+				SubtaskConcurrency: subtaskConcurrency,
+			})
+		}
+	}
+	return res, nil
+}
+
+// GetTasksInStates gets the tasks in the states ordered by rank.
+func (m *TaskManager) GetTasksInStates(_ context.Context, states ...any) ([]*proto.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(states) == 0 {
+		return nil, nil
+	}
+	tasks := m.sortedTasks(func(t *proto.Task) bool {
+		for _, s := range states {
+			if fmt.Sprint(s) == string(t.State) {
+				return true
+			}
+		}
+		return false
+	})
+	res := make([]*proto.Task, 0, len(tasks))
+	for _, t := range tasks {
+		res = append(res, cloneTask(t))
+	}
+	return res, nil
+}
+
+// GetTaskByID gets the task by the task ID.
+func (m *TaskManager) GetTaskByID(_ context.Context, taskID int64) (*proto.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tasks[taskID]
+	if !ok {
+		return nil, storage.ErrTaskNotFound
+	}
+	return cloneTask(t), nil
+}
+
+// GetTaskBaseByID gets the task base by the task ID.
+func (m *TaskManager) GetTaskBaseByID(_ context.Context, taskID int64) (*proto.TaskBase, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tasks[taskID]
+	if !ok {
+		return nil, storage.ErrTaskNotFound
+	}
+	return cloneTaskBase(t), nil
+}
+
+// GetTaskByIDWithHistory gets the task by the task ID from both task and history.
+func (m *TaskManager) GetTaskByIDWithHistory(_ context.Context, taskID int64) (*proto.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if t, ok := m.tasks[taskID]; ok {
+		return cloneTask(t), nil
+	}
+	if t, ok := m.historyTasks[taskID]; ok {
+		return cloneTask(t), nil
+	}
+	return nil, storage.ErrTaskNotFound
+}
+
+// GetTaskStatesWithHistory implements the scheduler.TaskManager interface.
+func (m *TaskManager) GetTaskStatesWithHistory(_ context.Context, taskIDs []int64) (map[int64]proto.TaskState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	states := make(map[int64]proto.TaskState, len(taskIDs))
+	for _, id := range taskIDs {
+		if t, ok := m.tasks[id]; ok {
+			states[id] = t.State
+		} else if t, ok := m.historyTasks[id]; ok {
+			states[id] = t.State
+		}
+	}
+	return states, nil
+}
+
+// GCHistory deletes the history tasks and subtasks which are finished before
+// the retention period, see tidb_dist_task_history_retention.
+func (m *TaskManager) GCHistory(context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	deadline := time.Now().Add(-variable.DistTaskHistoryRetention.Load())
+	// the history tasks which unfinished tasks depend on are kept.
+	dependencies := make(map[int64]struct{})
+	for _, t := range m.tasks {
+		for _, id := range t.Dependencies {
+			dependencies[id] = struct{}{}
+		}
+	}
+	for id, t := range m.historyTasks {
+		if _, ok := dependencies[id]; ok {
+			continue
+		}
+		if t.StateUpdateTime.Before(deadline) {
+			delete(m.historyTasks, id)
+		}
+	}
+	for id, e := range m.historySubtasks {
+		if e.subtask.UpdateTime.Before(deadline) {
+			delete(m.historySubtasks, id)
+		}
+	}
+	return nil
+}
+
+// TransferTasks2History transfer tasks and their subtasks to history.
+func (m *TaskManager) TransferTasks2History(_ context.Context, tasks []*proto.Task) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for _, task := range tasks {
+		t, ok := m.tasks[task.ID]
+		if !ok {
+			continue
+		}
+		// sensitive data in meta might be redacted.
+		t.Meta = slices.Clone(task.Meta)
+		t.StateUpdateTime = now
+		m.historyTasks[t.ID] = t
+		delete(m.tasks, t.ID)
+		for id, e := range m.subtasks {
+			if e.subtask.TaskID == t.ID {
+				m.historySubtasks[id] = e
+				delete(m.subtasks, id)
+			}
+		}
+	}
+	return nil
+}
+
+// updateTask updates the task if it's in one of the from states, returns
+// whether the task is updated.
+func (m *TaskManager) updateTask(taskID int64, fn func(t *proto.Task), from ...proto.TaskState) bool {
+	t, ok := m.tasks[taskID]
+	if !ok || !slices.Contains(from, t.State) {
+		return false
+	}
+	fn(t)
+	t.StateUpdateTime = time.Now()
+	return true
+}
+
+func setTaskState(state proto.TaskState) func(t *proto.Task) {
+	return func(t *proto.Task) {
+		t.State = state
+	}
+}
+
+func verifyTaskStateTransform(taskID int64, from, to proto.TaskState) error {
+	if proto.VerifyTaskStateTransform(from, to) {
+		return nil
+	}
+	return errors.Annotatef(storage.ErrInvalidTaskStateTransform, "task %d from %s to %s", taskID, from, to)
+}
+
+// CancelTask updates task state to cancelling.
+func (m *TaskManager) CancelTask(_ context.Context, taskID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateTask(taskID, setTaskState(proto.TaskStateCancelling),
+		proto.TaskStatePending, proto.TaskStateRunning)
+	return nil
+}
+
+// ModifyTaskConcurrency modifies the concurrency of a pending task.
+func (m *TaskManager) ModifyTaskConcurrency(_ context.Context, taskID int64, concurrency int) (bool, error) {
+	if concurrency <= 0 {
+		return false, errors.Errorf("invalid task concurrency %d, should be positive", concurrency)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tasks[taskID]
+	if !ok || t.State != proto.TaskStatePending {
+		return false, nil
+	}
+	t.Concurrency = concurrency
+	return true, nil
+}
+
+// FailTask updates task state to failed and updates task error.
+func (m *TaskManager) FailTask(_ context.Context, taskID int64, currentState proto.TaskState, taskErr error) error {
+	if err := verifyTaskStateTransform(taskID, currentState, proto.TaskStateFailed); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateTask(taskID, func(t *proto.Task) {
+		t.State = proto.TaskStateFailed
+		t.Error = taskErr
+	}, currentState)
+	return nil
+}
+
+// RevertTask updates task state to reverting and updates task error.
+func (m *TaskManager) RevertTask(_ context.Context, taskID int64, taskState proto.TaskState, taskErr error) error {
+	if err := verifyTaskStateTransform(taskID, taskState, proto.TaskStateReverting); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateTask(taskID, func(t *proto.Task) {
+		t.State = proto.TaskStateReverting
+		t.Error = taskErr
+	}, taskState)
+	return nil
+}
+
+// RevertedTask updates task state from reverting to reverted.
+func (m *TaskManager) RevertedTask(_ context.Context, taskID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateTask(taskID, setTaskState(proto.TaskStateReverted), proto.TaskStateReverting)
+	return nil
+}
+
+func (m *TaskManager) getTaskIDByKey(key string) int64 {
+	for _, t := range m.tasks {
+		if t.Key == key {
+			return t.ID
+		}
+	}
+	return 0
+}
+
+// PauseTask updates task state to pausing.
+func (m *TaskManager) PauseTask(_ context.Context, taskKey string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.updateTask(m.getTaskIDByKey(taskKey), setTaskState(proto.TaskStatePausing),
+		proto.TaskStatePending, proto.TaskStateRunning), nil
+}
+
+// PausedTask updates task state from pausing to paused.
+func (m *TaskManager) PausedTask(_ context.Context, taskID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateTask(taskID, setTaskState(proto.TaskStatePaused), proto.TaskStatePausing)
+	return nil
+}
+
+// ResumeTask updates task state from paused to resuming.
+func (m *TaskManager) ResumeTask(_ context.Context, taskKey string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.updateTask(m.getTaskIDByKey(taskKey), setTaskState(proto.TaskStateResuming),
+		proto.TaskStatePaused), nil
+}
+
+// ResumedTask updates task state from resuming to running.
+func (m *TaskManager) ResumedTask(_ context.Context, taskID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateTask(taskID, setTaskState(proto.TaskStateRunning), proto.TaskStateResuming)
+	return nil
+}
+
+// SucceedTask updates task state from running to succeed.
+func (m *TaskManager) SucceedTask(_ context.Context, taskID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateTask(taskID, func(t *proto.Task) {
+		t.State = proto.TaskStateSucceed
+		t.Step = proto.StepDone
+	}, proto.TaskStateRunning)
+	return nil
+}
+
+// switchTaskStep updates the task state and step if it's not changed by others,
+// returns whether the task is updated.
+func (m *TaskManager) switchTaskStep(task *proto.Task, nextState proto.TaskState, nextStep proto.Step) bool {
+	t, ok := m.tasks[task.ID]
+	if !ok || t.State != task.State || t.Step != task.Step {
+		return false
+	}
+	now := time.Now()
+	if t.State == proto.TaskStatePending {
+		t.StartTime = now
+	}
+	t.State = nextState
+	t.Step = nextStep
+	t.Meta = slices.Clone(task.Meta)
+	t.StateUpdateTime = now
+	return true
+}
+
+func (m *TaskManager) insertSubtasks(subtasks []*proto.Subtask) {
+	now := time.Now()
+	for _, s := range subtasks {
+		m.lastSubtaskID++
+		c := cloneSubtask(s)
+		c.ID = m.lastSubtaskID
+		c.State = proto.SubtaskStatePending
+		c.CreateTime = now
+		c.StartTime = time.Time{}
+		c.UpdateTime = time.Time{}
+		m.subtasks[c.ID] = &subtaskEntry{subtask: c}
+	}
+}
+
+// SwitchTaskStep switches the task to the next step and add subtasks.
+func (m *TaskManager) SwitchTaskStep(
+	_ context.Context,
+	task *proto.Task,
+	nextState proto.TaskState,
+	nextStep proto.Step,
+	subtasks []*proto.Subtask,
+) error {
+	if err := verifyTaskStateTransform(task.ID, task.State, nextState); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.switchTaskStep(task, nextState, nextStep) {
+		m.insertSubtasks(subtasks)
+	}
+	return nil
+}
+
+// SwitchTaskStepInBatch is the same as SwitchTaskStep, subtasks which are
+// already inserted by others are skipped.
+func (m *TaskManager) SwitchTaskStepInBatch(
+	_ context.Context,
+	task *proto.Task,
+	nextState proto.TaskState,
+	nextStep proto.Step,
+	subtasks []*proto.Subtask,
+) error {
+	if err := verifyTaskStateTransform(task.ID, task.State, nextState); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	existing := 0
+	for _, e := range m.subtasks {
+		if e.subtask.TaskID == task.ID && e.subtask.Step == nextStep {
+			existing++
+		}
+	}
+	if existing > len(subtasks) {
+		return storage.ErrUnstableSubtasks
+	}
+	if t, ok := m.tasks[task.ID]; !ok || t.State != task.State || t.Step != task.Step {
+		return nil
+	}
+	m.insertSubtasks(subtasks[existing:])
+	m.switchTaskStep(task, nextState, nextStep)
+	return nil
+}
+
+// GetAllNodes gets all the managed nodes ordered by ID.
+func (m *TaskManager) GetAllNodes(context.Context) ([]proto.ManagedNode, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	nodes := make([]proto.ManagedNode, 0, len(m.nodes))
+	for _, n := range m.nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes, nil
+}
+
+// InitMeta adds the node, or updates its cpu count and role if it exists.
+func (m *TaskManager) InitMeta(_ context.Context, execID string, role string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nodes[execID] = proto.ManagedNode{ID: execID, Role: role, CPUCount: cpu.GetCPUCount()}
+	return nil
+}
+
+// RecoverMeta adds the node, or updates its cpu count if it exists.
+func (m *TaskManager) RecoverMeta(_ context.Context, execID string, role string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, ok := m.nodes[execID]
+	if !ok {
+		n = proto.ManagedNode{ID: execID, Role: role}
+	}
+	n.CPUCount = cpu.GetCPUCount()
+	m.nodes[execID] = n
+	return nil
+}
+
+// DeleteDeadNodes deletes the dead nodes.
+func (m *TaskManager) DeleteDeadNodes(_ context.Context, nodes []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, n := range nodes {
+		delete(m.nodes, n)
+	}
+	return nil
+}
+
+// GetUsedSlotsOnNodes implements the scheduler.TaskManager interface.
+func (m *TaskManager) GetUsedSlotsOnNodes(context.Context) (map[string]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// subtasks of one task on one node is only accounted once.
+	type key struct {
+		execID string
+		taskID int64
+	}
+	concurrency := make(map[key]int)
+	for _, e := range m.subtasks {
+		s := e.subtask
+		if s.State == proto.SubtaskStatePending || s.State == proto.SubtaskStateRunning {
+			k := key{execID: s.ExecID, taskID: s.TaskID}
+			concurrency[k] = max(concurrency[k], s.Concurrency)
+		}
+	}
+	slots := make(map[string]int)
+	for k, c := range concurrency {
+		slots[k.execID] += c
+	}
+	return slots, nil
+}
+
+// GetAllSubtasks gets all subtasks with basic columns.
+func (m *TaskManager) GetAllSubtasks(context.Context) ([]*proto.SubtaskBase, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := m.sortedSubtasks(func(*proto.Subtask) bool { return true })
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	res := make([]*proto.SubtaskBase, 0, len(entries))
+	for _, e := range entries {
+		b := e.subtask.SubtaskBase
+		res = append(res, &b)
+	}
+	return res, nil
+}
+
+// GetActiveSubtasks returns subtasks of the task that are in pending/running state.
+func (m *TaskManager) GetActiveSubtasks(_ context.Context, taskID int64) ([]*proto.SubtaskBase, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := m.sortedSubtasks(func(s *proto.Subtask) bool {
+		return s.TaskID == taskID &&
+			(s.State == proto.SubtaskStatePending || s.State == proto.SubtaskStateRunning)
+	})
+	res := make([]*proto.SubtaskBase, 0, len(entries))
+	for _, e := range entries {
+		b := e.subtask.SubtaskBase
+		res = append(res, &b)
+	}
+	return res, nil
+}
+
+func cloneSubtasks(entries []*subtaskEntry) []*proto.Subtask {
+	res := make([]*proto.Subtask, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, cloneSubtask(e.subtask))
+	}
+	return res
+}
+
+// GetAllSubtasksByStepAndState gets all subtasks by given state for one step.
+func (m *TaskManager) GetAllSubtasksByStepAndState(_ context.Context, taskID int64, step proto.Step, state proto.SubtaskState) ([]*proto.Subtask, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := m.sortedSubtasks(func(s *proto.Subtask) bool {
+		return s.TaskID == taskID && s.Step == step && s.State == state
+	})
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return cloneSubtasks(entries), nil
+}
+
+// GetSubtasksByExecIDAndStepAndStates gets all subtasks by given states on one node.
+func (m *TaskManager) GetSubtasksByExecIDAndStepAndStates(_ context.Context, execID string, taskID int64, step proto.Step, states ...proto.SubtaskState) ([]*proto.Subtask, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := m.sortedSubtasks(func(s *proto.Subtask) bool {
+		return s.ExecID == execID && s.TaskID == taskID && s.Step == step && slices.Contains(states, s.State)
+	})
+	return cloneSubtasks(entries), nil
+}
+
+// GetFirstSubtaskInStates gets the first subtask by given states on one node.
+func (m *TaskManager) GetFirstSubtaskInStates(ctx context.Context, execID string, taskID int64, step proto.Step, states ...proto.SubtaskState) (*proto.Subtask, error) {
+	subtasks, err := m.GetSubtasksByExecIDAndStepAndStates(ctx, execID, taskID, step, states...)
+	if err != nil || len(subtasks) == 0 {
+		return nil, err
+	}
+	return subtasks[0], nil
+}
+
+// HasSubtasksInStates checks if there are subtasks in the states on one node.
+func (m *TaskManager) HasSubtasksInStates(ctx context.Context, execID string, taskID int64, step proto.Step, states ...proto.SubtaskState) (bool, error) {
+	subtask, err := m.GetFirstSubtaskInStates(ctx, execID, taskID, step, states...)
+	return subtask != nil, err
+}
+
+// GetSubtaskCntGroupByStates gets the subtask count of some step by states.
+func (m *TaskManager) GetSubtaskCntGroupByStates(_ context.Context, taskID int64, step proto.Step) (map[proto.SubtaskState]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make(map[proto.SubtaskState]int64)
+	for _, e := range m.subtasks {
+		if e.subtask.TaskID == taskID && e.subtask.Step == step {
+			res[e.subtask.State]++
+		}
+	}
+	return res, nil
+}
+
+// GetSubtaskErrors gets the errors of failed or canceled subtasks.
+func (m *TaskManager) GetSubtaskErrors(_ context.Context, taskID int64) ([]error, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := m.sortedSubtasks(func(s *proto.Subtask) bool {
+		return s.TaskID == taskID &&
+			(s.State == proto.SubtaskStateFailed || s.State == proto.SubtaskStateCanceled)
+	})
+	res := make([]error, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, e.err)
+	}
+	return res, nil
+}
+
+// updateSubtasks updates the subtasks which match the filter, at most limit
+// subtasks ordered by id are updated if limit > 0.
+func (m *TaskManager) updateSubtasks(filter func(s *proto.Subtask) bool, limit int, fn func(e *subtaskEntry)) int {
+	entries := m.sortedSubtasks(filter)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	now := time.Now()
+	for _, e := range entries {
+		fn(e)
+		e.subtask.UpdateTime = now
+	}
+	return len(entries)
+}
+
+// getOwnedSubtask returns the subtask owned by execID.
+func (m *TaskManager) getOwnedSubtask(execID string, subtaskID int64) *subtaskEntry {
+	e, ok := m.subtasks[subtaskID]
+	if !ok || e.subtask.ExecID != execID {
+		return nil
+	}
+	return e
+}
+
+func verifySubtaskStateTransform(subtaskID int64, from, to proto.SubtaskState) error {
+	if proto.VerifySubtaskStateTransform(from, to) {
+		return nil
+	}
+	return errors.Annotatef(storage.ErrInvalidSubtaskStateTransform, "subtask %d from %s to %s", subtaskID, from, to)
+}
+
+// StartSubtask updates the subtask state to running if it's owned by execID.
+func (m *TaskManager) StartSubtask(_ context.Context, subtaskID int64, execID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.getOwnedSubtask(execID, subtaskID)
+	if e == nil {
+		return storage.ErrSubtaskNotFound
+	}
+	if err := verifySubtaskStateTransform(subtaskID, e.subtask.State, proto.SubtaskStateRunning); err != nil {
+		return err
+	}
+	e.subtask.State = proto.SubtaskStateRunning
+	e.subtask.StartTime = time.Now()
+	e.subtask.UpdateTime = time.Now()
+	return nil
+}
+
+// FinishSubtask updates the subtask meta and marks it as succeed.
+func (m *TaskManager) FinishSubtask(_ context.Context, execID string, subtaskID int64, meta []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.getOwnedSubtask(execID, subtaskID)
+	if e == nil {
+		return nil
+	}
+	if err := verifySubtaskStateTransform(subtaskID, e.subtask.State, proto.SubtaskStateSucceed); err != nil {
+		return err
+	}
+	e.subtask.State = proto.SubtaskStateSucceed
+	e.subtask.Meta = slices.Clone(meta)
+	e.subtask.UpdateTime = time.Now()
+	return nil
+}
+
+// UpdateSubtaskStateAndError updates the subtask state and error.
+func (m *TaskManager) UpdateSubtaskStateAndError(_ context.Context, execID string, subtaskID int64, state proto.SubtaskState, err error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.getOwnedSubtask(execID, subtaskID)
+	if e == nil {
+		return nil
+	}
+	if err := verifySubtaskStateTransform(subtaskID, e.subtask.State, state); err != nil {
+		return err
+	}
+	e.subtask.State = state
+	e.err = err
+	e.subtask.UpdateTime = time.Now()
+	return nil
+}
+
+// endActiveSubtask moves one pending/running subtask of the task on execID to
+// the state.
+func (m *TaskManager) endActiveSubtask(execID string, taskID int64, state proto.SubtaskState, err error) {
+	m.updateSubtasks(func(s *proto.Subtask) bool {
+		return s.ExecID == execID && s.TaskID == taskID &&
+			(s.State == proto.SubtaskStatePending || s.State == proto.SubtaskStateRunning)
+	}, 1, func(e *subtaskEntry) {
+		e.subtask.State = state
+		e.subtask.StartTime = time.Now()
+		e.err = err
+	})
+}
+
+// FailSubtask updates one pending/running subtask of the task to failed.
+func (m *TaskManager) FailSubtask(_ context.Context, execID string, taskID int64, err error) error {
+	if err == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endActiveSubtask(execID, taskID, proto.SubtaskStateFailed, err)
+	return nil
+}
+
+// CancelSubtask updates one pending/running subtask of the task to canceled.
+func (m *TaskManager) CancelSubtask(_ context.Context, execID string, taskID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endActiveSubtask(execID, taskID, proto.SubtaskStateCanceled, nil)
+	return nil
+}
+
+// UpdateSubtaskCheckpoint updates the checkpoint of the subtask if it's
+// running on execID.
+func (m *TaskManager) UpdateSubtaskCheckpoint(_ context.Context, execID string, subtaskID int64, checkpoint []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.getOwnedSubtask(execID, subtaskID)
+	if e == nil || e.subtask.State != proto.SubtaskStateRunning {
+		return storage.ErrSubtaskNotFound
+	}
+	e.subtask.Checkpoint = slices.Clone(checkpoint)
+	return nil
+}
+
+// PauseSubtasks updates pending/running subtasks of the task on execID to paused.
+func (m *TaskManager) PauseSubtasks(_ context.Context, execID string, taskID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateSubtasks(func(s *proto.Subtask) bool {
+		return s.ExecID == execID && s.TaskID == taskID &&
+			(s.State == proto.SubtaskStatePending || s.State == proto.SubtaskStateRunning)
+	}, 0, func(e *subtaskEntry) {
+		e.subtask.State = proto.SubtaskStatePaused
+	})
+	return nil
+}
+
+// ResumeSubtasks updates paused subtasks of the task to pending.
+func (m *TaskManager) ResumeSubtasks(_ context.Context, taskID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateSubtasks(func(s *proto.Subtask) bool {
+		return s.TaskID == taskID && s.State == proto.SubtaskStatePaused
+	}, 0, func(e *subtaskEntry) {
+		e.subtask.State = proto.SubtaskStatePending
+		e.err = nil
+	})
+	return nil
+}
+
+// RunningSubtasksBack2Pending updates the running subtasks back to pending.
+func (m *TaskManager) RunningSubtasksBack2Pending(_ context.Context, subtasks []*proto.SubtaskBase) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, st := range subtasks {
+		m.updateSubtasks(func(s *proto.Subtask) bool {
+			return s.ID == st.ID && s.ExecID == st.ExecID && s.State == proto.SubtaskStateRunning
+		}, 0, func(e *subtaskEntry) {
+			e.subtask.State = proto.SubtaskStatePending
+		})
+	}
+	return nil
+}
+
+// UpdateSubtasksExecIDs updates the exec id of subtasks if their state is not changed.
+func (m *TaskManager) UpdateSubtasksExecIDs(_ context.Context, subtasks []*proto.SubtaskBase) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, st := range subtasks {
+		if e, ok := m.subtasks[st.ID]; ok && e.subtask.State == st.State {
+			e.subtask.ExecID = st.ExecID
+		}
+	}
+	return nil
+}
+
+// RetrySubtask moves the failed subtask back to pending state on the node
+// execID, and increases its retry count.
+func (m *TaskManager) RetrySubtask(_ context.Context, subtaskID int64, execID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateSubtasks(func(s *proto.Subtask) bool {
+		return s.ID == subtaskID && s.State == proto.SubtaskStateFailed
+	}, 0, func(e *subtaskEntry) {
+		e.subtask.State = proto.SubtaskStatePending
+		e.subtask.ExecID = execID
+		e.subtask.StartTime = time.Time{}
+		e.subtask.RetryCount++
+		e.err = nil
+	})
+	return nil
+}
+
+// FailRunningSubtask updates the subtask to failed state with the error if
+// it's still running.
+func (m *TaskManager) FailRunningSubtask(_ context.Context, subtaskID int64, err error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateSubtasks(func(s *proto.Subtask) bool {
+		return s.ID == subtaskID && s.State == proto.SubtaskStateRunning
+	}, 0, func(e *subtaskEntry) {
+		e.subtask.State = proto.SubtaskStateFailed
+		e.err = err
+	})
+	return nil
+}
+
+// WithNewSession returns ErrSessionNotSupported.
+func (*TaskManager) WithNewSession(func(se sessionctx.Context) error) error {
+	return ErrSessionNotSupported
+}
+
+// WithNewTxn returns ErrSessionNotSupported.
+func (*TaskManager) WithNewTxn(context.Context, func(se sessionctx.Context) error) error {
+	return ErrSessionNotSupported
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memstore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage/memstore"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ scheduler.TaskManager  = (*memstore.TaskManager)(nil)
+	_ taskexecutor.TaskTable = (*memstore.TaskManager)(nil)
+)
+
+func TestTaskLifecycle(t *testing.T) {
+	ctx := context.Background()
+	mgr := memstore.NewTaskManager()
+
+	require.NoError(t, mgr.InitMeta(ctx, ":4000", ""))
+	require.NoError(t, mgr.InitMeta(ctx, ":4001", ""))
+	nodes, err := mgr.GetAllNodes(ctx)
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	require.Equal(t, ":4000", nodes[0].ID)
+
+	id, err := mgr.CreateTask(ctx, "key1", proto.TaskTypeExample, 2, "", []byte("meta"))
+	require.NoError(t, err)
+	_, err = mgr.CreateTask(ctx, "key1", proto.TaskTypeExample, 2, "", nil)
+	require.ErrorIs(t, err, storage.ErrTaskAlreadyExists)
+	_, err = mgr.CreateTaskWithDependencies(ctx, "key2", proto.TaskTypeExample, 2, "", nil, []int64{100})
+	require.ErrorContains(t, err, "dependency task 100 not found")
+	tasks, err := mgr.GetTopUnfinishedTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, proto.TaskStatePending, tasks[0].State)
+
+	// switch to the first step.
+	task, err := mgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	subtasks := []*proto.Subtask{
+		proto.NewSubtask(proto.StepOne, id, proto.TaskTypeExample, ":4000", 2, []byte("1"), 1),
+		proto.NewSubtask(proto.StepOne, id, proto.TaskTypeExample, ":4001", 2, []byte("2"), 2),
+	}
+	require.NoError(t, mgr.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, subtasks))
+	// switch again using the stale task is skipped.
+	require.NoError(t, mgr.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, subtasks))
+	task, err = mgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateRunning, task.State)
+	require.Equal(t, proto.StepOne, task.Step)
+	cntByStates, err := mgr.GetSubtaskCntGroupByStates(ctx, id, proto.StepOne)
+	require.NoError(t, err)
+	require.Equal(t, map[proto.SubtaskState]int64{proto.SubtaskStatePending: 2}, cntByStates)
+	slots, err := mgr.GetUsedSlotsOnNodes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{":4000": 2, ":4001": 2}, slots)
+	execInfos, err := mgr.GetTaskExecInfoByExecID(ctx, ":4000")
+	require.NoError(t, err)
+	require.Len(t, execInfos, 1)
+	require.Equal(t, 2, execInfos[0].SubtaskConcurrency)
+
+	// run subtasks.
+	subtask, err := mgr.GetFirstSubtaskInStates(ctx, ":4000", id, proto.StepOne, proto.SubtaskStatePending)
+	require.NoError(t, err)
+	require.ErrorIs(t, mgr.StartSubtask(ctx, subtask.ID, ":4001"), storage.ErrSubtaskNotFound)
+	require.NoError(t, mgr.StartSubtask(ctx, subtask.ID, ":4000"))
+	require.NoError(t, mgr.FinishSubtask(ctx, ":4000", subtask.ID, []byte("result")))
+	require.ErrorIs(t, mgr.StartSubtask(ctx, subtask.ID, ":4000"), storage.ErrInvalidSubtaskStateTransform)
+	require.ErrorIs(t, mgr.UpdateSubtaskStateAndError(ctx, ":4000", subtask.ID, proto.SubtaskStateFailed, nil),
+		storage.ErrInvalidSubtaskStateTransform)
+	require.NoError(t, mgr.FailSubtask(ctx, ":4001", id, errors.New("mock err")))
+	subtaskErrs, err := mgr.GetSubtaskErrors(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, subtaskErrs, 1)
+	require.ErrorContains(t, subtaskErrs[0], "mock err")
+	succeed, err := mgr.GetAllSubtasksByStepAndState(ctx, id, proto.StepOne, proto.SubtaskStateSucceed)
+	require.NoError(t, err)
+	require.Len(t, succeed, 1)
+	require.Equal(t, []byte("result"), succeed[0].Meta)
+
+	// retry the failed subtask on another node.
+	failed, err := mgr.GetAllSubtasksByStepAndState(ctx, id, proto.StepOne, proto.SubtaskStateFailed)
+	require.NoError(t, err)
+	require.NoError(t, mgr.RetrySubtask(ctx, failed[0].ID, ":4000"))
+	pending, err := mgr.GetSubtasksByExecIDAndStepAndStates(ctx, ":4000", id, proto.StepOne, proto.SubtaskStatePending)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, 1, pending[0].RetryCount)
+	require.NoError(t, mgr.StartSubtask(ctx, pending[0].ID, ":4000"))
+	require.NoError(t, mgr.FinishSubtask(ctx, ":4000", pending[0].ID, nil))
+
+	// illegal state transform is rejected.
+	require.ErrorIs(t, mgr.FailTask(ctx, id, proto.TaskStateSucceed, nil), storage.ErrInvalidTaskStateTransform)
+	require.NoError(t, mgr.SucceedTask(ctx, id))
+	task, err = mgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateSucceed, task.State)
+	require.Equal(t, proto.StepDone, task.Step)
+
+	// move to history.
+	require.NoError(t, mgr.TransferTasks2History(ctx, []*proto.Task{task}))
+	_, err = mgr.GetTaskByID(ctx, id)
+	require.ErrorIs(t, err, storage.ErrTaskNotFound)
+	states, err := mgr.GetTaskStatesWithHistory(ctx, []int64{id, id + 1})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]proto.TaskState{id: proto.TaskStateSucceed}, states)
+	allSubtasks, err := mgr.GetAllSubtasks(ctx)
+	require.NoError(t, err)
+	require.Empty(t, allSubtasks)
+
+	require.ErrorIs(t, mgr.WithNewSession(nil), memstore.ErrSessionNotSupported)
+}
+
+func TestPauseResumeAndCancel(t *testing.T) {
+	ctx := context.Background()
+	mgr := memstore.NewTaskManager()
+
+	id, err := mgr.CreateTask(ctx, "key1", proto.TaskTypeExample, 1, "", nil)
+	require.NoError(t, err)
+	task, err := mgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.NoError(t, mgr.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, []*proto.Subtask{
+		proto.NewSubtask(proto.StepOne, id, proto.TaskTypeExample, ":4000", 1, []byte("1"), 1),
+	}))
+
+	found, err := mgr.PauseTask(ctx, "key1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.NoError(t, mgr.PauseSubtasks(ctx, ":4000", id))
+	require.NoError(t, mgr.PausedTask(ctx, id))
+	cntByStates, err := mgr.GetSubtaskCntGroupByStates(ctx, id, proto.StepOne)
+	require.NoError(t, err)
+	require.Equal(t, map[proto.SubtaskState]int64{proto.SubtaskStatePaused: 1}, cntByStates)
+
+	found, err = mgr.ResumeTask(ctx, "key1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.NoError(t, mgr.ResumeSubtasks(ctx, id))
+	require.NoError(t, mgr.ResumedTask(ctx, id))
+	task, err = mgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateRunning, task.State)
+
+	require.NoError(t, mgr.CancelTask(ctx, id))
+	require.NoError(t, mgr.RevertTask(ctx, id, proto.TaskStateCancelling, errors.New("cancelled by user")))
+	require.NoError(t, mgr.RevertedTask(ctx, id))
+	task, err = mgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, proto.TaskStateReverted, task.State)
+	require.ErrorContains(t, task.Error, "cancelled by user")
+}
@@ -0,0 +1,214 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/golang/snappy"
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// task and subtask meta is stored as is if it's small, else it's encoded as
+//
+//	metaMagic | flag | payload
+//
+// if metaFlagCompressed is set, the data is compressed by snappy.
+// if metaFlagChunked is set, the data is split into chunks and stored in
+// mysql.tidb_global_task_meta_chunk, the payload is the count of chunks in
+// 4 bytes and the chunk key, else the payload is the data.
+// meta written by old versions doesn't have metaMagic, it's read as is.
+const (
+	metaFlagCompressed byte = 1 << iota
+	metaFlagChunked
+)
+
+// metaCompressThreshold is the min size of meta to compress, small meta is
+// stored as is, so it's still readable when querying the tables directly.
+const metaCompressThreshold = 4 << 10
+
+var metaMagic = []byte("\x00DXF")
+
+// encodedMeta is the meta to store, chunks should be stored before the meta
+// is visible.
+type encodedMeta struct {
+	data     []byte
+	chunkKey string
+	chunks   [][]byte
+}
+
+// encodeMeta compresses the meta if it's large, and splits it into chunks if
+// it's still larger than tidb_dist_task_max_meta_size. The meta is stored as is
+// until tidb_enable_dist_task_meta_encoding is enabled, since old versions can't
+// read the encoded meta during rolling upgrade.
+func encodeMeta(meta []byte) *encodedMeta {
+	if !variable.EnableDistTaskMetaEncoding.Load() {
+		return &encodedMeta{data: meta}
+	}
+	data, flag := meta, byte(0)
+	if len(meta) >= metaCompressThreshold {
+		if compressed := snappy.Encode(nil, meta); len(compressed) < len(meta) {
+			data, flag = compressed, metaFlagCompressed
+		}
+	}
+	maxSize := int(variable.DistTaskMaxMetaSize.Load())
+	if flag == 0 && len(meta) <= maxSize && !bytes.HasPrefix(meta, metaMagic) {
+		return &encodedMeta{data: meta}
+	}
+	if len(metaMagic)+1+len(data) <= maxSize {
+		return &encodedMeta{data: append(newMetaHeader(flag, len(data)), data...)}
+	}
+	res := &encodedMeta{chunkKey: uuid.NewString()}
+	for len(data) > 0 {
+		size := min(maxSize, len(data))
+		res.chunks = append(res.chunks, data[:size])
+		data = data[size:]
+	}
+	res.data = newMetaHeader(flag|metaFlagChunked, 4+len(res.chunkKey))
+	res.data = binary.BigEndian.AppendUint32(res.data, uint32(len(res.chunks)))
+	res.data = append(res.data, res.chunkKey...)
+	return res
+}
+
+func newMetaHeader(flag byte, payloadLen int) []byte {
+	header := make([]byte, 0, len(metaMagic)+1+payloadLen)
+	header = append(header, metaMagic...)
+	return append(header, flag)
+}
+
+// decodeMeta decodes the stored meta, chunked meta is returned as is, and
+// should be loaded by loadMetaChunks.
+func decodeMeta(stored []byte) ([]byte, error) {
+	if !bytes.HasPrefix(stored, metaMagic) || len(stored) == len(metaMagic) {
+		return stored, nil
+	}
+	flag, payload := stored[len(metaMagic)], stored[len(metaMagic)+1:]
+	if flag&metaFlagChunked != 0 {
+		return stored, nil
+	}
+	return decompressMeta(flag, payload)
+}
+
+func decompressMeta(flag byte, data []byte) ([]byte, error) {
+	if flag&metaFlagCompressed == 0 {
+		return data, nil
+	}
+	meta, err := snappy.Decode(nil, data)
+	return meta, errors.Annotate(err, "decompress meta")
+}
+
+// mustDecodeMeta is decodeMeta used when converting rows, the stored meta is
+// returned as is if it's corrupted.
+func mustDecodeMeta(stored []byte) []byte {
+	meta, err := decodeMeta(stored)
+	if err != nil {
+		logutil.BgLogger().Warn("decode meta failed", zap.Error(err))
+		return stored
+	}
+	return meta
+}
+
+// isChunkedMeta checks whether the stored meta is split into chunks.
+func isChunkedMeta(stored []byte) bool {
+	return bytes.HasPrefix(stored, metaMagic) && len(stored) > len(metaMagic) &&
+		stored[len(metaMagic)]&metaFlagChunked != 0
+}
+
+// insertMetaChunks stores the chunks of the encoded meta which belongs to the
+// task, it should be called in the same transaction which stores the meta.
+func insertMetaChunks(ctx context.Context, se sessionctx.Context, taskID int64, meta *encodedMeta) error {
+	for i, chunk := range meta.chunks {
+		_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `
+			insert into mysql.tidb_global_task_meta_chunk(chunk_key, seq, task_id, data)
+			values (%?, %?, %?, %?)`, meta.chunkKey, i, taskID, chunk)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadMetaChunks replaces the chunked metas with the data read from their
+// chunks.
+func loadMetaChunks(ctx context.Context, exec sqlexec.SQLExecutor, metas ...*[]byte) error {
+	for _, meta := range metas {
+		if !isChunkedMeta(*meta) {
+			continue
+		}
+		flag, payload := (*meta)[len(metaMagic)], (*meta)[len(metaMagic)+1:]
+		if len(payload) < 4 {
+			return errors.New("invalid chunked meta")
+		}
+		cnt, chunkKey := int(binary.BigEndian.Uint32(payload)), string(payload[4:])
+		rs, err := sqlexec.ExecSQL(ctx, exec, `
+			select data from mysql.tidb_global_task_meta_chunk
+			where chunk_key = %? order by seq`, chunkKey)
+		if err != nil {
+			return err
+		}
+		if len(rs) != cnt {
+			return errors.Errorf("meta chunks %s are incomplete, expect %d, got %d", chunkKey, cnt, len(rs))
+		}
+		var data []byte
+		for _, r := range rs {
+			data = append(data, r.GetBytes(0)...)
+		}
+		if *meta, err = decompressMeta(flag, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadTaskMetaChunks loads the chunked meta of the tasks.
+func (mgr *TaskManager) loadTaskMetaChunks(ctx context.Context, tasks ...*proto.Task) error {
+	metas := make([]*[]byte, 0, 1)
+	for _, task := range tasks {
+		if isChunkedMeta(task.Meta) {
+			metas = append(metas, &task.Meta)
+		}
+	}
+	if len(metas) == 0 {
+		return nil
+	}
+	return mgr.WithNewSession(func(se sessionctx.Context) error {
+		return loadMetaChunks(ctx, se.GetSQLExecutor(), metas...)
+	})
+}
+
+// loadSubtaskMetaChunks loads the chunked meta of the subtasks.
+func (mgr *TaskManager) loadSubtaskMetaChunks(ctx context.Context, subtasks ...*proto.Subtask) error {
+	metas := make([]*[]byte, 0, 1)
+	for _, subtask := range subtasks {
+		if isChunkedMeta(subtask.Meta) {
+			metas = append(metas, &subtask.Meta)
+		}
+	}
+	if len(metas) == 0 {
+		return nil
+	}
+	return mgr.WithNewSession(func(se sessionctx.Context) error {
+		return loadMetaChunks(ctx, se.GetSQLExecutor(), metas...)
+	})
+}
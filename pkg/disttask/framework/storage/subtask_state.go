@@ -16,17 +16,51 @@ package storage
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
 )
 
+// verifySubtaskStateTransform rejects the illegal subtask state transform.
+func verifySubtaskStateTransform(subtaskID int64, from, to proto.SubtaskState) error {
+	if proto.VerifySubtaskStateTransform(from, to) {
+		return nil
+	}
+	logutil.BgLogger().Warn("invalid subtask state transform", zap.Int64("subtask-id", subtaskID),
+		zap.String("from", from.String()), zap.String("to", to.String()))
+	return errors.Annotatef(ErrInvalidSubtaskStateTransform, "subtask %d from %s to %s", subtaskID, from, to)
+}
+
+// getSubtaskStateForUpdate locks the subtask owned by execID and returns its
+// state, found is false if there is no such subtask.
+func getSubtaskStateForUpdate(ctx context.Context, se sessionctx.Context, execID string, id int64) (state proto.SubtaskState, found bool, err error) {
+	rs, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(),
+		`select state from mysql.tidb_background_subtask where id = %? and exec_id = %? for update`, id, execID)
+	if err != nil || len(rs) == 0 {
+		return "", false, err
+	}
+	return proto.SubtaskState(rs[0].GetString(0)), true, nil
+}
+
 // StartSubtask updates the subtask state to running.
 func (mgr *TaskManager) StartSubtask(ctx context.Context, subtaskID int64, execID string) error {
 	err := mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
-		vars := se.GetSessionVars()
-		_, err := sqlexec.ExecSQL(ctx,
+		state, found, err := getSubtaskStateForUpdate(ctx, se, execID, subtaskID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrSubtaskNotFound
+		}
+		if err = verifySubtaskStateTransform(subtaskID, state, proto.SubtaskStateRunning); err != nil {
+			return err
+		}
+		_, err = sqlexec.ExecSQL(ctx,
 			se.GetSQLExecutor(),
 			`update mysql.tidb_background_subtask
 			 set state = %?, start_time = unix_timestamp(), state_update_time = unix_timestamp()
@@ -34,24 +68,39 @@ func (mgr *TaskManager) StartSubtask(ctx context.Context, subtaskID int64, execI
 			proto.SubtaskStateRunning,
 			subtaskID,
 			execID)
-		if err != nil {
-			return err
-		}
-		if vars.StmtCtx.AffectedRows() == 0 {
-			return ErrSubtaskNotFound
-		}
-		return nil
+		return err
 	})
 	return err
 }
 
 // FinishSubtask updates the subtask meta and mark state to succeed.
 func (mgr *TaskManager) FinishSubtask(ctx context.Context, execID string, id int64, meta []byte) error {
-	_, err := mgr.ExecuteSQLWithNewSession(ctx, `update mysql.tidb_background_subtask
-		set meta = %?, state = %?, state_update_time = unix_timestamp(), end_time = CURRENT_TIMESTAMP()
-		where id = %? and exec_id = %?`,
-		meta, proto.SubtaskStateSucceed, id, execID)
-	return err
+	encoded := encodeMeta(meta)
+	return mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		state, found, err := getSubtaskStateForUpdate(ctx, se, execID, id)
+		if err != nil || !found {
+			return err
+		}
+		if err = verifySubtaskStateTransform(id, state, proto.SubtaskStateSucceed); err != nil {
+			return err
+		}
+		if len(encoded.chunks) > 0 {
+			rs, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `
+				select cast(task_key as signed) from mysql.tidb_background_subtask
+				where id = %? and exec_id = %?`, id, execID)
+			if err != nil || len(rs) == 0 {
+				return err
+			}
+			if err = insertMetaChunks(ctx, se, rs[0].GetInt64(0), encoded); err != nil {
+				return err
+			}
+		}
+		_, err = sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `update mysql.tidb_background_subtask
+			set meta = %?, state = %?, state_update_time = unix_timestamp(), end_time = CURRENT_TIMESTAMP()
+			where id = %? and exec_id = %?`,
+			encoded.data, proto.SubtaskStateSucceed, id, execID)
+		return err
+	})
 }
 
 // FailSubtask update the task's subtask state to failed and set the err.
@@ -59,24 +108,29 @@ func (mgr *TaskManager) FailSubtask(ctx context.Context, execID string, taskID i
 	if err == nil {
 		return nil
 	}
-	_, err1 := mgr.ExecuteSQLWithNewSession(ctx,
-		`update mysql.tidb_background_subtask
-		set state = %?,
-		error = %?,
-		start_time = unix_timestamp(),
-		state_update_time = unix_timestamp(),
-		end_time = CURRENT_TIMESTAMP()
-		where exec_id = %? and
-		task_key = %? and
-		state in (%?, %?)
-		limit 1;`,
-		proto.SubtaskStateFailed,
-		serializeErr(err),
-		execID,
-		taskID,
-		proto.SubtaskStatePending,
-		proto.SubtaskStateRunning)
-	return err1
+	return mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		_, err1 := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(),
+			`update mysql.tidb_background_subtask
+			set state = %?,
+			error = %?,
+			start_time = unix_timestamp(),
+			state_update_time = unix_timestamp(),
+			end_time = CURRENT_TIMESTAMP()
+			where exec_id = %? and
+			task_key = %? and
+			state in (%?, %?)
+			limit 1;`,
+			proto.SubtaskStateFailed,
+			serializeErr(err),
+			execID,
+			taskID,
+			proto.SubtaskStatePending,
+			proto.SubtaskStateRunning)
+		if err1 != nil || se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+			return err1
+		}
+		return insertTaskEvent(ctx, se, taskID, proto.TaskEventError, execID, err.Error())
+	})
 }
 
 // CancelSubtask update the task's subtasks' state to canceled.
@@ -135,13 +189,55 @@ func (mgr *TaskManager) RunningSubtasksBack2Pending(ctx context.Context, subtask
 	return err
 }
 
+// RetrySubtask moves the failed subtask back to pending state on the node
+// execID, and increases its retry count.
+func (mgr *TaskManager) RetrySubtask(ctx context.Context, subtaskID int64, execID string) error {
+	return mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `update mysql.tidb_background_subtask
+			set state = %?, exec_id = %?, error = null, start_time = null, retry_count = retry_count + 1,
+				state_update_time = unix_timestamp()
+			where id = %? and state = %?`,
+			proto.SubtaskStatePending, execID, subtaskID, proto.SubtaskStateFailed)
+		if err != nil || se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+			return err
+		}
+		return insertSubtaskEvent(ctx, se, subtaskID, proto.TaskEventRetry,
+			fmt.Sprintf("retry subtask %d", subtaskID))
+	})
+}
+
+// FailRunningSubtask updates the subtask to failed state with the error if
+// it's still running.
+func (mgr *TaskManager) FailRunningSubtask(ctx context.Context, subtaskID int64, subTaskErr error) error {
+	return mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `update mysql.tidb_background_subtask
+			set state = %?, error = %?, state_update_time = unix_timestamp()
+			where id = %? and state = %?`,
+			proto.SubtaskStateFailed, serializeErr(subTaskErr), subtaskID, proto.SubtaskStateRunning)
+		if err != nil || se.GetSessionVars().StmtCtx.AffectedRows() == 0 || subTaskErr == nil {
+			return err
+		}
+		return insertSubtaskEvent(ctx, se, subtaskID, proto.TaskEventError,
+			fmt.Sprintf("subtask %d: %s", subtaskID, subTaskErr.Error()))
+	})
+}
+
 // UpdateSubtaskStateAndError updates the subtask state.
 func (mgr *TaskManager) UpdateSubtaskStateAndError(
 	ctx context.Context,
 	execID string,
 	id int64, state proto.SubtaskState, subTaskErr error) error {
-	_, err := mgr.ExecuteSQLWithNewSession(ctx, `update mysql.tidb_background_subtask
-		set state = %?, error = %?, state_update_time = unix_timestamp() where id = %? and exec_id = %?`,
-		state, serializeErr(subTaskErr), id, execID)
-	return err
+	return mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		currentState, found, err := getSubtaskStateForUpdate(ctx, se, execID, id)
+		if err != nil || !found {
+			return err
+		}
+		if err = verifySubtaskStateTransform(id, currentState, state); err != nil {
+			return err
+		}
+		_, err = sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `update mysql.tidb_background_subtask
+			set state = %?, error = %?, state_update_time = unix_timestamp() where id = %? and exec_id = %?`,
+			state, serializeErr(subTaskErr), id, execID)
+		return err
+	})
 }
@@ -17,6 +17,7 @@ package storage_test
 import (
 	"context"
 	"fmt"
+	"math"
 	"slices"
 	"sort"
 	"testing"
@@ -350,11 +351,11 @@ func TestSwitchTaskStepInBatch(t *testing.T) {
 func TestGetTopUnfinishedTasks(t *testing.T) {
 	_, gm, ctx := testutil.InitTableTest(t)
 
-	bak := proto.MaxConcurrentTask
+	bak := variable.DistTaskDispatchConcurrency.Load()
 	t.Cleanup(func() {
-		proto.MaxConcurrentTask = bak
+		variable.DistTaskDispatchConcurrency.Store(bak)
 	})
-	proto.MaxConcurrentTask = 4
+	variable.DistTaskDispatchConcurrency.Store(4)
 	require.NoError(t, gm.InitMeta(ctx, ":4000", ""))
 	taskStates := []proto.TaskState{
 		proto.TaskStateSucceed,
@@ -414,6 +415,21 @@ func TestGetTopUnfinishedTasks(t *testing.T) {
 		taskKeys = append(taskKeys, task.Key)
 	}
 	require.Equal(t, []string{"key/6", "key/5", "key/1", "key/2", "key/3", "key/4", "key/8", "key/9"}, taskKeys)
+
+	// pending tasks with dependencies are not counted toward the limit.
+	require.NoError(t, gm.WithNewSession(func(se sessionctx.Context) error {
+		_, err := se.GetSQLExecutor().ExecuteInternal(ctx, `
+				update mysql.tidb_global_task set dependencies = '1'
+				where task_key in ('key/1', 'key/2', 'key/8')`)
+		return err
+	}))
+	tasks, err = gm.GetTopUnfinishedTasks(ctx)
+	require.NoError(t, err)
+	taskKeys = taskKeys[:0]
+	for _, task := range tasks {
+		taskKeys = append(taskKeys, task.Key)
+	}
+	require.Equal(t, []string{"key/6", "key/5", "key/1", "key/2", "key/3", "key/4", "key/8", "key/9", "key/10", "key/11"}, taskKeys)
 }
 
 func TestGetUsedSlotsOnNodes(t *testing.T) {
@@ -449,6 +465,7 @@ func TestGetActiveSubtasks(t *testing.T) {
 		)
 	}
 	require.NoError(t, tm.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, subtasks))
+	require.NoError(t, tm.StartSubtask(ctx, 1, "tidb0"))
 	require.NoError(t, tm.FinishSubtask(ctx, "tidb0", 1, []byte("{}}")))
 	require.NoError(t, tm.StartSubtask(ctx, 2, "tidb1"))
 
@@ -564,7 +581,14 @@ func TestSubTaskTable(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, subtasks, 1)
 	subtaskID := subtasks[0].ID
+	// can't finish a pending subtask.
+	require.ErrorIs(t, sm.FinishSubtask(ctx, "tidb1", subtaskID, []byte{}), storage.ErrInvalidSubtaskStateTransform)
+	require.NoError(t, sm.StartSubtask(ctx, subtaskID, "tidb1"))
 	require.NoError(t, sm.FinishSubtask(ctx, "tidb1", subtaskID, []byte{}))
+	// the succeed subtask can't be moved to other states.
+	require.ErrorIs(t, sm.StartSubtask(ctx, subtaskID, "tidb1"), storage.ErrInvalidSubtaskStateTransform)
+	require.ErrorIs(t, sm.UpdateSubtaskStateAndError(ctx, "tidb1", subtaskID, proto.SubtaskStateFailed, nil),
+		storage.ErrInvalidSubtaskStateTransform)
 
 	subtasks, err = sm.GetAllSubtasksByStepAndState(ctx, 2, proto.StepOne, proto.SubtaskStateSucceed)
 	require.NoError(t, err)
@@ -573,7 +597,7 @@ func TestSubTaskTable(t *testing.T) {
 	rowCount, err := sm.GetSubtaskRowCount(ctx, 2, proto.StepOne)
 	require.NoError(t, err)
 	require.Equal(t, int64(0), rowCount)
-	require.NoError(t, sm.UpdateSubtaskRowCount(ctx, subtaskID, 100))
+	require.NoError(t, sm.UpdateSubtaskSummary(ctx, subtaskID, 100, 2048))
 	rowCount, err = sm.GetSubtaskRowCount(ctx, 2, proto.StepOne)
 	require.NoError(t, err)
 	require.Equal(t, int64(100), rowCount)
@@ -632,6 +656,90 @@ func TestSubTaskTable(t *testing.T) {
 	require.ErrorContains(t, subtaskErrs[0], "test err")
 }
 
+func TestTaskDependencies(t *testing.T) {
+	_, sm, ctx := testutil.InitTableTest(t)
+	require.NoError(t, sm.InitMeta(ctx, ":4000", ""))
+	id1, err := sm.CreateTask(ctx, "key1", "test", 4, "", []byte("test"))
+	require.NoError(t, err)
+	id2, err := sm.CreateTask(ctx, "key2", "test", 4, "", []byte("test"))
+	require.NoError(t, err)
+	_, err = sm.CreateTaskWithDependencies(ctx, "key3", "test", 4, "", []byte("test"), []int64{id1, 100})
+	require.ErrorContains(t, err, "dependency task 100 not found")
+	// dependencies must fit in the task table.
+	dependencies := make([]int64, storage.MaxTaskDependencies+1)
+	_, err = sm.CreateTaskWithDependencies(ctx, "key3", "test", 4, "", []byte("test"), dependencies)
+	require.ErrorIs(t, err, storage.ErrTooManyTaskDependencies)
+	require.ErrorContains(t, err, "task depends on 65 tasks, exceeds the limit 64")
+	for i := 0; i < storage.MaxTaskDependencies; i++ {
+		dependencies[i] = math.MaxInt64 - int64(i)
+	}
+	_, err = sm.CreateTaskWithDependencies(ctx, "key3", "test", 4, "", []byte("test"), dependencies[:storage.MaxTaskDependencies])
+	require.ErrorIs(t, err, storage.ErrTooManyTaskDependencies)
+	require.ErrorContains(t, err, "encoded dependencies take 1279 bytes, exceeds the limit 1024")
+	id3, err := sm.CreateTaskWithDependencies(ctx, "key3", "test", 4, "", []byte("test"), []int64{id1, id2})
+	require.NoError(t, err)
+
+	task, err := sm.GetTaskByID(ctx, id1)
+	require.NoError(t, err)
+	require.Nil(t, task.Dependencies)
+	task, err = sm.GetTaskByID(ctx, id3)
+	require.NoError(t, err)
+	require.Equal(t, []int64{id1, id2}, task.Dependencies)
+	require.Equal(t, "key3", task.Key)
+	require.Equal(t, []byte("test"), task.Meta)
+	taskBase, err := sm.GetTaskBaseByID(ctx, id3)
+	require.NoError(t, err)
+	require.Equal(t, []int64{id1, id2}, taskBase.Dependencies)
+
+	// states of tasks in the history table are returned too.
+	require.NoError(t, sm.FailTask(ctx, id1, proto.TaskStatePending, errors.New("mock err")))
+	task, err = sm.GetTaskByID(ctx, id1)
+	require.NoError(t, err)
+	require.NoError(t, sm.TransferTasks2History(ctx, []*proto.Task{task}))
+	states, err := sm.GetTaskStatesWithHistory(ctx, []int64{id1, id2, 100})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]proto.TaskState{
+		id1: proto.TaskStateFailed,
+		id2: proto.TaskStatePending,
+	}, states)
+}
+
+func TestGetAllTaskProgress(t *testing.T) {
+	_, sm, ctx := testutil.InitTableTest(t)
+	require.NoError(t, sm.InitMeta(ctx, ":4000", ""))
+	id, err := sm.CreateTask(ctx, "key1", "test", 4, "", []byte("test"))
+	require.NoError(t, err)
+	// no subtask yet.
+	progress, err := sm.GetAllTaskProgress(ctx)
+	require.NoError(t, err)
+	require.Empty(t, progress)
+
+	require.NoError(t, sm.SwitchTaskStep(
+		ctx,
+		&proto.Task{TaskBase: proto.TaskBase{ID: id, State: proto.TaskStatePending, Step: proto.StepInit}},
+		proto.TaskStateRunning,
+		proto.StepOne,
+		[]*proto.Subtask{
+			proto.NewSubtask(proto.StepOne, id, proto.TaskTypeExample, "tidb1", 1, []byte("test"), 1),
+			proto.NewSubtask(proto.StepOne, id, proto.TaskTypeExample, "tidb1", 1, []byte("test"), 2),
+		},
+	))
+	subtasks, err := testutil.GetSubtasksByTaskID(ctx, sm, id)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 2)
+	require.NoError(t, sm.UpdateSubtaskSummary(ctx, subtasks[0].ID, 100, 1000))
+	require.NoError(t, sm.UpdateSubtaskSummary(ctx, subtasks[1].ID, 20, 200))
+	require.NoError(t, sm.StartSubtask(ctx, subtasks[0].ID, "tidb1"))
+	require.NoError(t, sm.FinishSubtask(ctx, "tidb1", subtasks[0].ID, []byte{}))
+
+	progress, err = sm.GetAllTaskProgress(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]*proto.TaskProgress{
+		id: {Step: proto.StepOne, SubtaskCnt: 2, FinishedCnt: 1, RowCount: 120, Bytes: 1200},
+	}, progress)
+	require.Equal(t, float64(50), progress[id].Percent())
+}
+
 func TestBothTaskAndSubTaskTable(t *testing.T) {
 	_, sm, ctx := testutil.InitTableTest(t)
 	require.NoError(t, sm.InitMeta(ctx, ":4000", ""))
@@ -794,6 +902,7 @@ func TestSubtaskHistoryTable(t *testing.T) {
 	)
 
 	subTask1 := testutil.CreateSubTask(t, sm, taskID, proto.StepInit, tidb1, []byte(meta), proto.TaskTypeExample, 11)
+	require.NoError(t, sm.StartSubtask(ctx, subTask1, tidb1))
 	require.NoError(t, sm.FinishSubtask(ctx, tidb1, subTask1, []byte(finishedMeta)))
 	subTask2 := testutil.CreateSubTask(t, sm, taskID, proto.StepInit, tidb2, []byte(meta), proto.TaskTypeExample, 11)
 	require.NoError(t, sm.UpdateSubtaskStateAndError(ctx, tidb2, subTask2, proto.SubtaskStateCanceled, nil))
@@ -831,7 +940,7 @@ func TestSubtaskHistoryTable(t *testing.T) {
 	require.NoError(t, sm.UpdateSubtaskStateAndError(ctx, tidb1, subTask4, proto.SubtaskStateFailed, nil))
 	require.NoError(t, testutil.TransferSubTasks2History(ctx, sm, taskID2))
 
-	require.NoError(t, sm.GCSubtasks(ctx))
+	require.NoError(t, sm.GCHistory(ctx))
 
 	historySubTasksCnt, err = testutil.GetSubtasksFromHistory(ctx, sm)
 	require.NoError(t, err)
@@ -887,6 +996,127 @@ func TestTaskHistoryTable(t *testing.T) {
 	num, err = testutil.GetTasksFromHistory(ctx, gm)
 	require.NoError(t, err)
 	require.Equal(t, 3, num)
+
+	// unfinished tasks are listed along with history tasks.
+	_, err = gm.CreateTask(ctx, "4", proto.TaskTypeExample, 1, "", nil)
+	require.NoError(t, err)
+	tasks, err = gm.GetAllTasksWithHistory(ctx)
+	require.NoError(t, err)
+	keys := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		keys = append(keys, task.Key)
+	}
+	require.Equal(t, []string{"4", "3", "2", "1"}, keys)
+
+	// only history tasks finished before the retention period are deleted.
+	require.NoError(t, gm.GCHistory(ctx))
+	num, err = testutil.GetTasksFromHistory(ctx, gm)
+	require.NoError(t, err)
+	require.Equal(t, 3, num)
+	_, err = gm.ExecuteSQLWithNewSession(ctx, `update mysql.tidb_global_task_history
+		set state_update_time = date_sub(now(), interval 30 day) where task_key = '1'`)
+	require.NoError(t, err)
+	require.NoError(t, gm.GCHistory(ctx))
+	num, err = testutil.GetTasksFromHistory(ctx, gm)
+	require.NoError(t, err)
+	require.Equal(t, 2, num)
+
+	// history tasks which unfinished tasks depend on are kept.
+	task, err = gm.GetTaskByKeyWithHistory(ctx, "2")
+	require.NoError(t, err)
+	_, err = gm.CreateTaskWithDependencies(ctx, "5", proto.TaskTypeExample, 1, "", nil, []int64{task.ID})
+	require.NoError(t, err)
+	_, err = gm.ExecuteSQLWithNewSession(ctx, `update mysql.tidb_global_task_history
+		set state_update_time = date_sub(now(), interval 30 day)`)
+	require.NoError(t, err)
+	require.NoError(t, gm.GCHistory(ctx))
+	tasks, err = gm.GetAllTasksWithHistory(ctx)
+	require.NoError(t, err)
+	keys = keys[:0]
+	for _, task := range tasks {
+		keys = append(keys, task.Key)
+	}
+	require.Equal(t, []string{"5", "4", "2"}, keys)
+
+	require.NoError(t, gm.PurgeHistory(ctx))
+	num, err = testutil.GetTasksFromHistory(ctx, gm)
+	require.NoError(t, err)
+	require.Equal(t, 0, num)
+	num, err = testutil.GetSubtasksFromHistory(ctx, gm)
+	require.NoError(t, err)
+	require.Equal(t, 0, num)
+}
+
+func TestLargeMeta(t *testing.T) {
+	store, gm, ctx := testutil.InitTableTest(t)
+	tk := testkit.NewTestKit(t, store)
+	bak := variable.DistTaskMaxMetaSize.Load()
+	t.Cleanup(func() {
+		variable.DistTaskMaxMetaSize.Store(bak)
+		variable.EnableDistTaskMetaEncoding.Store(false)
+	})
+	variable.DistTaskMaxMetaSize.Store(1 << 10)
+	variable.EnableDistTaskMetaEncoding.Store(true)
+	largeMeta := func(seed int) []byte {
+		meta := make([]byte, 0, 3<<10)
+		for i := 0; len(meta) < 3<<10; i++ {
+			meta = fmt.Appendf(meta, "%d-%d,", seed, i*i*7919)
+		}
+		return meta
+	}
+	countChunks := func() int {
+		rs := tk.MustQuery("select count(1) from mysql.tidb_global_task_meta_chunk").Rows()
+		var cnt int
+		_, err := fmt.Sscan(rs[0][0].(string), &cnt)
+		require.NoError(t, err)
+		return cnt
+	}
+
+	require.NoError(t, gm.InitMeta(ctx, ":4000", ""))
+	taskMeta := largeMeta(0)
+	taskID, err := gm.CreateTask(ctx, "key1", proto.TaskTypeExample, 1, "", taskMeta)
+	require.NoError(t, err)
+	require.Positive(t, countChunks())
+	task, err := gm.GetTaskByID(ctx, taskID)
+	require.NoError(t, err)
+	require.Equal(t, taskMeta, task.Meta)
+	tasks, err := gm.GetTasksInStates(ctx, proto.TaskStatePending)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, taskMeta, tasks[0].Meta)
+
+	// switch step with large task and subtask meta.
+	task.Meta = largeMeta(1)
+	subtasks := []*proto.Subtask{
+		proto.NewSubtask(proto.StepOne, taskID, proto.TaskTypeExample, ":4000", 1, largeMeta(2), 1),
+		proto.NewSubtask(proto.StepOne, taskID, proto.TaskTypeExample, ":4000", 1, []byte("small"), 2),
+	}
+	require.NoError(t, gm.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, subtasks))
+	task, err = gm.GetTaskByID(ctx, taskID)
+	require.NoError(t, err)
+	require.Equal(t, largeMeta(1), task.Meta)
+	gotSubtasks, err := gm.GetSubtasksByExecIDAndStepAndStates(ctx, ":4000", taskID, proto.StepOne, proto.SubtaskStatePending)
+	require.NoError(t, err)
+	require.Len(t, gotSubtasks, 2)
+	require.Equal(t, largeMeta(2), gotSubtasks[0].Meta)
+	require.Equal(t, []byte("small"), gotSubtasks[1].Meta)
+
+	// finish subtask with large meta.
+	require.NoError(t, gm.StartSubtask(ctx, gotSubtasks[1].ID, ":4000"))
+	require.NoError(t, gm.FinishSubtask(ctx, ":4000", gotSubtasks[1].ID, largeMeta(3)))
+	gotSubtasks, err = gm.GetSubtasksByExecIDAndStepAndStates(ctx, ":4000", taskID, proto.StepOne, proto.SubtaskStateSucceed)
+	require.NoError(t, err)
+	require.Len(t, gotSubtasks, 1)
+	require.Equal(t, largeMeta(3), gotSubtasks[0].Meta)
+
+	// chunks are kept in history, and removed after purge.
+	require.NoError(t, gm.TransferTasks2History(ctx, []*proto.Task{task}))
+	task, err = gm.GetTaskByIDWithHistory(ctx, taskID)
+	require.NoError(t, err)
+	require.Equal(t, largeMeta(1), task.Meta)
+	require.Positive(t, countChunks())
+	require.NoError(t, gm.PurgeHistory(ctx))
+	require.Zero(t, countChunks())
 }
 
 func TestPauseAndResume(t *testing.T) {
@@ -907,6 +1137,7 @@ func TestPauseAndResume(t *testing.T) {
 	require.Equal(t, int64(3), cntByStates[proto.SubtaskStatePending])
 
 	// 2.1 pause 2 subtasks.
+	require.NoError(t, sm.StartSubtask(ctx, 1, "tidb1"))
 	require.NoError(t, sm.UpdateSubtaskStateAndError(ctx, "tidb1", 1, proto.SubtaskStateSucceed, nil))
 	require.NoError(t, sm.PauseSubtasks(ctx, "tidb1", 1))
 	cntByStates, err = sm.GetSubtaskCntGroupByStates(ctx, 1, proto.StepInit)
@@ -1180,3 +1411,106 @@ func TestGetActiveTaskExecInfo(t *testing.T) {
 	checkBasicTaskEq(t, &tasks[3].TaskBase, taskExecInfos[2].TaskBase)
 	require.Equal(t, 8, taskExecInfos[2].SubtaskConcurrency)
 }
+
+func TestListTasksAndSubtasks(t *testing.T) {
+	_, sm, ctx := testutil.InitTableTest(t)
+	require.NoError(t, sm.InitMeta(ctx, ":4000", ""))
+	ids := make([]int64, 0, 5)
+	for i := 0; i < 5; i++ {
+		tp := proto.TaskTypeExample
+		if i%2 == 1 {
+			tp = proto.ImportInto
+		}
+		id, err := sm.CreateTask(ctx, fmt.Sprintf("key%d", i), tp, 4, "", []byte("test"))
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	require.NoError(t, sm.FailTask(ctx, ids[4], proto.TaskStatePending, errors.New("mock err")))
+
+	getIDs := func(tasks []*proto.Task) []int64 {
+		res := make([]int64, 0, len(tasks))
+		for _, task := range tasks {
+			res = append(res, task.ID)
+		}
+		return res
+	}
+	tasks, err := sm.ListTasks(ctx, storage.TaskFilter{}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, ids, getIDs(tasks))
+	tasks, err = sm.ListTasks(ctx, storage.TaskFilter{}, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, ids[1:3], getIDs(tasks))
+	tasks, err = sm.ListTasks(ctx, storage.TaskFilter{}, 2, 4)
+	require.NoError(t, err)
+	require.Equal(t, ids[4:], getIDs(tasks))
+	tasks, err = sm.ListTasks(ctx, storage.TaskFilter{Types: []proto.TaskType{proto.TaskTypeExample}}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []int64{ids[0], ids[2], ids[4]}, getIDs(tasks))
+	tasks, err = sm.ListTasks(ctx, storage.TaskFilter{
+		States: []proto.TaskState{proto.TaskStatePending},
+		Types:  []proto.TaskType{proto.TaskTypeExample},
+	}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []int64{ids[0], ids[2]}, getIDs(tasks))
+	tasks, err = sm.ListTasks(ctx, storage.TaskFilter{CreatedBefore: time.Now().Add(-time.Hour)}, 0, 0)
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+	tasks, err = sm.ListTasks(ctx, storage.TaskFilter{CreatedAfter: time.Now().Add(-time.Hour)}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, tasks, 5)
+
+	testutil.InsertSubtask(t, sm, ids[0], proto.StepOne, "tidb1", []byte("m"), proto.SubtaskStatePending, proto.TaskTypeExample, 1)
+	testutil.InsertSubtask(t, sm, ids[0], proto.StepOne, "tidb2", []byte("m"), proto.SubtaskStateRunning, proto.TaskTypeExample, 1)
+	testutil.InsertSubtask(t, sm, ids[0], proto.StepTwo, "tidb1", []byte("m"), proto.SubtaskStatePending, proto.TaskTypeExample, 1)
+	testutil.InsertSubtask(t, sm, ids[2], proto.StepOne, "tidb1", []byte("m"), proto.SubtaskStatePending, proto.TaskTypeExample, 1)
+	subtasks, err := sm.ListSubtasks(ctx, storage.SubtaskFilter{}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 4)
+	subtasks, err = sm.ListSubtasks(ctx, storage.SubtaskFilter{TaskID: ids[0]}, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 2)
+	require.Equal(t, "tidb2", subtasks[0].ExecID)
+	require.Equal(t, proto.StepTwo, subtasks[1].Step)
+	subtasks, err = sm.ListSubtasks(ctx, storage.SubtaskFilter{
+		TaskID: ids[0],
+		Step:   proto.StepOne,
+		States: []proto.SubtaskState{proto.SubtaskStatePending},
+	}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 1)
+	require.Equal(t, "tidb1", subtasks[0].ExecID)
+	subtasks, err = sm.ListSubtasks(ctx, storage.SubtaskFilter{ExecID: "tidb1"}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 3)
+}
+
+func TestSubtaskCheckpoint(t *testing.T) {
+	_, sm, ctx := testutil.InitTableTest(t)
+	require.NoError(t, sm.InitMeta(ctx, ":4000", ""))
+	taskID, err := sm.CreateTask(ctx, "key1", proto.TaskTypeExample, 1, "", []byte("test"))
+	require.NoError(t, err)
+	subtaskID := testutil.InsertSubtask(t, sm, taskID, proto.StepOne, ":4000", []byte("m"), proto.SubtaskStatePending, proto.TaskTypeExample, 1)
+
+	subtask, err := sm.GetFirstSubtaskInStates(ctx, ":4000", taskID, proto.StepOne, proto.SubtaskStatePending)
+	require.NoError(t, err)
+	require.Nil(t, subtask.Checkpoint)
+
+	// only the running subtask on the exec node can update the checkpoint.
+	require.ErrorIs(t, sm.UpdateSubtaskCheckpoint(ctx, ":4000", subtaskID, []byte("cp0")), storage.ErrSubtaskNotFound)
+	require.NoError(t, sm.StartSubtask(ctx, subtaskID, ":4000"))
+	require.ErrorIs(t, sm.UpdateSubtaskCheckpoint(ctx, ":4001", subtaskID, []byte("cp0")), storage.ErrSubtaskNotFound)
+	require.NoError(t, sm.UpdateSubtaskCheckpoint(ctx, ":4000", subtaskID, []byte("cp1")))
+	require.NoError(t, sm.UpdateSubtaskCheckpoint(ctx, ":4000", subtaskID, []byte("cp2")))
+	// update with an unchanged checkpoint.
+	require.NoError(t, sm.UpdateSubtaskCheckpoint(ctx, ":4000", subtaskID, []byte("cp2")))
+	subtask, err = sm.GetFirstSubtaskInStates(ctx, ":4000", taskID, proto.StepOne, proto.SubtaskStateRunning)
+	require.NoError(t, err)
+	require.Equal(t, []byte("cp2"), subtask.Checkpoint)
+
+	// checkpoint is kept when the subtask is moved back to pending.
+	require.NoError(t, sm.RunningSubtasksBack2Pending(ctx, []*proto.SubtaskBase{&subtask.SubtaskBase}))
+	subtasks, err := sm.GetSubtasksByExecIDAndStepAndStates(ctx, ":4000", taskID, proto.StepOne, proto.SubtaskStatePending)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 1)
+	require.Equal(t, []byte("cp2"), subtasks[0].Checkpoint)
+}
@@ -17,14 +17,18 @@ package storage
 import (
 	"context"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
 )
 
 // CancelTask cancels task.
 func (mgr *TaskManager) CancelTask(ctx context.Context, taskID int64) error {
-	_, err := mgr.ExecuteSQLWithNewSession(ctx,
+	_, err := mgr.updateTaskWithEvent(ctx, taskID,
+		stateChangeMsg(proto.TaskStateCancelling, proto.TaskStatePending, proto.TaskStateRunning), nil,
 		`update mysql.tidb_global_task
 		 set state = %?,
 			 state_update_time = CURRENT_TIMESTAMP()
@@ -34,6 +38,63 @@ func (mgr *TaskManager) CancelTask(ctx context.Context, taskID int64) error {
 	return err
 }
 
+// CheckTaskPriority checks whether the priority is in the valid range of task
+// priority.
+func CheckTaskPriority(priority int64) error {
+	if priority < proto.MinPriority || priority > proto.MaxPriority {
+		return errors.Errorf("invalid task priority %d, should be in range [%d, %d]",
+			priority, proto.MinPriority, proto.MaxPriority)
+	}
+	return nil
+}
+
+// ModifyTaskPriority modifies the priority of a pending task, the task with
+// smaller priority value is scheduled first.
+// returns false if the task is not found or not in pending state.
+func (mgr *TaskManager) ModifyTaskPriority(ctx context.Context, taskID int64, priority int) (bool, error) {
+	if err := CheckTaskPriority(int64(priority)); err != nil {
+		return false, err
+	}
+	found := false
+	err := mgr.WithNewSession(func(se sessionctx.Context) error {
+		_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(),
+			`update mysql.tidb_global_task
+			 set priority = %?
+			 where id = %? and state = %?`,
+			priority, taskID, proto.TaskStatePending,
+		)
+		if err != nil {
+			return err
+		}
+		found = se.GetSessionVars().StmtCtx.AffectedRows() != 0
+		return nil
+	})
+	return found, err
+}
+
+// ModifyTaskConcurrency modifies the concurrency of a pending task.
+// returns false if the task is not found or not in pending state.
+func (mgr *TaskManager) ModifyTaskConcurrency(ctx context.Context, taskID int64, concurrency int) (bool, error) {
+	if concurrency <= 0 {
+		return false, errors.Errorf("invalid task concurrency %d, should be positive", concurrency)
+	}
+	found := false
+	err := mgr.WithNewSession(func(se sessionctx.Context) error {
+		_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(),
+			`update mysql.tidb_global_task
+			 set concurrency = %?
+			 where id = %? and state = %?`,
+			concurrency, taskID, proto.TaskStatePending,
+		)
+		if err != nil {
+			return err
+		}
+		found = se.GetSessionVars().StmtCtx.AffectedRows() != 0
+		return nil
+	})
+	return found, err
+}
+
 // CancelTaskByKeySession cancels task by key using input session.
 func (*TaskManager) CancelTaskByKeySession(ctx context.Context, se sessionctx.Context, taskKey string) error {
 	_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(),
@@ -42,12 +103,20 @@ func (*TaskManager) CancelTaskByKeySession(ctx context.Context, se sessionctx.Co
 			 state_update_time = CURRENT_TIMESTAMP()
 		 where task_key = %? and state in (%?, %?)`,
 		proto.TaskStateCancelling, taskKey, proto.TaskStatePending, proto.TaskStateRunning)
-	return err
+	if err != nil || se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+		return err
+	}
+	return insertTaskEventByKey(ctx, se, taskKey, proto.TaskEventStateChange,
+		stateChangeMsg(proto.TaskStateCancelling, proto.TaskStatePending, proto.TaskStateRunning))
 }
 
 // FailTask implements the scheduler.TaskManager interface.
 func (mgr *TaskManager) FailTask(ctx context.Context, taskID int64, currentState proto.TaskState, taskErr error) error {
-	_, err := mgr.ExecuteSQLWithNewSession(ctx,
+	if err := verifyTaskStateTransform(taskID, currentState, proto.TaskStateFailed); err != nil {
+		return err
+	}
+	_, err := mgr.updateTaskWithEvent(ctx, taskID,
+		stateChangeMsg(proto.TaskStateFailed, currentState), taskErr,
 		`update mysql.tidb_global_task
 		 set state = %?,
 			 error = %?,
@@ -61,7 +130,11 @@ func (mgr *TaskManager) FailTask(ctx context.Context, taskID int64, currentState
 
 // RevertTask implements the scheduler.TaskManager interface.
 func (mgr *TaskManager) RevertTask(ctx context.Context, taskID int64, taskState proto.TaskState, taskErr error) error {
-	_, err := mgr.ExecuteSQLWithNewSession(ctx, `
+	if err := verifyTaskStateTransform(taskID, taskState, proto.TaskStateReverting); err != nil {
+		return err
+	}
+	_, err := mgr.updateTaskWithEvent(ctx, taskID,
+		stateChangeMsg(proto.TaskStateReverting, taskState), taskErr, `
 		update mysql.tidb_global_task
 		set state = %?,
 			error = %?,
@@ -74,7 +147,8 @@ func (mgr *TaskManager) RevertTask(ctx context.Context, taskID int64, taskState
 
 // RevertedTask implements the scheduler.TaskManager interface.
 func (mgr *TaskManager) RevertedTask(ctx context.Context, taskID int64) error {
-	_, err := mgr.ExecuteSQLWithNewSession(ctx,
+	_, err := mgr.updateTaskWithEvent(ctx, taskID,
+		stateChangeMsg(proto.TaskStateReverted, proto.TaskStateReverting), nil,
 		`update mysql.tidb_global_task
 		 set state = %?,
 			 state_update_time = CURRENT_TIMESTAMP(),
@@ -99,10 +173,12 @@ func (mgr *TaskManager) PauseTask(ctx context.Context, taskKey string) (bool, er
 		if err != nil {
 			return err
 		}
-		if se.GetSessionVars().StmtCtx.AffectedRows() != 0 {
-			found = true
+		if se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+			return nil
 		}
-		return err
+		found = true
+		return insertTaskEventByKey(ctx, se, taskKey, proto.TaskEventStateChange,
+			stateChangeMsg(proto.TaskStatePausing, proto.TaskStatePending, proto.TaskStateRunning))
 	})
 	if err != nil {
 		return found, err
@@ -112,7 +188,8 @@ func (mgr *TaskManager) PauseTask(ctx context.Context, taskKey string) (bool, er
 
 // PausedTask update the task state from pausing to paused.
 func (mgr *TaskManager) PausedTask(ctx context.Context, taskID int64) error {
-	_, err := mgr.ExecuteSQLWithNewSession(ctx,
+	_, err := mgr.updateTaskWithEvent(ctx, taskID,
+		stateChangeMsg(proto.TaskStatePaused, proto.TaskStatePausing), nil,
 		`update mysql.tidb_global_task
 		 set state = %?,
 			 state_update_time = CURRENT_TIMESTAMP()
@@ -136,10 +213,12 @@ func (mgr *TaskManager) ResumeTask(ctx context.Context, taskKey string) (bool, e
 		if err != nil {
 			return err
 		}
-		if se.GetSessionVars().StmtCtx.AffectedRows() != 0 {
-			found = true
+		if se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+			return nil
 		}
-		return err
+		found = true
+		return insertTaskEventByKey(ctx, se, taskKey, proto.TaskEventStateChange,
+			stateChangeMsg(proto.TaskStateResuming, proto.TaskStatePaused))
 	})
 	if err != nil {
 		return found, err
@@ -149,7 +228,8 @@ func (mgr *TaskManager) ResumeTask(ctx context.Context, taskKey string) (bool, e
 
 // ResumedTask implements the scheduler.TaskManager interface.
 func (mgr *TaskManager) ResumedTask(ctx context.Context, taskID int64) error {
-	_, err := mgr.ExecuteSQLWithNewSession(ctx, `
+	_, err := mgr.updateTaskWithEvent(ctx, taskID,
+		stateChangeMsg(proto.TaskStateRunning, proto.TaskStateResuming), nil, `
 		update mysql.tidb_global_task
 		set state = %?,
 			state_update_time = CURRENT_TIMESTAMP()
@@ -161,16 +241,26 @@ func (mgr *TaskManager) ResumedTask(ctx context.Context, taskID int64) error {
 
 // SucceedTask update task state from running to succeed.
 func (mgr *TaskManager) SucceedTask(ctx context.Context, taskID int64) error {
-	return mgr.WithNewSession(func(se sessionctx.Context) error {
-		_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `
-			update mysql.tidb_global_task
-			set state = %?,
-			    step = %?,
-			    state_update_time = CURRENT_TIMESTAMP(),
-			    end_time = CURRENT_TIMESTAMP()
-			where id = %? and state = %?`,
-			proto.TaskStateSucceed, proto.StepDone, taskID, proto.TaskStateRunning,
-		)
-		return err
-	})
+	_, err := mgr.updateTaskWithEvent(ctx, taskID,
+		stateChangeMsg(proto.TaskStateSucceed, proto.TaskStateRunning), nil, `
+		update mysql.tidb_global_task
+		set state = %?,
+		    step = %?,
+		    state_update_time = CURRENT_TIMESTAMP(),
+		    end_time = CURRENT_TIMESTAMP()
+		where id = %? and state = %?`,
+		proto.TaskStateSucceed, proto.StepDone, taskID, proto.TaskStateRunning,
+	)
+	return err
+}
+
+// verifyTaskStateTransform rejects the illegal task state transform, it's a bug
+// of the caller, so we log it instead of persisting it silently.
+func verifyTaskStateTransform(taskID int64, from, to proto.TaskState) error {
+	if proto.VerifyTaskStateTransform(from, to) {
+		return nil
+	}
+	logutil.BgLogger().Error("invalid task state transform", zap.Int64("task-id", taskID),
+		zap.String("from", from.String()), zap.String("to", to.String()))
+	return errors.Annotatef(ErrInvalidTaskStateTransform, "task %d from %s to %s", taskID, from, to)
 }
@@ -16,9 +16,11 @@ package storage_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/disttask/framework/testutil"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/sessionctx"
@@ -126,3 +128,156 @@ func TestTaskState(t *testing.T) {
 	require.NoError(t, err)
 	checkTaskStateStep(t, task, proto.TaskStateSucceed, proto.StepDone)
 }
+
+func TestModifyTaskPriority(t *testing.T) {
+	_, gm, ctx := testutil.InitTableTest(t)
+
+	require.NoError(t, gm.InitMeta(ctx, ":4000", ""))
+	id1, err := gm.CreateTask(ctx, "key1", "test", 4, "", []byte("test"))
+	require.NoError(t, err)
+	id2, err := gm.CreateTask(ctx, "key2", "test", 4, "", []byte("test"))
+	require.NoError(t, err)
+
+	// invalid priority
+	_, err = gm.ModifyTaskPriority(ctx, id2, 0)
+	require.ErrorContains(t, err, "invalid task priority 0")
+	_, err = gm.ModifyTaskPriority(ctx, id2, proto.MaxPriority+1)
+	require.ErrorContains(t, err, "invalid task priority 1025")
+	// task not exist
+	found, err := gm.ModifyTaskPriority(ctx, id2+100, 1)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// pending task is scheduled first after raising its priority.
+	found, err = gm.ModifyTaskPriority(ctx, id2, 1)
+	require.NoError(t, err)
+	require.True(t, found)
+	task, err := gm.GetTaskByID(ctx, id2)
+	require.NoError(t, err)
+	require.Equal(t, 1, task.Priority)
+	tasks, err := gm.GetTopUnfinishedTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	require.Equal(t, []int64{id2, id1}, []int64{tasks[0].ID, tasks[1].ID})
+
+	// only pending task can be modified.
+	require.NoError(t, gm.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, nil))
+	found, err = gm.ModifyTaskPriority(ctx, id2, proto.NormalPriority)
+	require.NoError(t, err)
+	require.False(t, found)
+	task, err = gm.GetTaskByID(ctx, id2)
+	require.NoError(t, err)
+	require.Equal(t, 1, task.Priority)
+}
+
+func TestModifyTaskConcurrency(t *testing.T) {
+	_, gm, ctx := testutil.InitTableTest(t)
+
+	require.NoError(t, gm.InitMeta(ctx, ":4000", ""))
+	id, err := gm.CreateTask(ctx, "key1", "test", 4, "", []byte("test"))
+	require.NoError(t, err)
+
+	_, err = gm.ModifyTaskConcurrency(ctx, id, 0)
+	require.ErrorContains(t, err, "invalid task concurrency 0")
+	found, err := gm.ModifyTaskConcurrency(ctx, id+100, 2)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	found, err = gm.ModifyTaskConcurrency(ctx, id, 2)
+	require.NoError(t, err)
+	require.True(t, found)
+	task, err := gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 2, task.Concurrency)
+
+	// only pending task can be modified.
+	require.NoError(t, gm.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, nil))
+	found, err = gm.ModifyTaskConcurrency(ctx, id, 8)
+	require.NoError(t, err)
+	require.False(t, found)
+	task, err = gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 2, task.Concurrency)
+}
+
+func TestInvalidTaskStateTransform(t *testing.T) {
+	_, gm, ctx := testutil.InitTableTest(t)
+
+	require.NoError(t, gm.InitMeta(ctx, ":4000", ""))
+	id, err := gm.CreateTask(ctx, "key1", "test", 4, "", []byte("test"))
+	require.NoError(t, err)
+	task, err := gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+
+	// the task is cancelled by user, but the scheduler still holds the old
+	// state, the task must not be moved back to running.
+	require.NoError(t, gm.CancelTask(ctx, id))
+	task.State = proto.TaskStateCancelling
+	err = gm.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, nil)
+	require.ErrorIs(t, err, storage.ErrInvalidTaskStateTransform)
+	err = gm.SwitchTaskStepInBatch(ctx, task, proto.TaskStateRunning, proto.StepOne, nil)
+	require.ErrorIs(t, err, storage.ErrInvalidTaskStateTransform)
+	require.NoError(t, gm.RevertTask(ctx, id, proto.TaskStateCancelling, nil))
+	require.NoError(t, gm.RevertedTask(ctx, id))
+	err = gm.RevertTask(ctx, id, proto.TaskStateReverted, nil)
+	require.ErrorIs(t, err, storage.ErrInvalidTaskStateTransform)
+	err = gm.FailTask(ctx, id, proto.TaskStateReverted, errors.New("mock err"))
+	require.ErrorIs(t, err, storage.ErrInvalidTaskStateTransform)
+	task, err = gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	checkTaskStateStep(t, task, proto.TaskStateReverted, proto.StepInit)
+}
+
+func TestTaskEvents(t *testing.T) {
+	_, gm, ctx := testutil.InitTableTest(t)
+
+	require.NoError(t, gm.InitMeta(ctx, ":4000", ""))
+	id, err := gm.CreateTask(ctx, "key1", proto.TaskTypeExample, 4, "", []byte("test"))
+	require.NoError(t, err)
+	task, err := gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	subtasks := []*proto.Subtask{
+		proto.NewSubtask(proto.StepOne, id, proto.TaskTypeExample, ":4000", 4, []byte("1"), 1),
+		proto.NewSubtask(proto.StepOne, id, proto.TaskTypeExample, ":4000", 4, []byte("2"), 2),
+	}
+	require.NoError(t, gm.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, subtasks))
+	// the stale switch is skipped, no event is appended.
+	require.NoError(t, gm.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, subtasks))
+	require.NoError(t, gm.FailSubtask(ctx, ":4000", id, errors.New("mock subtask err")))
+	failed, err := gm.GetAllSubtasksByStepAndState(ctx, id, proto.StepOne, proto.SubtaskStateFailed)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	require.NoError(t, gm.RetrySubtask(ctx, failed[0].ID, ":4000"))
+	require.NoError(t, gm.RevertTask(ctx, id, proto.TaskStateRunning, errors.New("mock task err")))
+	require.NoError(t, gm.RevertedTask(ctx, id))
+
+	events, err := gm.GetTaskEvents(ctx, id)
+	require.NoError(t, err)
+	type event struct {
+		tp     proto.TaskEventType
+		execID string
+		msg    string
+	}
+	actual := make([]event, 0, len(events))
+	for _, e := range events {
+		require.Equal(t, id, e.TaskID)
+		require.False(t, e.CreateTime.IsZero())
+		actual = append(actual, event{tp: e.Type, execID: e.ExecID, msg: e.Message})
+	}
+	require.Equal(t, []event{
+		{proto.TaskEventStateChange, "", "pending -> running, step init -> one"},
+		{proto.TaskEventAssign, "", "step one: 2 subtasks on :4000"},
+		{proto.TaskEventError, ":4000", "mock subtask err"},
+		{proto.TaskEventRetry, ":4000", fmt.Sprintf("retry subtask %d", failed[0].ID)},
+		{proto.TaskEventStateChange, "", "running -> reverting"},
+		{proto.TaskEventError, "", "mock task err"},
+		{proto.TaskEventStateChange, "", "reverting -> reverted"},
+	}, actual)
+
+	// events are purged with the history.
+	require.NoError(t, gm.TransferTasks2History(ctx, []*proto.Task{task}))
+	require.NoError(t, gm.PurgeHistory(ctx))
+	events, err = gm.GetTaskEvents(ctx, id)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
@@ -16,6 +16,7 @@ package storage
 
 import (
 	"context"
+	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -34,21 +35,26 @@ import (
 )
 
 const (
-	defaultSubtaskKeepDays = 14
-
-	basicTaskColumns = `t.id, t.task_key, t.type, t.state, t.step, t.priority, t.concurrency, t.create_time, t.target_scope`
+	basicTaskColumns = `t.id, t.task_key, t.type, t.state, t.step, t.priority, t.concurrency, t.create_time, t.target_scope, t.dependencies`
 	// TaskColumns is the columns for task.
 	// TODO: dispatcher_id will update to scheduler_id later
 	TaskColumns = basicTaskColumns + `, t.start_time, t.state_update_time, t.meta, t.dispatcher_id, t.error`
 	// InsertTaskColumns is the columns used in insert task.
-	InsertTaskColumns   = `task_key, type, state, priority, concurrency, step, meta, create_time, target_scope`
+	InsertTaskColumns   = `task_key, type, state, priority, concurrency, step, meta, create_time, target_scope, dependencies`
 	basicSubtaskColumns = `id, step, task_key, type, exec_id, state, concurrency, create_time, ordinal, start_time`
 	// SubtaskColumns is the columns for subtask.
-	SubtaskColumns = basicSubtaskColumns + `, state_update_time, meta, summary`
+	SubtaskColumns = basicSubtaskColumns + `, state_update_time, meta, summary, retry_count, checkpoint`
 	// InsertSubtaskColumns is the columns used in insert subtask.
 	InsertSubtaskColumns = `step, task_key, exec_id, meta, state, type, concurrency, ordinal, create_time, checkpoint, summary`
 )
 
+const (
+	// MaxTaskDependencies is the max number of tasks a task can depend on.
+	MaxTaskDependencies = 64
+	// maxDependenciesLen is the length of the dependencies column of the task table.
+	maxDependenciesLen = 1024
+)
+
 var (
 	maxSubtaskBatchSize = 16 * units.MiB
 
@@ -65,9 +71,21 @@ var (
 	// i.e. SubmitTask in handle may submit a task twice.
 	ErrTaskAlreadyExists = errors.New("task already exists")
 
+	// ErrTooManyTaskDependencies is the error when the dependencies of a task
+	// can't be stored in the task table, see CheckTaskDependencies.
+	ErrTooManyTaskDependencies = errors.New("too many task dependencies")
+
 	// ErrSubtaskNotFound is the error when can't find subtask by subtask_id and execId,
 	// i.e. scheduler change the subtask's execId when subtask need to balance to other nodes.
 	ErrSubtaskNotFound = errors.New("subtask not found")
+
+	// ErrInvalidTaskStateTransform is the error when we try to transform the
+	// task state illegally, see proto.VerifyTaskStateTransform.
+	ErrInvalidTaskStateTransform = errors.New("invalid task state transform")
+
+	// ErrInvalidSubtaskStateTransform is the error when we try to transform the
+	// subtask state illegally, see proto.VerifySubtaskStateTransform.
+	ErrInvalidSubtaskStateTransform = errors.New("invalid subtask state transform")
 )
 
 // TaskExecInfo is the execution information of a task, on some exec node.
@@ -81,6 +99,12 @@ type TaskExecInfo struct {
 	SubtaskConcurrency int
 }
 
+// SubtaskWithError is a subtask along with the error it failed or was canceled with.
+type SubtaskWithError struct {
+	*proto.Subtask
+	Error error
+}
+
 // SessionExecutor defines the interface for executing SQLs in a session.
 type SessionExecutor interface {
 	// WithNewSession executes the function with a new session.
@@ -193,14 +217,42 @@ func (mgr *TaskManager) ExecuteSQLWithNewSession(ctx context.Context, sql string
 
 // CreateTask adds a new task to task table.
 func (mgr *TaskManager) CreateTask(ctx context.Context, key string, tp proto.TaskType, concurrency int, targetScope string, meta []byte) (taskID int64, err error) {
-	err = mgr.WithNewSession(func(se sessionctx.Context) error {
+	return mgr.CreateTaskWithDependencies(ctx, key, tp, concurrency, targetScope, meta, nil)
+}
+
+// CreateTaskWithDependencies adds a new task which will only be scheduled after
+// all the tasks in dependencies succeed.
+func (mgr *TaskManager) CreateTaskWithDependencies(
+	ctx context.Context,
+	key string,
+	tp proto.TaskType,
+	concurrency int,
+	targetScope string,
+	meta []byte,
+	dependencies []int64,
+) (taskID int64, err error) {
+	err = mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
 		var err2 error
-		taskID, err2 = mgr.CreateTaskWithSession(ctx, se, key, tp, concurrency, targetScope, meta)
+		taskID, err2 = mgr.CreateTaskWithSession(ctx, se, key, tp, concurrency, targetScope, meta, dependencies)
 		return err2
 	})
 	return
 }
 
+// CheckTaskDependencies checks whether the dependencies of a task can be stored
+// in the task table.
+func CheckTaskDependencies(dependencies []int64) error {
+	if len(dependencies) > MaxTaskDependencies {
+		return errors.Annotatef(ErrTooManyTaskDependencies,
+			"task depends on %d tasks, exceeds the limit %d", len(dependencies), MaxTaskDependencies)
+	}
+	if l := len(dependencies2Str(dependencies)); l > maxDependenciesLen {
+		return errors.Annotatef(ErrTooManyTaskDependencies,
+			"encoded dependencies take %d bytes, exceeds the limit %d", l, maxDependenciesLen)
+	}
+	return nil
+}
+
 // CreateTaskWithSession adds a new task to task table with session.
 func (mgr *TaskManager) CreateTaskWithSession(
 	ctx context.Context,
@@ -210,7 +262,11 @@ func (mgr *TaskManager) CreateTaskWithSession(
 	concurrency int,
 	targetScope string,
 	meta []byte,
+	dependencies []int64,
 ) (taskID int64, err error) {
+	if err = CheckTaskDependencies(dependencies); err != nil {
+		return 0, err
+	}
 	cpuCount, err := mgr.getCPUCountOfNode(ctx, se)
 	if err != nil {
 		return 0, err
@@ -218,10 +274,25 @@ func (mgr *TaskManager) CreateTaskWithSession(
 	if concurrency > cpuCount {
 		return 0, errors.Errorf("task concurrency(%d) larger than cpu count(%d) of managed node", concurrency, cpuCount)
 	}
+	// dependencies must exist before the task is created, so there is no cycle
+	// in the dependency graph.
+	if len(dependencies) > 0 {
+		states, err2 := getTaskStatesWithHistory(ctx, se.GetSQLExecutor(), dependencies)
+		if err2 != nil {
+			return 0, err2
+		}
+		for _, id := range dependencies {
+			if _, ok := states[id]; !ok {
+				return 0, errors.Errorf("dependency task %d not found", id)
+			}
+		}
+	}
+	encoded := encodeMeta(meta)
 	_, err = sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `
 			insert into mysql.tidb_global_task(`+InsertTaskColumns+`)
-			values (%?, %?, %?, %?, %?, %?, %?, CURRENT_TIMESTAMP(), %?)`,
-		key, tp, proto.TaskStatePending, proto.NormalPriority, concurrency, proto.StepInit, meta, targetScope)
+			values (%?, %?, %?, %?, %?, %?, %?, CURRENT_TIMESTAMP(), %?, %?)`,
+		key, tp, proto.TaskStatePending, proto.NormalPriority, concurrency, proto.StepInit, encoded.data, targetScope,
+		dependencies2Str(dependencies))
 	if err != nil {
 		return 0, err
 	}
@@ -232,16 +303,22 @@ func (mgr *TaskManager) CreateTaskWithSession(
 	}
 
 	taskID = int64(rs[0].GetUint64(0))
+	if err = insertMetaChunks(ctx, se, taskID, encoded); err != nil {
+		return 0, err
+	}
 	failpoint.Inject("testSetLastTaskID", func() { TestLastTaskID.Store(taskID) })
 
 	return taskID, nil
 }
 
 // GetTopUnfinishedTasks implements the scheduler.TaskManager interface.
+// pending tasks which depend on other tasks might be blocked, they are not
+// counted toward the limit, and are all returned for the scheduler manager to
+// check their dependencies.
 func (mgr *TaskManager) GetTopUnfinishedTasks(ctx context.Context) ([]*proto.TaskBase, error) {
 	rs, err := mgr.ExecuteSQLWithNewSession(ctx,
 		`select `+basicTaskColumns+` from mysql.tidb_global_task t
-		where state in (%?, %?, %?, %?, %?, %?)
+		where state in (%?, %?, %?, %?, %?, %?) and (state != %? or dependencies = '')
 		order by priority asc, create_time asc, id asc
 		limit %?`,
 		proto.TaskStatePending,
@@ -250,16 +327,29 @@ func (mgr *TaskManager) GetTopUnfinishedTasks(ctx context.Context) ([]*proto.Tas
 		proto.TaskStateCancelling,
 		proto.TaskStatePausing,
 		proto.TaskStateResuming,
-		proto.MaxConcurrentTask*2,
+		proto.TaskStatePending,
+		variable.DistTaskDispatchConcurrency.Load()*2,
 	)
 	if err != nil {
 		return nil, err
 	}
+	dependentRS, err := mgr.ExecuteSQLWithNewSession(ctx,
+		`select `+basicTaskColumns+` from mysql.tidb_global_task t
+		where state = %? and dependencies != ''`, proto.TaskStatePending)
+	if err != nil {
+		return nil, err
+	}
 
-	tasks := make([]*proto.TaskBase, 0, len(rs))
+	tasks := make([]*proto.TaskBase, 0, len(rs)+len(dependentRS))
 	for _, r := range rs {
 		tasks = append(tasks, row2TaskBasic(r))
 	}
+	for _, r := range dependentRS {
+		tasks = append(tasks, row2TaskBasic(r))
+	}
+	slices.SortFunc(tasks, func(a, b *proto.TaskBase) int {
+		return a.Compare(b)
+	})
 	return tasks, nil
 }
 
@@ -282,12 +372,46 @@ func (mgr *TaskManager) GetTaskExecInfoByExecID(ctx context.Context, execID stri
 	for _, r := range rs {
 		res = append(res, &TaskExecInfo{
 			TaskBase:           row2TaskBasic(r),
-			SubtaskConcurrency: int(r.GetInt64(9)),
+			SubtaskConcurrency: int(r.GetInt64(10)),
 		})
 	}
 	return res, nil
 }
 
+// GetAllTasks gets all the tasks which are not moved to history table, order by id.
+func (mgr *TaskManager) GetAllTasks(ctx context.Context) ([]*proto.Task, error) {
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx, "select "+TaskColumns+" from mysql.tidb_global_task t order by id")
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*proto.Task, 0, len(rs))
+	for _, r := range rs {
+		tasks = append(tasks, Row2Task(r))
+	}
+	if err = mgr.loadTaskMetaChunks(ctx, tasks...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetAllTasksWithHistory gets all tasks from both tidb_global_task and
+// tidb_global_task_history, order by id desc.
+func (mgr *TaskManager) GetAllTasksWithHistory(ctx context.Context) ([]*proto.Task, error) {
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx, "select "+TaskColumns+" from mysql.tidb_global_task t "+
+		"union all select "+TaskColumns+" from mysql.tidb_global_task_history t order by id desc")
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*proto.Task, 0, len(rs))
+	for _, r := range rs {
+		tasks = append(tasks, Row2Task(r))
+	}
+	if err = mgr.loadTaskMetaChunks(ctx, tasks...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
 // GetTasksInStates gets the tasks in the states(order by priority asc, create_time acs, id asc).
 func (mgr *TaskManager) GetTasksInStates(ctx context.Context, states ...any) (task []*proto.Task, err error) {
 	if len(states) == 0 {
@@ -305,6 +429,9 @@ func (mgr *TaskManager) GetTasksInStates(ctx context.Context, states ...any) (ta
 	for _, r := range rs {
 		task = append(task, Row2Task(r))
 	}
+	if err = mgr.loadTaskMetaChunks(ctx, task...); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
@@ -318,7 +445,11 @@ func (mgr *TaskManager) GetTaskByID(ctx context.Context, taskID int64) (task *pr
 		return nil, ErrTaskNotFound
 	}
 
-	return Row2Task(rs[0]), nil
+	task = Row2Task(rs[0])
+	if err = mgr.loadTaskMetaChunks(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
 // GetTaskBaseByID implements the TaskManager.GetTaskBaseByID interface.
@@ -345,7 +476,46 @@ func (mgr *TaskManager) GetTaskByIDWithHistory(ctx context.Context, taskID int64
 		return nil, ErrTaskNotFound
 	}
 
-	return Row2Task(rs[0]), nil
+	task = Row2Task(rs[0])
+	if err = mgr.loadTaskMetaChunks(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetTaskStatesWithHistory gets the states of the tasks from both tidb_global_task
+// and tidb_global_task_history, tasks which are not found are not included.
+func (mgr *TaskManager) GetTaskStatesWithHistory(ctx context.Context, taskIDs []int64) (map[int64]proto.TaskState, error) {
+	var states map[int64]proto.TaskState
+	err := mgr.WithNewSession(func(se sessionctx.Context) error {
+		var err2 error
+		states, err2 = getTaskStatesWithHistory(ctx, se.GetSQLExecutor(), taskIDs)
+		return err2
+	})
+	return states, err
+}
+
+func getTaskStatesWithHistory(ctx context.Context, exec sqlexec.SQLExecutor, taskIDs []int64) (map[int64]proto.TaskState, error) {
+	states := make(map[int64]proto.TaskState, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return states, nil
+	}
+	placeholders := strings.Repeat("%?,", len(taskIDs)-1) + "%?"
+	args := make([]any, 0, 2*len(taskIDs))
+	for _, id := range taskIDs {
+		args = append(args, id)
+	}
+	// the ids are used in both the task and the history table.
+	args = append(args, args...)
+	rs, err := sqlexec.ExecSQL(ctx, exec, `select id, state from mysql.tidb_global_task where id in (`+placeholders+`)
+		union select id, state from mysql.tidb_global_task_history where id in (`+placeholders+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rs {
+		states[r.GetInt64(0)] = proto.TaskState(r.GetString(1))
+	}
+	return states, nil
 }
 
 // GetTaskBaseByIDWithHistory gets the task by the task ID from both tidb_global_task and tidb_global_task_history.
@@ -372,7 +542,25 @@ func (mgr *TaskManager) GetTaskByKey(ctx context.Context, key string) (task *pro
 		return nil, ErrTaskNotFound
 	}
 
-	return Row2Task(rs[0]), nil
+	task = Row2Task(rs[0])
+	if err = mgr.loadTaskMetaChunks(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// UpdateTaskMeta updates the meta of the task, such as saving the result
+// into the meta when the task is done.
+func (mgr *TaskManager) UpdateTaskMeta(ctx context.Context, taskID int64, meta []byte) error {
+	return mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		encoded := encodeMeta(meta)
+		if err := insertMetaChunks(ctx, se, taskID, encoded); err != nil {
+			return err
+		}
+		_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(),
+			"update mysql.tidb_global_task set meta = %? where id = %?", encoded.data, taskID)
+		return err
+	})
 }
 
 // GetTaskByKeyWithHistory gets the task from history table by the task key.
@@ -386,7 +574,11 @@ func (mgr *TaskManager) GetTaskByKeyWithHistory(ctx context.Context, key string)
 		return nil, ErrTaskNotFound
 	}
 
-	return Row2Task(rs[0]), nil
+	task = Row2Task(rs[0])
+	if err = mgr.loadTaskMetaChunks(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
 // GetTaskBaseByKeyWithHistory gets the task base from history table by the task key.
@@ -420,6 +612,9 @@ func (mgr *TaskManager) GetSubtasksByExecIDAndStepAndStates(ctx context.Context,
 	for i, row := range rs {
 		subtasks[i] = Row2SubTask(row)
 	}
+	if err = mgr.loadSubtaskMetaChunks(ctx, subtasks...); err != nil {
+		return nil, err
+	}
 	return subtasks, nil
 }
 
@@ -439,7 +634,11 @@ func (mgr *TaskManager) GetFirstSubtaskInStates(ctx context.Context, tidbID stri
 	if len(rs) == 0 {
 		return nil, nil
 	}
-	return Row2SubTask(rs[0]), nil
+	subtask := Row2SubTask(rs[0])
+	if err = mgr.loadSubtaskMetaChunks(ctx, subtask); err != nil {
+		return nil, err
+	}
+	return subtask, nil
 }
 
 // GetActiveSubtasks implements TaskManager.GetActiveSubtasks.
@@ -473,6 +672,9 @@ func (mgr *TaskManager) GetAllSubtasksByStepAndState(ctx context.Context, taskID
 	for _, r := range rs {
 		subtasks = append(subtasks, Row2SubTask(r))
 	}
+	if err = mgr.loadSubtaskMetaChunks(ctx, subtasks...); err != nil {
+		return nil, err
+	}
 	return subtasks, nil
 }
 
@@ -491,15 +693,63 @@ func (mgr *TaskManager) GetSubtaskRowCount(ctx context.Context, taskID int64, st
 	return rs[0].GetInt64(0), nil
 }
 
-// UpdateSubtaskRowCount updates the subtask row count.
-func (mgr *TaskManager) UpdateSubtaskRowCount(ctx context.Context, subtaskID int64, rowCount int64) error {
+// UpdateSubtaskSummary updates the row count and processed bytes in the subtask summary.
+func (mgr *TaskManager) UpdateSubtaskSummary(ctx context.Context, subtaskID int64, rowCount, bytes int64) error {
 	_, err := mgr.ExecuteSQLWithNewSession(ctx,
 		`update mysql.tidb_background_subtask
-		set summary = json_set(summary, '$.row_count', %?) where id = %?`,
-		rowCount, subtaskID)
+		set summary = json_set(summary, '$.row_count', %?, '$.bytes', %?) where id = %?`,
+		rowCount, bytes, subtaskID)
 	return err
 }
 
+// UpdateSubtaskCheckpoint updates the checkpoint of the subtask if it's
+// running on execID, otherwise ErrSubtaskNotFound is returned.
+func (mgr *TaskManager) UpdateSubtaskCheckpoint(ctx context.Context, execID string, subtaskID int64, checkpoint []byte) error {
+	return mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		// the affected rows is 0 if the checkpoint is unchanged, so we check
+		// whether the subtask exists first.
+		state, found, err := getSubtaskStateForUpdate(ctx, se, execID, subtaskID)
+		if err != nil {
+			return err
+		}
+		if !found || state != proto.SubtaskStateRunning {
+			return ErrSubtaskNotFound
+		}
+		_, err = sqlexec.ExecSQL(ctx, se.GetSQLExecutor(),
+			`update mysql.tidb_background_subtask set checkpoint = %?
+			where id = %? and exec_id = %? and state = %?`,
+			checkpoint, subtaskID, execID, proto.SubtaskStateRunning)
+		return err
+	})
+}
+
+// GetAllTaskProgress gets the progress of the current step of all tasks, tasks
+// which have no subtask on current step are not included.
+func (mgr *TaskManager) GetAllTaskProgress(ctx context.Context) (map[int64]*proto.TaskProgress, error) {
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx, `select
+		t.id, t.step, count(*),
+		cast(sum(s.state = %?) as signed),
+		cast(coalesce(sum(json_extract(s.summary, '$.row_count')), 0) as signed),
+		cast(coalesce(sum(json_extract(s.summary, '$.bytes')), 0) as signed)
+		from mysql.tidb_global_task t join mysql.tidb_background_subtask s
+		on s.task_key = t.id and s.step = t.step
+		group by t.id, t.step`, proto.SubtaskStateSucceed)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]*proto.TaskProgress, len(rs))
+	for _, r := range rs {
+		res[r.GetInt64(0)] = &proto.TaskProgress{
+			Step:        proto.Step(r.GetInt64(1)),
+			SubtaskCnt:  r.GetInt64(2),
+			FinishedCnt: r.GetInt64(3),
+			RowCount:    r.GetInt64(4),
+			Bytes:       r.GetInt64(5),
+		}
+	}
+	return res, nil
+}
+
 // GetSubtaskCntGroupByStates gets the subtask count by states.
 func (mgr *TaskManager) GetSubtaskCntGroupByStates(ctx context.Context, taskID int64, step proto.Step) (map[proto.SubtaskState]int64, error) {
 	rs, err := mgr.ExecuteSQLWithNewSession(ctx, `
@@ -597,6 +847,9 @@ func (mgr *TaskManager) SwitchTaskStep(
 	nextStep proto.Step,
 	subtasks []*proto.Subtask,
 ) error {
+	if err := verifyTaskStateTransform(task.ID, task.State, nextState); err != nil {
+		return err
+	}
 	return mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
 		vars := se.GetSessionVars()
 		if vars.MemQuotaQuery < variable.DefTiDBMemQuotaQuery {
@@ -620,7 +873,10 @@ func (mgr *TaskManager) SwitchTaskStep(
 			// Or when there is no such task.
 			return nil
 		}
-		return mgr.insertSubtasks(ctx, se, subtasks)
+		if err = mgr.insertSubtasks(ctx, se, subtasks); err != nil {
+			return err
+		}
+		return insertSwitchStepEvents(ctx, se, task, nextState, nextStep, subtasks)
 	})
 }
 
@@ -632,6 +888,10 @@ func (*TaskManager) updateTaskStateStep(ctx context.Context, se sessionctx.Conte
 	}
 	// TODO: during generating subtask, task meta might change, maybe move meta
 	// update to another place.
+	encoded := encodeMeta(task.Meta)
+	if err := insertMetaChunks(ctx, se, task.ID, encoded); err != nil {
+		return err
+	}
 	_, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `
 		update mysql.tidb_global_task
 		set state = %?,
@@ -639,7 +899,7 @@ func (*TaskManager) updateTaskStateStep(ctx context.Context, se sessionctx.Conte
 			state_update_time = CURRENT_TIMESTAMP(),
 			meta = %?
 		where id = %? and state = %? and step = %?`,
-		nextState, nextStep, task.Meta, task.ID, task.State, task.Step)
+		nextState, nextStep, encoded.data, task.ID, task.State, task.Step)
 	return err
 }
 
@@ -661,8 +921,12 @@ func (*TaskManager) insertSubtasks(ctx context.Context, se sessionctx.Context, s
 	)
 	sb.WriteString(`insert into mysql.tidb_background_subtask(` + InsertSubtaskColumns + `) values `)
 	for _, subtask := range subtasks {
+		encoded := encodeMeta(subtask.Meta)
+		if err := insertMetaChunks(ctx, se, subtask.TaskID, encoded); err != nil {
+			return err
+		}
 		markerList = append(markerList, "(%?, %?, %?, %?, %?, %?, %?, %?, CURRENT_TIMESTAMP(), '{}', '{}')")
-		args = append(args, subtask.Step, subtask.TaskID, subtask.ExecID, subtask.Meta,
+		args = append(args, subtask.Step, subtask.TaskID, subtask.ExecID, encoded.data,
 			proto.SubtaskStatePending, proto.Type2Int(subtask.Type), subtask.Concurrency, subtask.Ordinal)
 	}
 	sb.WriteString(strings.Join(markerList, ","))
@@ -678,6 +942,9 @@ func (mgr *TaskManager) SwitchTaskStepInBatch(
 	nextStep proto.Step,
 	subtasks []*proto.Subtask,
 ) error {
+	if err := verifyTaskStateTransform(task.ID, task.State, nextState); err != nil {
+		return err
+	}
 	return mgr.WithNewSession(func(se sessionctx.Context) error {
 		// some subtasks may be inserted by other schedulers, we can skip them.
 		rs, err := sqlexec.ExecSQL(ctx, se.GetSQLExecutor(), `
@@ -697,7 +964,13 @@ func (mgr *TaskManager) SwitchTaskStepInBatch(
 				return err
 			}
 		}
-		return mgr.updateTaskStateStep(ctx, se, task, nextState, nextStep)
+		if err = mgr.updateTaskStateStep(ctx, se, task, nextState, nextStep); err != nil {
+			return err
+		}
+		if se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+			return nil
+		}
+		return insertSwitchStepEvents(ctx, se, task, nextState, nextStep, subtasks)
 	})
 }
 
@@ -778,6 +1051,9 @@ func (mgr *TaskManager) GetSubtasksWithHistory(ctx context.Context, taskID int64
 	for _, r := range rs {
 		subtasks = append(subtasks, Row2SubTask(r))
 	}
+	if err = mgr.loadSubtaskMetaChunks(ctx, subtasks...); err != nil {
+		return nil, err
+	}
 	return subtasks, nil
 }
 
@@ -797,6 +1073,27 @@ func (mgr *TaskManager) GetAllSubtasks(ctx context.Context) ([]*proto.SubtaskBas
 	return subtasks, nil
 }
 
+// GetAllSubtasksWithError gets all the subtasks with their errors, order by task and id.
+func (mgr *TaskManager) GetAllSubtasksWithError(ctx context.Context) ([]*SubtaskWithError, error) {
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx, `select `+SubtaskColumns+`, error from mysql.tidb_background_subtask
+		order by cast(task_key as signed), id`)
+	if err != nil {
+		return nil, err
+	}
+	subtasks := make([]*SubtaskWithError, 0, len(rs))
+	for _, r := range rs {
+		subtask := &SubtaskWithError{Subtask: Row2SubTask(r)}
+		if !r.IsNull(15) {
+			subtask.Error = bytes2Error(r.GetBytes(15))
+		}
+		if err = mgr.loadSubtaskMetaChunks(ctx, subtask.Subtask); err != nil {
+			return nil, err
+		}
+		subtasks = append(subtasks, subtask)
+	}
+	return subtasks, nil
+}
+
 // AdjustTaskOverflowConcurrency change the task concurrency to a max value supported by current cluster.
 // This is a workaround for an upgrade bug: in v7.5.x, the task concurrency is hard-coded to 16, resulting in
 // a stuck issue if the new version TiDB has less than 16 CPU count.
@@ -15,11 +15,14 @@
 package storage
 
 import (
+	"bytes"
+	"crypto/rand"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/testkit/testsetup"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
@@ -62,3 +65,74 @@ func TestSplitSubtasks(t *testing.T) {
 	require.Equal(t, subtasks[6:9], splitSubtasks[2])
 	require.Equal(t, subtasks[9:], splitSubtasks[3])
 }
+
+func TestEncodeMeta(t *testing.T) {
+	bak := variable.DistTaskMaxMetaSize.Load()
+	t.Cleanup(func() {
+		variable.DistTaskMaxMetaSize.Store(bak)
+		variable.EnableDistTaskMetaEncoding.Store(false)
+	})
+	checkDecoded := func(meta []byte, encoded *encodedMeta) {
+		decoded, err := decodeMeta(encoded.data)
+		require.NoError(t, err)
+		require.Equal(t, meta, decoded)
+	}
+
+	// meta is stored as is before the encoding is enabled.
+	meta := bytes.Repeat([]byte("abcdefgh"), 8<<10)
+	encoded := encodeMeta(meta)
+	require.Equal(t, meta, encoded.data)
+	require.Empty(t, encoded.chunks)
+	variable.EnableDistTaskMetaEncoding.Store(true)
+
+	// small meta is stored as is.
+	meta = []byte(`{"a":1}`)
+	encoded = encodeMeta(meta)
+	require.Equal(t, meta, encoded.data)
+	require.Empty(t, encoded.chunks)
+	checkDecoded(meta, encoded)
+	// meta written by old versions.
+	checkDecoded(meta, &encodedMeta{data: meta})
+	// empty meta.
+	encoded = encodeMeta(nil)
+	require.Empty(t, encoded.data)
+	checkDecoded(nil, encoded)
+
+	// raw meta which happens to have the magic prefix.
+	meta = append(append([]byte{}, metaMagic...), metaFlagChunked, 'x')
+	encoded = encodeMeta(meta)
+	require.NotEqual(t, meta, encoded.data)
+	require.False(t, isChunkedMeta(encoded.data))
+	checkDecoded(meta, encoded)
+
+	// large compressible meta is compressed.
+	meta = bytes.Repeat([]byte("abcdefgh"), 8<<10)
+	encoded = encodeMeta(meta)
+	require.Less(t, len(encoded.data), len(meta))
+	require.Empty(t, encoded.chunks)
+	checkDecoded(meta, encoded)
+
+	// incompressible meta is stored as is.
+	meta = make([]byte, 8<<10)
+	_, err := rand.Read(meta)
+	require.NoError(t, err)
+	encoded = encodeMeta(meta)
+	require.Equal(t, meta, encoded.data)
+
+	// meta larger than the max size is split into chunks.
+	variable.DistTaskMaxMetaSize.Store(1 << 10)
+	encoded = encodeMeta(meta)
+	require.True(t, isChunkedMeta(encoded.data))
+	require.Len(t, encoded.chunks, 8)
+	require.NotEmpty(t, encoded.chunkKey)
+	require.Equal(t, meta, bytes.Join(encoded.chunks, nil))
+	// chunked meta is returned as is, it's loaded by loadMetaChunks.
+	decoded, err := decodeMeta(encoded.data)
+	require.NoError(t, err)
+	require.Equal(t, encoded.data, decoded)
+	// compressed before split.
+	meta = bytes.Repeat([]byte("abcdefgh"), 8<<10)
+	encoded = encodeMeta(meta)
+	require.True(t, isChunkedMeta(encoded.data))
+	require.Less(t, len(bytes.Join(encoded.chunks, nil)), len(meta))
+}
@@ -51,6 +51,8 @@ type StepExecutor interface {
 // SubtaskSummary contains the summary of a subtask.
 type SubtaskSummary struct {
 	RowCount int64
+	// Bytes is the size of data processed by the subtask, it's optional.
+	Bytes int64
 }
 
 // StepExecFrameworkInfo is an interface that should be embedded into the
@@ -66,12 +68,19 @@ type StepExecFrameworkInfo interface {
 	restricted()
 	// GetResource returns the expected resource of this step executor.
 	GetResource() *proto.StepResource
+	// UpdateCheckpoint saves the checkpoint of the running subtask, it will be
+	// set to Subtask.Checkpoint when the subtask is run again.
+	UpdateCheckpoint(ctx context.Context, subtaskID int64, checkpoint []byte) error
 }
 
+// CheckpointUpdater is used to save the checkpoint of a subtask.
+type CheckpointUpdater func(ctx context.Context, subtaskID int64, checkpoint []byte) error
+
 var stepExecFrameworkInfoName = reflect.TypeOf((*StepExecFrameworkInfo)(nil)).Elem().Name()
 
 type frameworkInfo struct {
-	resource *proto.StepResource
+	resource          *proto.StepResource
+	checkpointUpdater CheckpointUpdater
 }
 
 func (*frameworkInfo) restricted() {}
@@ -80,12 +89,19 @@ func (f *frameworkInfo) GetResource() *proto.StepResource {
 	return f.resource
 }
 
+func (f *frameworkInfo) UpdateCheckpoint(ctx context.Context, subtaskID int64, checkpoint []byte) error {
+	if f.checkpointUpdater == nil {
+		return nil
+	}
+	return f.checkpointUpdater(ctx, subtaskID, checkpoint)
+}
+
 // SetFrameworkInfo sets the framework info for the StepExecutor.
-func SetFrameworkInfo(exec StepExecutor, resource *proto.StepResource) {
+func SetFrameworkInfo(exec StepExecutor, resource *proto.StepResource, checkpointUpdater CheckpointUpdater) {
 	if exec == nil {
 		return
 	}
-	toInject := &frameworkInfo{resource: resource}
+	toInject := &frameworkInfo{resource: resource, checkpointUpdater: checkpointUpdater}
 	// use reflection to set the framework info
 	e := reflect.ValueOf(exec)
 	if e.Kind() == reflect.Ptr || e.Kind() == reflect.Interface {
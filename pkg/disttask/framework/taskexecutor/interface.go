@@ -50,6 +50,9 @@ type TaskTable interface {
 	CancelSubtask(ctx context.Context, exe string, taskID int64) error
 	// FinishSubtask updates the subtask meta and mark state to succeed.
 	FinishSubtask(ctx context.Context, execID string, subtaskID int64, meta []byte) error
+	// UpdateSubtaskCheckpoint updates the checkpoint of the subtask if it's
+	// running on execID.
+	UpdateSubtaskCheckpoint(ctx context.Context, execID string, subtaskID int64, checkpoint []byte) error
 	// PauseSubtasks update subtasks state to paused.
 	PauseSubtasks(ctx context.Context, execID string, taskID int64) error
 
@@ -58,6 +61,8 @@ type TaskTable interface {
 	// node from running to pending.
 	// see subtask state machine for more detail.
 	RunningSubtasksBack2Pending(ctx context.Context, subtasks []*proto.SubtaskBase) error
+	// DeleteDeadNodes deletes the nodes from dist_framework_meta.
+	DeleteDeadNodes(ctx context.Context, nodes []string) error
 }
 
 // Pool defines the interface of a pool.
@@ -101,6 +106,9 @@ type TaskExecutor interface {
 	// before return, but we only want its context cancelled and check whether it's
 	// closed later.
 	Cancel()
+	// Drain makes the TaskExecutor stop running new subtasks, the running subtask
+	// is not affected, and Run will exit after it's finished.
+	Drain()
 	// Close closes the TaskExecutor.
 	Close()
 	IsRetryableError(err error) bool
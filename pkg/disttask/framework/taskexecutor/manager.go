@@ -17,6 +17,7 @@ package taskexecutor
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/docker/go-units"
@@ -73,6 +74,8 @@ type Manager struct {
 	cancel      context.CancelFunc
 	logger      *zap.Logger
 	slotManager *slotManager
+	// draining is set when the Manager is draining, see Drain.
+	draining atomic.Bool
 
 	totalCPU int
 	totalMem int64
@@ -116,7 +119,7 @@ func NewManager(ctx context.Context, id string, taskTable TaskTable) (*Manager,
 		id:          id,
 		taskTable:   taskTable,
 		logger:      logger,
-		slotManager: newSlotManager(totalCPU),
+		slotManager: newSlotManager(getSlotCapacity(totalCPU)),
 		totalCPU:    totalCPU,
 		totalMem:    int64(totalMem),
 	}
@@ -162,6 +165,83 @@ func (m *Manager) Stop() {
 	m.wg.Wait()
 }
 
+// Drain drains the Manager before the node shutdowns, so rolling restart won't
+// fail the running tasks:
+//   - stop starting task executors for new tasks.
+//   - wait at most timeout for the running task executors to finish their
+//     running subtasks, they won't run any new subtask.
+//   - cancel the remaining task executors, move their running subtasks back
+//     to pending, and remove current node from dist_framework_meta, so the
+//     balancer will schedule the subtasks of this node to other nodes.
+//
+// Stop should still be called after Drain.
+func (m *Manager) Drain(timeout time.Duration) {
+	m.mu.Lock()
+	if m.draining.Load() {
+		m.mu.Unlock()
+		return
+	}
+	m.draining.Store(true)
+	for _, executor := range m.mu.taskExecutors {
+		executor.Drain()
+	}
+	m.mu.Unlock()
+
+	m.logger.Info("draining task executor manager", zap.Duration("timeout", timeout))
+	done := make(chan struct{})
+	go func() {
+		m.executorWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		m.logger.Info("drain timeout, cancel remaining task executors")
+		m.mu.RLock()
+		for _, executor := range m.mu.taskExecutors {
+			executor.Cancel()
+		}
+		m.mu.RUnlock()
+		<-done
+	}
+
+	if err := m.runningSubtasksBack2Pending(); err != nil {
+		m.logErr(err)
+	}
+	if err := m.runWithRetry(func() error {
+		return m.taskTable.DeleteDeadNodes(m.ctx, []string{m.id})
+	}, "delete meta failed"); err == nil {
+		m.logger.Info("task executor manager drained")
+	}
+}
+
+func (m *Manager) runningSubtasksBack2Pending() error {
+	tasks, err := m.taskTable.GetTaskExecInfoByExecID(m.ctx, m.id)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		subtasks, err := m.taskTable.GetSubtasksByExecIDAndStepAndStates(m.ctx, m.id, task.ID, task.Step,
+			proto.SubtaskStateRunning)
+		if err != nil {
+			return err
+		}
+		if len(subtasks) == 0 {
+			continue
+		}
+		subtaskBases := make([]*proto.SubtaskBase, 0, len(subtasks))
+		for _, st := range subtasks {
+			subtaskBases = append(subtaskBases, &st.SubtaskBase)
+		}
+		if err = m.taskTable.RunningSubtasksBack2Pending(m.ctx, subtaskBases); err != nil {
+			return err
+		}
+		m.logger.Info("move running subtasks back to pending on drain",
+			zap.Int64("task-id", task.ID), zap.Stringers("subtasks", subtaskBases))
+	}
+	return nil
+}
+
 // handleTasksLoop handle tasks of interested states, including:
 //   - pending/running: start the task executor.
 //   - reverting: cancel the task executor, and mark running subtasks as Canceled.
@@ -181,6 +261,7 @@ func (m *Manager) handleTasksLoop() {
 		case <-ticker.C:
 		}
 
+		m.resizeSlots()
 		m.handleTasks()
 		// service scope might change, so we call WithLabelValues every time.
 		metrics.DistTaskUsedSlotsGauge.WithLabelValues(variable.ServiceScope.Load()).
@@ -189,7 +270,32 @@ func (m *Manager) handleTasksLoop() {
 	}
 }
 
+// getSlotCapacity returns the slot capacity of the node, it's the value of
+// tidb_dist_task_subtask_concurrency, or the CPU count if it's not set.
+func getSlotCapacity(totalCPU int) int {
+	if concurrency := int(variable.DistTaskSubtaskConcurrency.Load()); concurrency > 0 {
+		return concurrency
+	}
+	return totalCPU
+}
+
+// resizeSlots resizes the slots of the node when tidb_dist_task_subtask_concurrency
+// changes, running task executors keep running, and if the capacity shrinks,
+// new task executors are not started until enough slots are freed.
+func (m *Manager) resizeSlots() {
+	capacity := getSlotCapacity(m.totalCPU)
+	if old := m.slotManager.getCapacity(); old != capacity {
+		m.slotManager.resize(capacity)
+		m.logger.Info("resize slots of task executor manager",
+			zap.Int("old-capacity", old), zap.Int("new-capacity", capacity),
+			zap.Int("available", m.slotManager.availableSlots()))
+	}
+}
+
 func (m *Manager) handleTasks() {
+	if m.draining.Load() {
+		return
+	}
 	tasks, err := m.taskTable.GetTaskExecInfoByExecID(m.ctx, m.id)
 	if err != nil {
 		m.logErr(err)
@@ -222,6 +328,12 @@ func (m *Manager) handleTasks() {
 // handleExecutableTasks handles executable tasks.
 func (m *Manager) handleExecutableTasks(taskInfos []*storage.TaskExecInfo) {
 	for _, task := range taskInfos {
+		if maxCnt := getMaxConcurrentSubtasks(task.Type); maxCnt > 0 &&
+			m.getExecutorCountOfType(task.Type) >= maxCnt {
+			m.logger.Debug("task executors of the task type reached limit",
+				zap.Int64("task-id", task.ID), zap.Stringer("type", task.Type))
+			continue
+		}
 		canAlloc, tasksNeedFree := m.slotManager.canAlloc(task.TaskBase)
 		if len(tasksNeedFree) > 0 {
 			m.cancelTaskExecutors(tasksNeedFree)
@@ -280,6 +392,10 @@ func (m *Manager) recoverMetaLoop() {
 			m.logger.Info("recoverMetaLoop done")
 			return
 		case <-ticker.C:
+			// meta is removed on purpose when draining.
+			if m.draining.Load() {
+				continue
+			}
 			if err := m.recoverMeta(); err != nil {
 				m.logErr(err)
 				continue
@@ -350,6 +466,10 @@ func (m *Manager) addTaskExecutor(executor TaskExecutor) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.mu.taskExecutors[executor.GetTaskBase().ID] = executor
+	// Drain might happen between handleTasks and here.
+	if m.draining.Load() {
+		executor.Drain()
+	}
 }
 
 func (m *Manager) delTaskExecutor(executor TaskExecutor) {
@@ -358,6 +478,18 @@ func (m *Manager) delTaskExecutor(executor TaskExecutor) {
 	delete(m.mu.taskExecutors, executor.GetTaskBase().ID)
 }
 
+func (m *Manager) getExecutorCountOfType(taskType proto.TaskType) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	cnt := 0
+	for _, executor := range m.mu.taskExecutors {
+		if executor.GetTaskBase().Type == taskType {
+			cnt++
+		}
+	}
+	return cnt
+}
+
 func (m *Manager) isExecutorStarted(taskID int64) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
 	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/stretchr/testify/require"
@@ -172,6 +173,57 @@ func TestHandleExecutableTasks(t *testing.T) {
 	require.False(t, m.isExecutorStarted(taskID))
 }
 
+func TestHandleExecutableTasksMaxConcurrentSubtasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskTable := mock.NewMockTaskTable(ctrl)
+	mockInternalExecutor := mock.NewMockTaskExecutor(ctrl)
+	ctx := context.Background()
+
+	RegisterTaskType("type",
+		func(ctx context.Context, id string, task *proto.Task, taskTable TaskTable) TaskExecutor {
+			return mockInternalExecutor
+		}, WithMaxConcurrentSubtasks(1))
+	t.Cleanup(ClearTaskExecutors)
+
+	m, err := NewManager(ctx, "test", mockTaskTable)
+	require.NoError(t, err)
+	m.slotManager.available.Store(16)
+	task1 := &proto.TaskBase{ID: 1, State: proto.TaskStateRunning, Step: proto.StepOne, Type: "type", Concurrency: 1}
+	mockInternalExecutor.EXPECT().GetTaskBase().Return(task1).AnyTimes()
+	m.addTaskExecutor(mockInternalExecutor)
+	require.Equal(t, 1, m.getExecutorCountOfType("type"))
+	require.Equal(t, 0, m.getExecutorCountOfType("other"))
+
+	// task executor of task2 isn't started as the limit is reached, so no
+	// GetTaskByID call.
+	task2 := &proto.TaskBase{ID: 2, State: proto.TaskStateRunning, Step: proto.StepOne, Type: "type", Concurrency: 1}
+	m.handleExecutableTasks([]*storage.TaskExecInfo{{TaskBase: task2}})
+	require.True(t, ctrl.Satisfied())
+	require.False(t, m.isExecutorStarted(task2.ID))
+	require.Equal(t, 16, m.slotManager.availableSlots())
+}
+
+func TestManagerResizeSlots(t *testing.T) {
+	t.Cleanup(func() {
+		variable.DistTaskSubtaskConcurrency.Store(variable.DefTiDBDistTaskSubtaskConcurrency)
+	})
+	variable.DistTaskSubtaskConcurrency.Store(4)
+	m, err := NewManager(context.Background(), "test", nil)
+	require.NoError(t, err)
+	require.Equal(t, 4, m.slotManager.getCapacity())
+
+	variable.DistTaskSubtaskConcurrency.Store(8)
+	m.resizeSlots()
+	require.Equal(t, 8, m.slotManager.getCapacity())
+	require.Equal(t, 8, m.slotManager.availableSlots())
+
+	// reset to the cpu count.
+	variable.DistTaskSubtaskConcurrency.Store(0)
+	m.resizeSlots()
+	require.Equal(t, m.totalCPU, m.slotManager.getCapacity())
+}
+
 func TestManager(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -478,3 +530,54 @@ func TestManagerInitMeta(t *testing.T) {
 	require.ErrorIs(t, m.InitMeta(), context.Canceled)
 	require.True(t, ctrl.Satisfied())
 }
+
+func TestManagerDrain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskTable := mock.NewMockTaskTable(ctrl)
+	mockInternalExecutor := mock.NewMockTaskExecutor(ctrl)
+	RegisterTaskType("type",
+		func(ctx context.Context, id string, task *proto.Task, taskTable TaskTable) TaskExecutor {
+			return mockInternalExecutor
+		})
+	m, err := NewManager(context.Background(), "test", mockTaskTable)
+	require.NoError(t, err)
+	m.slotManager.available.Store(16)
+
+	ch := make(chan struct{})
+	task1 := &proto.TaskBase{ID: 1, State: proto.TaskStateRunning, Step: proto.StepOne, Type: "type", Concurrency: 1}
+	mockInternalExecutor.EXPECT().GetTaskBase().Return(task1).AnyTimes()
+	mockTaskTable.EXPECT().GetTaskExecInfoByExecID(m.ctx, m.id).
+		Return([]*storage.TaskExecInfo{{TaskBase: task1}}, nil)
+	mockTaskTable.EXPECT().GetTaskByID(gomock.Any(), task1.ID).Return(&proto.Task{TaskBase: *task1}, nil)
+	mockInternalExecutor.EXPECT().Init(gomock.Any()).Return(nil)
+	mockInternalExecutor.EXPECT().Run(gomock.Any()).Do(func(_ *proto.StepResource) {
+		<-ch
+	})
+	m.handleTasks()
+	require.Eventually(t, func() bool {
+		return ctrl.Satisfied()
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// running subtask doesn't finish in time, executor is canceled, and the
+	// running subtask is moved back to pending.
+	subtask := &proto.Subtask{SubtaskBase: proto.SubtaskBase{ID: 1, TaskID: task1.ID, Step: proto.StepOne,
+		State: proto.SubtaskStateRunning, ExecID: m.id}}
+	mockInternalExecutor.EXPECT().Drain()
+	mockInternalExecutor.EXPECT().Cancel().Do(func() { close(ch) })
+	mockInternalExecutor.EXPECT().Close()
+	mockTaskTable.EXPECT().GetTaskExecInfoByExecID(m.ctx, m.id).
+		Return([]*storage.TaskExecInfo{{TaskBase: task1}}, nil)
+	mockTaskTable.EXPECT().GetSubtasksByExecIDAndStepAndStates(m.ctx, m.id, task1.ID, task1.Step,
+		proto.SubtaskStateRunning).Return([]*proto.Subtask{subtask}, nil)
+	mockTaskTable.EXPECT().RunningSubtasksBack2Pending(m.ctx, []*proto.SubtaskBase{&subtask.SubtaskBase}).Return(nil)
+	mockTaskTable.EXPECT().DeleteDeadNodes(m.ctx, []string{m.id}).Return(nil)
+	m.Drain(100 * time.Millisecond)
+	require.True(t, ctrl.Satisfied())
+	require.False(t, m.isExecutorStarted(task1.ID))
+
+	// no new task executor is started after drain, and drain again is no-op.
+	m.handleTasks()
+	m.Drain(time.Second)
+	require.True(t, ctrl.Satisfied())
+}
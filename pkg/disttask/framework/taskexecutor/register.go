@@ -21,11 +21,21 @@ import (
 )
 
 type taskTypeOptions struct {
+	maxConcurrentSubtasks int
 }
 
 // TaskTypeOption is the option of TaskType.
 type TaskTypeOption func(opts *taskTypeOptions)
 
+// WithMaxConcurrentSubtasks limits the number of subtasks of the task type which
+// run concurrently on one node. A task executor runs one subtask at a time, so
+// it's the number of task executors of the task type. 0 means no limit.
+func WithMaxConcurrentSubtasks(maxCnt int) TaskTypeOption {
+	return func(opts *taskTypeOptions) {
+		opts.maxConcurrentSubtasks = maxCnt
+	}
+}
+
 var (
 	// key is task type
 	taskTypes             = make(map[proto.TaskType]taskTypeOptions)
@@ -49,6 +59,12 @@ func GetTaskExecutorFactory(taskType proto.TaskType) taskExecutorFactoryFn {
 	return taskExecutorFactories[taskType]
 }
 
+// getMaxConcurrentSubtasks gets the max number of concurrent subtasks of the
+// task type on one node.
+func getMaxConcurrentSubtasks(taskType proto.TaskType) int {
+	return taskTypes[taskType].maxConcurrentSubtasks
+}
+
 // ClearTaskExecutors is only used in test
 func ClearTaskExecutors() {
 	taskTypes = make(map[proto.TaskType]taskTypeOptions)
@@ -38,4 +38,7 @@ func TestRegisterTaskType(t *testing.T) {
 	RegisterTaskType("test2", factoryFn)
 	require.Len(t, taskTypes, 2)
 	require.Len(t, taskExecutorFactories, 2)
+	require.Equal(t, 0, getMaxConcurrentSubtasks("test2"))
+	RegisterTaskType("test2", factoryFn, WithMaxConcurrentSubtasks(2))
+	require.Equal(t, 2, getMaxConcurrentSubtasks("test2"))
 }
@@ -33,7 +33,8 @@ type slotManager struct {
 
 	capacity int
 	// The number of slots that can be used by the executor.
-	// Its initial value is always equal to CPU cores of the instance.
+	// Its initial value is always equal to the capacity, it might be negative
+	// after the capacity shrinks, until enough running tasks exit.
 	available atomic.Int32
 }
 
@@ -108,5 +109,22 @@ func (sm *slotManager) availableSlots() int {
 }
 
 func (sm *slotManager) usedSlots() int {
+	sm.RLock()
+	defer sm.RUnlock()
 	return sm.capacity - int(sm.available.Load())
 }
+
+func (sm *slotManager) getCapacity() int {
+	sm.RLock()
+	defer sm.RUnlock()
+	return sm.capacity
+}
+
+// resize changes the capacity of the slots, running tasks are not affected,
+// the change takes effect when allocating slots for new tasks.
+func (sm *slotManager) resize(capacity int) {
+	sm.Lock()
+	defer sm.Unlock()
+	sm.available.Add(int32(capacity - sm.capacity))
+	sm.capacity = capacity
+}
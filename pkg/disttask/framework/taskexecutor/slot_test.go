@@ -131,3 +131,28 @@ func TestSlotManager(t *testing.T) {
 	require.Len(t, sm.executorTasks, 0)
 	require.Len(t, sm.taskID2Index, 0)
 }
+
+func TestSlotManagerResize(t *testing.T) {
+	sm := newSlotManager(10)
+	task := &proto.TaskBase{ID: 1, Concurrency: 8}
+	task2 := &proto.TaskBase{ID: 2, Concurrency: 4}
+	sm.alloc(task)
+	require.Equal(t, 2, sm.availableSlots())
+
+	// shrink while task is running, it keeps running.
+	sm.resize(4)
+	require.Equal(t, 4, sm.getCapacity())
+	require.Equal(t, -4, sm.availableSlots())
+	require.Equal(t, 8, sm.usedSlots())
+	canAlloc, _ := sm.canAlloc(task2)
+	require.False(t, canAlloc)
+	sm.free(task.ID)
+	require.Equal(t, 4, sm.availableSlots())
+	canAlloc, _ = sm.canAlloc(task2)
+	require.True(t, canAlloc)
+
+	// enlarge.
+	sm.resize(16)
+	require.Equal(t, 16, sm.availableSlots())
+	require.Zero(t, sm.usedSlots())
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
 	"github.com/pingcap/tidb/pkg/lightning/common"
 	llog "github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/backoff"
 	"github.com/pingcap/tidb/pkg/util/gctuner"
@@ -75,6 +76,8 @@ type BaseTaskExecutor struct {
 	Extension
 
 	currSubtaskID atomic.Int64
+	// draining is set by Drain, see TaskExecutor.Drain.
+	draining atomic.Bool
 
 	mu struct {
 		sync.RWMutex
@@ -112,6 +115,8 @@ func NewBaseTaskExecutor(ctx context.Context, id string, task *proto.Task, taskT
 //     `pending` state, to make sure subtasks can be balanced later when node scale out.
 //   - If current running subtask are scheduled away from this node, i.e. this node
 //     is taken as down, cancel running.
+//   - If current running subtask is not running anymore, i.e. it's marked as
+//     failed by the scheduler on timeout, cancel running too.
 func (e *BaseTaskExecutor) checkBalanceSubtask(ctx context.Context) {
 	ticker := time.NewTicker(checkBalanceSubtaskInterval)
 	defer ticker.Stop()
@@ -166,9 +171,9 @@ func (e *BaseTaskExecutor) updateSubtaskSummaryLoop(
 	curSubtaskID := e.currSubtaskID.Load()
 	update := func() {
 		summary := stepExec.RealtimeSummary()
-		err := taskMgr.UpdateSubtaskRowCount(runStepCtx, curSubtaskID, summary.RowCount)
+		err := taskMgr.UpdateSubtaskSummary(runStepCtx, curSubtaskID, summary.RowCount, summary.Bytes)
 		if err != nil {
-			e.logger.Info("update subtask row count failed", zap.Error(err))
+			e.logger.Info("update subtask summary failed", zap.Error(err))
 		}
 	}
 	for {
@@ -207,6 +212,10 @@ func (e *BaseTaskExecutor) Run(resource *proto.StepResource) {
 			return
 		case <-time.After(checkInterval):
 		}
+		if e.draining.Load() {
+			e.logger.Info("task executor is draining, exit")
+			return
+		}
 		if err = e.refreshTask(); err != nil {
 			if errors.Cause(err) == storage.ErrTaskNotFound {
 				return
@@ -304,7 +313,9 @@ func (e *BaseTaskExecutor) runStep(resource *proto.StepResource) (resErr error)
 		e.onError(err)
 		return e.getError()
 	}
-	execute.SetFrameworkInfo(stepExecutor, resource)
+	execute.SetFrameworkInfo(stepExecutor, resource, func(ctx context.Context, subtaskID int64, checkpoint []byte) error {
+		return e.taskTable.UpdateSubtaskCheckpoint(ctx, e.id, subtaskID, checkpoint)
+	})
 
 	failpoint.Inject("mockExecSubtaskInitEnvErr", func() {
 		failpoint.Return(errors.New("mockExecSubtaskInitEnvErr"))
@@ -330,6 +341,9 @@ func (e *BaseTaskExecutor) runStep(resource *proto.StepResource) (resErr error)
 		if runStepCtx.Err() != nil {
 			break
 		}
+		if e.draining.Load() {
+			break
+		}
 
 		subtask, err := e.taskTable.GetFirstSubtaskInStates(runStepCtx, e.id, task.ID, task.Step,
 			proto.SubtaskStatePending, proto.SubtaskStateRunning)
@@ -357,9 +371,10 @@ func (e *BaseTaskExecutor) runStep(resource *proto.StepResource) (resErr error)
 			err := e.startSubtask(runStepCtx, subtask.ID)
 			if err != nil {
 				e.logger.Warn("startSubtask meets error", zap.Error(err))
-				// should ignore ErrSubtaskNotFound
-				// since it only means that the subtask not owned by current task executor.
-				if err == storage.ErrSubtaskNotFound {
+				// should ignore ErrSubtaskNotFound and the invalid state transform
+				// since it only means that the subtask not owned by current task executor,
+				// or it's moved to another state by the scheduler.
+				if err == storage.ErrSubtaskNotFound || isInvalidSubtaskStateTransform(err) {
 					continue
 				}
 				e.onError(err)
@@ -382,6 +397,7 @@ func (e *BaseTaskExecutor) hasRealtimeSummary(stepExecutor execute.StepExecutor)
 }
 
 func (e *BaseTaskExecutor) runSubtask(ctx context.Context, stepExecutor execute.StepExecutor, subtask *proto.Subtask) {
+	startTime := time.Now()
 	err := func() error {
 		e.currSubtaskID.Store(subtask.ID)
 
@@ -416,6 +432,9 @@ func (e *BaseTaskExecutor) runSubtask(ctx context.Context, stepExecutor execute.
 
 	if err != nil {
 		e.onError(err)
+	} else {
+		metrics.DistTaskSubtaskDuration.WithLabelValues(subtask.Type.String(), proto.Step2Str(subtask.Type, subtask.Step)).
+			Observe(time.Since(startTime).Seconds())
 	}
 
 	finished := e.markSubTaskCanceledOrFailed(ctx, subtask)
@@ -492,6 +511,11 @@ func (e *BaseTaskExecutor) Cancel() {
 	e.cancel()
 }
 
+// Drain implements TaskExecutor.Drain.
+func (e *BaseTaskExecutor) Drain() {
+	e.draining.Store(true)
+}
+
 // Close closes the TaskExecutor when all the subtasks are complete.
 func (e *BaseTaskExecutor) Close() {
 	e.Cancel()
@@ -571,12 +595,28 @@ func (e *BaseTaskExecutor) updateSubtaskStateAndErrorImpl(ctx context.Context, e
 	backoffer := backoff.NewExponential(scheduler.RetrySQLInterval, 2, scheduler.RetrySQLMaxInterval)
 	err := handle.RunWithRetry(ctx, scheduler.RetrySQLTimes, backoffer, e.logger,
 		func(ctx context.Context) (bool, error) {
-			return true, e.taskTable.UpdateSubtaskStateAndError(ctx, execID, subtaskID, state, subTaskErr)
+			err := e.taskTable.UpdateSubtaskStateAndError(ctx, execID, subtaskID, state, subTaskErr)
+			return !isInvalidSubtaskStateTransform(err), err
 		},
 	)
 	if err != nil {
-		e.onError(err)
+		e.onSubtaskUpdateError(subtaskID, err)
+	}
+}
+
+func isInvalidSubtaskStateTransform(err error) bool {
+	return errors.Cause(err) == storage.ErrInvalidSubtaskStateTransform
+}
+
+// onSubtaskUpdateError handles the error of updating the subtask state. If the
+// transform is invalid, the subtask has been moved to another state, such as
+// paused by the scheduler, so we only log it.
+func (e *BaseTaskExecutor) onSubtaskUpdateError(subtaskID int64, err error) {
+	if isInvalidSubtaskStateTransform(err) {
+		e.logger.Warn("skip updating the subtask", zap.Int64("subtask-id", subtaskID), zap.Error(err))
+		return
 	}
+	e.onError(err)
 }
 
 // startSubtask try to change the state of the subtask to running.
@@ -588,7 +628,7 @@ func (e *BaseTaskExecutor) startSubtask(ctx context.Context, subtaskID int64) er
 	return handle.RunWithRetry(ctx, scheduler.RetrySQLTimes, backoffer, e.logger,
 		func(ctx context.Context) (bool, error) {
 			err := e.taskTable.StartSubtask(ctx, subtaskID, e.id)
-			if err == storage.ErrSubtaskNotFound {
+			if err == storage.ErrSubtaskNotFound || isInvalidSubtaskStateTransform(err) {
 				// No need to retry.
 				return false, err
 			}
@@ -601,11 +641,12 @@ func (e *BaseTaskExecutor) finishSubtask(ctx context.Context, subtask *proto.Sub
 	backoffer := backoff.NewExponential(scheduler.RetrySQLInterval, 2, scheduler.RetrySQLMaxInterval)
 	err := handle.RunWithRetry(ctx, scheduler.RetrySQLTimes, backoffer, e.logger,
 		func(ctx context.Context) (bool, error) {
-			return true, e.taskTable.FinishSubtask(ctx, subtask.ExecID, subtask.ID, subtask.Meta)
+			err := e.taskTable.FinishSubtask(ctx, subtask.ExecID, subtask.ID, subtask.Meta)
+			return !isInvalidSubtaskStateTransform(err), err
 		},
 	)
 	if err != nil {
-		e.onError(err)
+		e.onSubtaskUpdateError(subtask.ID, err)
 	}
 }
 
@@ -542,7 +542,20 @@ func TestExecutorErrHandling(t *testing.T) {
 func TestInject(t *testing.T) {
 	e := &EmptyStepExecutor{}
 	r := &proto.StepResource{CPU: proto.NewAllocatable(1)}
-	execute.SetFrameworkInfo(e, r)
+	execute.SetFrameworkInfo(e, r, nil)
 	got := e.GetResource()
 	require.Equal(t, r, got)
+	require.NoError(t, e.UpdateCheckpoint(context.Background(), 1, []byte("cp")))
+
+	var (
+		updatedID         int64
+		updatedCheckpoint []byte
+	)
+	execute.SetFrameworkInfo(e, r, func(_ context.Context, subtaskID int64, checkpoint []byte) error {
+		updatedID, updatedCheckpoint = subtaskID, checkpoint
+		return nil
+	})
+	require.NoError(t, e.UpdateCheckpoint(context.Background(), 1, []byte("cp")))
+	require.Equal(t, int64(1), updatedID)
+	require.Equal(t, []byte("cp"), updatedCheckpoint)
 }
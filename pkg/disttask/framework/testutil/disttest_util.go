@@ -67,12 +67,12 @@ func registerTaskMetaInner(t testing.TB, taskType proto.TaskType, schedulerExt s
 		scheduler.ClearSchedulerCleanUpFactory()
 		taskexecutor.ClearTaskExecutors()
 	})
-	scheduler.RegisterSchedulerFactory(taskType,
+	require.NoError(t, scheduler.RegisterSchedulerFactory(taskType,
 		func(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
 			baseScheduler := scheduler.NewBaseScheduler(ctx, task, param)
 			baseScheduler.Extension = schedulerExt
 			return baseScheduler
-		})
+		}))
 
 	scheduler.RegisterSchedulerCleanUpFactory(taskType,
 		func() scheduler.CleanUpRoutine {
@@ -16,6 +16,7 @@ package importinto
 
 import (
 	"context"
+	"encoding/json"
 	"path"
 	"strconv"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
 	"github.com/pingcap/tidb/pkg/disttask/operator"
 	"github.com/pingcap/tidb/pkg/executor/importer"
 	"github.com/pingcap/tidb/pkg/lightning/backend/external"
@@ -244,6 +246,24 @@ func getWriterMemorySizeLimit(resource *proto.StepResource, plan *importer.Plan)
 	return uint64(memPerShare * 3), uint64(memPerShare)
 }
 
+// EstimateSubtaskResource estimates the resource each subtask of the step of
+// the IMPORT INTO task requires. When using global sort, each chunk worker of
+// the encode step keeps a writer for data KV and each index KV group, and each
+// writer allocates at least one block, so that's the least memory the step
+// requires. Other steps are not estimated.
+func EstimateSubtaskResource(_ context.Context, task *proto.Task, step proto.Step) (scheduler.SubtaskResource, error) {
+	if step != proto.ImportStepEncodeAndSort {
+		return scheduler.SubtaskResource{}, nil
+	}
+	taskMeta := &TaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return scheduler.SubtaskResource{}, errors.Trace(err)
+	}
+	indexKVGroupCnt := getNumOfIndexGenKV(taskMeta.Plan.DesiredTableInfo)
+	memPerCon := int64(getKVGroupBlockSize(dataKVGroup)) + int64(indexKVGroupCnt)*int64(getKVGroupBlockSize(""))
+	return scheduler.SubtaskResource{Mem: memPerCon * int64(task.Concurrency)}, nil
+}
+
 func getNumOfIndexGenKV(tblInfo *model.TableInfo) int {
 	var count int
 	var nonClusteredPK bool
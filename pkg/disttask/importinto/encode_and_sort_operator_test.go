@@ -16,6 +16,7 @@ package importinto
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path"
 	"sync"
@@ -27,6 +28,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
 	"github.com/pingcap/tidb/pkg/disttask/importinto/mock"
 	"github.com/pingcap/tidb/pkg/disttask/operator"
 	"github.com/pingcap/tidb/pkg/executor/importer"
@@ -225,6 +227,32 @@ func TestGetWriterMemorySizeLimit(t *testing.T) {
 	}
 }
 
+func TestEstimateSubtaskResource(t *testing.T) {
+	node, err := parser.New().ParseOneStmt("create table t (a int primary key clustered, b int, key(b), key(a,b))", "", "")
+	require.NoError(t, err)
+	info, err := ddl.MockTableInfo(utilmock.NewContext(), node.(*ast.CreateTableStmt), 1)
+	require.NoError(t, err)
+	info.State = model.StatePublic
+	taskMeta, err := json.Marshal(&TaskMeta{Plan: importer.Plan{DesiredTableInfo: info}})
+	require.NoError(t, err)
+	task := &proto.Task{TaskBase: proto.TaskBase{Concurrency: 8}, Meta: taskMeta}
+
+	// a data writer of 32MiB and 2 index writers of 16MiB for each worker.
+	res, err := EstimateSubtaskResource(context.Background(), task, proto.ImportStepEncodeAndSort)
+	require.NoError(t, err)
+	require.Equal(t, scheduler.SubtaskResource{Mem: 8 * 64 * units.MiB}, res)
+	for _, step := range []proto.Step{proto.ImportStepImport, proto.ImportStepMergeSort,
+		proto.ImportStepWriteAndIngest, proto.ImportStepPostProcess} {
+		res, err = EstimateSubtaskResource(context.Background(), task, step)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.SubtaskResource{}, res)
+	}
+
+	task.Meta = []byte("invalid")
+	_, err = EstimateSubtaskResource(context.Background(), task, proto.ImportStepEncodeAndSort)
+	require.ErrorContains(t, err, "invalid character")
+}
+
 func TestGetKVGroupBlockSize(t *testing.T) {
 	require.Equal(t, 32*units.MiB, getKVGroupBlockSize(dataKVGroup))
 	require.Equal(t, 16*units.MiB, getKVGroupBlockSize(""))
@@ -56,6 +56,18 @@ const (
 	registerTimeout        = 5 * time.Second
 )
 
+const (
+	// MaxConcurrentTasks is the max number of IMPORT INTO tasks which can be
+	// scheduled at the same time, so a burst of imports can't starve tasks of
+	// other types, such as add index.
+	MaxConcurrentTasks = 16
+	// MaxConcurrentSubtasksPerNode is the max number of IMPORT INTO subtasks
+	// running at the same time on one node. Each subtask sorts data in the local
+	// sort dir and writes to TiKV with 2*thread workers, running more of them
+	// only makes them compete for the disk and network.
+	MaxConcurrentSubtasksPerNode = 4
+)
+
 // NewTaskRegisterWithTTL is the ctor for TaskRegister.
 // It is exported for testing.
 var NewTaskRegisterWithTTL = utils.NewTaskRegisterWithTTL
@@ -111,6 +111,7 @@ func TestSchedulerExtLocalSort(t *testing.T) {
 	gotSubtasks, err := manager.GetSubtasksWithHistory(ctx, taskID, proto.ImportStepImport)
 	require.NoError(t, err)
 	for _, s := range gotSubtasks {
+		require.NoError(t, manager.StartSubtask(ctx, s.ID, s.ExecID))
 		require.NoError(t, manager.FinishSubtask(ctx, s.ExecID, s.ID, []byte("{}")))
 	}
 	// to post-process stage, job should be running and in validating step
@@ -287,6 +288,7 @@ func TestSchedulerExtGlobalSort(t *testing.T) {
 	sortStepMetaBytes, err := json.Marshal(sortStepMeta)
 	require.NoError(t, err)
 	for _, s := range gotSubtasks {
+		require.NoError(t, manager.StartSubtask(ctx, s.ID, s.ExecID))
 		require.NoError(t, manager.FinishSubtask(ctx, s.ExecID, s.ID, sortStepMetaBytes))
 	}
 
@@ -323,6 +325,7 @@ func TestSchedulerExtGlobalSort(t *testing.T) {
 	mergeSortStepMetaBytes, err := json.Marshal(mergeSortStepMeta)
 	require.NoError(t, err)
 	for _, s := range gotSubtasks {
+		require.NoError(t, manager.StartSubtask(ctx, s.ID, s.ExecID))
 		require.NoError(t, manager.FinishSubtask(ctx, s.ExecID, s.ID, mergeSortStepMetaBytes))
 	}
 
@@ -173,12 +173,18 @@ type Domain struct {
 		sync.RWMutex
 		expiredTimeStamp types.Time
 	}
+	// clusterConfigCacheExpiredTime is the unix nano time before which the
+	// cached config items of information_schema.cluster_config are expired.
+	clusterConfigCacheExpiredTime atomicutil.Int64
 
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
+	distTaskExecutorMgr      atomic.Pointer[taskexecutor.Manager]
+	distTaskSchedulerMgr     atomic.Pointer[scheduler.Manager]
 	runawayManager           *resourcegroup.RunawayManager
 	runawaySyncer            *runawaySyncer
+	throttleTracker          *resourcegroup.ThrottleTracker
 	resourceGroupsController *rmclient.ResourceGroupsController
 
 	serverID             uint64
@@ -1038,6 +1044,16 @@ func (do *Domain) Close() {
 		return
 	}
 	startTime := time.Now()
+	// drain before removing server info, else the node will be taken as dead,
+	// and its running subtasks will be scheduled away.
+	if executorManager := do.distTaskExecutorMgr.Load(); executorManager != nil {
+		executorManager.Drain(func() time.Duration {
+			if intest.InTest {
+				return 0
+			}
+			return 30 * time.Second
+		}())
+	}
 	if do.ddl != nil {
 		terror.Log(do.ddl.Stop())
 	}
@@ -1115,7 +1131,8 @@ func NewDomain(store kv.Storage, ddlLease time.Duration, statsLease time.Duratio
 			jobsVerMap: make(map[int64]int64),
 			jobsIDsMap: make(map[int64]string),
 		},
-		mdlCheckCh: make(chan struct{}),
+		mdlCheckCh:      make(chan struct{}),
+		throttleTracker: resourcegroup.NewThrottleTracker(),
 	}
 
 	do.infoCache = infoschema.NewCache(do, int(variable.SchemaVersionCacheLimit.Load()))
@@ -1320,6 +1337,8 @@ func (do *Domain) Init(
 	do.wg.Run(do.globalConfigSyncerKeeper, "globalConfigSyncerKeeper")
 	do.wg.Run(do.runawayStartLoop, "runawayStartLoop")
 	do.wg.Run(do.requestUnitsWriterLoop, "requestUnitsWriterLoop")
+	do.wg.Run(do.planCacheFlushLoop, "planCacheFlushLoop")
+	do.wg.Run(do.clusterConfigCacheLoop, "clusterConfigCacheLoop")
 	if !skipRegisterToDashboard {
 		do.wg.Run(do.topologySyncerKeeper, "topologySyncerKeeper")
 	}
@@ -1529,6 +1548,7 @@ func (do *Domain) InitDistTaskLoop() error {
 	}
 
 	storage.SetTaskManager(taskManager)
+	do.distTaskExecutorMgr.Store(executorManager)
 	if err = executorManager.InitMeta(); err != nil {
 		// executor manager loop will try to recover meta repeatedly, so we can
 		// just log the error here.
@@ -1563,9 +1583,11 @@ func (do *Domain) distTaskFrameworkLoop(ctx context.Context, taskManager *storag
 		}
 		schedulerManager = scheduler.NewManager(ctx, taskManager, serverID)
 		schedulerManager.Start()
+		do.distTaskSchedulerMgr.Store(schedulerManager)
 	}
 	stopSchedulerMgrIfNeeded := func() {
 		if schedulerManager != nil && schedulerManager.Initialized() {
+			do.distTaskSchedulerMgr.Store(nil)
 			logutil.BgLogger().Info("stopping dist task scheduler manager because the current node is not DDL owner anymore", zap.String("id", do.ddl.GetID()))
 			schedulerManager.Stop()
 			logutil.BgLogger().Info("dist task scheduler manager stopped", zap.String("id", do.ddl.GetID()))
@@ -1588,6 +1610,12 @@ func (do *Domain) distTaskFrameworkLoop(ctx context.Context, taskManager *storag
 	}
 }
 
+// DistTaskSchedulerManager returns the dist task scheduler manager, it's nil
+// if the current node is not the DDL owner.
+func (do *Domain) DistTaskSchedulerManager() *scheduler.Manager {
+	return do.distTaskSchedulerMgr.Load()
+}
+
 type sessionPool struct {
 	resources chan pools.Resource
 	factory   pools.Factory
@@ -1907,6 +1935,7 @@ func (do *Domain) LoadBindInfoLoop(ctxForHandle sessionctx.Context, ctxForEvolve
 
 	owner := do.newOwnerManager(bindinfo.Prompt, bindinfo.OwnerKey)
 	do.globalBindHandleWorkerLoop(owner)
+	do.handleEvolvePlanTasksLoop(owner)
 	return nil
 }
 
@@ -1935,6 +1964,7 @@ func (do *Domain) globalBindHandleWorkerLoop(owner owner.Manager) {
 					logutil.BgLogger().Error("update bindinfo failed", zap.Error(err))
 				}
 				bindHandle.DropInvalidGlobalBinding()
+				bindHandle.SaveEvolveTasksToStorage()
 				// Get Global
 				optVal, err := do.GetGlobalVar(variable.TiDBCapturePlanBaseline)
 				if err == nil && variable.TiDBOptOn(optVal) {
@@ -1953,6 +1983,37 @@ func (do *Domain) globalBindHandleWorkerLoop(owner owner.Manager) {
 	}, "globalBindHandleWorkerLoop")
 }
 
+// handleEvolvePlanTasksLoop verifies the evolve tasks of the bindings on the owner, when the baseline evolution
+// is enabled globally.
+func (do *Domain) handleEvolvePlanTasksLoop(owner owner.Manager) {
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("handleEvolvePlanTasksLoop exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "handleEvolvePlanTasksLoop", nil, false)
+
+		ticker := time.NewTicker(bindinfo.Lease)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-do.exit:
+				return
+			case <-ticker.C:
+			}
+			if !owner.IsOwner() {
+				continue
+			}
+			optVal, err := do.GetGlobalVar(variable.TiDBEvolvePlanBaselines)
+			if err != nil || !variable.TiDBOptOn(optVal) {
+				continue
+			}
+			if err := do.BindHandle().HandleEvolveTasks(false); err != nil {
+				logutil.BgLogger().Warn("handle evolve plan tasks failed", zap.Error(err))
+			}
+		}
+	}, "handleEvolvePlanTasksLoop")
+}
+
 // SetupPlanReplayerHandle setup plan replayer handle
 func (do *Domain) SetupPlanReplayerHandle(collectorSctx sessionctx.Context, workersSctxs []sessionctx.Context) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
@@ -1987,6 +2048,11 @@ func (do *Domain) RunawayManager() *resourcegroup.RunawayManager {
 	return do.runawayManager
 }
 
+// ThrottleTracker returns the tracker of throttled resource groups.
+func (do *Domain) ThrottleTracker() *resourcegroup.ThrottleTracker {
+	return do.throttleTracker
+}
+
 // ResourceGroupsController returns the resource groups controller.
 func (do *Domain) ResourceGroupsController() *rmclient.ResourceGroupsController {
 	return do.resourceGroupsController
@@ -2616,6 +2682,9 @@ const (
 	privilegeKey          = "/tidb/privilege"
 	sysVarCacheKey        = "/tidb/sysvars"
 	tiflashComputeNodeKey = "/tiflash/new_tiflash_compute_nodes"
+	planCacheFlushKey     = "/tidb/plan_cache/flush"
+	clusterConfigCacheKey = "/tidb/cluster_config/cache"
+	costModelFactorsKey   = "/tidb/cost_model/factors"
 )
 
 // NotifyUpdatePrivilege updates privilege key in etcd, TiDB client that watches
@@ -2668,6 +2737,122 @@ func (do *Domain) NotifyUpdateSysVarCache(updateLocal bool) {
 	}
 }
 
+// NotifyFlushPlanCache updates the plan cache flush key in etcd, the TiDB instances that watch
+// the key will expire the plan cache of all their sessions.
+func (do *Domain) NotifyFlushPlanCache() error {
+	if do.etcdClient == nil {
+		return nil
+	}
+	_, err := do.etcdClient.KV.Put(context.Background(), planCacheFlushKey, "")
+	return errors.Trace(err)
+}
+
+// planCacheFlushLoop watches the plan cache flush key in etcd, and expires the plan cache of
+// all the sessions once other instances execute `admin flush cluster plan_cache`.
+func (do *Domain) planCacheFlushLoop() {
+	do.watchNotifyKeyLoop(planCacheFlushKey, "planCacheFlushLoop", func() {
+		do.SetExpiredTimeStamp4PC(types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3))
+	})
+}
+
+// ClusterConfigCacheExpiredTime returns the time before which the cached config items of
+// information_schema.cluster_config are expired.
+func (do *Domain) ClusterConfigCacheExpiredTime() time.Time {
+	return time.Unix(0, do.clusterConfigCacheExpiredTime.Load())
+}
+
+// ExpireClusterConfigCache expires the config items of information_schema.cluster_config
+// cached by this instance.
+func (do *Domain) ExpireClusterConfigCache() {
+	do.clusterConfigCacheExpiredTime.Store(time.Now().UnixNano())
+}
+
+// NotifyExpireClusterConfigCache updates the cluster config cache key in etcd, the TiDB
+// instances that watch the key will expire the config items they cached.
+func (do *Domain) NotifyExpireClusterConfigCache() error {
+	if do.etcdClient == nil {
+		return nil
+	}
+	_, err := do.etcdClient.KV.Put(context.Background(), clusterConfigCacheKey, "")
+	return errors.Trace(err)
+}
+
+// clusterConfigCacheLoop watches the cluster config cache key in etcd, and expires the cached
+// config items once other instances execute `admin reload cluster_config` or `set config`.
+func (do *Domain) clusterConfigCacheLoop() {
+	do.watchNotifyKeyLoop(clusterConfigCacheKey, "clusterConfigCacheLoop", do.ExpireClusterConfigCache)
+}
+
+// NotifyUpdateCostModelFactors updates the cost model factors key in etcd, the TiDB instances that watch
+// the key will reload the calibrated factors of the cost model.
+func (do *Domain) NotifyUpdateCostModelFactors() error {
+	if do.etcdClient == nil {
+		return nil
+	}
+	_, err := do.etcdClient.KV.Put(context.Background(), costModelFactorsKey, "")
+	return errors.Trace(err)
+}
+
+// WatchCostModelFactors creates a goroutine which reloads the factors of the cost model by load and expires the
+// plan cache once other instances execute `admin calibrate cost model`. It should be called only once in
+// BootstrapSession.
+func (do *Domain) WatchCostModelFactors(load func(sctx sessionctx.Context) error) {
+	do.wg.Run(func() {
+		do.watchNotifyKeyLoop(costModelFactorsKey, "costModelFactorsLoop", func() {
+			if err := do.ReloadCostModelFactors(load); err != nil {
+				logutil.BgLogger().Warn("reload cost model factors failed", zap.Error(err))
+			}
+		})
+	}, "costModelFactorsLoop")
+}
+
+// ReloadCostModelFactors reloads the factors of the cost model by load, and expires the plan cache of all the
+// sessions since the cached plans are chosen by the old factors.
+func (do *Domain) ReloadCostModelFactors(load func(sctx sessionctx.Context) error) error {
+	pool := do.SysSessionPool()
+	se, err := pool.Get()
+	if err != nil {
+		return err
+	}
+	defer pool.Put(se)
+	if err = load(se.(sessionctx.Context)); err != nil {
+		return err
+	}
+	do.SetExpiredTimeStamp4PC(types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3))
+	return nil
+}
+
+// watchNotifyKeyLoop watches the key in etcd, and calls onNotify each time the key is updated.
+func (do *Domain) watchNotifyKeyLoop(key, name string, onNotify func()) {
+	if do.etcdClient == nil {
+		return
+	}
+	defer func() {
+		logutil.BgLogger().Info(name + " exited.")
+	}()
+	defer util.Recover(metrics.LabelDomain, name, nil, false)
+
+	watchCh := do.etcdClient.Watch(context.Background(), key)
+	for {
+		select {
+		case <-do.exit:
+			return
+		case _, ok := <-watchCh:
+			if !ok {
+				logutil.BgLogger().Warn("watch channel closed", zap.String("key", key))
+				select {
+				case <-do.exit:
+					return
+				case <-time.After(time.Second):
+				}
+				watchCh = do.etcdClient.Watch(context.Background(), key)
+				continue
+			}
+		}
+		onNotify()
+	}
+}
+
 // LoadSigningCertLoop loads the signing cert periodically to make sure it's fresh new.
 func (do *Domain) LoadSigningCertLoop(signingCert, signingKey string) {
 	sessionstates.SetCertPath(signingCert)
@@ -3013,6 +3198,24 @@ func (do *Domain) serverIDKeeper() {
 	}
 }
 
+// RunInBackground runs fn in a goroutine which is waited when the domain is
+// closed, the context passed to fn is canceled once the domain is closing.
+// Note that the label shouldn't be duplicated.
+func (do *Domain) RunInBackground(fn func(ctx context.Context), label string) {
+	do.wg.Run(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-do.exit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		fn(ctx)
+	}, label)
+}
+
 // StartTTLJobManager creates and starts the ttl job manager
 func (do *Domain) StartTTLJobManager() {
 	ttlJobManager := ttlworker.NewJobManager(do.ddl.GetID(), do.sysSessionPool, do.store, do.etcdClient, do.ddl.OwnerManager().IsOwner)
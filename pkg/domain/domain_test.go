@@ -170,6 +170,44 @@ func TestInfo(t *testing.T) {
 	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/domain/infosync/FailPlacement"))
 }
 
+func TestClusterConfigCacheNotify(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("integration.NewClusterV3 will create file contains a colon which is not allowed on Windows")
+	}
+	integration.BeforeTestExternal(t)
+
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	// dom1 executes `admin reload cluster_config`, dom2 watches the key.
+	dom1 := NewDomain(store, 0, 0, 0, mockFactory)
+	dom1.etcdClient = cluster.RandClient()
+	dom2 := NewDomain(store, 0, 0, 0, mockFactory)
+	dom2.etcdClient = cluster.RandClient()
+	done := make(chan struct{})
+	go func() {
+		dom2.clusterConfigCacheLoop()
+		close(done)
+	}()
+	defer func() {
+		close(dom2.exit)
+		<-done
+		require.NoError(t, store.Close())
+	}()
+
+	before := dom2.ClusterConfigCacheExpiredTime()
+	dom1.ExpireClusterConfigCache()
+	require.True(t, dom1.ClusterConfigCacheExpiredTime().After(before))
+	require.Equal(t, before, dom2.ClusterConfigCacheExpiredTime())
+	// the watch may not be created yet, so notify until it's received.
+	require.Eventually(t, func() bool {
+		require.NoError(t, dom1.NotifyExpireClusterConfigCache())
+		return dom2.ClusterConfigCacheExpiredTime().After(before)
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 func TestStatWorkRecoverFromPanic(t *testing.T) {
 	store, err := mockstore.NewMockStore()
 	require.NoError(t, err)
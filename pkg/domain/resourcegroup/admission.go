@@ -0,0 +1,92 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+// throttleStaleDuration is the duration after which the throttle state of a resource group is discarded
+// if no statement of the group is observed. It lets the group admit statements again after rejecting
+// all of them for a while, so the throttle state can be re-evaluated.
+const throttleStaleDuration = 5 * time.Second
+
+type throttleState struct {
+	// since is the time when the resource group started to be throttled.
+	since time.Time
+	// last is the time when the resource group was observed to be throttled last time.
+	last time.Time
+}
+
+// ThrottleTracker tracks how long resource groups have been throttled by their token buckets.
+// A resource group is regarded as throttled since a statement of it waited for RU tokens, until a
+// statement of it finishes without waiting.
+type ThrottleTracker struct {
+	mu     sync.Mutex
+	groups map[string]throttleState
+}
+
+// NewThrottleTracker creates a new ThrottleTracker.
+func NewThrottleTracker() *ThrottleTracker {
+	return &ThrottleTracker{groups: make(map[string]throttleState)}
+}
+
+// Observe records whether a finished statement of the resource group waited for RU tokens.
+func (t *ThrottleTracker) Observe(resourceGroupName string, waited bool, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !waited {
+		delete(t.groups, resourceGroupName)
+		return
+	}
+	state, ok := t.groups[resourceGroupName]
+	if !ok || now.Sub(state.last) > throttleStaleDuration {
+		state.since = now
+	}
+	state.last = now
+	t.groups[resourceGroupName] = state
+}
+
+// ThrottledDuration returns how long the resource group has been throttled continuously.
+func (t *ThrottleTracker) ThrottledDuration(resourceGroupName string, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.groups[resourceGroupName]
+	if !ok {
+		return 0
+	}
+	if now.Sub(state.last) > throttleStaleDuration {
+		delete(t.groups, resourceGroupName)
+		return 0
+	}
+	return now.Sub(state.since)
+}
+
+// CheckAdmission returns an error if the resource group has been throttled longer than the admission timeout.
+func (t *ThrottleTracker) CheckAdmission(resourceGroupName string, admissionTimeout time.Duration, now time.Time) error {
+	if admissionTimeout <= 0 {
+		return nil
+	}
+	if dur := t.ThrottledDuration(resourceGroupName, now); dur > admissionTimeout {
+		metrics.ResourceGroupAdmissionRejectedCounter.WithLabelValues(resourceGroupName).Inc()
+		return errors.Trace(exeerrors.ErrResourceGroupAdmissionRejected.GenWithStackByArgs(resourceGroupName, dur.Round(time.Millisecond).String()))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"sync"
+
+	"github.com/pingcap/tidb/pkg/metrics"
+)
+
+// InternalResourceGroupName is the pseudo resource group name which the RU consumption of internal
+// restricted SQLs is reported as.
+const InternalResourceGroupName = "internal"
+
+var (
+	internalRUMu sync.Mutex
+	internalRRU  float64
+	internalWRU  float64
+)
+
+// RecordInternalRU records the RU consumed by an internal restricted SQL.
+func RecordInternalRU(rru, wru float64) {
+	if rru <= 0 && wru <= 0 {
+		return
+	}
+	internalRUMu.Lock()
+	internalRRU += rru
+	internalWRU += wru
+	internalRUMu.Unlock()
+	metrics.InternalRUCounter.WithLabelValues(InternalResourceGroupName, "rru").Add(rru)
+	metrics.InternalRUCounter.WithLabelValues(InternalResourceGroupName, "wru").Add(wru)
+}
+
+// InternalRU returns the total RU consumed by internal restricted SQLs in this instance.
+func InternalRU() (rru, wru float64) {
+	internalRUMu.Lock()
+	defer internalRUMu.Unlock()
+	return internalRRU, internalWRU
+}
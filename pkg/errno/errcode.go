@@ -1084,6 +1084,9 @@ const (
 	ErrBRJobNotFound                       = 8174
 	ErrMemoryExceedForQuery                = 8175
 	ErrMemoryExceedForInstance             = 8176
+	ErrStmtMemoryQueueTimeout              = 8177
+	ErrDiskExceedForQuery                  = 8178
+	ErrHashAggPartialResult                = 8179
 
 	// Error codes used by TiDB ddl package
 	ErrUnsupportedDDLOperation            = 8200
@@ -1140,6 +1143,9 @@ const (
 	ErrPausedDDLJob       = 8262
 	ErrBDRRestrictedDDL   = 8263
 
+	// Distributed execution framework errors.
+	ErrDistTaskResourceNotEnough = 8264
+
 	// Resource group errors.
 	ErrResourceGroupExists                    = 8248
 	ErrResourceGroupNotExists                 = 8249
@@ -1149,6 +1155,8 @@ const (
 	ErrResourceGroupQueryRunawayInterrupted   = 8253
 	ErrResourceGroupQueryRunawayQuarantine    = 8254
 	ErrResourceGroupInvalidBackgroundTaskName = 8255
+	ErrResourceGroupAdmissionRejected         = 8257
+	ErrStmtRUQuotaExceeded                    = 8258
 
 	// TiKV/PD/TiFlash errors.
 	ErrPDServerTimeout           = 9001
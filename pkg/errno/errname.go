@@ -1077,6 +1077,9 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrLoadDataPreCheckFailed:           mysql.Message("PreCheck failed: %s", nil),
 	ErrMemoryExceedForQuery:             mysql.Message("Your query has been cancelled due to exceeding the allowed memory limit for a single SQL query. Please try narrowing your query scope or increase the tidb_mem_quota_query limit and try again.[conn=%d]", nil),
 	ErrMemoryExceedForInstance:          mysql.Message("Your query has been cancelled due to exceeding the allowed memory limit for the tidb-server instance and this query is currently using the most memory. Please try narrowing your query scope or increase the tidb_server_memory_limit and try again.[conn=%d]", nil),
+	ErrStmtMemoryQueueTimeout:           mysql.Message("Statement has been queued for %s because the memory usage of the tidb-server instance is above tidb_server_memory_queue_watermark, please retry later", nil),
+	ErrDiskExceedForQuery:               mysql.Message("Your query has been cancelled due to exceeding the allowed disk quota for a single SQL query. Please try narrowing your query scope or increase the tidb_disk_quota_query limit and try again.[conn=%d]", nil),
+	ErrHashAggPartialResult:             mysql.Message("The memory quota of the query is exceeded, hash aggregation stops reading its input and the result is approximate", nil),
 	ErrHTTPServiceError:                 mysql.Message("HTTP request failed with status %s", nil),
 
 	ErrWarnOptimizerHintInvalidInteger:  mysql.Message("integer value is out of range in '%s'", nil),
@@ -1150,6 +1153,8 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrResourceGroupQueryRunawayInterrupted:   mysql.Message("Query execution was interrupted, identified as runaway query", nil),
 	ErrResourceGroupQueryRunawayQuarantine:    mysql.Message("Quarantined and interrupted because of being in runaway watch list", nil),
 	ErrResourceGroupInvalidBackgroundTaskName: mysql.Message("Unknown background task name '%-.192s'", nil),
+	ErrResourceGroupAdmissionRejected:         mysql.Message("Rejected because resource group '%-.192s' has been throttled for %s, please retry later", nil),
+	ErrStmtRUQuotaExceeded:                    mysql.Message("Query execution was interrupted, the statement consumed %.2f RU which exceeds tidb_stmt_ru_quota(%d)", nil),
 
 	// TiKV/PD errors.
 	ErrPDServerTimeout:           mysql.Message("PD server timeout: %s", nil),
@@ -1170,4 +1175,6 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrCannotResumeDDLJob: mysql.Message("Job [%v] can't be resumed: %s", nil),
 	ErrPausedDDLJob:       mysql.Message("Job [%v] has already been paused", nil),
 	ErrBDRRestrictedDDL:   mysql.Message("The operation is not allowed while the bdr role of this cluster is set to %s.", nil),
+
+	ErrDistTaskResourceNotEnough: mysql.Message("Resource of the node is not enough to run the task: %s", nil),
 }
@@ -46,6 +46,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/planner"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/plugin"
@@ -180,6 +181,7 @@ func (a *recordSet) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 	if numRows == 0 {
 		if a.stmt != nil {
 			a.stmt.Ctx.GetSessionVars().LastFoundRows = a.stmt.Ctx.GetSessionVars().StmtCtx.FoundRows()
+			a.stmt.executorDrained = true
 		}
 		return nil
 	}
@@ -262,6 +264,8 @@ type ExecStmt struct {
 	isSelectForUpdate bool
 	retryCount        uint
 	retryStartTime    time.Time
+	// executorDrained indicates whether all the rows of the executor are returned.
+	executorDrained bool
 
 	// Phase durations are splited into two parts: 1. trying to lock keys (but
 	// failed); 2. the final iteration of the retry loop. Here we use
@@ -473,6 +477,7 @@ func (a *ExecStmt) Exec(ctx context.Context) (_ sqlexec.RecordSet, err error) {
 		recoverdErr, ok := r.(error)
 		if !ok || !(exeerrors.ErrMemoryExceedForQuery.Equal(recoverdErr) ||
 			exeerrors.ErrMemoryExceedForInstance.Equal(recoverdErr) ||
+			exeerrors.ErrDiskExceedForQuery.Equal(recoverdErr) ||
 			exeerrors.ErrQueryInterrupted.Equal(recoverdErr) ||
 			exeerrors.ErrMaxExecTimeExceeded.Equal(recoverdErr)) {
 			panic(r)
@@ -534,6 +539,14 @@ func (a *ExecStmt) Exec(ctx context.Context) (_ sqlexec.RecordSet, err error) {
 
 	// must set plan according to the `Execute` plan before getting planDigest
 	a.inheritContextFromExecuteStmt()
+	if variable.EnableResourceControl.Load() {
+		if err := a.checkResourceGroupAdmission(); err != nil {
+			return nil, err
+		}
+	}
+	if err := a.checkMemoryAdmission(); err != nil {
+		return nil, err
+	}
 	if variable.EnableResourceControl.Load() && domain.GetDomain(sctx).RunawayManager() != nil {
 		stmtCtx := sctx.GetSessionVars().StmtCtx
 		_, planDigest := GetPlanDigest(stmtCtx)
@@ -988,6 +1001,7 @@ func (a *ExecStmt) handleNoDelayExecutor(ctx context.Context, e exec.Executor) (
 	if err != nil {
 		return nil, err
 	}
+	a.executorDrained = true
 	err = a.handleStmtForeignKeyTrigger(ctx, e)
 	return nil, err
 }
@@ -1388,6 +1402,7 @@ func (a *ExecStmt) FinishExecuteStmt(txnTS uint64, err error, hasMoreResults boo
 	// `LowSlowQuery` and `SummaryStmt` must be called before recording `PrevStmt`.
 	a.LogSlowQuery(txnTS, succ, hasMoreResults)
 	a.SummaryStmt(succ)
+	a.recordCardinalityFeedback(succ)
 	a.observeStmtFinishedForTopSQL()
 	if sessVars.StmtCtx.IsTiFlash.Load() {
 		if succ {
@@ -1398,6 +1413,7 @@ func (a *ExecStmt) FinishExecuteStmt(txnTS uint64, err error, hasMoreResults boo
 	}
 	sessVars.PrevStmt = FormatSQL(a.GetTextToLog(false))
 	a.recordLastQueryInfo(err)
+	a.observeResourceGroupThrottle(err)
 	a.observePhaseDurations(sessVars.InRestrictedSQL, execDetail.CommitDetail)
 	executeDuration := time.Since(sessVars.StartTime) - sessVars.DurationCompile
 	if sessVars.InRestrictedSQL {
@@ -1444,6 +1460,62 @@ func (a *ExecStmt) FinishExecuteStmt(txnTS uint64, err error, hasMoreResults boo
 	a.Ctx.ReportUsageStats()
 }
 
+// checkResourceGroupAdmission rejects the statement if its resource group has been throttled longer than
+// the ADMISSION_TIMEOUT of the group.
+func (a *ExecStmt) checkResourceGroupAdmission() error {
+	sessVars := a.Ctx.GetSessionVars()
+	if sessVars.InRestrictedSQL {
+		return nil
+	}
+	switch a.StmtNode.(type) {
+	case *ast.ExecuteStmt, ast.DMLNode:
+	default:
+		return nil
+	}
+	groupName := sessVars.StmtCtx.ResourceGroupName
+	admissionTimeout := a.getAdmissionTimeout(groupName)
+	if admissionTimeout == 0 {
+		return nil
+	}
+	return domain.GetDomain(a.Ctx).ThrottleTracker().CheckAdmission(groupName, admissionTimeout, time.Now())
+}
+
+func (a *ExecStmt) getAdmissionTimeout(groupName string) time.Duration {
+	if a.InfoSchema == nil {
+		return 0
+	}
+	group, ok := a.InfoSchema.ResourceGroupByName(model.NewCIStr(groupName))
+	if !ok {
+		return 0
+	}
+	return time.Duration(group.AdmissionTimeoutMs) * time.Millisecond
+}
+
+// observeResourceGroupThrottle records whether the statement waited for RU tokens of its resource group.
+func (a *ExecStmt) observeResourceGroupThrottle(err error) {
+	sessVars := a.Ctx.GetSessionVars()
+	if !variable.EnableResourceControl.Load() || sessVars.InRestrictedSQL {
+		return
+	}
+	// the rejected statement is not executed, it tells nothing about the token bucket.
+	if exeerrors.ErrResourceGroupAdmissionRejected.Equal(err) {
+		return
+	}
+	// only the resource groups with ADMISSION_TIMEOUT need to be tracked.
+	groupName := sessVars.StmtCtx.ResourceGroupName
+	if a.getAdmissionTimeout(groupName) == 0 {
+		return
+	}
+	var waited bool
+	if ruDetailRaw := a.GoCtx.Value(util.RUDetailsCtxKey); ruDetailRaw != nil {
+		waited = ruDetailRaw.(*util.RUDetails).RUWaitDuration() > 0
+	}
+	failpoint.Inject("mockRUWaitDuration", func(val failpoint.Value) {
+		waited = val.(bool)
+	})
+	domain.GetDomain(a.Ctx).ThrottleTracker().Observe(groupName, waited, time.Now())
+}
+
 func (a *ExecStmt) recordLastQueryInfo(err error) {
 	sessVars := a.Ctx.GetSessionVars()
 	// Record diagnostic information for DML statements
@@ -1493,6 +1565,18 @@ func (a *ExecStmt) checkPlanReplayerCapture(txnTS uint64) {
 	}
 }
 
+// recordCardinalityFeedback records the actual row counts of the tables whose estimated row counts are far from the
+// actual ones, which are used when the same statement is optimized later.
+func (a *ExecStmt) recordCardinalityFeedback(succ bool) {
+	stmtCtx := a.Ctx.GetSessionVars().StmtCtx
+	// the row counts are smaller than the actual ones if the executor is not drained.
+	if !succ || !a.executorDrained || stmtCtx.CardinalityFeedbackKey == "" {
+		return
+	}
+	rowCounts := plannercore.CollectCardinalityFeedback(a.Plan, stmtCtx.RuntimeStatsColl)
+	cardinality.GlobalFeedbackStore.Update(stmtCtx.CardinalityFeedbackKey, rowCounts)
+}
+
 // CloseRecordSet will finish the execution of current statement and do some record work
 func (a *ExecStmt) CloseRecordSet(txnStartTS uint64, lastErr error) {
 	a.FinishExecuteStmt(txnStartTS, lastErr, false)
@@ -2049,6 +2133,8 @@ func (a *ExecStmt) observeStmtBeginForTopSQL(ctx context.Context) context.Contex
 		return topsql.AttachSQLAndPlanInfo(ctx, sqlDigest, planDigest)
 	}
 
+	// The resource group may be changed by the hint in optimization.
+	ctx = topsql.AttachResourceGroup(ctx, sc.ResourceGroupName)
 	if stats != nil {
 		stats.OnExecutionBegin(sqlDigestByte, planDigestByte)
 		// This is a special logic prepared for TiKV's SQLExecCount.
@@ -2077,6 +2163,12 @@ func (a *ExecStmt) observeStmtFinishedForTopSQL() {
 		execDuration := time.Since(vars.StartTime) + vars.DurationParse
 		stats.OnExecutionFinished(sqlDigest, planDigest, execDuration)
 	}
+	if topsqlstate.TopSQLEnabled() {
+		if ruDetailRaw := a.GoCtx.Value(util.RUDetailsCtxKey); ruDetailRaw != nil {
+			ruDetail := ruDetailRaw.(*util.RUDetails)
+			topsql.ObserveResourceGroupRU(vars.StmtCtx.ResourceGroupName, ruDetail.RRU()+ruDetail.WRU())
+		}
+	}
 }
 
 func (a *ExecStmt) getSQLPlanDigest() ([]byte, []byte) {
@@ -154,6 +154,11 @@ type HashAggExec struct {
 	spillHelper *parallelHashAggSpillHelper
 	// isChildDrained indicates whether the all data from child has been taken out.
 	isChildDrained bool
+	// partialResultAction save the Action for the partial result mode.
+	partialResultAction *AggPartialResultAction
+	// isPartialResult indicates whether HashAgg has stopped reading from the child because the
+	// memory quota is exceeded in the partial result mode, the result is approximate then.
+	isPartialResult atomic.Bool
 }
 
 // Close implements the Executor Close interface.
@@ -176,6 +181,7 @@ func (e *HashAggExec) Close() error {
 			e.spillAction.SetFinished()
 		}
 		e.spillAction, e.tmpChkForSpill = nil, nil
+		e.closePartialResultAction()
 		err := e.BaseExecutor.Close()
 		if err != nil {
 			return err
@@ -212,6 +218,7 @@ func (e *HashAggExec) Close() error {
 			e.parallelAggSpillAction = nil
 			e.spillHelper.close()
 		}
+		e.closePartialResultAction()
 	}
 
 	err := e.BaseExecutor.Close()
@@ -282,6 +289,7 @@ func (e *HashAggExec) initForUnparallelExec() {
 		e.dataInDisk.GetDiskTracker().AttachTo(e.diskTracker)
 		vars.MemTracker.FallbackOldAndSetNewActionForSoftLimit(e.ActionSpill())
 	}
+	e.initPartialResultAction()
 }
 
 func (e *HashAggExec) initPartialWorkers(partialConcurrency int, finalConcurrency int, ctx sessionctx.Context) {
@@ -404,6 +412,7 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) error {
 		e.spillHelper.diskTracker = e.diskTracker
 		sessionVars.MemTracker.FallbackOldAndSetNewActionForSoftLimit(e.ActionSpill())
 	}
+	e.initPartialResultAction()
 
 	e.partialWorkers = make([]HashAggPartialWorker, partialConcurrency)
 	e.finalWorkers = make([]HashAggFinalWorker, finalConcurrency)
@@ -466,6 +475,10 @@ func (e *HashAggExec) fetchChildData(ctx context.Context, waitGroup *sync.WaitGr
 		}
 
 		mSize := chk.MemoryUsage()
+		if e.isPartialResult.Load() {
+			e.memTracker.Consume(-mSize)
+			return
+		}
 		err = exec.Next(ctx, e.Children(0), chk)
 		if err != nil {
 			e.finalOutputCh <- &AfFinalResult{err: err}
@@ -784,6 +797,10 @@ func (e *HashAggExec) spillUnprocessedData(isFullChk bool) (err error) {
 
 func (e *HashAggExec) getNextChunk(ctx context.Context) (err error) {
 	e.childResult.Reset()
+	if !e.isChildDrained && e.isPartialResult.Load() {
+		// The memory quota is exceeded in the partial result mode, aggregate the data read so far.
+		e.isChildDrained = true
+	}
 	if !e.isChildDrained {
 		if err := exec.Next(ctx, e.Children(0), e.childResult); err != nil {
 			return err
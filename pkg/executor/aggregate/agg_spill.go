@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/disk"
 	"github.com/pingcap/tidb/pkg/util/hack"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -45,6 +46,8 @@ const (
 	spilledPartitionNum = 256
 
 	spillLogInfo string = "memory exceeds quota, set aggregate mode to spill-mode"
+
+	partialResultLogInfo string = "memory exceeds quota, stop reading the child of aggregate and return partial result"
 )
 
 type parallelHashAggSpillHelper struct {
@@ -355,6 +358,40 @@ func (*AggSpillDiskAction) GetPriority() int64 {
 	return memory.DefSpillPriority
 }
 
+// AggPartialResultAction implements memory.ActionOnExceed for HashAgg in the
+// partial result mode. If the memory quota of a query is exceeded, HashAgg stops
+// reading from its child and returns the result aggregated from the data read
+// so far, instead of cancelling the query.
+type AggPartialResultAction struct {
+	memory.BaseOOMAction
+	e *HashAggExec
+}
+
+// Action stops HashAgg reading from its child.
+func (a *AggPartialResultAction) Action(t *memory.Tracker) {
+	if a.e.isPartialResult.Load() {
+		// HashAgg only finishes the data it has read, so the memory usage won't grow much.
+		return
+	}
+	// Only take over the action when HashAgg is the main memory consumer of the query.
+	if hasEnoughDataToSpill(a.e.memTracker, t) {
+		a.e.isPartialResult.Store(true)
+		logutil.BgLogger().Info(partialResultLogInfo,
+			zap.Int64("consumed", t.BytesConsumed()),
+			zap.Int64("quota", t.GetBytesLimit()))
+		a.e.Ctx().GetSessionVars().StmtCtx.AppendWarning(exeerrors.ErrHashAggPartialResult.FastGenByArgs())
+		return
+	}
+	if fallback := a.GetFallback(); fallback != nil {
+		fallback.Action(t)
+	}
+}
+
+// GetPriority get the priority of the Action
+func (*AggPartialResultAction) GetPriority() int64 {
+	return memory.DefLogPriority
+}
+
 // ParallelAggSpillDiskAction implements memory.ActionOnExceed for parallel HashAgg.
 type ParallelAggSpillDiskAction struct {
 	memory.BaseOOMAction
@@ -279,6 +279,25 @@ func (e *HashAggExec) ActionSpill() memory.ActionOnExceed {
 	return e.actionSpillForParallel()
 }
 
+// initPartialResultAction registers the Action for the partial result mode when
+// tidb_enable_hashagg_partial_result is on.
+func (e *HashAggExec) initPartialResultAction() {
+	e.isPartialResult.Store(false)
+	vars := e.Ctx().GetSessionVars()
+	if !vars.TrackAggregateMemoryUsage || !vars.EnableHashAggPartialResult {
+		return
+	}
+	e.partialResultAction = &AggPartialResultAction{e: e}
+	vars.MemTracker.FallbackOldAndSetNewAction(e.partialResultAction)
+}
+
+func (e *HashAggExec) closePartialResultAction() {
+	if e.partialResultAction != nil {
+		e.partialResultAction.SetFinished()
+		e.partialResultAction = nil
+	}
+}
+
 func failpointError() error {
 	var err error
 	failpoint.Inject("enableAggSpillIntest", func(val failpoint.Value) {
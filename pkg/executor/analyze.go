@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
@@ -107,6 +108,18 @@ func (e *AnalyzeExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 	if err != nil {
 		return err
 	}
+	// the cardinality feedback of the tables is out of date once they're analyzed.
+	defer func() {
+		for _, task := range tasks {
+			cardinality.GlobalFeedbackStore.DeleteTable(getTableIDFromTask(task).TableID)
+		}
+	}()
+	if dbName, tblInfo := e.distAnalyzeTable(tasks, infoSchema); tblInfo != nil {
+		if err = e.runDistAnalyze(ctx, dbName, tblInfo, concurrency); err != nil {
+			return err
+		}
+		return statsHandle.Update(infoSchema)
+	}
 	concurrency = min(len(tasks), concurrency)
 
 	// Start workers with channel to collect results.
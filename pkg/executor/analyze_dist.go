@@ -0,0 +1,118 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/disttask/analyze"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// distAnalyzeTable returns the table to analyze on the distributed task
+// framework, tblInfo is nil if the tasks should run locally.
+// Only analyzing all partitions of a partitioned table with stats version 2
+// in dynamic prune mode runs on the framework, each partition is analyzed in
+// a subtask, and the partition stats are merged into global stats at last.
+func (e *AnalyzeExec) distAnalyzeTable(tasks []*analyzeTask, is infoschema.InfoSchema) (dbName string, tblInfo *model.TableInfo) {
+	vars := e.Ctx().GetSessionVars()
+	if !variable.EnableDistAnalyze.Load() || !variable.EnableDistTask.Load() || vars.InRestrictedSQL ||
+		vars.AnalyzeVersion != statistics.Version2 ||
+		variable.PartitionPruneMode(vars.PartitionPruneMode.Load()) != variable.Dynamic {
+		return "", nil
+	}
+	var tableID int64
+	partitionIDs := make(map[int64]struct{}, len(tasks))
+	for i, task := range tasks {
+		id := getTableIDFromTask(task)
+		if !id.IsPartitionTable() || (i > 0 && id.TableID != tableID) {
+			return "", nil
+		}
+		tableID = id.TableID
+		partitionIDs[id.PartitionID] = struct{}{}
+		dbName = task.job.DBName
+	}
+	tbl, ok := is.TableByID(tableID)
+	if !ok {
+		return "", nil
+	}
+	// the partitions might be locked or specified in the statement.
+	pi := tbl.Meta().GetPartitionInfo()
+	if pi == nil || len(pi.Definitions) != len(partitionIDs) {
+		return "", nil
+	}
+	for _, def := range pi.Definitions {
+		if _, ok := partitionIDs[def.ID]; !ok {
+			return "", nil
+		}
+	}
+	return dbName, tbl.Meta()
+}
+
+// runDistAnalyze analyzes the table on the distributed task framework, and
+// waits for the task to finish.
+func (e *AnalyzeExec) runDistAnalyze(ctx context.Context, dbName string, tblInfo *model.TableInfo, concurrency int) error {
+	vars := e.Ctx().GetSessionVars()
+	opts, filledOpts := e.opts, e.opts
+	if v2Options, ok := e.OptionsMap[tblInfo.ID]; ok {
+		opts, filledOpts = v2Options.RawOpts, v2Options.FilledOpts
+	}
+	taskMeta, err := analyze.NewTaskMeta(vars, dbName, tblInfo, opts, filledOpts)
+	if err != nil {
+		return err
+	}
+	ver, err := e.Ctx().GetStore().CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return err
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnStats)
+	// the task concurrency can't be larger than the CPU count of nodes.
+	cpuCount, err := handle.GetCPUCountOfNode(ctx)
+	if err != nil {
+		return err
+	}
+	task, err := analyze.SubmitTask(ctx, taskMeta, ver.Ver, min(concurrency, cpuCount))
+	if err != nil {
+		return err
+	}
+	logutil.Logger(ctx).Info("analyze table on the distributed task framework",
+		zap.Int64("task-id", task.ID), zap.String("task-key", task.Key))
+	if err = handle.WaitTaskDoneOrPaused(ctx, task.ID); err != nil {
+		if ctx.Err() != nil {
+			cancelCtx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+			if cancelErr := handle.CancelTask(cancelCtx, task.Key); cancelErr != nil {
+				logutil.Logger(ctx).Warn("cancel analyze task failed", zap.String("task-key", task.Key), zap.Error(cancelErr))
+			}
+		}
+		return err
+	}
+
+	if err = recordHistoricalStats(e.Ctx(), tblInfo.ID); err != nil {
+		logutil.BgLogger().Error("record historical stats failed", zap.Error(err))
+	}
+	// Update analyze options to mysql.analyze_options for auto analyze.
+	if err = e.saveV2AnalyzeOpts(); err != nil {
+		vars.StmtCtx.AppendWarning(err)
+	}
+	return nil
+}
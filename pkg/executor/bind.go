@@ -60,7 +60,7 @@ func (e *SQLBindExec) Next(_ context.Context, req *chunk.Chunk) error {
 	case plannercore.OpCaptureBindings:
 		e.captureBindings()
 	case plannercore.OpEvolveBindings:
-		return nil // not support yet
+		return e.evolveBindings()
 	case plannercore.OpReloadBindings:
 		return e.reloadBindings()
 	case plannercore.OpSetBindingStatus:
@@ -155,6 +155,10 @@ func (e *SQLBindExec) captureBindings() {
 	domain.GetDomain(e.Ctx()).BindHandle().CaptureBaselines()
 }
 
+func (e *SQLBindExec) evolveBindings() error {
+	return domain.GetDomain(e.Ctx()).BindHandle().HandleEvolveTasks(true)
+}
+
 func (e *SQLBindExec) reloadBindings() error {
 	return domain.GetDomain(e.Ctx()).BindHandle().LoadFromStorageToCache(true)
 }
@@ -220,6 +220,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildSelectLock(v)
 	case *plannercore.CancelDDLJobs:
 		return b.buildCancelDDLJobs(v)
+	case *plannercore.CancelTask:
+		return b.buildCancelTask(v)
 	case *plannercore.PauseDDLJobs:
 		return b.buildPauseDDLJobs(v)
 	case *plannercore.ResumeDDLJobs:
@@ -314,6 +316,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildCompactTable(v)
 	case *plannercore.AdminShowBDRRole:
 		return b.buildAdminShowBDRRole(v)
+	case *plannercore.CalibrateCostModel:
+		return &CalibrateCostModelExec{BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID())}
 	default:
 		if mp, ok := p.(testutil.MockPhysicalPlan); ok {
 			return mp.GetExecutor()
@@ -335,6 +339,17 @@ func (b *executorBuilder) buildCancelDDLJobs(v *plannercore.CancelDDLJobs) exec.
 	return e
 }
 
+func (b *executorBuilder) buildCancelTask(v *plannercore.CancelTask) exec.Executor {
+	e := &CancelTaskExec{
+		CommandDDLJobsExec: &CommandDDLJobsExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			jobIDs:       v.TaskIDs,
+			execute:      cancelTasks,
+		},
+	}
+	return e
+}
+
 func (b *executorBuilder) buildPauseDDLJobs(v *plannercore.PauseDDLJobs) exec.Executor {
 	e := &PauseDDLJobsExec{
 		CommandDDLJobsExec: &CommandDDLJobsExec{
@@ -515,6 +530,7 @@ func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) exec.Execut
 			indexInfos:   v.IndexInfos,
 			is:           b.is,
 			err:          &atomic.Pointer[error]{},
+			concurrency:  b.ctx.GetSessionVars().CheckTableConcurrency,
 		}
 		return e
 	}
@@ -541,6 +557,7 @@ func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) exec.Execut
 		exitCh:       make(chan struct{}),
 		retCh:        make(chan error, len(readerExecs)),
 		checkIndex:   v.CheckIndex,
+		concurrency:  b.ctx.GetSessionVars().CheckTableConcurrency,
 	}
 	return e
 }
@@ -881,6 +898,8 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) exec.Executor {
 		Extended:              v.Extended,
 		Extractor:             v.Extractor,
 		ImportJobID:           v.ImportJobID,
+		TaskID:                v.TaskID,
+		LoadDataJobID:         v.LoadDataJobID,
 	}
 	if e.Tp == ast.ShowMasterStatus || e.Tp == ast.ShowBinlogStatus {
 		// show master status need start ts.
@@ -904,6 +923,7 @@ func (b *executorBuilder) buildSimple(v *plannercore.Simple) exec.Executor {
 			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), 0),
 			WorkloadType: s.Tp,
 			OptionList:   s.DynamicCalibrateResourceOptionList,
+			Groups:       s.Groups,
 		}
 	case *ast.AddQueryWatchStmt:
 		return &querywatch.AddExecutor{
@@ -916,6 +936,17 @@ func (b *executorBuilder) buildSimple(v *plannercore.Simple) exec.Executor {
 			tp:           s.Tp,
 			jobID:        s.JobID,
 		}
+	case *ast.LoadDataActionStmt:
+		return &LoadDataActionExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, nil, 0),
+			tp:           s.Tp,
+			jobID:        s.JobID,
+		}
+	case *ast.FetchResultStmt:
+		return &FetchResultExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			token:        s.Token,
+		}
 	}
 	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID())
 	base.SetInitCap(chunk.ZeroCapacity)
@@ -1052,11 +1083,15 @@ func (b *executorBuilder) buildLoadData(v *plannercore.LoadData) exec.Executor {
 		return nil
 	}
 
-	return &LoadDataExec{
+	e := &LoadDataExec{
 		BaseExecutor:   base,
 		loadDataWorker: worker,
 		FileLocRef:     v.FileLocRef,
 	}
+	if v.Detached {
+		e.detachedPlan = v
+	}
+	return e
 }
 
 func (b *executorBuilder) buildLoadStats(v *plannercore.LoadStats) exec.Executor {
@@ -1238,10 +1273,16 @@ func (b *executorBuilder) buildSelectInto(v *plannercore.SelectInto) exec.Execut
 	if b.err != nil {
 		return nil
 	}
+	colNames := make([]string, 0, len(v.TargetNames))
+	for _, name := range v.TargetNames {
+		colNames = append(colNames, name.ColName.O)
+	}
 	return &SelectIntoExec{
 		BaseExecutor:   exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), child),
 		intoOpt:        v.IntoOpt,
 		LineFieldsInfo: v.LineFieldsInfo,
+		options:        v.Options,
+		colNames:       colNames,
 	}
 }
 
@@ -2047,6 +2088,7 @@ func (b *executorBuilder) getSnapshot() (kv.Snapshot, error) {
 	snapshot.SetOption(kv.TiKVClientReadTimeout, sessVars.GetTiKVClientReadTimeout())
 	snapshot.SetOption(kv.ResourceGroupName, sessVars.StmtCtx.ResourceGroupName)
 	snapshot.SetOption(kv.ExplicitRequestSourceType, sessVars.ExplicitRequestSourceType)
+	snapshot.SetOption(kv.StmtRUQuota, sessVars.StmtRUQuota)
 
 	if replicaReadType.IsClosestRead() && b.readReplicaScope != kv.GlobalTxnScope {
 		snapshot.SetOption(kv.MatchStoreLabels, []*metapb.StoreLabel{
@@ -2219,13 +2261,19 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableMemoryUsageOpsHistory),
 			strings.ToLower(infoschema.ClusterTableMemoryUsage),
 			strings.ToLower(infoschema.ClusterTableMemoryUsageOpsHistory),
+			strings.ToLower(infoschema.TableMemoryUsageByModule),
+			strings.ToLower(infoschema.ClusterTableMemoryUsageByModule),
 			strings.ToLower(infoschema.TableResourceGroups),
 			strings.ToLower(infoschema.TableRunawayWatches),
 			strings.ToLower(infoschema.TableCheckConstraints),
 			strings.ToLower(infoschema.TableTiDBCheckConstraints),
 			strings.ToLower(infoschema.TableKeywords),
 			strings.ToLower(infoschema.TableTiDBIndexUsage),
-			strings.ToLower(infoschema.ClusterTableTiDBIndexUsage):
+			strings.ToLower(infoschema.ClusterTableTiDBIndexUsage),
+			strings.ToLower(infoschema.TableClusterCapacity),
+			strings.ToLower(infoschema.TableResourceGroupsRuntime),
+			strings.ToLower(infoschema.TableTiDBGlobalTasks),
+			strings.ToLower(infoschema.TableTiDBBackgroundSubtasks):
 			memTracker := memory.NewTracker(v.ID(), -1)
 			memTracker.AttachTo(b.ctx.GetSessionVars().StmtCtx.MemTracker)
 			return &MemTableReaderExec{
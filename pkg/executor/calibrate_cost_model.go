@@ -0,0 +1,181 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	// calibrationRows is the number of rows read by the calibration queries.
+	calibrationRows = 10000
+	// calibrationPadLen is the length of the padding column of the rows.
+	calibrationPadLen = 128
+	// calibrationRowSize is the size of the rows, two bigint columns and the padding column.
+	calibrationRowSize = 8 + 8 + calibrationPadLen
+	// calibrationInsertBatch is the number of rows inserted by one statement.
+	calibrationInsertBatch = 500
+	// calibrationRounds is the times each query is run, the shortest execution time is used to reduce the noise.
+	calibrationRounds = 5
+)
+
+// calibrationMu prevents the calibrations on the same instance from running at the same time, since their
+// queries compete for the resources and make the execution time inaccurate.
+var calibrationMu sync.Mutex
+
+// CalibrateCostModelExec is the executor of `ADMIN CALIBRATE COST MODEL`. It runs some queries on the rows written
+// to mysql.tidb_cost_model_calibration_data, calculates the factors of the cost model from their execution time,
+// and saves the factors to mysql.tidb_cost_model_factors, which is loaded by the planner.
+type CalibrateCostModelExec struct {
+	exec.BaseExecutor
+
+	done bool
+}
+
+// Next implements the Executor Next interface.
+func (e *CalibrateCostModelExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+	if !calibrationMu.TryLock() {
+		return errors.New("another ADMIN CALIBRATE COST MODEL is running on this instance")
+	}
+	defer calibrationMu.Unlock()
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnAdmin)
+	sqlExec := e.Ctx().GetRestrictedSQLExecutor()
+	times, err := runCostCalibration(ctx, sqlExec)
+	if err != nil {
+		return err
+	}
+	factors := plannercore.CalibrateCostFactors(times)
+	logutil.Logger(ctx).Info("calibrate cost model", zap.Any("times", times), zap.Any("factors", factors))
+	if len(factors) == 0 {
+		e.Ctx().GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackError("no cost factor is calibrated, the execution time of the queries is covered by noise"))
+		return nil
+	}
+
+	var sql strings.Builder
+	args := make([]any, 0, 2*len(factors))
+	sql.WriteString("REPLACE INTO mysql.tidb_cost_model_factors (factor_name, factor_value) VALUES ")
+	for i, factor := range factors {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString("(%?, %?)")
+		args = append(args, factor.Name, factor.Value)
+	}
+	if _, _, err = sqlExec.ExecRestrictedSQL(ctx, nil, sql.String(), args...); err != nil {
+		return err
+	}
+	// the factors are used by all the instances, so the others reload them once notified.
+	dom := domain.GetDomain(e.Ctx())
+	if err = dom.ReloadCostModelFactors(func(sctx sessionctx.Context) error {
+		return LoadCostModelFactors(ctx, sctx)
+	}); err != nil {
+		return err
+	}
+	if err = dom.NotifyUpdateCostModelFactors(); err != nil {
+		return err
+	}
+	for _, factor := range factors {
+		req.AppendString(0, factor.Name)
+		req.AppendFloat64(1, factor.Value)
+	}
+	return nil
+}
+
+// runCostCalibration writes the rows of a calibration, runs the queries on them and returns the execution time.
+func runCostCalibration(ctx context.Context, sqlExec sqlexec.RestrictedSQLExecutor) (times plannercore.CostCalibrationTimes, err error) {
+	calibrationID := time.Now().UnixNano()
+	defer func() {
+		// clean up the rows even if the statement is killed.
+		cleanupCtx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnAdmin)
+		if _, _, err := sqlExec.ExecRestrictedSQL(cleanupCtx, nil,
+			"DELETE FROM mysql.tidb_cost_model_calibration_data WHERE calibration_id = %?", calibrationID); err != nil {
+			logutil.Logger(ctx).Warn("clean up the cost model calibration data failed", zap.Int64("calibrationID", calibrationID), zap.Error(err))
+		}
+	}()
+
+	insertSQL := "INSERT INTO mysql.tidb_cost_model_calibration_data (calibration_id, id, pad) VALUES " +
+		strings.TrimSuffix(strings.Repeat("(%?, %?, %?), ", calibrationInsertBatch), ", ")
+	args := make([]any, 0, 3*calibrationInsertBatch)
+	for i := 0; i < calibrationRows; i++ {
+		// shuffle the padding values, so sorting them takes the usual time.
+		args = append(args, calibrationID, i, fmt.Sprintf("%0*d", calibrationPadLen, i*7919%calibrationRows))
+		if len(args) == cap(args) {
+			if _, _, err = sqlExec.ExecRestrictedSQL(ctx, nil, insertSQL, args...); err != nil {
+				return times, err
+			}
+			args = args[:0]
+		}
+	}
+
+	times = plannercore.CostCalibrationTimes{Rows: calibrationRows, RowSize: calibrationRowSize}
+	queries := []struct {
+		sql  string
+		time *time.Duration
+	}{
+		{"SELECT COUNT(*) FROM mysql.tidb_cost_model_calibration_data WHERE calibration_id = %? AND id < 0", &times.Base},
+		{"SELECT COUNT(*) FROM mysql.tidb_cost_model_calibration_data WHERE calibration_id = %?", &times.Count},
+		{"SELECT COUNT(*) FROM mysql.tidb_cost_model_calibration_data WHERE calibration_id = %? AND pad != ''", &times.Filter},
+		{"SELECT id, pad FROM mysql.tidb_cost_model_calibration_data WHERE calibration_id = %?", &times.Read},
+		{"SELECT id, pad FROM mysql.tidb_cost_model_calibration_data WHERE calibration_id = %? ORDER BY pad", &times.Sort},
+	}
+	for _, q := range queries {
+		for i := 0; i < calibrationRounds; i++ {
+			start := time.Now()
+			if _, _, err = sqlExec.ExecRestrictedSQL(ctx, nil, q.sql, calibrationID); err != nil {
+				return times, err
+			}
+			if d := time.Since(start); i == 0 || d < *q.time {
+				*q.time = d
+			}
+		}
+	}
+	return times, nil
+}
+
+// LoadCostModelFactors loads the calibrated factors of the cost model from table mysql.tidb_cost_model_factors.
+func LoadCostModelFactors(ctx context.Context, sctx sessionctx.Context) error {
+	rows, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil,
+		"SELECT HIGH_PRIORITY factor_name, factor_value FROM mysql.tidb_cost_model_factors")
+	if err != nil {
+		return err
+	}
+	factors := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		factors[row.GetString(0)] = row.GetFloat64(1)
+	}
+	plannercore.SetCalibratedCostFactors(factors)
+	return nil
+}
@@ -20,6 +20,8 @@ import (
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/distsql"
+	"github.com/pingcap/tidb/pkg/disttask/checksum"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/model"
@@ -53,6 +55,11 @@ func (e *ChecksumTableExec) Open(ctx context.Context) error {
 		return err
 	}
 
+	vars := e.Ctx().GetSessionVars()
+	if variable.EnableDistChecksum.Load() && variable.EnableDistTask.Load() && !vars.InRestrictedSQL {
+		return e.distChecksum(ctx, concurrency)
+	}
+
 	tasks, err := e.buildTasks()
 	if err != nil {
 		return err
@@ -111,6 +118,44 @@ func (e *ChecksumTableExec) Next(_ context.Context, req *chunk.Chunk) error {
 	return nil
 }
 
+// distChecksum computes the checksum of each table by a task on the
+// distributed task framework, the key ranges of the table and its indexes are
+// computed on multiple nodes.
+func (e *ChecksumTableExec) distChecksum(ctx context.Context, concurrency int) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	// the task concurrency can't be larger than the CPU count of nodes.
+	cpuCount, err := handle.GetCPUCountOfNode(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range e.tables {
+		taskMeta := checksum.NewTaskMeta(t.dbInfo.Name.O, t.tableInfo, t.startTs)
+		task, err := checksum.SubmitTask(ctx, taskMeta, min(concurrency, cpuCount))
+		if err != nil {
+			return err
+		}
+		if err = handle.WaitTaskDoneOrPaused(ctx, task.ID); err != nil {
+			if ctx.Err() != nil {
+				cancelCtx := kv.WithInternalSourceType(context.Background(), kv.InternalDistTask)
+				if cancelErr := handle.CancelTask(cancelCtx, task.Key); cancelErr != nil {
+					logutil.Logger(ctx).Warn("cancel checksum task failed", zap.String("task-key", task.Key), zap.Error(cancelErr))
+				}
+			}
+			return err
+		}
+		result, err := checksum.GetResult(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		t.response = &tipb.ChecksumResponse{
+			Checksum:   result.Checksum,
+			TotalKvs:   result.TotalKvs,
+			TotalBytes: result.TotalBytes,
+		}
+	}
+	return nil
+}
+
 func (e *ChecksumTableExec) buildTasks() ([]*checksumTask, error) {
 	allTasks := make([][]*checksumTask, 0, len(e.tables))
 	taskCnt := 0
@@ -52,6 +52,7 @@ func (c *Compiler) Compile(ctx context.Context, stmtNode ast.StmtNode) (_ *ExecS
 		recoveredErr, ok := r.(error)
 		if !ok || !(exeerrors.ErrMemoryExceedForQuery.Equal(recoveredErr) ||
 			exeerrors.ErrMemoryExceedForInstance.Equal(recoveredErr) ||
+			exeerrors.ErrDiskExceedForQuery.Equal(recoveredErr) ||
 			exeerrors.ErrQueryInterrupted.Equal(recoveredErr) ||
 			exeerrors.ErrMaxExecTimeExceeded.Equal(recoveredErr)) {
 			panic(r)
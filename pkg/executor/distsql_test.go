@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/store/copr"
 	"github.com/pingcap/tidb/pkg/store/mockstore"
 	"github.com/pingcap/tidb/pkg/table/tables"
@@ -43,6 +44,7 @@ import (
 	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/client-go/v2/testutils"
+	"github.com/tikv/client-go/v2/util"
 )
 
 // checkGoroutineExists
@@ -591,3 +593,76 @@ func TestCoprCacheWithoutExecutionInfo(t *testing.T) {
 	tk.MustQuery("select * from t").Check(testkit.Rows("1", "2", "3"))
 	tk.MustQueryWithContext(ctx, "select * from t").Check(testkit.Rows("1", "2", "3"))
 }
+
+func TestStmtRUQuota(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1), (2), (3)")
+	tk.MustExec("create table t2 (a int primary key)")
+	tk.MustExec("insert into t2 values (1), (2), (3)")
+
+	// preset the RU the statement has consumed.
+	ctx := context.WithValue(context.Background(), util.RUDetailsCtxKey, util.NewRUDetailsWith(80, 40, 0))
+	tk.MustQueryWithContext(ctx, "select * from t").Sort().Check(testkit.Rows("1", "2", "3"))
+	tk.MustExec("set @@tidb_stmt_ru_quota = 200")
+	tk.MustQueryWithContext(ctx, "select * from t").Sort().Check(testkit.Rows("1", "2", "3"))
+	tk.MustExec("set @@tidb_stmt_ru_quota = 100")
+	checkQuotaExceeded := func(sql string) {
+		rs, err := tk.ExecWithContext(ctx, sql)
+		if err == nil && rs != nil {
+			_, err = session.GetRows4Test(ctx, tk.Session(), rs)
+			require.NoError(t, rs.Close())
+		}
+		require.Error(t, err, sql)
+		require.Regexp(t, `the statement consumed 12\d\.\d\d RU which exceeds tidb_stmt_ru_quota\(100\)`, err.Error())
+	}
+	checkQuotaExceeded("select * from t")
+	// point get and batch point get.
+	checkQuotaExceeded("select * from t2 where a = 1")
+	checkQuotaExceeded("select * from t2 where a in (1, 2)")
+	// the transaction is not committed.
+	checkQuotaExceeded("insert into t values (4)")
+	tk.MustExec("set @@tidb_stmt_ru_quota = 0")
+	tk.MustQueryWithContext(ctx, "select * from t").Sort().Check(testkit.Rows("1", "2", "3"))
+	tk.MustQueryWithContext(ctx, "select * from t2 where a = 1").Check(testkit.Rows("1"))
+}
+
+func TestAdaptiveDistSQLConcurrency(t *testing.T) {
+	var cluster testutils.Cluster
+	store, dom := testkit.CreateMockStoreAndDomain(t, mockstore.WithClusterInspector(func(c testutils.Cluster) {
+		mockstore.BootstrapWithSingleStore(c)
+		cluster = c
+	}))
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key)")
+	var values []string
+	for i := 0; i < 100; i++ {
+		values = append(values, fmt.Sprintf("(%d)", i))
+	}
+	tk.MustExec("insert t values " + strings.Join(values, ","))
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tableStart := tablecodec.GenTableRecordPrefix(tbl.Meta().ID)
+	cluster.SplitKeys(tableStart, tableStart.PrefixNext(), 10)
+	tk.MustExec("set @@tidb_store_batch_size = 0")
+
+	executionInfo := func() string {
+		for _, row := range tk.MustQuery("explain analyze select * from t").Rows() {
+			if strings.HasPrefix(row[0].(string), "TableReader") {
+				return row[5].(string)
+			}
+		}
+		require.FailNow(t, "TableReader not found")
+		return ""
+	}
+	require.NotContains(t, executionInfo(), "adaptive_concurrency")
+	tk.MustExec("set @@tidb_enable_adaptive_distsql_concurrency = on")
+	require.Contains(t, executionInfo(), "adaptive_concurrency: [0s:2")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("100"))
+	// The concurrency isn't adapted when it's not larger than the initial one.
+	tk.MustExec("set @@tidb_distsql_scan_concurrency = 2")
+	require.NotContains(t, executionInfo(), "adaptive_concurrency")
+}
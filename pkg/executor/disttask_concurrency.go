@@ -0,0 +1,46 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/executor/internal/calibrateresource"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+)
+
+// DistTaskResourceHeadroom returns the RU headroom of the cluster, i.e. the
+// ratio of the RU capacity estimated by hardware that isn't consumed recently,
+// it's used to scale down the concurrency of pending dist tasks submitted to a
+// busy cluster.
+// It's registered as the scheduler.ResourceHeadroomGetter, and returns 1 when
+// resource control is disabled, i.e. the concurrency is kept unchanged.
+func DistTaskResourceHeadroom(ctx context.Context) (float64, error) {
+	if !variable.EnableResourceControl.Load() {
+		return 1, nil
+	}
+	taskMgr, err := fstorage.GetTaskManager()
+	if err != nil {
+		return 0, err
+	}
+	var ratio float64
+	err = taskMgr.WithNewSession(func(se sessionctx.Context) error {
+		ratio, err = calibrateresource.RUHeadroomRatio(ctx, se)
+		return err
+	})
+	return ratio, err
+}
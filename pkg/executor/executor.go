@@ -35,6 +35,8 @@ import (
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/ddl/schematracker"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errctx"
@@ -263,6 +265,41 @@ type CancelDDLJobsExec struct {
 	*CommandDDLJobsExec
 }
 
+// CancelTaskExec represents a cancel distributed task executor.
+type CancelTaskExec struct {
+	*CommandDDLJobsExec
+}
+
+// cancelTasks cancels the pending or running distributed tasks, the scheduler
+// will revert the cancelled tasks and notify the executors to cancel the
+// running subtasks.
+func cancelTasks(_ sessionctx.Context, ids []int64) ([]error, error) {
+	taskManager, err := fstorage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalDistTask)
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		task, err := taskManager.GetTaskBaseByIDWithHistory(ctx, id)
+		if err != nil {
+			if errors.ErrorEqual(err, fstorage.ErrTaskNotFound) {
+				errs[i] = errors.Errorf("task %d not found", id)
+				continue
+			}
+			return nil, err
+		}
+		if task.State != proto.TaskStatePending && task.State != proto.TaskStateRunning {
+			errs[i] = errors.Errorf("task %d is in %s state, only pending or running task can be cancelled", id, task.State)
+			continue
+		}
+		if err = taskManager.CancelTask(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return errs, nil
+}
+
 // PauseDDLJobsExec indicates an Executor for Pause a DDL Job.
 type PauseDDLJobsExec struct {
 	*CommandDDLJobsExec
@@ -848,6 +885,8 @@ type CheckTableExec struct {
 	exitCh     chan struct{}
 	retCh      chan error
 	checkIndex bool
+	// concurrency is the number of indices checked concurrently.
+	concurrency int
 }
 
 // Open implements the Executor Open interface.
@@ -946,7 +985,7 @@ func (e *CheckTableExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 	}
 
 	// The number of table rows is equal to the number of index rows.
-	// TODO: Make the value of concurrency adjustable. And we can consider the number of records.
+	// TODO: We can consider the number of records.
 	if len(e.srcs) == 1 {
 		err = e.checkIndexHandle(ctx, e.srcs[0])
 		if err == nil && e.srcs[0].index.MVIndex {
@@ -958,7 +997,7 @@ func (e *CheckTableExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 	}
 	taskCh := make(chan *IndexLookUpExecutor, len(e.srcs))
 	failure := atomicutil.NewBool(false)
-	concurrency := min(3, len(e.srcs))
+	concurrency := min(e.concurrency, len(e.srcs))
 	var wg util.WaitGroupWrapper
 	for _, src := range e.srcs {
 		taskCh <- src
@@ -1854,6 +1893,10 @@ func ResetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode) (err error) {
 	sc.MemTracker.SessionID.Store(vars.ConnectionID)
 	sc.MemTracker.AttachTo(vars.MemTracker)
 	sc.InitDiskTracker(memory.LabelForSQLText, -1)
+	if vars.DiskQuotaQuery > 0 {
+		sc.DiskTracker.SetBytesLimit(vars.DiskQuotaQuery)
+		sc.DiskTracker.SetActionOnExceed(&disk.PanicOnExceed{Killer: &vars.SQLKiller})
+	}
 	globalConfig := config.GetGlobalConfig()
 	if variable.EnableTmpStorageOnOOM.Load() && sc.DiskTracker != nil {
 		sc.DiskTracker.AttachTo(vars.DiskTracker)
@@ -2145,6 +2188,9 @@ type FastCheckTableExec struct {
 	err        *atomic.Pointer[error]
 	wg         sync.WaitGroup
 	contextCtx context.Context
+	// concurrency is the number of the workers, the rows of the table are
+	// split into handle ranges if there are fewer indices than workers.
+	concurrency int
 }
 
 // Open implements the Executor Open interface.
@@ -2160,6 +2206,9 @@ func (e *FastCheckTableExec) Open(ctx context.Context) error {
 
 type checkIndexTask struct {
 	indexOffset int
+	// handleRange is the condition of the handles to check, it's empty if
+	// the whole table is checked by the task.
+	handleRange string
 }
 
 type checkIndexWorker struct {
@@ -2217,6 +2266,10 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask, _ func(workerpool.Non
 	defer w.e.wg.Done()
 	idxInfo := w.indexInfos[task.indexOffset]
 	bucketSize := int(CheckTableFastBucketSize.Load())
+	var handleRange string
+	if task.handleRange != "" {
+		handleRange = " and " + task.handleRange
+	}
 
 	ctx := kv.WithInternalSourceType(w.e.contextCtx, kv.InternalTxnAdmin)
 
@@ -2327,10 +2380,10 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask, _ func(workerpool.Non
 		}
 		checkOnce = true
 
-		tblQuery := fmt.Sprintf("select /*+ read_from_storage(tikv[%s]) */ bit_xor(%s), %s, count(*) from %s use index() where %s = 0 group by %s", TableName(w.e.dbName, w.e.table.Meta().Name.String()), md5HandleAndIndexCol.String(), groupByKey, TableName(w.e.dbName, w.e.table.Meta().Name.String()), whereKey, groupByKey)
-		idxQuery := fmt.Sprintf("select bit_xor(%s), %s, count(*) from %s use index(`%s`) where %s = 0 group by %s", md5HandleAndIndexCol.String(), groupByKey, TableName(w.e.dbName, w.e.table.Meta().Name.String()), idxInfo.Name, whereKey, groupByKey)
+		tblQuery := fmt.Sprintf("select /*+ read_from_storage(tikv[%s]) */ bit_xor(%s), %s, count(*) from %s use index() where %s = 0%s group by %s", TableName(w.e.dbName, w.e.table.Meta().Name.String()), md5HandleAndIndexCol.String(), groupByKey, TableName(w.e.dbName, w.e.table.Meta().Name.String()), whereKey, handleRange, groupByKey)
+		idxQuery := fmt.Sprintf("select bit_xor(%s), %s, count(*) from %s use index(`%s`) where %s = 0%s group by %s", md5HandleAndIndexCol.String(), groupByKey, TableName(w.e.dbName, w.e.table.Meta().Name.String()), idxInfo.Name, whereKey, handleRange, groupByKey)
 
-		logutil.BgLogger().Info("fast check table by group", zap.String("table name", w.table.Meta().Name.String()), zap.String("index name", idxInfo.Name.String()), zap.Int("times", times), zap.Int("current offset", offset), zap.Int("current mod", mod), zap.String("table sql", tblQuery), zap.String("index sql", idxQuery))
+		logutil.BgLogger().Info("fast check table by group", zap.String("table name", w.table.Meta().Name.String()), zap.String("index name", idxInfo.Name.String()), zap.String("handle range", task.handleRange), zap.Int("times", times), zap.Int("current offset", offset), zap.Int("current mod", mod), zap.String("table sql", tblQuery), zap.String("index sql", idxQuery))
 
 		// compute table side checksum.
 		tableChecksum, err := getCheckSum(w.e.contextCtx, se, tblQuery)
@@ -2412,8 +2465,8 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask, _ func(workerpool.Non
 
 	if meetError {
 		groupByKey := fmt.Sprintf("((cast(%s as signed) - %d) %% %d)", md5Handle.String(), offset, mod)
-		indexSQL := fmt.Sprintf("select %s, %s, %s from %s use index(`%s`) where %s = 0 order by %s", handleColumnField, indexColumnField.String(), md5HandleAndIndexCol.String(), TableName(w.e.dbName, w.e.table.Meta().Name.String()), idxInfo.Name, groupByKey, handleColumnField)
-		tableSQL := fmt.Sprintf("select /*+ read_from_storage(tikv[%s]) */ %s, %s, %s from %s use index() where %s = 0 order by %s", TableName(w.e.dbName, w.e.table.Meta().Name.String()), handleColumnField, indexColumnField.String(), md5HandleAndIndexCol.String(), TableName(w.e.dbName, w.e.table.Meta().Name.String()), groupByKey, handleColumnField)
+		indexSQL := fmt.Sprintf("select %s, %s, %s from %s use index(`%s`) where %s = 0%s order by %s", handleColumnField, indexColumnField.String(), md5HandleAndIndexCol.String(), TableName(w.e.dbName, w.e.table.Meta().Name.String()), idxInfo.Name, groupByKey, handleRange, handleColumnField)
+		tableSQL := fmt.Sprintf("select /*+ read_from_storage(tikv[%s]) */ %s, %s, %s from %s use index() where %s = 0%s order by %s", TableName(w.e.dbName, w.e.table.Meta().Name.String()), handleColumnField, indexColumnField.String(), md5HandleAndIndexCol.String(), TableName(w.e.dbName, w.e.table.Meta().Name.String()), groupByKey, handleRange, handleColumnField)
 
 		idxRow, err := queryToRow(se, indexSQL)
 		if err != nil {
@@ -2576,13 +2629,17 @@ func (e *FastCheckTableExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		e.Ctx().GetSessionVars().OptimizerUseInvisibleIndexes = false
 	}()
 
+	tasks, err := e.buildTasks(ctx)
+	if err != nil {
+		return err
+	}
 	workerPool := workerpool.NewWorkerPool[checkIndexTask]("checkIndex",
-		poolutil.CheckTable, 3, e.createWorker)
+		poolutil.CheckTable, e.concurrency, e.createWorker)
 	workerPool.Start(ctx)
 
-	e.wg.Add(len(e.indexInfos))
-	for i := range e.indexInfos {
-		workerPool.AddTask(checkIndexTask{indexOffset: i})
+	e.wg.Add(len(tasks))
+	for _, task := range tasks {
+		workerPool.AddTask(task)
 	}
 
 	e.wg.Wait()
@@ -2595,6 +2652,114 @@ func (e *FastCheckTableExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 	return *p
 }
 
+// buildTasks builds a task for each index. If there are fewer indices than
+// workers and the table is a non-partitioned table using signed int handles,
+// the rows of the table are split into handle ranges, so that a large table
+// can be checked by all the workers rather than one worker per index.
+// The handles of the partitions are not unique, and the global indices can't
+// filter them without reading the table, so partitioned tables are not split.
+func (e *FastCheckTableExec) buildTasks(ctx context.Context) ([]checkIndexTask, error) {
+	tblInfo := e.table.Meta()
+	var handleRanges []string
+	if parts := e.concurrency / len(e.indexInfos); parts > 1 && !tblInfo.IsCommonHandle && tblInfo.GetPartitionInfo() == nil {
+		handleCol := model.ExtraHandleName.O
+		if tblInfo.PKIsHandle {
+			pkCol := tblInfo.GetPkColInfo()
+			if mysql.HasUnsignedFlag(pkCol.GetFlag()) {
+				handleCol = ""
+			} else {
+				handleCol = pkCol.Name.O
+			}
+		}
+		if handleCol != "" {
+			lo, hi, ok, err := e.handleBounds(ctx, handleCol)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				handleRanges = handleRangeConditions(ColumnName(handleCol), splitHandleRange(lo, hi, parts))
+			}
+		}
+	}
+	if len(handleRanges) == 0 {
+		handleRanges = []string{""}
+	}
+	tasks := make([]checkIndexTask, 0, len(e.indexInfos)*len(handleRanges))
+	for i := range e.indexInfos {
+		for _, r := range handleRanges {
+			tasks = append(tasks, checkIndexTask{indexOffset: i, handleRange: r})
+		}
+	}
+	return tasks, nil
+}
+
+// handleBounds returns the min and max handle of the table, ok is false if the
+// table is empty.
+func (e *FastCheckTableExec) handleBounds(ctx context.Context, handleCol string) (lo, hi int64, ok bool, err error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnAdmin)
+	se, err := e.BaseExecutor.GetSysSession()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	defer e.BaseExecutor.ReleaseSysSession(ctx, se)
+	if snapshotTS := e.Ctx().GetSessionVars().SnapshotTS; snapshotTS != 0 {
+		se.GetSessionVars().SnapshotTS = snapshotTS
+		defer func() {
+			se.GetSessionVars().SnapshotTS = 0
+		}()
+	}
+	tblName := TableName(e.dbName, e.table.Meta().Name.String())
+	sql := fmt.Sprintf("select /*+ read_from_storage(tikv[%s]) */ min(%s), max(%s) from %s use index()", tblName, ColumnName(handleCol), ColumnName(handleCol), tblName)
+	rs, err := se.GetSQLExecutor().ExecuteInternal(ctx, sql)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	defer func() {
+		if err := rs.Close(); err != nil {
+			logutil.BgLogger().Warn("close result set failed", zap.Error(err))
+		}
+	}()
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, 1)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if len(rows) == 0 || rows[0].IsNull(0) {
+		return 0, 0, false, nil
+	}
+	return rows[0].GetInt64(0), rows[0].GetInt64(1), true, nil
+}
+
+// splitHandleRange splits [lo, hi] into at most parts ranges of the same
+// size, and returns the start handles of the ranges except the first one.
+func splitHandleRange(lo, hi int64, parts int) []int64 {
+	span := uint64(hi) - uint64(lo)
+	step := span/uint64(parts) + 1
+	points := make([]int64, 0, parts-1)
+	for i := 1; i < parts; i++ {
+		offset := step * uint64(i)
+		if offset > span {
+			break
+		}
+		points = append(points, int64(uint64(lo)+offset))
+	}
+	return points
+}
+
+// handleRangeConditions returns the conditions of the ranges split by points.
+// The first and the last ranges are unbounded, so the rows written after the
+// bounds are read are still checked.
+func handleRangeConditions(handleCol string, points []int64) []string {
+	if len(points) == 0 {
+		return nil
+	}
+	conds := make([]string, 0, len(points)+1)
+	conds = append(conds, fmt.Sprintf("%s < %d", handleCol, points[0]))
+	for i := 1; i < len(points); i++ {
+		conds = append(conds, fmt.Sprintf("%s >= %d and %s < %d", handleCol, points[i-1], handleCol, points[i]))
+	}
+	return append(conds, fmt.Sprintf("%s >= %d", handleCol, points[len(points)-1]))
+}
+
 // TableName returns `schema`.`table`
 func TableName(schema, table string) string {
 	return fmt.Sprintf("`%s`.`%s`", escapeName(schema), escapeName(table))
@@ -16,6 +16,7 @@ package executor
 
 import (
 	"fmt"
+	"math"
 	"runtime"
 	"strconv"
 	"strings"
@@ -491,3 +492,13 @@ func TestErrLevelsForResetStmtContext(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitHandleRange(t *testing.T) {
+	require.Equal(t, []int64{26, 51, 76}, splitHandleRange(1, 100, 4))
+	require.Equal(t, []int64{2, 3}, splitHandleRange(1, 3, 8))
+	require.Empty(t, splitHandleRange(5, 5, 4))
+	require.Equal(t, []int64{0}, splitHandleRange(math.MinInt64, math.MaxInt64, 2))
+
+	require.Nil(t, handleRangeConditions("`a`", nil))
+	require.Equal(t, []string{"`a` < 26", "`a` >= 26 and `a` < 51", "`a` >= 51"}, handleRangeConditions("`a`", []int64{26, 51}))
+}
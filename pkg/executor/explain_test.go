@@ -160,6 +160,39 @@ func TestExplainAnalyzeExecutionInfo(t *testing.T) {
 	tk.MustExec("drop table if exists lineitem")
 }
 
+func TestExplainAnalyzeTimeBreakdown(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, key(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+
+	executionInfoCol := 5
+	sql := "explain analyze select /*+ HASH_JOIN(t1, t2) */ * from t t1, t t2 where t1.a = t2.b"
+	rows := tk.MustQuery(sql).Rows()
+	for _, row := range rows {
+		require.NotEqual(t, "TimeBreakdown", row[0])
+		require.NotContains(t, row[executionInfoCol], "time_breakdown")
+	}
+
+	tk.MustExec("set @@tidb_explain_analyze_time_breakdown = on")
+	rows = tk.MustQuery(sql).Rows()
+	summary := rows[len(rows)-1]
+	require.Equal(t, "TimeBreakdown", summary[0])
+	require.Regexp(t, `^time_breakdown:\{tidb_cpu:.*, tikv_cpu:.*, network:.*, queue:.*\}$`, summary[executionInfoCol])
+	for _, row := range rows[:len(rows)-1] {
+		info := row[executionInfoCol].(string)
+		if row[3] == "root" {
+			require.Contains(t, info, "time_breakdown:{tidb_cpu:", row[0])
+		} else {
+			require.NotContains(t, info, "time_breakdown", row[0])
+		}
+	}
+	// the summary row is not added without analyze.
+	rows = tk.MustQuery("explain select * from t").Rows()
+	require.NotEqual(t, "TimeBreakdown", rows[len(rows)-1][0])
+}
+
 func checkExecutionInfo(t *testing.T, tk *testkit.TestKit, sql string) {
 	executionInfoCol := 4
 	rows := tk.MustQuery(sql).Rows()
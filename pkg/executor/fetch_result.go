@@ -0,0 +1,78 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"math"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// FetchResultExec represents a `FETCH NEXT FROM 'token'` executor, it returns the next tidb_result_batch_size
+// rows of a result kept in the server, all the rest rows are returned if tidb_result_batch_size is 0.
+type FetchResultExec struct {
+	exec.BaseExecutor
+	token string
+
+	fetcher   plannercore.ResultBatchFetcher
+	remaining int
+	done      bool
+}
+
+var (
+	_ exec.Executor = (*FetchResultExec)(nil)
+)
+
+// Open implements the Executor Open interface.
+func (e *FetchResultExec) Open(context.Context) error {
+	fetcher, ok := e.Ctx().Value(plannercore.ResultBatchFetcherKey).(plannercore.ResultBatchFetcher)
+	if !ok {
+		return errors.Annotatef(mysql.NewErr(mysql.ErrSpCursorNotOpen), "result token %s", e.token)
+	}
+	e.fetcher = fetcher
+	e.remaining = e.Ctx().GetSessionVars().ResultBatchSize
+	if e.remaining == 0 {
+		e.remaining = math.MaxInt
+	}
+	return nil
+}
+
+// Next implements the Executor Next interface.
+func (e *FetchResultExec) Next(_ context.Context, req *chunk.Chunk) (err error) {
+	req.Reset()
+	if e.done || e.remaining == 0 {
+		return nil
+	}
+	vars := e.Ctx().GetSessionVars()
+	maxRows := min(e.remaining, req.RequiredRows())
+	e.done, err = e.fetcher.FetchRows(e.token, req, maxRows)
+	if err != nil {
+		e.done = true
+		vars.LastResultToken = ""
+		return err
+	}
+	e.remaining -= req.NumRows()
+	if e.done {
+		vars.LastResultToken = ""
+	} else {
+		vars.LastResultToken = e.token
+	}
+	return nil
+}
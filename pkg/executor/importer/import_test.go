@@ -306,6 +306,7 @@ func TestGetLocalBackendCfg(t *testing.T) {
 	require.Equal(t, "/tmp", cfg.LocalStoreDir)
 	require.True(t, cfg.DisableAutomaticCompactions)
 	require.Zero(t, cfg.RaftKV2SwitchModeDuration)
+	require.Equal(t, tikvutil.ExplicitTypeLightning, cfg.TaskType)
 
 	c.Plan.IsRaftKV2 = true
 	cfg = c.getLocalBackendCfg("http://1.1.1.1:1234", "/tmp")
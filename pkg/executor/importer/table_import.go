@@ -886,9 +886,12 @@ func checksumTable(ctx context.Context, se sessionctx.Context, plan *Plan, logge
 	}()
 
 	distSQLScanConcurrencyBak := se.GetSessionVars().DistSQLScanConcurrency()
+	requestSourceTypeBak := se.GetSessionVars().ExplicitRequestSourceType
 	defer func() {
 		se.GetSessionVars().SetDistSQLScanConcurrency(distSQLScanConcurrencyBak)
+		se.GetSessionVars().ExplicitRequestSourceType = requestSourceTypeBak
 	}()
+	se.GetSessionVars().ExplicitRequestSourceType = util.ExplicitTypeLightning
 	ctx = util.WithInternalSourceType(checkCtx, tidbkv.InternalImportInto)
 	for i := 0; i < maxErrorRetryCount; i++ {
 		txnErr = func() error {
@@ -24,6 +24,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -31,12 +32,16 @@ import (
 	"github.com/pingcap/kvproto/pkg/deadlock"
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
 	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/tidb/pkg/ddl/ingest"
 	"github.com/pingcap/tidb/pkg/ddl/label"
 	"github.com/pingcap/tidb/pkg/ddl/placement"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/executor/internal/calibrateresource"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/executor/internal/pdhelper"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -82,6 +87,7 @@ import (
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/tikvrpc"
 	"github.com/tikv/client-go/v2/txnkv/txnlock"
+	pdclient "github.com/tikv/pd/client"
 	pd "github.com/tikv/pd/client/http"
 	"go.uber.org/zap"
 )
@@ -194,6 +200,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForClusterMemoryUsage(sctx)
 		case infoschema.TableMemoryUsageOpsHistory:
 			err = e.setDataForMemoryUsageOpsHistory()
+		case infoschema.TableMemoryUsageByModule:
+			err = e.setDataForMemoryUsageByModule(sctx)
+		case infoschema.ClusterTableMemoryUsageByModule:
+			err = e.setDataForClusterMemoryUsageByModule(sctx)
 		case infoschema.ClusterTableMemoryUsageOpsHistory:
 			err = e.setDataForClusterMemoryUsageOpsHistory(sctx)
 		case infoschema.TableResourceGroups:
@@ -210,6 +220,14 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexUsage(sctx, dbs)
 		case infoschema.ClusterTableTiDBIndexUsage:
 			err = e.setDataForClusterIndexUsage(sctx, dbs)
+		case infoschema.TableClusterCapacity:
+			err = e.setDataFromClusterCapacity(sctx)
+		case infoschema.TableResourceGroupsRuntime:
+			err = e.setDataFromResourceGroupsRuntime()
+		case infoschema.TableTiDBGlobalTasks:
+			err = e.setDataFromGlobalTasks(ctx, sctx)
+		case infoschema.TableTiDBBackgroundSubtasks:
+			err = e.setDataFromBackgroundSubtasks(ctx, sctx)
 		}
 		if err != nil {
 			return nil, err
@@ -2659,6 +2677,59 @@ func (e *memtableRetriever) setDataForClusterMemoryUsage(ctx sessionctx.Context)
 	return nil
 }
 
+// setDataForMemoryUsageByModule decomposes the memory usage of the instance into the modules tracked by the
+// memory trackers, the memory which is not tracked by any module is shown as "untracked".
+func (e *memtableRetriever) setDataForMemoryUsageByModule(sctx sessionctx.Context) error {
+	var executorUsage int64
+	if sm := sctx.GetSessionManager(); sm != nil {
+		for _, pi := range sm.ShowProcessList() {
+			if pi.MemTracker != nil {
+				executorUsage += pi.MemTracker.BytesConsumed()
+			}
+		}
+	}
+	var statsCacheUsage int64
+	if dom := domain.GetDomain(sctx); dom != nil && dom.StatsHandle() != nil {
+		statsCacheUsage = dom.StatsHandle().MemConsumed()
+	}
+	var distTaskUsage int64
+	if ingest.LitMemRoot != nil {
+		distTaskUsage = ingest.LitMemRoot.CurrentUsage()
+	}
+	modules := []struct {
+		name    string
+		current int64
+		// maxUsed is nil if the module doesn't record it.
+		maxUsed any
+	}{
+		{name: "executor", current: executorUsage},
+		{name: "analyze", current: GlobalAnalyzeMemoryTracker.BytesConsumed(), maxUsed: GlobalAnalyzeMemoryTracker.MaxConsumed()},
+		{name: "plan_cache", current: plannercore.GlobalPlanCacheMemoryTracker.BytesConsumed(), maxUsed: plannercore.GlobalPlanCacheMemoryTracker.MaxConsumed()},
+		{name: "stats_cache", current: statsCacheUsage},
+		{name: "disttask", current: distTaskUsage},
+	}
+	untracked := int64(memory.ReadMemStats().HeapInuse)
+	for _, m := range modules {
+		e.rows = append(e.rows, types.MakeDatums(m.name, m.current, m.maxUsed))
+		untracked -= m.current
+	}
+	e.rows = append(e.rows, types.MakeDatums("untracked", max(untracked, 0), nil))
+	return nil
+}
+
+func (e *memtableRetriever) setDataForClusterMemoryUsageByModule(ctx sessionctx.Context) error {
+	err := e.setDataForMemoryUsageByModule(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := infoschema.AppendHostInfoToRows(ctx, e.rows)
+	if err != nil {
+		return err
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) setDataForMemoryUsageOpsHistory() error {
 	e.rows = servermemorylimit.GlobalMemoryOpsHistoryManager.GetRows()
 	return nil
@@ -3567,6 +3638,237 @@ func (e *memtableRetriever) setDataFromResourceGroups() error {
 	return nil
 }
 
+// minRUSampleInterval is the minimum interval between two RU consumption samples of a resource group,
+// it avoids calculating a noisy consumption rate when RESOURCE_GROUPS_RUNTIME is queried frequently.
+const minRUSampleInterval = 5 * time.Second
+
+type ruSample struct {
+	consumed float64
+	ts       time.Time
+	// rate is the consumption rate between this sample and the previous one, it's valid if hasRate is true.
+	rate    float64
+	hasRate bool
+}
+
+// ruSamples keeps the last sampled RU consumption of each resource group in this instance,
+// it's used to calculate the recent consumption rate of RESOURCE_GROUPS_RUNTIME.
+var ruSamples = struct {
+	sync.Mutex
+	groups map[string]ruSample
+}{groups: make(map[string]ruSample)}
+
+// sampleRUConsumption samples the RU consumption of resource groups at most once per minRUSampleInterval, and
+// returns the consumption rate of each group between its last two samples. Groups with less than two samples
+// don't have a rate.
+func sampleRUConsumption(consumed map[string]float64, now time.Time) map[string]float64 {
+	ruSamples.Lock()
+	defer ruSamples.Unlock()
+	rates := make(map[string]float64, len(consumed))
+	groups := make(map[string]ruSample, len(consumed))
+	for name, ru := range consumed {
+		sample := ruSample{consumed: ru, ts: now}
+		last, ok := ruSamples.groups[name]
+		// the consumption is reset when the group is recreated, restart sampling.
+		if ok && ru >= last.consumed {
+			if elapsed := now.Sub(last.ts); elapsed < minRUSampleInterval {
+				sample = last
+			} else {
+				sample.rate, sample.hasRate = (ru-last.consumed)/elapsed.Seconds(), true
+			}
+		}
+		if sample.hasRate {
+			rates[name] = sample.rate
+		}
+		groups[name] = sample
+	}
+	ruSamples.groups = groups
+	return rates
+}
+
+func (e *memtableRetriever) setDataFromResourceGroupsRuntime() error {
+	resourceGroups, err := infosync.ListResourceGroups(context.TODO(), pdclient.WithRUStats)
+	if err != nil {
+		return errors.Errorf("failed to access resource group manager, error message is %s", err.Error())
+	}
+	consumed := make(map[string]float64, len(resourceGroups)+1)
+	hasInternalGroup := false
+	for _, group := range resourceGroups {
+		if group.RUStats != nil {
+			consumed[group.Name] = group.RUStats.RRU + group.RUStats.WRU
+		}
+		hasInternalGroup = hasInternalGroup || group.Name == resourcegroup.InternalResourceGroupName
+	}
+	// the RU consumed by internal SQLs of this instance is shown as a pseudo resource group,
+	// unless there is a real resource group with the same name.
+	if !hasInternalGroup {
+		rru, wru := resourcegroup.InternalRU()
+		consumed[resourcegroup.InternalResourceGroupName] = rru + wru
+	}
+	rates := sampleRUConsumption(consumed, time.Now())
+	rows := make([][]types.Datum, 0, len(resourceGroups)+1)
+	for _, group := range resourceGroups {
+		if group.Mode != rmpb.GroupMode_RUMode {
+			rows = append(rows, types.MakeDatums(group.Name, nil, nil, nil, nil, nil))
+			continue
+		}
+		fillrate := unlimitedFillRate
+		isDefaultInReservedSetting := group.Name == resourcegroup.DefaultResourceGroupName && group.RUSettings.RU.Settings.FillRate == math.MaxInt32
+		if !isDefaultInReservedSetting {
+			fillrate = strconv.FormatUint(group.RUSettings.RU.Settings.FillRate, 10)
+		}
+		burstable := burstdisableStr
+		if group.RUSettings.RU.Settings.BurstLimit < 0 {
+			burstable = burstableStr
+		}
+		row := types.MakeDatums(
+			group.Name,
+			fillrate,
+			burstable,
+			group.RUSettings.RU.Tokens,
+			nil,
+			nil,
+		)
+		if ru, ok := consumed[group.Name]; ok {
+			row[4].SetFloat64(ru)
+		}
+		if rate, ok := rates[group.Name]; ok {
+			row[5].SetFloat64(rate)
+		}
+		rows = append(rows, row)
+	}
+	if !hasInternalGroup {
+		name := resourcegroup.InternalResourceGroupName
+		row := types.MakeDatums(name, nil, nil, nil, consumed[name], nil)
+		if rate, ok := rates[name]; ok {
+			row[5].SetFloat64(rate)
+		}
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataFromGlobalTasks(ctx context.Context, sctx sessionctx.Context) error {
+	if !hasPriv(sctx, mysql.ProcessPriv) {
+		return plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("PROCESS")
+	}
+	taskManager, err := fstorage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	tasks, err := taskManager.GetAllTasks(ctx)
+	if err != nil {
+		return err
+	}
+	progresses, err := taskManager.GetAllTaskProgress(ctx)
+	if err != nil {
+		return err
+	}
+	rows := make([][]types.Datum, 0, len(tasks))
+	for _, task := range tasks {
+		row := types.MakeDatums(
+			task.ID,
+			task.Key,
+			string(task.Type),
+			string(task.State),
+			proto.Step2Str(task.Type, task.Step),
+			task.Priority,
+			task.Concurrency,
+			task.TargetScope,
+			task.SchedulerID,
+			goTimeToDatetime(task.CreateTime),
+			goTimeToDatetime(task.StartTime),
+			goTimeToDatetime(task.StateUpdateTime),
+			nil, // ROW_COUNT
+			nil, // BYTES
+			nil, // PROGRESS
+			nil, // ERROR
+		)
+		// the progress of a step is only available after its subtasks are created.
+		if p, ok := progresses[task.ID]; ok && p.Step == task.Step {
+			row[12].SetInt64(p.RowCount)
+			row[13].SetInt64(p.Bytes)
+			row[14].SetFloat64(p.Percent())
+		}
+		if task.Error != nil {
+			row[15].SetString(task.Error.Error(), mysql.DefaultCollationName)
+		}
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataFromBackgroundSubtasks(ctx context.Context, sctx sessionctx.Context) error {
+	if !hasPriv(sctx, mysql.ProcessPriv) {
+		return plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("PROCESS")
+	}
+	taskManager, err := fstorage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	subtasks, err := taskManager.GetAllSubtasksWithError(kv.WithInternalSourceType(ctx, kv.InternalDistTask))
+	if err != nil {
+		return err
+	}
+	rows := make([][]types.Datum, 0, len(subtasks))
+	for _, subtask := range subtasks {
+		row := types.MakeDatums(
+			subtask.ID,
+			subtask.TaskID,
+			string(subtask.Type),
+			proto.Step2Str(subtask.Type, subtask.Step),
+			subtask.Ordinal,
+			string(subtask.State),
+			subtask.ExecID,
+			subtask.Concurrency,
+			goTimeToDatetime(subtask.CreateTime),
+			goTimeToDatetime(subtask.StartTime),
+			goTimeToDatetime(subtask.UpdateTime),
+			subtask.Summary,
+			nil,
+		)
+		if subtask.Error != nil {
+			row[12].SetString(subtask.Error.Error(), mysql.DefaultCollationName)
+		}
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
+
+// goTimeToDatetime converts the go time to a datetime, zero time is converted to NULL.
+func goTimeToDatetime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return types.NewTime(types.FromGoTime(t), mysql.TypeDatetime, types.DefaultFsp)
+}
+
+func (e *memtableRetriever) setDataFromClusterCapacity(sctx sessionctx.Context) error {
+	if !variable.EnableResourceControl.Load() {
+		return infoschema.ErrResourceGroupSupportDisabled
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	if checker != nil && !checker.RequestDynamicVerification(sctx.GetSessionVars().ActiveRoles, "RESOURCE_GROUP_ADMIN", false) {
+		return plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
+	}
+	rows := make([][]types.Datum, 0, len(calibrateresource.StaticWorkloads))
+	for _, workload := range calibrateresource.StaticWorkloads {
+		name := calibrateresource.WorkloadName(workload)
+		quota, err := calibrateresource.StaticCalibrate(sctx, workload)
+		if err != nil {
+			// skip the workload which can't be calibrated, so the others are still shown.
+			sctx.GetSessionVars().StmtCtx.AppendWarning(errors.Annotatef(err, "calibrate workload %s", name))
+			continue
+		}
+		rows = append(rows, types.MakeDatums(name, quota))
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) setDataFromKeywords() error {
 	rows := make([][]types.Datum, 0, len(parser.Keywords))
 	for _, kw := range parser.Keywords {
@@ -16,6 +16,7 @@ package executor
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/parser/model"
@@ -158,3 +159,30 @@ func TestSetDataFromKeywords(t *testing.T) {
 	require.Equal(t, types.NewStringDatum("ADD"), mt.rows[0][0]) // Keyword: ADD
 	require.Equal(t, types.NewIntDatum(1), mt.rows[0][1])        // Reserved: true(1)
 }
+
+func TestSampleRUConsumption(t *testing.T) {
+	now := time.Now()
+	// the first sample doesn't have a rate.
+	rates := sampleRUConsumption(map[string]float64{"rg1": 100, "rg2": 50}, now)
+	require.Empty(t, rates)
+
+	now = now.Add(10 * time.Second)
+	rates = sampleRUConsumption(map[string]float64{"rg1": 600, "rg2": 50, "rg3": 10}, now)
+	require.Equal(t, map[string]float64{"rg1": 50, "rg2": 0}, rates)
+
+	// no sample is taken within the minimum interval, the rate of the last two samples is returned.
+	now = now.Add(time.Second)
+	rates = sampleRUConsumption(map[string]float64{"rg1": 1400, "rg3": 20}, now)
+	require.Equal(t, map[string]float64{"rg1": 50}, rates)
+	now = now.Add(time.Second)
+	rates = sampleRUConsumption(map[string]float64{"rg1": 1600, "rg3": 30}, now)
+	require.Equal(t, map[string]float64{"rg1": 50}, rates)
+	now = now.Add(3 * time.Second)
+	rates = sampleRUConsumption(map[string]float64{"rg1": 1600, "rg3": 60}, now)
+	require.Equal(t, map[string]float64{"rg1": 200, "rg3": 10}, rates)
+
+	// the consumption is reset after the group is recreated.
+	now = now.Add(10 * time.Second)
+	rates = sampleRUConsumption(map[string]float64{"rg1": 10, "rg2": 5}, now)
+	require.Empty(t, rates)
+}
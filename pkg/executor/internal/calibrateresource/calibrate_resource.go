@@ -128,6 +128,8 @@ const (
 	// duration Indicates the supported calibration duration
 	maxDuration = time.Hour * 24
 	minDuration = time.Minute
+	// defaultGroupsHistoryDuration is the history window of `CALIBRATE RESOURCE GROUPS` when no time option is specified.
+	defaultGroupsHistoryDuration = time.Hour
 )
 
 // Executor is used as executor of calibrate resource.
@@ -135,6 +137,7 @@ type Executor struct {
 	OptionList []*ast.DynamicCalibrateResourceOption
 	exec.BaseExecutor
 	WorkloadType ast.CalibrateResourceType
+	Groups       bool
 	done         bool
 }
 
@@ -256,6 +259,9 @@ func (e *Executor) Next(ctx context.Context, req *chunk.Chunk) error {
 		return infoschema.ErrResourceGroupSupportDisabled
 	}
 	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	if e.Groups {
+		return e.groupsCalibrate(ctx, req)
+	}
 	if len(e.OptionList) > 0 {
 		return e.dynamicCalibrate(ctx, req)
 	}
@@ -287,6 +293,60 @@ func (e *Executor) dynamicCalibrate(ctx context.Context, req *chunk.Chunk) error
 	return nil
 }
 
+// groupsCalibrate recommends the RU_PER_SEC of every resource group. The cluster capacity estimated
+// by hardware is split among the groups in proportion to the RU they consumed in the history window.
+// Groups without any consumption in the window get no recommendation.
+func (e *Executor) groupsCalibrate(ctx context.Context, req *chunk.Chunk) error {
+	capacity, err := StaticCalibrate(e.Ctx(), e.WorkloadType)
+	if err != nil {
+		return err
+	}
+	endTs := time.Now()
+	startTs := endTs.Add(-defaultGroupsHistoryDuration)
+	if len(e.OptionList) > 0 {
+		startTs, endTs, err = e.parseCalibrateDuration(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	startTime := startTs.In(e.Ctx().GetSessionVars().Location()).Format(time.DateTime)
+	endTime := endTs.In(e.Ctx().GetSessionVars().Location()).Format(time.DateTime)
+	consumptions, err := getRUPerSecByGroup(ctx, e.Ctx().GetRestrictedSQLExecutor(), startTime, endTime)
+	if err != nil {
+		return err
+	}
+
+	groups := e.Ctx().GetInfoSchema().(infoschema.InfoSchema).AllResourceGroups()
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name.L < groups[j].Name.L
+	})
+	var total float64
+	consumedGroups := 0
+	for _, group := range groups {
+		if consumed, ok := consumptions[group.Name.L]; ok {
+			total += consumed
+			consumedGroups++
+		}
+	}
+	for _, group := range groups {
+		req.AppendString(0, group.Name.O)
+		req.AppendUint64(1, group.RURate)
+		consumed, ok := consumptions[group.Name.L]
+		if !ok {
+			req.AppendNull(2)
+			req.AppendNull(3)
+			continue
+		}
+		req.AppendUint64(2, uint64(math.Round(consumed)))
+		share := 1 / float64(consumedGroups)
+		if total > 0 {
+			share = consumed / total
+		}
+		req.AppendUint64(3, uint64(float64(capacity)*share))
+	}
+	return nil
+}
+
 func (e *Executor) getTiDBQuota(
 	ctx context.Context,
 	exec sqlexec.RestrictedSQLExecutor,
@@ -442,36 +502,73 @@ func (e *Executor) getTiFlashQuota(
 }
 
 func (e *Executor) staticCalibrate(req *chunk.Chunk) error {
-	resourceGroupCtl := domain.GetDomain(e.Ctx()).ResourceGroupsController()
+	quota, err := StaticCalibrate(e.Ctx(), e.WorkloadType)
+	if err != nil {
+		return err
+	}
+	req.AppendUint64(0, quota)
+	return nil
+}
+
+// StaticWorkloads are the workload types that can be calibrated by hardware, in display order.
+var StaticWorkloads = []ast.CalibrateResourceType{
+	ast.TPCC,
+	ast.OLTPREADWRITE,
+	ast.OLTPREADONLY,
+	ast.OLTPWRITEONLY,
+	ast.TPCH10,
+}
+
+// WorkloadName returns the name of the workload used in `CALIBRATE RESOURCE WORKLOAD`.
+func WorkloadName(workload ast.CalibrateResourceType) string {
+	switch workload {
+	case ast.TPCC:
+		return "TPCC"
+	case ast.OLTPREADWRITE:
+		return "OLTP_READ_WRITE"
+	case ast.OLTPREADONLY:
+		return "OLTP_READ_ONLY"
+	case ast.OLTPWRITEONLY:
+		return "OLTP_WRITE_ONLY"
+	case ast.TPCH10:
+		return "TPCH_10"
+	}
+	return ""
+}
+
+// StaticCalibrate estimates the RU capacity of the cluster by hardware for the specified workload.
+// It's shared by `CALIBRATE RESOURCE` and `information_schema.cluster_capacity`.
+func StaticCalibrate(sctx sessionctx.Context, workload ast.CalibrateResourceType) (uint64, error) {
+	resourceGroupCtl := domain.GetDomain(sctx).ResourceGroupsController()
 	// first fetch the ru settings config.
 	if resourceGroupCtl == nil {
-		return errors.New("resource group controller is not initialized")
+		return 0, errors.New("resource group controller is not initialized")
 	}
-	clusterInfo, err := infoschema.GetClusterServerInfo(e.Ctx())
+	clusterInfo, err := infoschema.GetClusterServerInfo(sctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	ruCfg := resourceGroupCtl.GetConfig()
-	if e.WorkloadType == ast.TPCH10 {
-		return staticCalibrateTpch10(req, clusterInfo, ruCfg)
+	if workload == ast.TPCH10 {
+		return staticCalibrateTpch10(clusterInfo, ruCfg)
 	}
 
 	totalKVCPUQuota, err := getTiKVTotalCPUQuota(clusterInfo)
 	if err != nil {
-		return errNoCPUQuotaMetrics.FastGenByArgs(err.Error())
+		return 0, errNoCPUQuotaMetrics.FastGenByArgs(err.Error())
 	}
 	totalTiDBCPUQuota, err := getTiDBTotalCPUQuota(clusterInfo)
 	if err != nil {
-		return errNoCPUQuotaMetrics.FastGenByArgs(err.Error())
+		return 0, errNoCPUQuotaMetrics.FastGenByArgs(err.Error())
 	}
 
 	// The default workload to calculate the RU capacity.
-	if e.WorkloadType == ast.WorkloadNone {
-		e.WorkloadType = ast.TPCC
+	if workload == ast.WorkloadNone {
+		workload = ast.TPCC
 	}
-	baseCost, ok := workloadBaseRUCostMap[e.WorkloadType]
+	baseCost, ok := workloadBaseRUCostMap[workload]
 	if !ok {
-		return errors.Errorf("unknown workload '%T'", e.WorkloadType)
+		return 0, errors.Errorf("unknown workload '%T'", workload)
 	}
 
 	if totalTiDBCPUQuota/baseCost.tidbToKVCPURatio < totalKVCPUQuota {
@@ -483,11 +580,47 @@ func (e *Executor) staticCalibrate(req *chunk.Chunk) error {
 		float64(ruCfg.WriteBaseCost)*float64(baseCost.writeReqCount) +
 		float64(ruCfg.WriteBytesCost)*float64(baseCost.writeBytes)
 	quota := totalKVCPUQuota * ruPerKVCPU
-	req.AppendUint64(0, uint64(quota))
-	return nil
+	return uint64(quota), nil
+}
+
+// recentRUWindow is the window used to estimate the current RU consumption of the cluster.
+const recentRUWindow = time.Minute
+
+// RUHeadroomRatio returns the ratio of the RU capacity estimated by hardware that isn't
+// consumed in the recent window, the result is in [0, 1].
+func RUHeadroomRatio(ctx context.Context, sctx sessionctx.Context) (float64, error) {
+	capacity, err := StaticCalibrate(sctx, ast.WorkloadNone)
+	if err != nil {
+		return 0, err
+	}
+	loc := sctx.GetSessionVars().Location()
+	endTs := time.Now()
+	startTime := endTs.Add(-recentRUWindow).In(loc).Format(time.DateTime)
+	endTime := endTs.In(loc).Format(time.DateTime)
+	rus, err := getRUPerSec(ctx, sctx, sctx.GetRestrictedSQLExecutor(), startTime, endTime)
+	if err != nil {
+		return 0, err
+	}
+	return headroomRatio(capacity, rus), nil
+}
+
+// headroomRatio returns the ratio of capacity left by the average consumption.
+func headroomRatio(capacity uint64, consumption *timeSeriesValues) float64 {
+	if capacity == 0 {
+		return 0
+	}
+	if len(consumption.vals) == 0 {
+		return 1
+	}
+	var total float64
+	for _, v := range consumption.vals {
+		total += v.val
+	}
+	used := total / float64(len(consumption.vals))
+	return math.Max(0, math.Min(1, 1-used/float64(capacity)))
 }
 
-func staticCalibrateTpch10(req *chunk.Chunk, clusterInfo []infoschema.ServerInfo, ruCfg *resourceControlClient.RUConfig) error {
+func staticCalibrateTpch10(clusterInfo []infoschema.ServerInfo, ruCfg *resourceControlClient.RUConfig) (uint64, error) {
 	// TPCH10 only considers the resource usage of the TiFlash including cpu and read bytes. Others are ignored.
 	// cpu usage: 105494.666484 / 20 / 20 = 263.74
 	// read bytes: 401799161689.0 / 20 / 20 = 1004497904.22
@@ -496,11 +629,10 @@ func staticCalibrateTpch10(req *chunk.Chunk, clusterInfo []infoschema.ServerInfo
 	ruPerCPU := float64(ruCfg.CPUMsCost)*cpuTimePerCPUPerSec + float64(ruCfg.ReadBytesCost)*readBytesPerCPUPerSec
 	totalTiFlashLogicalCores, err := getTiFlashLogicalCores(clusterInfo)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	quota := totalTiFlashLogicalCores * ruPerCPU
-	req.AppendUint64(0, uint64(quota))
-	return nil
+	return uint64(quota), nil
 }
 
 func getTiDBTotalCPUQuota(clusterInfo []infoschema.ServerInfo) (float64, error) {
@@ -590,6 +722,23 @@ func getRUPerSec(ctx context.Context, sctx sessionctx.Context, exec sqlexec.Rest
 	return getValuesFromMetrics(ctx, sctx, exec, query)
 }
 
+// getRUPerSecByGroup returns the average RU consumption per second of each resource group, keyed by the lower-case group name.
+func getRUPerSecByGroup(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, startTime, endTime string) (map[string]float64, error) {
+	query := fmt.Sprintf("SELECT name, avg(value) FROM METRICS_SCHEMA.resource_manager_resource_unit_by_group where time >= '%s' and time <= '%s' GROUP BY name", startTime, endTime)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession}, query)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ret := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		if row.IsNull(0) || row.IsNull(1) {
+			continue
+		}
+		ret[strings.ToLower(row.GetString(0))] = row.GetFloat64(1)
+	}
+	return ret, nil
+}
+
 func getComponentCPUUsagePerSec(ctx context.Context, sctx sessionctx.Context, exec sqlexec.RestrictedSQLExecutor, component, startTime, endTime string) (*timeSeriesValues, error) {
 	query := fmt.Sprintf("SELECT time, sum(value) FROM METRICS_SCHEMA.process_cpu_usage where time >= '%s' and time <= '%s' and job like '%%%s' GROUP BY time ORDER BY time asc", startTime, endTime, component)
 	return getValuesFromMetrics(ctx, sctx, exec, query)
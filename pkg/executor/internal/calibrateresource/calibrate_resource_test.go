@@ -77,6 +77,11 @@ func TestCalibrateResource(t *testing.T) {
 	err = rs.Next(context.Background(), rs.NewChunk(nil))
 	require.ErrorContains(t, err, "no server with type 'tikv' is found")
 
+	// the workloads which can't be calibrated are skipped with warnings.
+	tk.MustQuery("SELECT * FROM information_schema.cluster_capacity").Check(testkit.Rows("TPCH_10 0"))
+	require.Len(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings(), 4)
+	tk.MustQuery("SHOW WARNINGS").CheckContain("no server with type 'tikv' is found")
+
 	// error sql
 	_, err = tk.Exec("CALIBRATE RESOURCE WORKLOAD tpcc START_TIME '2020-02-12 10:35:00'")
 	require.Error(t, err)
@@ -149,6 +154,41 @@ tiflash_proxy_tikv_server_cpu_cores_quota 20
 	tk.MustQueryWithContext(ctx, "CALIBRATE RESOURCE WORKLOAD OLTP_READ_WRITE").Check(testkit.Rows("55823"))
 	tk.MustQueryWithContext(ctx, "CALIBRATE RESOURCE WORKLOAD OLTP_READ_ONLY").Check(testkit.Rows("34926"))
 	tk.MustQueryWithContext(ctx, "CALIBRATE RESOURCE WORKLOAD OLTP_WRITE_ONLY").Check(testkit.Rows("109776"))
+	tk.MustQueryWithContext(ctx, "SELECT * FROM information_schema.cluster_capacity").Check(testkit.Rows(
+		"TPCC 69768",
+		"OLTP_READ_WRITE 55823",
+		"OLTP_READ_ONLY 34926",
+		"OLTP_WRITE_ONLY 109776",
+		"TPCH_10 0",
+	))
+	tk.MustQueryWithContext(ctx, "SELECT ru_capacity FROM information_schema.cluster_capacity WHERE workload = 'OLTP_READ_ONLY'").Check(testkit.Rows("34926"))
+
+	// recommend RU_PER_SEC of each resource group
+	tk.MustExec("CREATE RESOURCE GROUP rg1 RU_PER_SEC = 1000")
+	tk.MustExec("CREATE RESOURCE GROUP rg2 RU_PER_SEC = 2000")
+	tk.MustExec("CREATE RESOURCE GROUP rg3 RU_PER_SEC = 3000")
+	mockData["resource_manager_resource_unit_by_group"] = [][]types.Datum{
+		types.MakeDatums(datetimeBeforeNow(3*time.Minute), "rg1", 250.0),
+		types.MakeDatums(datetimeBeforeNow(2*time.Minute), "rg1", 350.0),
+		types.MakeDatums(datetimeBeforeNow(3*time.Minute), "rg2", 100.0),
+		types.MakeDatums(datetimeBeforeNow(2*time.Minute), "rg2", 100.0),
+	}
+	tk.MustQueryWithContext(ctx, "CALIBRATE RESOURCE GROUPS WORKLOAD TPCC").Check(testkit.Rows(
+		"default 2147483647 <nil> <nil>",
+		"rg1 1000 300 52326",
+		"rg2 2000 100 17442",
+		"rg3 3000 <nil> <nil>",
+	))
+	tk.MustQueryWithContext(ctx, "CALIBRATE RESOURCE GROUPS DURATION '10m'").Check(testkit.Rows(
+		"default 2147483647 <nil> <nil>",
+		"rg1 1000 300 52326",
+		"rg2 2000 100 17442",
+		"rg3 3000 <nil> <nil>",
+	))
+	delete(mockData, "resource_manager_resource_unit_by_group")
+	tk.MustExec("DROP RESOURCE GROUP rg1")
+	tk.MustExec("DROP RESOURCE GROUP rg2")
+	tk.MustExec("DROP RESOURCE GROUP rg3")
 
 	// change total tidb cpu to less than tikv_cpu_quota
 	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/executor/internal/calibrateresource/mockGOMAXPROCS", "return(8)"))
@@ -20,6 +20,7 @@ import (
 	"io"
 	"math"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -74,6 +75,10 @@ type LoadDataExec struct {
 
 	// fields for loading local file
 	infileReader io.ReadCloser
+
+	// detachedPlan is set if the statement is submitted as a background job.
+	detachedPlan *plannercore.LoadData
+	jobSubmitted bool
 }
 
 // Open implements the Executor interface.
@@ -111,7 +116,10 @@ func (e *LoadDataExec) closeLocalReader(originalErr error) error {
 }
 
 // Next implements the Executor Next interface.
-func (e *LoadDataExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
+func (e *LoadDataExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
+	if e.detachedPlan != nil {
+		return e.submitJob(ctx, req)
+	}
 	switch e.FileLocRef {
 	case ast.FileLocServerOrRemote:
 		return e.loadDataWorker.loadRemote(ctx)
@@ -131,6 +139,25 @@ func (e *LoadDataExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 	return nil
 }
 
+// submitJob submits the detached job and returns its ID.
+func (e *LoadDataExec) submitJob(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.jobSubmitted {
+		return nil
+	}
+	e.jobSubmitted = true
+	// check the data files before submitting, so a wrong path fails the statement rather than the job.
+	if err := e.loadDataWorker.controller.InitDataFiles(ctx); err != nil {
+		return err
+	}
+	jobID, err := submitLoadDataJob(ctx, e.Ctx(), e.detachedPlan)
+	if err != nil {
+		return err
+	}
+	req.AppendInt64(0, jobID)
+	return nil
+}
+
 type planInfo struct {
 	ID          int
 	Columns     []*ast.ColumnName
@@ -620,6 +647,9 @@ func (w *commitWorker) commitOneTask(ctx context.Context, task commitTask) error
 		logutil.Logger(ctx).Error("commit error CheckAndInsert", zap.Error(err))
 		return err
 	}
+	if loadedRows, ok := ctx.Value(loadDataJobProgressKey).(*atomic.Uint64); ok {
+		loadedRows.Add(task.cnt)
+	}
 	failpoint.Inject("commitOneTaskErr", func() {
 		failpoint.Return(errors.New("mock commit one task error"))
 	})
@@ -0,0 +1,298 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// The states of the detached LOAD DATA jobs in mysql.tidb_load_data_jobs.
+const (
+	loadDataJobRunning  = "running"
+	loadDataJobFinished = "finished"
+	loadDataJobFailed   = "failed"
+	loadDataJobCanceled = "canceled"
+)
+
+// loadDataJobUpdateInterval is the interval for a detached LOAD DATA job to record its progress and check whether
+// it's canceled.
+const loadDataJobUpdateInterval = 5 * time.Second
+
+// loadDataJobOrphanTimeout is the duration after which a running job which hasn't recorded its progress is taken as
+// orphaned, such as when the TiDB instance running it restarts with the same address.
+const loadDataJobOrphanTimeout = 20 * loadDataJobUpdateInterval
+
+// loadDataJobProgressKey is the context key of the number of the rows loaded by a detached LOAD DATA job.
+type loadDataJobProgressKeyType struct{}
+
+var loadDataJobProgressKey loadDataJobProgressKeyType
+
+// submitLoadDataJob records a running job in mysql.tidb_load_data_jobs and starts it in the background, it returns
+// the job ID without waiting for the job. The job runs the statement without the DETACHED option in a new session,
+// so it outlives the connection which submits it. The job is canceled by CANCEL LOAD DATA JOB or when the domain is
+// closed, and it's taken as failed by failOrphanedLoadDataJobs if the TiDB instance running it exits.
+func submitLoadDataJob(ctx context.Context, userSctx sessionctx.Context, plan *plannercore.LoadData) (int64, error) {
+	var instance string
+	serverInfo, err := infosync.GetServerInfo()
+	if err != nil {
+		logutil.Logger(ctx).Warn("failed to get server info", zap.Error(err))
+		instance = "unknown"
+	} else {
+		instance = net.JoinHostPort(serverInfo.IP, strconv.Itoa(int(serverInfo.Port)))
+	}
+
+	metaSe, err := CreateSession(userSctx)
+	if err != nil {
+		return 0, err
+	}
+	loadSe, err := CreateSession(userSctx)
+	if err != nil {
+		CloseSession(metaSe)
+		return 0, err
+	}
+	const insertJob = `INSERT INTO mysql.tidb_load_data_jobs (table_schema, table_name, data_source, created_by, state, instance)
+		VALUES (%?, %?, %?, %?, %?, %?)`
+	_, err = execLoadDataJobSQL(metaSe, insertJob, plan.Table.Schema.O, plan.Table.Name.O, ast.RedactURL(plan.Path),
+		userSctx.GetSessionVars().User.String(), loadDataJobRunning, instance)
+	var rows []chunk.Row
+	if err == nil {
+		rows, err = execLoadDataJobSQL(metaSe, "SELECT LAST_INSERT_ID()")
+	}
+	if err != nil {
+		CloseSession(metaSe)
+		CloseSession(loadSe)
+		return 0, err
+	}
+	jobID := rows[0].GetInt64(0)
+
+	// The job runs with the SQL mode and the time zone of the user session, which decide how the data is converted,
+	// and it's limited by the resource group and the memory quota of the user session.
+	userVars, loadVars := userSctx.GetSessionVars(), loadSe.GetSessionVars()
+	loadVars.SQLMode = userVars.SQLMode
+	loadVars.TimeZone = userVars.TimeZone
+	loadVars.ResourceGroupName = userVars.ResourceGroupName
+	loadVars.MemQuotaQuery = userVars.MemQuotaQuery
+	run := func(ctx context.Context) {
+		runLoadDataJob(ctx, jobID, metaSe, loadSe, plan.JobStmt)
+	}
+	if dom := domain.GetDomain(userSctx); dom != nil {
+		dom.RunInBackground(run, fmt.Sprintf("loadDataJob-%d", jobID))
+	} else {
+		go run(context.Background())
+	}
+	return jobID, nil
+}
+
+// runLoadDataJob runs the job statement in loadSe, and records the progress and the result of the job in metaSe.
+// The job is stopped when ctx is done. Both sessions are closed after the job finishes.
+func runLoadDataJob(ctx context.Context, jobID int64, metaSe, loadSe sessionctx.Context, stmt string) {
+	defer CloseSession(metaSe)
+	defer CloseSession(loadSe)
+	logger := logutil.BgLogger().With(zap.Int64("jobID", jobID))
+	ctx, cancel := context.WithCancel(kv.WithInternalSourceType(ctx, kv.InternalLoadData))
+	defer cancel()
+
+	var loadedRows atomic.Uint64
+	done := make(chan error, 1)
+	go func() {
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("detached load data job panicked", zap.Any("r", r), zap.Stack("stack"))
+				err = util.GetRecoverError(r)
+			}
+			done <- err
+		}()
+		var rs sqlexec.RecordSet
+		rs, err = loadSe.GetSQLExecutor().ExecuteInternal(context.WithValue(ctx, loadDataJobProgressKey, &loadedRows), stmt)
+		if rs != nil {
+			terror.Log(rs.Close())
+		}
+	}()
+
+	updateInterval := loadDataJobUpdateInterval
+	failpoint.Inject("loadDataJobUpdateInterval", func(val failpoint.Value) {
+		updateInterval = time.Duration(val.(int)) * time.Millisecond
+	})
+	ticker := time.NewTicker(updateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			finishLoadDataJob(logger, metaSe, jobID, loadedRows.Load(), loadSe.GetSessionVars().StmtCtx.GetMessage(), err)
+			return
+		case <-ticker.C:
+			canceled, err := updateLoadDataJob(metaSe, jobID, loadedRows.Load())
+			if err != nil {
+				logger.Warn("failed to update detached load data job", zap.Error(err))
+				continue
+			}
+			if canceled {
+				logger.Info("detached load data job is canceled")
+				cancel()
+			}
+		}
+	}
+}
+
+// updateLoadDataJob records the loaded rows of a running job, it returns true if the job is canceled.
+func updateLoadDataJob(se sessionctx.Context, jobID int64, loadedRows uint64) (bool, error) {
+	rows, err := execLoadDataJobSQL(se, "SELECT state FROM mysql.tidb_load_data_jobs WHERE id = %?", jobID)
+	if err != nil {
+		return false, err
+	}
+	if len(rows) == 0 || rows[0].GetEnum(0).String() == loadDataJobCanceled {
+		return true, nil
+	}
+	_, err = execLoadDataJobSQL(se, `UPDATE mysql.tidb_load_data_jobs SET loaded_rows = %?, update_time = NOW(6)
+		WHERE id = %? AND state = %?`, loadedRows, jobID, loadDataJobRunning)
+	return false, err
+}
+
+// finishLoadDataJob records the result of a job. The job is finished if the statement succeeds even if it's canceled
+// meanwhile, since the data is committed. A failed job keeps the canceled state if it's canceled.
+func finishLoadDataJob(logger *zap.Logger, se sessionctx.Context, jobID int64, loadedRows uint64, message string, runErr error) {
+	var err error
+	if runErr == nil {
+		logger.Info("detached load data job finished", zap.Uint64("loadedRows", loadedRows))
+		_, err = execLoadDataJobSQL(se, `UPDATE mysql.tidb_load_data_jobs
+			SET state = %?, loaded_rows = %?, result_message = %?, update_time = NOW(6), end_time = NOW(6) WHERE id = %?`,
+			loadDataJobFinished, loadedRows, message, jobID)
+	} else {
+		logger.Warn("detached load data job failed", zap.Uint64("loadedRows", loadedRows), zap.Error(runErr))
+		_, err = execLoadDataJobSQL(se, `UPDATE mysql.tidb_load_data_jobs
+			SET state = IF(state = %?, state, %?), loaded_rows = %?, result_message = %?, update_time = NOW(6), end_time = NOW(6)
+			WHERE id = %?`, loadDataJobCanceled, loadDataJobFailed, loadedRows, runErr.Error(), jobID)
+	}
+	if err != nil {
+		logger.Warn("failed to finish detached load data job", zap.Error(err))
+	}
+}
+
+// failOrphanedLoadDataJobs marks the running jobs as failed if the TiDB instances running them have exited, or they
+// haven't recorded their progress for loadDataJobOrphanTimeout, since the jobs don't survive the instances.
+func failOrphanedLoadDataJobs(ctx context.Context, se sessionctx.Context) error {
+	servers, err := infosync.GetAllServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, `UPDATE mysql.tidb_load_data_jobs SET state = %?, result_message = %?, end_time = NOW(6)
+		WHERE state = %? AND (IFNULL(update_time, create_time) < DATE_SUB(NOW(6), INTERVAL %? SECOND)`,
+		loadDataJobFailed, "the TiDB instance running the job has exited", loadDataJobRunning,
+		int64(loadDataJobOrphanTimeout/time.Second))
+	if len(servers) > 0 {
+		sql.WriteString(" OR instance NOT IN (")
+		first := true
+		for _, server := range servers {
+			if !first {
+				sql.WriteString(", ")
+			}
+			first = false
+			sqlescape.MustFormatSQL(sql, "%?", net.JoinHostPort(server.IP, strconv.Itoa(int(server.Port))))
+		}
+		// the instance is unknown if the server info isn't got when the job is submitted.
+		sql.WriteString(") AND instance != 'unknown'")
+	}
+	sql.WriteString(")")
+	_, err = execLoadDataJobSQL(se, sql.String())
+	return err
+}
+
+func execLoadDataJobSQL(se sessionctx.Context, sql string, args ...any) ([]chunk.Row, error) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalLoadData)
+	rows, _, err := se.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession}, sql, args...)
+	return rows, err
+}
+
+// LoadDataActionExec represents a LOAD DATA job action executor.
+type LoadDataActionExec struct {
+	exec.BaseExecutor
+	tp    ast.LoadDataActionTp
+	jobID int64
+}
+
+var (
+	_ exec.Executor = (*LoadDataActionExec)(nil)
+)
+
+// Next implements the Executor Next interface.
+// The job is marked as canceled at once, it stops within loadDataJobUpdateInterval and its changes are rolled back.
+func (e *LoadDataActionExec) Next(_ context.Context, _ *chunk.Chunk) error {
+	// we use a new session, user ctx might not have enough privileges.
+	se, err := CreateSession(e.Ctx())
+	if err != nil {
+		return err
+	}
+	defer CloseSession(se)
+	job, err := getLoadDataJob(e.Ctx(), se, e.jobID)
+	if err != nil {
+		return err
+	}
+	if job.GetEnum(1).String() != loadDataJobRunning {
+		return exeerrors.ErrLoadDataInvalidOperation.FastGenByArgs("CANCEL")
+	}
+	_, err = execLoadDataJobSQL(se, `UPDATE mysql.tidb_load_data_jobs SET state = %?, end_time = NOW(6) WHERE id = %? AND state = %?`,
+		loadDataJobCanceled, e.jobID, loadDataJobRunning)
+	return err
+}
+
+// getLoadDataJob returns the created_by and the state of the job, users without SUPER privilege can only get their own jobs.
+func getLoadDataJob(userSctx, se sessionctx.Context, jobID int64) (chunk.Row, error) {
+	rows, err := execLoadDataJobSQL(se, "SELECT created_by, state FROM mysql.tidb_load_data_jobs WHERE id = %?", jobID)
+	if err != nil {
+		return chunk.Row{}, err
+	}
+	if len(rows) == 0 {
+		return chunk.Row{}, exeerrors.ErrLoadDataJobNotFound.GenWithStackByArgs(jobID)
+	}
+	if !hasSuperPriv(userSctx) && rows[0].GetString(0) != userSctx.GetSessionVars().User.String() {
+		return chunk.Row{}, plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+	}
+	return rows[0], nil
+}
+
+func hasSuperPriv(sctx sessionctx.Context) bool {
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil {
+		return pm.RequestVerification(sctx.GetSessionVars().ActiveRoles, "", "", "", mysql.SuperPriv)
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/property"
+	"github.com/pingcap/tidb/pkg/planner/util/optimizetrace"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+)
+
+// memoryQueuePollInterval is the interval for the queued statements to check the memory usage.
+const memoryQueuePollInterval = 100 * time.Millisecond
+
+// memoryAdmissionQueue queues the start of the expensive statements while the memory usage of the instance
+// is above the watermark, rather than letting them run and be killed by tidb_server_memory_limit later.
+// The queued statements are admitted one at a time in the order they come, and each admission waits for
+// the memory usage to be checked again, so they don't start together once the memory usage drops.
+type memoryAdmissionQueue struct {
+	mu      sync.Mutex
+	waiters *list.List
+	// heapInuse returns the memory usage of the instance.
+	heapInuse func() uint64
+}
+
+var stmtMemoryQueue = newMemoryAdmissionQueue(func() uint64 {
+	return memory.ReadMemStats().HeapInuse
+})
+
+func newMemoryAdmissionQueue(heapInuse func() uint64) *memoryAdmissionQueue {
+	return &memoryAdmissionQueue{waiters: list.New(), heapInuse: heapInuse}
+}
+
+// len returns the number of the queued statements.
+func (q *memoryAdmissionQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.waiters.Len()
+}
+
+// mayQueue returns whether a statement coming now may be queued, that is, the queue is not empty
+// or the memory usage is above watermark.
+func (q *memoryAdmissionQueue) mayQueue(watermark uint64) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.mayQueueLocked(watermark)
+}
+
+func (q *memoryAdmissionQueue) mayQueueLocked(watermark uint64) bool {
+	return q.waiters.Len() > 0 || q.heapInuse() > watermark
+}
+
+// wait blocks until the statement is at the head of the queue and the memory usage is not above watermark.
+// It returns an error if the statement is killed or it has been queued longer than timeout.
+func (q *memoryAdmissionQueue) wait(killer *sqlkiller.SQLKiller, watermark uint64, timeout time.Duration) error {
+	q.mu.Lock()
+	// The statement can't jump the queue even if the memory usage has dropped.
+	if !q.mayQueueLocked(watermark) {
+		q.mu.Unlock()
+		return nil
+	}
+	e := q.waiters.PushBack(struct{}{})
+	metrics.StmtMemoryQueueLength.Set(float64(q.waiters.Len()))
+	q.mu.Unlock()
+	defer func() {
+		q.mu.Lock()
+		q.waiters.Remove(e)
+		metrics.StmtMemoryQueueLength.Set(float64(q.waiters.Len()))
+		q.mu.Unlock()
+	}()
+
+	start := time.Now()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(memoryQueuePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			return errors.Trace(exeerrors.ErrStmtMemoryQueueTimeout.GenWithStackByArgs(time.Since(start).Round(time.Millisecond).String()))
+		}
+		if err := killer.HandleSignal(); err != nil {
+			return err
+		}
+		q.mu.Lock()
+		isHead := q.waiters.Front() == e
+		q.mu.Unlock()
+		if isHead && q.heapInuse() <= watermark {
+			return nil
+		}
+	}
+}
+
+// checkMemoryAdmission queues the statement before it starts if its plan cost is not less than
+// tidb_server_memory_queue_cost_threshold, and the memory usage of the instance is above
+// tidb_server_memory_queue_watermark of tidb_server_memory_limit.
+func (a *ExecStmt) checkMemoryAdmission() error {
+	sessVars := a.Ctx.GetSessionVars()
+	ratio := variable.ServerMemoryQueueWatermark.Load()
+	limit := memory.ServerMemoryLimit.Load()
+	if ratio == 0 || limit == 0 || sessVars.InRestrictedSQL {
+		return nil
+	}
+	switch a.StmtNode.(type) {
+	case *ast.ExecuteStmt, ast.DMLNode:
+	default:
+		return nil
+	}
+	watermark := uint64(float64(limit) * ratio)
+	// Computing the plan cost is not free, skip it when the statement won't be queued anyway.
+	if !stmtMemoryQueue.mayQueue(watermark) {
+		return nil
+	}
+	if getPlanCost(a.Plan) < variable.ServerMemoryQueueCostThreshold.Load() {
+		return nil
+	}
+	return stmtMemoryQueue.wait(&sessVars.SQLKiller, watermark, variable.ServerMemoryQueueTimeout.Load())
+}
+
+// getPlanCost returns the cost of the physical plan of the statement, it's 0 if the statement has no
+// physical plan, e.g. INSERT ... VALUES.
+func getPlanCost(p base.Plan) float64 {
+	var pp base.PhysicalPlan
+	switch x := p.(type) {
+	case *plannercore.Insert:
+		pp = x.SelectPlan
+	case *plannercore.Update:
+		pp = x.SelectPlan
+	case *plannercore.Delete:
+		pp = x.SelectPlan
+	case base.PhysicalPlan:
+		pp = x
+	}
+	if pp == nil {
+		return 0
+	}
+	cost, err := plannercore.GetPlanCost(pp, property.RootTaskType, optimizetrace.NewDefaultPlanCostOption())
+	if err != nil {
+		return 0
+	}
+	return cost
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryAdmissionQueue(t *testing.T) {
+	var heapInuse atomic.Uint64
+	q := newMemoryAdmissionQueue(heapInuse.Load)
+	var killer sqlkiller.SQLKiller
+
+	// not above the watermark.
+	heapInuse.Store(100)
+	require.False(t, q.mayQueue(100))
+	require.NoError(t, q.wait(&killer, 100, time.Second))
+
+	// timeout.
+	heapInuse.Store(101)
+	require.True(t, q.mayQueue(100))
+	err := q.wait(&killer, 100, 150*time.Millisecond)
+	require.True(t, exeerrors.ErrStmtMemoryQueueTimeout.Equal(err), err)
+	require.Equal(t, 0, q.len())
+
+	// killed.
+	killer.SendKillSignal(sqlkiller.QueryInterrupted)
+	err = q.wait(&killer, 100, time.Minute)
+	require.True(t, exeerrors.ErrQueryInterrupted.Equal(err), err)
+	killer.Reset()
+
+	// the statements are admitted in the order they come.
+	admitted := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		go func(i int) {
+			require.NoError(t, q.wait(&killer, 50, time.Minute))
+			admitted <- i
+		}(i)
+		require.Eventually(t, func() bool { return q.len() == i+1 }, time.Second, 10*time.Millisecond)
+	}
+	// a new statement can't jump the queue even if the memory usage is not above its watermark.
+	heapInuse.Store(100)
+	require.True(t, q.mayQueue(100))
+	err = q.wait(&killer, 100, 150*time.Millisecond)
+	require.True(t, exeerrors.ErrStmtMemoryQueueTimeout.Equal(err), err)
+	heapInuse.Store(50)
+	for i := 0; i < 3; i++ {
+		require.Equal(t, i, <-admitted)
+	}
+	require.Equal(t, 0, q.len())
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/kvproto/pkg/diagnosticspb"
 	"github.com/pingcap/sysutil"
 	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/parser/model"
@@ -160,6 +161,53 @@ func (e *clusterConfigRetriever) retrieve(_ context.Context, sctx sessionctx.Con
 	return fetchClusterConfig(sctx, e.extractor.NodeTypes, e.extractor.Instances)
 }
 
+type clusterConfigCacheEntry struct {
+	rows      [][]types.Datum
+	fetchedAt time.Time
+}
+
+// clusterConfigCache caches the config items fetched from each instance by
+// fetchClusterConfig, the entries expire after variable.ClusterConfigCacheTTL,
+// or if they are fetched before domain.ClusterConfigCacheExpiredTime.
+var clusterConfigCache = struct {
+	sync.Mutex
+	entries map[string]clusterConfigCacheEntry
+}{entries: make(map[string]clusterConfigCacheEntry)}
+
+func loadClusterConfigCache(key string, ttl time.Duration, expiredTime time.Time) ([][]types.Datum, bool) {
+	if ttl <= 0 {
+		return nil, false
+	}
+	clusterConfigCache.Lock()
+	defer clusterConfigCache.Unlock()
+	entry, ok := clusterConfigCache.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(entry.fetchedAt) >= ttl || entry.fetchedAt.Before(expiredTime) {
+		delete(clusterConfigCache.entries, key)
+		return nil, false
+	}
+	return slices.Clone(entry.rows), true
+}
+
+func storeClusterConfigCache(key string, ttl time.Duration, rows [][]types.Datum, fetchedAt time.Time) {
+	if ttl <= 0 {
+		return
+	}
+	clusterConfigCache.Lock()
+	clusterConfigCache.entries[key] = clusterConfigCacheEntry{rows: rows, fetchedAt: fetchedAt}
+	clusterConfigCache.Unlock()
+}
+
+// invalidateClusterConfigCache expires the cached config items on all the TiDB
+// instances, it's called by ADMIN RELOAD CLUSTER_CONFIG and after SET CONFIG.
+func invalidateClusterConfigCache(sctx sessionctx.Context) error {
+	dom := domain.GetDomain(sctx)
+	dom.ExpireClusterConfigCache()
+	return dom.NotifyExpireClusterConfigCache()
+}
+
 func fetchClusterConfig(sctx sessionctx.Context, nodeTypes, nodeAddrs set.StringSet) ([][]types.Datum, error) {
 	type result struct {
 		idx  int
@@ -184,6 +232,8 @@ func fetchClusterConfig(sctx sessionctx.Context, nodeTypes, nodeAddrs set.String
 	var finalRows [][]types.Datum
 	wg := sync.WaitGroup{}
 	ch := make(chan result, len(serversInfo))
+	cacheTTL := variable.ClusterConfigCacheTTL.Load()
+	cacheExpiredTime := domain.GetDomain(sctx).ClusterConfigCacheExpiredTime()
 	for i, srv := range serversInfo {
 		typ := srv.ServerType
 		address := srv.Address
@@ -215,6 +265,14 @@ func fetchClusterConfig(sctx sessionctx.Context, nodeTypes, nodeAddrs set.String
 					return
 				}
 
+				cacheKey := typ + "|" + address + "|" + url
+				if rows, ok := loadClusterConfigCache(cacheKey, cacheTTL, cacheExpiredTime); ok {
+					ch <- result{idx: index, rows: rows}
+					return
+				}
+				// the config may be changed during the request, so the items are
+				// considered fetched at the time the request is sent.
+				fetchedAt := time.Now()
 				req, err := http.NewRequest(http.MethodGet, url, nil)
 				if err != nil {
 					ch <- result{err: errors.Trace(err)}
@@ -272,6 +330,7 @@ func fetchClusterConfig(sctx sessionctx.Context, nodeTypes, nodeAddrs set.String
 						item.val,
 					))
 				}
+				storeClusterConfigCache(cacheKey, cacheTTL, rows, fetchedAt)
 				ch <- result{idx: index, rows: rows}
 			}, nil)
 		}(i)
@@ -348,6 +407,8 @@ type clusterLogRetriever struct {
 	heap       *logResponseHeap
 	extractor  *plannercore.ClusterLogTableExtractor
 	cancel     context.CancelFunc
+	// retrievedRows is the number of the rows returned, it's used to stop retrieving if the limit is pushed down.
+	retrievedRows uint64
 }
 
 type logStreamResult struct {
@@ -549,7 +610,8 @@ func (e *clusterLogRetriever) retrieve(ctx context.Context, sctx sessionctx.Cont
 
 	// Merge the results
 	var finalRows [][]types.Datum
-	for e.heap.Len() > 0 && len(finalRows) < clusterLogBatchSize {
+	limit := e.extractor.Limit
+	for e.heap.Len() > 0 && len(finalRows) < clusterLogBatchSize && (limit == 0 || e.retrievedRows < limit) {
 		minTimeItem := heap.Pop(e.heap).(logStreamResult)
 		headMessage := minTimeItem.messages[0]
 		loggingTime := time.UnixMilli(headMessage.Time)
@@ -560,6 +622,7 @@ func (e *clusterLogRetriever) retrieve(ctx context.Context, sctx sessionctx.Cont
 			strings.ToUpper(headMessage.Level.String()),
 			headMessage.Message,
 		))
+		e.retrievedRows++
 		minTimeItem.messages = minTimeItem.messages[1:]
 		// Current streaming result is drained, read the next to supply.
 		if len(minTimeItem.messages) == 0 {
@@ -578,6 +641,13 @@ func (e *clusterLogRetriever) retrieve(ctx context.Context, sctx sessionctx.Cont
 
 	// All streams are drained
 	e.isDrained = e.heap.Len() == 0
+	// Stop the remote streams early if the limit is reached.
+	if limit > 0 && e.retrievedRows >= limit {
+		e.isDrained = true
+		if e.cancel != nil {
+			e.cancel()
+		}
+	}
 
 	return finalRows, nil
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/fn"
 	"github.com/pingcap/sysutil"
+	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/executor"
 	"github.com/pingcap/tidb/pkg/testkit"
 	pmodel "github.com/prometheus/common/model"
@@ -88,6 +89,12 @@ func TestMetricTableData(t *testing.T) {
 				"2019-12-23 20:11:35.000000 127.0.0.1:10080 0.5 0.1",
 			},
 		},
+		{
+			sql: "select * from metrics_query where promql = 'up'",
+			exp: []string{
+				`2019-12-23 20:11:35.000000 up {instance="127.0.0.1:10080"} 0.1`,
+			},
+		},
 	}
 
 	for _, cas := range cases {
@@ -96,6 +103,14 @@ func TestMetricTableData(t *testing.T) {
 		result := tk.ResultSetToResultWithCtx(ctx, rs[0], fmt.Sprintf("sql: %s", cas.sql))
 		result.Check(testkit.Rows(cas.exp...))
 	}
+
+	// the PromQL must be specified.
+	for _, sql := range []string{
+		"select * from metrics_query",
+		"select * from metrics_query where promql in ('up', 'down')",
+	} {
+		require.ErrorContains(t, tk.QueryToErr(sql), "exactly one PromQL must be specified")
+	}
 }
 
 func TestTiDBClusterConfig(t *testing.T) {
@@ -479,6 +494,31 @@ func TestTiDBClusterConfig(t *testing.T) {
 		require.Len(t, warnings, 0, fmt.Sprintf("unexpected warnigns: %+v", warnings))
 		require.Equal(t, ca.reqCount, requestCounter, fmt.Sprintf("SQL: %s", ca.sql))
 	}
+
+	// cache the config items.
+	tikvRows := flatten(rows["tikv"][0], rows["tikv"][1], rows["tikv"][2])
+	tk.MustExec("set @@global.tidb_cluster_config_cache_ttl = '1h'")
+	defer tk.MustExec("set @@global.tidb_cluster_config_cache_ttl = default")
+	requestCounter = 0
+	tk.MustQuery("select * from information_schema.cluster_config").Check(testkit.Rows(cases[0].rows...))
+	require.Equal(t, int32(21), requestCounter)
+	tk.MustQuery("select * from information_schema.cluster_config").Check(testkit.Rows(cases[0].rows...))
+	tk.MustQuery("select * from information_schema.cluster_config where type='tikv'").Check(testkit.Rows(tikvRows...))
+	require.Equal(t, int32(21), requestCounter)
+	tk.MustExec("admin reload cluster_config")
+	tk.MustQuery("select * from information_schema.cluster_config where type='tikv'").Check(testkit.Rows(tikvRows...))
+	require.Equal(t, int32(24), requestCounter)
+	tk.MustQuery("select * from information_schema.cluster_config where type='tikv'").Check(testkit.Rows(tikvRows...))
+	require.Equal(t, int32(24), requestCounter)
+	// the cache is also expired when other instances reload it.
+	domain.GetDomain(tk.Session()).ExpireClusterConfigCache()
+	tk.MustQuery("select * from information_schema.cluster_config where type='tikv'").Check(testkit.Rows(tikvRows...))
+	require.Equal(t, int32(27), requestCounter)
+	tk.MustQuery("select * from information_schema.cluster_config where type='tikv'").Check(testkit.Rows(tikvRows...))
+	require.Equal(t, int32(27), requestCounter)
+	tk.MustExec("set @@global.tidb_cluster_config_cache_ttl = 0")
+	tk.MustQuery("select * from information_schema.cluster_config where type='tikv'").Check(testkit.Rows(tikvRows...))
+	require.Equal(t, int32(30), requestCounter)
 }
 
 func writeTmpFile(t *testing.T, dir, filename string, lines []string) {
@@ -991,6 +1031,18 @@ func TestTiDBClusterLog(t *testing.T) {
 		}
 		result.Check(testkit.Rows(expected...))
 	}
+
+	// the retrieving stops once the pushed down limit is reached.
+	sql := "select time, type, level, message from information_schema.cluster_log where time>='2019/08/26 06:18:13.011' and time<='2019/08/26 06:28:19.011' and level='critical'"
+	tk.MustQuery(sql + " limit 1, 2").Check(testkit.Rows(
+		restime("2019/08/26 06:22:17.011")+" pd CRITICAL [test log message pd 5, foo]",
+		restime("2019/08/26 06:23:17.011")+" ticdc CRITICAL [test log message ticdc 5, foo]",
+	))
+	tk.MustQuery(sql + " order by time limit 1").Check(testkit.Rows(
+		restime("2019/08/26 06:19:17.011") + " tidb CRITICAL [test log message tidb 5, foo]",
+	))
+	rows := tk.MustQuery("explain " + sql + " limit 2").Rows()
+	require.Contains(t, rows[len(rows)-1][4], "limit:2")
 }
 
 func TestTiDBClusterLogError(t *testing.T) {
@@ -64,6 +64,10 @@ func (e *MetricRetriever) retrieve(ctx context.Context, sctx sessionctx.Context)
 		}
 	})
 
+	if e.table.Name.L == infoschema.MetricsQueryTableName {
+		return e.retrievePassthrough(ctx, sctx)
+	}
+
 	tblDef, err := infoschema.GetMetricTableDef(e.table.Name.L)
 	if err != nil {
 		return nil, err
@@ -77,12 +81,10 @@ func (e *MetricRetriever) retrieve(ctx context.Context, sctx sessionctx.Context)
 	}
 	for _, quantile := range quantiles {
 		var queryValue pmodel.Value
-		queryValue, err = e.queryMetric(ctx, sctx, queryRange, quantile)
+		promQL := e.tblDef.GenPromQL(sctx.GetSessionVars().MetricSchemaRangeDuration, e.extractor.LabelConditions, quantile)
+		queryValue, err = e.queryMetric(ctx, promQL, queryRange)
 		if err != nil {
-			if err1, ok := err.(*promv1.Error); ok {
-				return nil, errors.Errorf("query metric error, msg: %v, detail: %v", err1.Msg, err1.Detail)
-			}
-			return nil, errors.Errorf("query metric error: %v", err.Error())
+			return nil, wrapQueryMetricErr(err)
 		}
 		partRows := e.genRows(queryValue, quantile)
 		totalRows = append(totalRows, partRows...)
@@ -90,10 +92,51 @@ func (e *MetricRetriever) retrieve(ctx context.Context, sctx sessionctx.Context)
 	return totalRows, nil
 }
 
+// retrievePassthrough runs the PromQL specified by the `promql` column of the
+// metrics_query table.
+func (e *MetricRetriever) retrievePassthrough(ctx context.Context, sctx sessionctx.Context) ([][]types.Datum, error) {
+	promQL := e.extractor.GetPassthroughPromQL()
+	if promQL == "" {
+		return nil, errors.Errorf("exactly one PromQL must be specified by `promql = '...'` to query %s", infoschema.MetricsQueryTableName)
+	}
+	value, err := e.queryMetric(ctx, promQL, e.getQueryRange(sctx))
+	if err != nil {
+		return nil, wrapQueryMetricErr(err)
+	}
+	var rows [][]types.Datum
+	if value.Type() == pmodel.ValMatrix {
+		for _, m := range value.(pmodel.Matrix) {
+			for _, v := range m.Values {
+				row := make([]types.Datum, 0, 4)
+				row = append(row, types.NewTimeDatum(types.NewTime(
+					types.FromGoTime(time.UnixMilli(int64(v.Timestamp))),
+					mysql.TypeDatetime,
+					types.MaxFsp,
+				)))
+				row = append(row, types.NewStringDatum(promQL), types.NewStringDatum(m.Metric.String()))
+				if math.IsNaN(float64(v.Value)) {
+					row = append(row, types.NewDatum(nil))
+				} else {
+					row = append(row, types.NewFloat64Datum(float64(v.Value)))
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+	return rows, nil
+}
+
+func wrapQueryMetricErr(err error) error {
+	if err1, ok := err.(*promv1.Error); ok {
+		return errors.Errorf("query metric error, msg: %v, detail: %v", err1.Msg, err1.Detail)
+	}
+	return errors.Errorf("query metric error: %v", err.Error())
+}
+
 // MockMetricsPromDataKey is for test
 type MockMetricsPromDataKey struct{}
 
-func (e *MetricRetriever) queryMetric(ctx context.Context, sctx sessionctx.Context, queryRange promv1.Range, quantile float64) (result pmodel.Value, err error) {
+func (*MetricRetriever) queryMetric(ctx context.Context, promQL string, queryRange promv1.Range) (result pmodel.Value, err error) {
 	failpoint.InjectContext(ctx, "mockMetricsPromData", func() {
 		failpoint.Return(ctx.Value(MockMetricsPromDataKey{}).(pmodel.Matrix), nil)
 	})
@@ -120,7 +163,6 @@ func (e *MetricRetriever) queryMetric(ctx context.Context, sctx sessionctx.Conte
 	promQLAPI := promv1.NewAPI(promClient)
 	ctx, cancel := context.WithTimeout(ctx, promReadTimeout)
 	defer cancel()
-	promQL := e.tblDef.GenPromQL(sctx.GetSessionVars().MetricSchemaRangeDuration, e.extractor.LabelConditions, quantile)
 
 	// Add retry to avoid network error.
 	for i := 0; i < 5; i++ {
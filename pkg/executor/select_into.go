@@ -18,24 +18,61 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/hex"
+	"fmt"
 	"math"
 	"os"
+	"path"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/executor/importer"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/xitongsys/parquet-go/parquet"
+	parquetwriter "github.com/xitongsys/parquet-go/writer"
 )
 
+const (
+	// selectIntoTarget is the name of the outfile in the error messages.
+	selectIntoTarget = "outfile"
+	// selectIntoCompressionOption is the only option supported by SELECT INTO OUTFILE now.
+	selectIntoCompressionOption = "compression"
+	// selectIntoChunkSize is the size of the buffer to compress a local outfile.
+	selectIntoChunkSize = 1024 * 1024
+)
+
+// selectIntoCompressions are the supported values of the compression option. The parquet format compresses the pages
+// of the file with the codec, and the other formats compress the whole file.
+var selectIntoCompressions = map[string]struct {
+	tp    storage.CompressType
+	codec parquet.CompressionCodec
+}{
+	"gzip":   {tp: storage.Gzip, codec: parquet.CompressionCodec_GZIP},
+	"snappy": {tp: storage.Snappy, codec: parquet.CompressionCodec_SNAPPY},
+	"zstd":   {tp: storage.Zstd, codec: parquet.CompressionCodec_ZSTD},
+}
+
 // SelectIntoExec represents a SelectInto executor.
 type SelectIntoExec struct {
 	exec.BaseExecutor
 	intoOpt *ast.SelectIntoOption
 	core.LineFieldsInfo
+	options  []*core.LoadDataOpt
+	colNames []string
+
+	format       string
+	compressTp   storage.CompressType
+	parquetCodec parquet.CompressionCodec
 
 	lineBuf   []byte
 	realBuf   []byte
@@ -43,9 +80,38 @@ type SelectIntoExec struct {
 	escapeBuf []byte
 	enclosed  bool
 	writer    *bufio.Writer
-	dstFile   *os.File
+	dstFile   *outfileWriter
+	store     storage.ExternalStorage
 	chk       *chunk.Chunk
 	started   bool
+
+	// sqlTable is the quoted table name of the INSERT statements in the sql format.
+	sqlTable      string
+	parquetWriter *parquetwriter.CSVWriter
+	parquetRow    []*string
+}
+
+// outfileWriter adapts the writer of an outfile to io.Writer.
+type outfileWriter struct {
+	ctx context.Context
+	w   storage.ExternalFileWriter
+}
+
+func (w *outfileWriter) Write(p []byte) (int, error) {
+	return w.w.Write(w.ctx, p)
+}
+
+// localOutfile is an outfile on the local disk of the TiDB instance.
+type localOutfile struct {
+	f *os.File
+}
+
+func (l localOutfile) Write(_ context.Context, p []byte) (int, error) {
+	return l.f.Write(p)
+}
+
+func (l localOutfile) Close(_ context.Context) error {
+	return l.f.Close()
 }
 
 // Open implements the Executor Open interface.
@@ -54,22 +120,183 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 	if s.intoOpt.Tp != ast.SelectIntoOutfile {
 		return errors.New("unsupported SelectInto type")
 	}
+	if err := s.initFormatAndOptions(); err != nil {
+		return err
+	}
 
-	// MySQL-compatible behavior: allow files to be group-readable
-	f, err := os.OpenFile(s.intoOpt.FileName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
+	compressTp := s.compressTp
+	if s.format == importer.DataFormatParquet {
+		compressTp = storage.NoCompression
+	}
+	w, name, err := s.createOutfile(ctx, compressTp)
 	if err != nil {
-		return errors.Trace(err)
+		return err
 	}
 	s.started = true
-	s.dstFile = f
+	s.dstFile = &outfileWriter{ctx: ctx, w: w}
 	s.writer = bufio.NewWriter(s.dstFile)
 	s.chk = exec.TryNewCacheChunk(s.Children(0))
 	s.lineBuf = make([]byte, 0, 1024)
 	s.fieldBuf = make([]byte, 0, 64)
 	s.escapeBuf = make([]byte, 0, 64)
+	switch s.format {
+	case importer.DataFormatSQL:
+		// the rows are inserted into the table named after the file, such as `t` for 't.sql.gz'.
+		if idx := strings.IndexByte(name, '.'); idx >= 0 {
+			name = name[:idx]
+		}
+		s.sqlTable = "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case importer.DataFormatParquet:
+		if err := s.initParquetWriter(); err != nil {
+			return err
+		}
+	}
 	return s.BaseExecutor.Open(ctx)
 }
 
+// initFormatAndOptions checks the format and the options, the FIELDS and LINES clauses are only supported by the
+// delimited data and the csv format.
+func (s *SelectIntoExec) initFormatAndOptions() error {
+	s.format = importer.DataFormatDelimitedData
+	if s.intoOpt.Format != nil {
+		s.format = strings.ToLower(*s.intoOpt.Format)
+	}
+	switch s.format {
+	case importer.DataFormatDelimitedData:
+	case importer.DataFormatCSV:
+		if s.intoOpt.FieldsInfo == nil {
+			s.FieldsTerminatedBy = ","
+			s.FieldsEnclosedBy = `"`
+		}
+	case importer.DataFormatSQL, importer.DataFormatParquet:
+		if s.intoOpt.FieldsInfo != nil {
+			return exeerrors.ErrLoadDataUnsupportedOption.FastGenByArgs("FIELDS", "non-CSV format")
+		}
+		if s.intoOpt.LinesInfo != nil {
+			return exeerrors.ErrLoadDataUnsupportedOption.FastGenByArgs("LINES", "non-CSV format")
+		}
+	default:
+		return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(s.format)
+	}
+
+	s.compressTp = storage.NoCompression
+	s.parquetCodec = parquet.CompressionCodec_UNCOMPRESSED
+	specifiedOptions := make(map[string]struct{}, len(s.options))
+	for _, opt := range s.options {
+		if opt.Name != selectIntoCompressionOption {
+			return exeerrors.ErrUnknownOption.FastGenByArgs(opt.Name)
+		}
+		if _, ok := specifiedOptions[opt.Name]; ok {
+			return exeerrors.ErrDuplicateOption.FastGenByArgs(opt.Name)
+		}
+		specifiedOptions[opt.Name] = struct{}{}
+		evalCtx := s.Ctx().GetExprCtx().GetEvalCtx()
+		if opt.Value == nil || opt.Value.GetType(evalCtx).GetType() != mysql.TypeVarString {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		val, isNull, err := opt.Value.EvalString(evalCtx, chunk.Row{})
+		if err != nil || isNull {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		compression, ok := selectIntoCompressions[strings.ToLower(val)]
+		if !ok {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		s.compressTp, s.parquetCodec = compression.tp, compression.codec
+	}
+	return nil
+}
+
+// createOutfile creates the outfile and returns its writer and its base name. The outfile is created on the local
+// disk of the TiDB instance, or on the external storage if the file name is an URI such as 's3://bucket/path/t.csv'.
+// It fails if the file already exists.
+func (s *SelectIntoExec) createOutfile(ctx context.Context, compressTp storage.CompressType) (storage.ExternalFileWriter, string, error) {
+	u, err := storage.ParseRawURL(s.intoOpt.FileName)
+	if err != nil || storage.IsLocal(u) {
+		// MySQL-compatible behavior: allow files to be group-readable
+		f, err := os.OpenFile(s.intoOpt.FileName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
+		if err != nil {
+			return nil, "", errors.Trace(err)
+		}
+		var w storage.ExternalFileWriter = localOutfile{f: f}
+		if compressTp != storage.NoCompression {
+			w = storage.NewUploaderWriter(w, selectIntoChunkSize, compressTp)
+		}
+		return w, filepath.Base(s.intoOpt.FileName), nil
+	}
+
+	dir, name := path.Split(u.Path)
+	if name == "" {
+		return nil, "", exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(selectIntoTarget, "the file name is empty")
+	}
+	u.Path = dir
+	b, err := storage.ParseBackendFromURL(u, nil)
+	if err != nil {
+		return nil, "", exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(selectIntoTarget, importer.GetMsgFromBRError(err))
+	}
+	store, err := storage.NewWithDefaultOpt(ctx, b)
+	if err != nil {
+		return nil, "", exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(selectIntoTarget, importer.GetMsgFromBRError(err))
+	}
+	exists, err := store.FileExists(ctx, name)
+	if err != nil {
+		store.Close()
+		return nil, "", exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(selectIntoTarget, importer.GetMsgFromBRError(err))
+	}
+	if exists {
+		store.Close()
+		return nil, "", exeerrors.ErrFileExists.GenWithStackByArgs(ast.RedactURL(s.intoOpt.FileName))
+	}
+	w, err := storage.WithCompression(store, compressTp, storage.DecompressConfig{}).Create(ctx, name, nil)
+	if err != nil {
+		store.Close()
+		return nil, "", exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(selectIntoTarget, importer.GetMsgFromBRError(err))
+	}
+	s.store = store
+	return w, name, nil
+}
+
+// initParquetWriter builds the schema of the parquet file from the columns, the integers and the floating-point
+// numbers keep their types and the other values are written as strings.
+func (s *SelectIntoExec) initParquetWriter() error {
+	nameReplacer := strings.NewReplacer(",", "_", "=", "_")
+	cols := s.Children(0).Schema().Columns
+	md := make([]string, 0, len(cols))
+	for i, col := range cols {
+		tp := col.GetType(s.Ctx().GetExprCtx().GetEvalCtx())
+		var parquetTp string
+		switch tp.GetType() {
+		case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+			parquetTp = "type=INT64"
+		case mysql.TypeLonglong:
+			parquetTp = "type=INT64"
+			if mysql.HasUnsignedFlag(tp.GetFlag()) {
+				parquetTp = "type=INT64, convertedtype=UINT_64"
+			}
+		case mysql.TypeFloat:
+			parquetTp = "type=FLOAT"
+		case mysql.TypeDouble:
+			parquetTp = "type=DOUBLE"
+		case mysql.TypeBit:
+			parquetTp = "type=BYTE_ARRAY"
+		default:
+			parquetTp = "type=BYTE_ARRAY, convertedtype=UTF8"
+			if types.IsBinaryStr(tp) {
+				parquetTp = "type=BYTE_ARRAY"
+			}
+		}
+		md = append(md, fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", nameReplacer.Replace(s.colNames[i]), parquetTp))
+	}
+	w, err := parquetwriter.NewCSVWriterFromWriter(md, s.writer, 1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.CompressionType = s.parquetCodec
+	s.parquetWriter = w
+	s.parquetRow = make([]*string, len(cols))
+	return nil
+}
+
 // Next implements the Executor Next interface.
 func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 	for {
@@ -79,9 +306,19 @@ func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		if s.chk.NumRows() == 0 {
 			break
 		}
-		if err := s.dumpToOutfile(); err != nil {
+		var err error
+		switch s.format {
+		case importer.DataFormatSQL:
+			err = s.dumpToSQL()
+		case importer.DataFormatParquet:
+			err = s.dumpToParquet()
+		default:
+			err = s.dumpToOutfile()
+		}
+		if err != nil {
 			return err
 		}
+		s.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
 	}
 	return nil
 }
@@ -121,6 +358,40 @@ func (s *SelectIntoExec) escapeField(f []byte) []byte {
 	return s.escapeBuf
 }
 
+// appendField appends the text of a non-null field to buf.
+func (s *SelectIntoExec) appendField(buf []byte, row chunk.Row, j int, tp *types.FieldType) []byte {
+	switch tp.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+		buf = strconv.AppendInt(buf, row.GetInt64(j), 10)
+	case mysql.TypeLonglong:
+		if mysql.HasUnsignedFlag(tp.GetFlag()) {
+			buf = strconv.AppendUint(buf, row.GetUint64(j), 10)
+		} else {
+			buf = strconv.AppendInt(buf, row.GetInt64(j), 10)
+		}
+	case mysql.TypeFloat:
+		s.realBuf, buf = DumpRealOutfile(s.realBuf, buf, float64(row.GetFloat32(j)), tp)
+	case mysql.TypeDouble:
+		s.realBuf, buf = DumpRealOutfile(s.realBuf, buf, row.GetFloat64(j), tp)
+	case mysql.TypeNewDecimal:
+		buf = append(buf, row.GetMyDecimal(j).String()...)
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeBit:
+		buf = append(buf, row.GetBytes(j)...)
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		buf = append(buf, row.GetTime(j).String()...)
+	case mysql.TypeDuration:
+		buf = append(buf, row.GetDuration(j, tp.GetDecimal()).String()...)
+	case mysql.TypeEnum:
+		buf = append(buf, row.GetEnum(j).String()...)
+	case mysql.TypeSet:
+		buf = append(buf, row.GetSet(j).String()...)
+	case mysql.TypeJSON:
+		buf = append(buf, row.GetJSON(j).String()...)
+	}
+	return buf
+}
+
 func (s *SelectIntoExec) dumpToOutfile() error {
 	encloseFlag := false
 	var encloseByte byte
@@ -149,7 +420,8 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 				s.lineBuf = append(s.lineBuf, nullTerm...)
 				continue
 			}
-			et := col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()).EvalType()
+			tp := col.GetType(s.Ctx().GetExprCtx().GetEvalCtx())
+			et := tp.EvalType()
 			if (encloseFlag && !encloseOpt) ||
 				(encloseFlag && encloseOpt && s.considerEncloseOpt(et)) {
 				s.lineBuf = append(s.lineBuf, encloseByte)
@@ -158,40 +430,14 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 				s.enclosed = false
 			}
 			s.fieldBuf = s.fieldBuf[:0]
-			switch col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()).GetType() {
-			case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
-				s.fieldBuf = strconv.AppendInt(s.fieldBuf, row.GetInt64(j), 10)
-			case mysql.TypeLonglong:
-				if mysql.HasUnsignedFlag(col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()).GetFlag()) {
-					s.fieldBuf = strconv.AppendUint(s.fieldBuf, row.GetUint64(j), 10)
-				} else {
-					s.fieldBuf = strconv.AppendInt(s.fieldBuf, row.GetInt64(j), 10)
-				}
-			case mysql.TypeFloat:
-				s.realBuf, s.fieldBuf = DumpRealOutfile(s.realBuf, s.fieldBuf, float64(row.GetFloat32(j)), col.RetType)
-			case mysql.TypeDouble:
-				s.realBuf, s.fieldBuf = DumpRealOutfile(s.realBuf, s.fieldBuf, row.GetFloat64(j), col.RetType)
-			case mysql.TypeNewDecimal:
-				s.fieldBuf = append(s.fieldBuf, row.GetMyDecimal(j).String()...)
-			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
-				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
-				s.fieldBuf = append(s.fieldBuf, row.GetBytes(j)...)
-			case mysql.TypeBit:
+			if tp.GetType() == mysql.TypeBit {
 				// bit value won't be escaped anyway (verified on MySQL, test case added)
 				s.lineBuf = append(s.lineBuf, row.GetBytes(j)...)
-			case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
-				s.fieldBuf = append(s.fieldBuf, row.GetTime(j).String()...)
-			case mysql.TypeDuration:
-				s.fieldBuf = append(s.fieldBuf, row.GetDuration(j, col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()).GetDecimal()).String()...)
-			case mysql.TypeEnum:
-				s.fieldBuf = append(s.fieldBuf, row.GetEnum(j).String()...)
-			case mysql.TypeSet:
-				s.fieldBuf = append(s.fieldBuf, row.GetSet(j).String()...)
-			case mysql.TypeJSON:
-				s.fieldBuf = append(s.fieldBuf, row.GetJSON(j).String()...)
+			} else {
+				s.fieldBuf = s.appendField(s.fieldBuf, row, j, tp)
 			}
 
-			switch col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()).EvalType() {
+			switch et {
 			case types.ETString, types.ETJson:
 				s.lineBuf = append(s.lineBuf, s.escapeField(s.fieldBuf)...)
 			default:
@@ -207,7 +453,69 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			return errors.Trace(err)
 		}
 	}
-	s.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
+	return nil
+}
+
+// dumpToSQL dumps the rows of the chunk as an INSERT statement. The binary strings and the bit values are written
+// as hexadecimal literals.
+func (s *SelectIntoExec) dumpToSQL() error {
+	s.lineBuf = append(s.lineBuf[:0], "INSERT INTO "...)
+	s.lineBuf = append(s.lineBuf, s.sqlTable...)
+	s.lineBuf = append(s.lineBuf, " VALUES\n"...)
+	cols := s.Children(0).Schema().Columns
+	for i := 0; i < s.chk.NumRows(); i++ {
+		row := s.chk.GetRow(i)
+		if i != 0 {
+			s.lineBuf = append(s.lineBuf, ",\n"...)
+		}
+		s.lineBuf = append(s.lineBuf, '(')
+		for j, col := range cols {
+			if j != 0 {
+				s.lineBuf = append(s.lineBuf, ',')
+			}
+			if row.IsNull(j) {
+				s.lineBuf = append(s.lineBuf, "NULL"...)
+				continue
+			}
+			tp := col.GetType(s.Ctx().GetExprCtx().GetEvalCtx())
+			s.fieldBuf = s.appendField(s.fieldBuf[:0], row, j, tp)
+			switch {
+			case tp.GetType() == mysql.TypeBit || types.IsBinaryStr(tp):
+				s.lineBuf = append(s.lineBuf, "X'"...)
+				s.lineBuf = append(s.lineBuf, hex.EncodeToString(s.fieldBuf)...)
+				s.lineBuf = append(s.lineBuf, '\'')
+			case s.considerEncloseOpt(tp.EvalType()):
+				s.lineBuf = append(s.lineBuf, '\'')
+				s.lineBuf = append(s.lineBuf, sqlescape.EscapeString(string(s.fieldBuf))...)
+				s.lineBuf = append(s.lineBuf, '\'')
+			default:
+				s.lineBuf = append(s.lineBuf, s.fieldBuf...)
+			}
+		}
+		s.lineBuf = append(s.lineBuf, ')')
+	}
+	s.lineBuf = append(s.lineBuf, ";\n"...)
+	_, err := s.writer.Write(s.lineBuf)
+	return errors.Trace(err)
+}
+
+func (s *SelectIntoExec) dumpToParquet() error {
+	cols := s.Children(0).Schema().Columns
+	for i := 0; i < s.chk.NumRows(); i++ {
+		row := s.chk.GetRow(i)
+		for j, col := range cols {
+			if row.IsNull(j) {
+				s.parquetRow[j] = nil
+				continue
+			}
+			s.fieldBuf = s.appendField(s.fieldBuf[:0], row, j, col.GetType(s.Ctx().GetExprCtx().GetEvalCtx()))
+			v := string(s.fieldBuf)
+			s.parquetRow[j] = &v
+		}
+		if err := s.parquetWriter.WriteString(s.parquetRow); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -216,8 +524,17 @@ func (s *SelectIntoExec) Close() error {
 	if !s.started {
 		return nil
 	}
-	err1 := s.writer.Flush()
-	err2 := s.dstFile.Close()
+	var err1 error
+	if s.parquetWriter != nil {
+		err1 = s.parquetWriter.WriteStop()
+	}
+	if err1 == nil {
+		err1 = s.writer.Flush()
+	}
+	err2 := s.dstFile.w.Close(s.dstFile.ctx)
+	if s.store != nil {
+		s.store.Close()
+	}
 	err3 := s.BaseExecutor.Close()
 	if err1 != nil {
 		return errors.Trace(err1)
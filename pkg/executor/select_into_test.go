@@ -15,7 +15,9 @@
 package executor_test
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -302,3 +304,53 @@ func TestYearType(t *testing.T) {
 	tk.MustExec(fmt.Sprintf("select * from t into outfile '%v' fields terminated by ',' optionally enclosed by '\"' lines terminated by '\\n';", outfile))
 	cmpAndRm("2010\n2011\n2012\n2030\n", outfile, t)
 }
+
+func TestSelectIntoOutfileFormats(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, s varchar(32), b varbinary(8), d datetime)")
+	tk.MustExec(`insert into t values (1, 'a,"b''', x'0102', '2024-01-02 03:04:05'), (2, null, null, null)`)
+
+	// the csv format uses ',' and '"' by default.
+	outfile := randomSelectFilePath("TestSelectIntoOutfileFormats")
+	tk.MustExec(fmt.Sprintf("select * from t order by id into outfile %q format 'csv'", outfile))
+	cmpAndRm("\"1\",\"a,\\\"b'\",\"\x01\x02\",\"2024-01-02 03:04:05\"\n\"2\",\\N,\\N,\\N\n", outfile, t)
+	tk.MustExec(fmt.Sprintf("select id, s from t order by id into outfile %q format 'CSV' fields terminated by '|'", outfile))
+	cmpAndRm("1|a,\"b'\n2|\\N\n", outfile, t)
+
+	// the rows of each chunk are dumped as an INSERT statement into the table named after the file.
+	outfile = filepath.Join(t.TempDir(), "t1.sql")
+	tk.MustExec(fmt.Sprintf("select * from t order by id into outfile %q format 'sql'", outfile))
+	cmpAndRm("INSERT INTO `t1` VALUES\n(1,'a,\\\"b\\'',X'0102','2024-01-02 03:04:05'),\n(2,NULL,NULL,NULL);\n", outfile, t)
+
+	// the whole file is compressed.
+	outfile = randomSelectFilePath("TestSelectIntoOutfileFormats")
+	tk.MustExec(fmt.Sprintf("select id from t order by id into outfile %q with compression='gzip'", outfile))
+	f, err := os.Open(outfile)
+	require.NoError(t, err)
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, "1\n2\n", string(content))
+	require.NoError(t, os.Remove(outfile))
+
+	for _, c := range []struct {
+		sql string
+		err string
+	}{
+		{"select * from t into outfile %q format 'json'", "The FORMAT 'json' is not supported"},
+		{"select * from t into outfile %q format 'sql' fields terminated by ','", "Unsupported option FIELDS for non-CSV format"},
+		{"select * from t into outfile %q format 'parquet' lines terminated by '\\n'", "Unsupported option LINES for non-CSV format"},
+		{"select * from t into outfile %q with threads=1", "Unknown option threads"},
+		{"select * from t into outfile %q with compression='lz4'", "Invalid option value for compression"},
+		{"select * from t into outfile %q with compression=1", "Invalid option value for compression"},
+		{"select * from t into outfile %q with compression='gzip', compression='zstd'", "Option compression specified more than once"},
+	} {
+		err := tk.ExecToErr(fmt.Sprintf(c.sql, outfile))
+		require.ErrorContains(t, err, c.err, c.sql)
+		require.NoFileExists(t, outfile)
+	}
+}
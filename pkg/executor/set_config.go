@@ -126,6 +126,10 @@ func (s *SetConfigExec) Next(_ context.Context, req *chunk.Chunk) error {
 			s.Ctx().GetSessionVars().StmtCtx.AppendWarning(err)
 		}
 	}
+	// the cached config items may be stale after changing the config.
+	if err := invalidateClusterConfigCache(s.Ctx()); err != nil {
+		s.Ctx().GetSessionVars().StmtCtx.AppendWarning(err)
+	}
 	return nil
 }
 
@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/pkg/bindinfo"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/disttask/importinto"
 	"github.com/pingcap/tidb/pkg/domain"
@@ -80,6 +81,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/sem"
 	"github.com/pingcap/tidb/pkg/util/set"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
 	"github.com/tikv/client-go/v2/oracle"
@@ -115,7 +117,9 @@ type ShowExec struct {
 	GlobalScope bool // GlobalScope is used by show variables
 	Extended    bool // Used for `show extended columns from ...`
 
-	ImportJobID *int64
+	ImportJobID   *int64
+	TaskID        int64  // Used for `show task <id> history`
+	LoadDataJobID *int64 // Used for `show load data job <id>`
 }
 
 type showTableRegionRowItem struct {
@@ -263,6 +267,8 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowBind()
 	case ast.ShowBindingCacheStatus:
 		return e.fetchShowBindingCacheStatus(ctx)
+	case ast.ShowBindingEvolveTasks:
+		return e.fetchShowBindingEvolveTasks(ctx)
 	case ast.ShowAnalyzeStatus:
 		return e.fetchShowAnalyzeStatus(ctx)
 	case ast.ShowRegions:
@@ -287,6 +293,12 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowSessionStates(ctx)
 	case ast.ShowImportJobs:
 		return e.fetchShowImportJobs(ctx)
+	case ast.ShowLoadDataJobs:
+		return e.fetchShowLoadDataJobs(ctx)
+	case ast.ShowTaskHistory:
+		return e.fetchShowTaskHistory(ctx)
+	case ast.ShowTasks:
+		return e.fetchShowTasks(ctx)
 	}
 	return nil
 }
@@ -404,6 +416,22 @@ func (e *ShowExec) fetchShowBindingCacheStatus(ctx context.Context) error {
 	return nil
 }
 
+func (e *ShowExec) fetchShowBindingEvolveTasks(ctx context.Context) error {
+	exec := e.Ctx().GetRestrictedSQLExecutor()
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnBindInfo)
+
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT original_sql, default_db, bind_sql, status, current_plan_time,
+		candidate_plan_time, message, create_time, update_time, sql_digest FROM mysql.tidb_binding_evolve_tasks
+		ORDER BY create_time`)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, row := range rows {
+		e.result.AppendRow(row)
+	}
+	return nil
+}
+
 func (e *ShowExec) fetchShowEngines(ctx context.Context) error {
 	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnMeta)
 	exec := e.Ctx().GetRestrictedSQLExecutor()
@@ -2331,6 +2359,145 @@ func (e *ShowExec) fetchShowImportJobs(ctx context.Context) error {
 	return nil
 }
 
+// fetchShowLoadDataJobs fills the result with the schema:
+// {"Job_ID", "Data_Source", "Target_Table", "State", "Loaded_Rows", "Result_Message",
+// "Create_Time", "Update_Time", "End_Time", "Created_By", "Instance"}
+// users without SUPER privilege can only see their own jobs.
+func (e *ShowExec) fetchShowLoadDataJobs(ctx context.Context) error {
+	// we use a new session, user ctx might not have system table privileges.
+	se, err := CreateSession(e.Ctx())
+	if err != nil {
+		return err
+	}
+	defer CloseSession(se)
+	if e.LoadDataJobID != nil {
+		if _, err = getLoadDataJob(e.Ctx(), se, *e.LoadDataJobID); err != nil {
+			return err
+		}
+	}
+
+	if err = failOrphanedLoadDataJobs(ctx, se); err != nil {
+		e.Ctx().GetSessionVars().StmtCtx.AppendWarning(err)
+	}
+
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, `SELECT id, data_source, table_schema, table_name, state, loaded_rows, result_message,
+		create_time, update_time, end_time, created_by, instance FROM mysql.tidb_load_data_jobs`)
+	if e.LoadDataJobID != nil {
+		sqlescape.MustFormatSQL(sql, " WHERE id = %?", *e.LoadDataJobID)
+	} else if !hasSuperPriv(e.Ctx()) {
+		sqlescape.MustFormatSQL(sql, " WHERE created_by = %?", e.Ctx().GetSessionVars().User.String())
+	}
+	sql.WriteString(" ORDER BY id")
+	rows, err := execLoadDataJobSQL(se, sql.String())
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		e.result.AppendInt64(0, row.GetInt64(0))
+		e.result.AppendString(1, row.GetString(1))
+		e.result.AppendString(2, utils.EncloseDBAndTable(row.GetString(2), row.GetString(3)))
+		e.result.AppendString(3, row.GetEnum(4).String())
+		e.result.AppendUint64(4, row.GetUint64(5))
+		if row.IsNull(6) {
+			e.result.AppendNull(5)
+		} else {
+			e.result.AppendString(5, row.GetString(6))
+		}
+		// create_time, update_time and end_time.
+		for i := 7; i < 10; i++ {
+			if row.IsNull(i) {
+				e.result.AppendNull(i - 1)
+			} else {
+				e.result.AppendTime(i-1, row.GetTime(i))
+			}
+		}
+		e.result.AppendString(9, row.GetString(10))
+		e.result.AppendString(10, row.GetString(11))
+	}
+	return nil
+}
+
+// fetchShowTaskHistory fills the result with the schema:
+// {"Event_ID", "Type", "Exec_ID", "Message", "Create_Time"}
+func (e *ShowExec) fetchShowTaskHistory(ctx context.Context) error {
+	taskManager, err := fstorage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	if _, err = taskManager.GetTaskBaseByIDWithHistory(ctx, e.TaskID); err != nil {
+		if errors.ErrorEqual(err, fstorage.ErrTaskNotFound) {
+			return errors.Errorf("task %d not found", e.TaskID)
+		}
+		return err
+	}
+	events, err := taskManager.GetTaskEvents(ctx, e.TaskID)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		e.result.AppendInt64(0, event.ID)
+		e.result.AppendString(1, event.Type.String())
+		e.result.AppendString(2, event.ExecID)
+		e.result.AppendString(3, event.Message)
+		e.result.AppendTime(4, types.NewTime(types.FromGoTime(event.CreateTime), mysql.TypeDatetime, types.MaxFsp))
+	}
+	return nil
+}
+
+// fetchShowTasks fills the result with the schema:
+// {"Task_ID", "Task_Key", "Type", "State", "Step", "Priority", "Concurrency",
+// "Create_Time", "Start_Time", "State_Update_Time", "Progress", "Error"}
+// tasks in history are included, the WHERE clause is applied by the selection
+// above.
+func (e *ShowExec) fetchShowTasks(ctx context.Context) error {
+	taskManager, err := fstorage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	tasks, err := taskManager.GetAllTasksWithHistory(ctx)
+	if err != nil {
+		return err
+	}
+	progresses, err := taskManager.GetAllTaskProgress(ctx)
+	if err != nil {
+		return err
+	}
+	appendTime := func(colIdx int, t time.Time) {
+		if t.IsZero() {
+			e.result.AppendNull(colIdx)
+			return
+		}
+		e.result.AppendTime(colIdx, types.NewTime(types.FromGoTime(t), mysql.TypeDatetime, types.MaxFsp))
+	}
+	for _, task := range tasks {
+		e.result.AppendInt64(0, task.ID)
+		e.result.AppendString(1, task.Key)
+		e.result.AppendString(2, string(task.Type))
+		e.result.AppendString(3, string(task.State))
+		e.result.AppendString(4, proto.Step2Str(task.Type, task.Step))
+		e.result.AppendInt64(5, int64(task.Priority))
+		e.result.AppendInt64(6, int64(task.Concurrency))
+		appendTime(7, task.CreateTime)
+		appendTime(8, task.StartTime)
+		appendTime(9, task.StateUpdateTime)
+		// the progress of a step is only available after its subtasks are created.
+		if p, ok := progresses[task.ID]; ok && p.Step == task.Step {
+			e.result.AppendFloat64(10, p.Percent())
+		} else {
+			e.result.AppendNull(10)
+		}
+		if task.Error != nil {
+			e.result.AppendString(11, task.Error.Error())
+		} else {
+			e.result.AppendNull(11)
+		}
+	}
+	return nil
+}
+
 // tryFillViewColumnType fill the columns type info of a view.
 // Because view's underlying table's column could change or recreate, so view's column type may change over time.
 // To avoid this situation we need to generate a logical plan and extract current column types from Schema.
@@ -31,6 +31,8 @@ import (
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl/placement"
 	"github.com/pingcap/tidb/pkg/distsql"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
@@ -193,7 +195,7 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 	case *ast.ShutdownStmt:
 		err = e.executeShutdown()
 	case *ast.AdminStmt:
-		err = e.executeAdmin(x)
+		err = e.executeAdmin(ctx, x)
 	case *ast.SetResourceGroupStmt:
 		err = e.executeSetResourceGroupName(x)
 	case *ast.AlterRangeStmt:
@@ -2535,6 +2537,9 @@ func (e *SimpleExec) executeKillStmt(ctx context.Context, s *ast.KillStmt) error
 		}
 		return errors.New("Invalid operation. Please use 'KILL TIDB [CONNECTION | QUERY] [connectionID | CONNECTION_ID()]' instead")
 	}
+	if s.SQLDigest != "" {
+		return e.killQueriesByDigest(ctx, s.SQLDigest)
+	}
 	if !config.GetGlobalConfig().EnableGlobalKill {
 		conf := config.GetGlobalConfig()
 		if s.TiDBExtension || conf.CompatibleKillQuery {
@@ -2589,6 +2594,52 @@ func (e *SimpleExec) executeKillStmt(ctx context.Context, s *ast.KillStmt) error
 	return nil
 }
 
+// killQueriesByDigest kills all the running statements whose SQL digest is digest. The statements on
+// the other TiDB instances are found by CLUSTER_PROCESSLIST and killed by global kill, so only the
+// statements on the current instance are killed when global kill is disabled.
+func (e *SimpleExec) killQueriesByDigest(ctx context.Context, digest string) error {
+	sm := e.Ctx().GetSessionManager()
+	if sm == nil {
+		return nil
+	}
+	if !config.GetGlobalConfig().EnableGlobalKill {
+		for id, pi := range sm.ShowProcessList() {
+			if pi.Digest == digest && pi.Command != mysql.ComSleep {
+				sm.Kill(id, true, false)
+			}
+		}
+		err := errors.NewNoStackError("Only the queries on the current TiDB instance are killed because global kill is disabled")
+		e.Ctx().GetSessionVars().StmtCtx.AppendWarning(err)
+		return nil
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := e.Ctx().GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil,
+		"SELECT ID FROM information_schema.cluster_processlist WHERE DIGEST = %? AND COMMAND != 'Sleep'", digest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, row := range rows {
+		connID := row.GetUint64(0)
+		gcid, _, err := globalconn.ParseConnID(connID)
+		if err != nil {
+			err1 := errors.NewNoStackError("Parse ConnectionID failed: " + err.Error())
+			e.Ctx().GetSessionVars().StmtCtx.AppendWarning(err1)
+			continue
+		}
+		if gcid.ServerID != sm.ServerID() {
+			if err := killRemoteConn(ctx, e.Ctx(), &gcid, true); err != nil {
+				err1 := errors.NewNoStackError("KILL remote connection failed: " + err.Error())
+				e.Ctx().GetSessionVars().StmtCtx.AppendWarning(err1)
+			}
+		} else {
+			sm.Kill(connID, true, false)
+		}
+	}
+	logutil.BgLogger().Info("Killed queries by SQL digest", zap.String("digest", digest), zap.Int("count", len(rows)))
+	return nil
+}
+
 func killRemoteConn(ctx context.Context, sctx sessionctx.Context, gcid *globalconn.GCID, query bool) error {
 	if gcid.ServerID == 0 {
 		return errors.New("Unexpected ZERO ServerID. Please file a bug to the TiDB Team")
@@ -2784,7 +2835,7 @@ func (e *SimpleExec) executeSetSessionStates(ctx context.Context, s *ast.SetSess
 	return e.Ctx().DecodeSessionStates(ctx, e.Ctx(), &sessionStates)
 }
 
-func (e *SimpleExec) executeAdmin(s *ast.AdminStmt) error {
+func (e *SimpleExec) executeAdmin(ctx context.Context, s *ast.AdminStmt) error {
 	switch s.Tp {
 	case ast.AdminReloadStatistics:
 		return e.executeAdminReloadStatistics(s)
@@ -2794,6 +2845,12 @@ func (e *SimpleExec) executeAdmin(s *ast.AdminStmt) error {
 		return e.executeAdminSetBDRRole(s)
 	case ast.AdminUnsetBDRRole:
 		return e.executeAdminUnsetBDRRole()
+	case ast.AdminCleanupTaskHistory:
+		return e.executeAdminCleanupTaskHistory(ctx)
+	case ast.AdminAlterTaskPriority:
+		return e.executeAdminAlterTaskPriority(ctx, s)
+	case ast.AdminReloadClusterConfig:
+		return invalidateClusterConfigCache(e.Ctx())
 	}
 	return nil
 }
@@ -2822,11 +2879,15 @@ func (e *SimpleExec) executeAdminFlushPlanCache(s *ast.AdminStmt) error {
 	now := types.NewTime(types.FromGoTime(time.Now().In(e.Ctx().GetSessionVars().StmtCtx.TimeZone())), mysql.TypeTimestamp, 3)
 	e.Ctx().GetSessionVars().LastUpdateTime4PC = now
 	e.Ctx().GetSessionPlanCache().DeleteAll()
-	if s.StatementScope == ast.StatementScopeInstance {
+	if s.StatementScope == ast.StatementScopeInstance || s.StatementScope == ast.StatementScopeCluster {
 		// Record the timestamp. When other sessions want to use the plan cache,
 		// it will check the timestamp first to decide whether the plan cache should be flushed.
 		domain.GetDomain(e.Ctx()).SetExpiredTimeStamp4PC(now)
 	}
+	if s.StatementScope == ast.StatementScopeCluster {
+		// Other instances expire their plan cache in the same way once they are notified.
+		return domain.GetDomain(e.Ctx()).NotifyFlushPlanCache()
+	}
 	return nil
 }
 
@@ -2850,6 +2911,46 @@ func (e *SimpleExec) executeAdminUnsetBDRRole() error {
 	return errors.Trace(meta.NewMeta(txn).ClearBDRRole())
 }
 
+func (*SimpleExec) executeAdminCleanupTaskHistory(ctx context.Context) error {
+	taskManager, err := fstorage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	return taskManager.PurgeHistory(kv.WithInternalSourceType(ctx, kv.InternalDistTask))
+}
+
+// executeAdminAlterTaskPriority modifies the priority of a pending distributed
+// task, the task with smaller priority value is scheduled first.
+func (*SimpleExec) executeAdminAlterTaskPriority(ctx context.Context, s *ast.AdminStmt) error {
+	if err := fstorage.CheckTaskPriority(s.Priority); err != nil {
+		return err
+	}
+	taskManager, err := fstorage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	id := s.JobIDs[0]
+	task, err := taskManager.GetTaskBaseByIDWithHistory(ctx, id)
+	if err != nil {
+		if errors.ErrorEqual(err, fstorage.ErrTaskNotFound) {
+			return errors.Errorf("task %d not found", id)
+		}
+		return err
+	}
+	if task.State == proto.TaskStatePending {
+		found, err := taskManager.ModifyTaskPriority(ctx, id, int(s.Priority))
+		if err != nil || found {
+			return err
+		}
+		// the task is scheduled concurrently, get its latest state.
+		if task, err = taskManager.GetTaskBaseByIDWithHistory(ctx, id); err != nil {
+			return err
+		}
+	}
+	return errors.Errorf("task %d is in %s state, only the priority of pending task can be modified", id, task.State)
+}
+
 func (e *SimpleExec) executeSetResourceGroupName(s *ast.SetResourceGroupStmt) error {
 	originalResourceGroup := e.Ctx().GetSessionVars().ResourceGroupName
 	if s.Name.L != "" {
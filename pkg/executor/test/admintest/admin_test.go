@@ -27,11 +27,15 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/ddl/util/callback"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	disttestutil "github.com/pingcap/tidb/pkg/disttask/framework/testutil"
 	"github.com/pingcap/tidb/pkg/domain"
 	mysql "github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/executor"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
@@ -1638,6 +1642,56 @@ func TestAdminCheckTableErrorLocate(t *testing.T) {
 	}
 }
 
+func TestAdminCheckTableConcurrency(t *testing.T) {
+	store, domain := testkit.CreateMockStoreAndDomain(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_check_table_concurrency = 4")
+	tk.MustQuery("select @@tidb_check_table_concurrency").Check(testkit.Rows("4"))
+	tk.MustExec("create table t1 (c1 int, c2 int, primary key(c1), key(c2))")
+	tk.MustExec("create table t2 (c1 int, c2 int, key(c2))")
+	tk.MustExec("create table t3 (c1 int, c2 int, key(c2))")
+	tk.MustExec("set cte_max_recursion_depth=1000;")
+	tk.MustExec("insert into t1 with recursive cte(a, b) as (select 1, 1 union select a+1, b+1 from cte where cte.a< 1000) select * from cte;")
+	tk.MustExec("insert into t2 select * from t1")
+	// The empty table is not split.
+	tk.MustExec("admin check table t3")
+
+	sctx := mock.NewContext()
+	sctx.Store = store
+	ctx := sctx.GetTableCtx()
+	is := domain.InfoSchema()
+	for _, tblName := range []string{"t1", "t2"} {
+		tk.MustExec("admin check table " + tblName)
+		tbl, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr(tblName))
+		require.NoError(t, err)
+		tblInfo := tbl.Meta()
+		indexOpr := tables.NewIndex(tblInfo.ID, tblInfo, tblInfo.Indices[0])
+		// Corrupt the index in the first, middle and last handle ranges.
+		for _, handle := range []int{1, 500, 1000} {
+			txn, err := store.Begin()
+			require.NoError(t, err)
+			err = indexOpr.Delete(ctx, txn, types.MakeDatums(handle), kv.IntHandle(handle))
+			require.NoError(t, err)
+			require.NoError(t, txn.Commit(context.Background()))
+			err = tk.ExecToErr("admin check table " + tblName)
+			require.ErrorContains(t, err, fmt.Sprintf("table: %s, index: c2, handle: %d,", tblName, handle))
+			tk.MustQuery("admin recover index " + tblName + " c2").Check(testkit.Rows("1 1000"))
+			tk.MustExec("admin check table " + tblName)
+		}
+		// Remove a row of the table.
+		txn, err := store.Begin()
+		require.NoError(t, err)
+		require.NoError(t, txn.Delete(tablecodec.EncodeRowKey(tblInfo.ID, kv.IntHandle(750).Encoded())))
+		require.NoError(t, txn.Commit(context.Background()))
+		err = tk.ExecToErr("admin check table " + tblName)
+		require.ErrorContains(t, err, fmt.Sprintf("table: %s, index: c2, handle: 750,", tblName))
+		tk.MustQuery("admin cleanup index " + tblName + " c2").Check(testkit.Rows("1"))
+		tk.MustExec("admin check table " + tblName)
+	}
+}
+
 func TestAdminCheckTableErrorLocateForClusterIndex(t *testing.T) {
 	store, domain := testkit.CreateMockStoreAndDomain(t)
 
@@ -2106,3 +2160,70 @@ func TestAdminCheckGlobalIndexDuringDDL(t *testing.T) {
 		}
 	}
 }
+
+func TestAdminCalibrateCostModel(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	defer func() {
+		tk.MustExec("delete from mysql.tidb_cost_model_factors")
+		plannercore.SetCalibratedCostFactors(nil)
+	}()
+
+	// the factors that are covered by noise on the mock store are not returned, so only check the returned
+	// factors are saved.
+	rows := tk.MustQuery("admin calibrate cost model").Sort().Rows()
+	tk.MustQuery("select factor_name, factor_value from mysql.tidb_cost_model_factors order by factor_name").Check(rows)
+	if len(rows) == 0 {
+		require.Len(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings(), 1)
+	}
+	tk.MustQuery("select count(*) from mysql.tidb_cost_model_calibration_data").Check(testkit.Rows("0"))
+
+	// the saved factors are loaded by the planner, and the plans cached before are expired.
+	tk.MustExec("replace into mysql.tidb_cost_model_factors (factor_name, factor_value) values ('tikv_scan_factor', 55.5)")
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnAdmin)
+	expiredTS := dom.ExpiredTimeStamp4PC()
+	time.Sleep(time.Millisecond)
+	require.NoError(t, dom.ReloadCostModelFactors(func(sctx sessionctx.Context) error {
+		return executor.LoadCostModelFactors(ctx, sctx)
+	}))
+	require.Greater(t, dom.ExpiredTimeStamp4PC().Compare(expiredTS), 0)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int)")
+	tk.MustExec("set @@tidb_cost_model_version=2")
+	tk.MustQuery("explain format='cost_trace' select * from t where a>0").CheckContain("tikv_scan_factor(55.5)")
+}
+
+func TestAdminAlterTaskPriority(t *testing.T) {
+	store, taskMgr, ctx := disttestutil.InitTableTest(t)
+	tk := testkit.NewTestKit(t, store)
+
+	require.NoError(t, taskMgr.InitMeta(ctx, ":4000", ""))
+	id, err := taskMgr.CreateTask(ctx, "key1", "test", 4, "", []byte("test"))
+	require.NoError(t, err)
+
+	tk.MustGetErrMsg(fmt.Sprintf("admin alter task %d priority = 0", id),
+		"invalid task priority 0, should be in range [1, 1024]")
+	tk.MustGetErrMsg(fmt.Sprintf("admin alter task %d priority = 1025", id),
+		"invalid task priority 1025, should be in range [1, 1024]")
+	tk.MustGetErrMsg(fmt.Sprintf("admin alter task %d priority = 1", id+100),
+		fmt.Sprintf("task %d not found", id+100))
+
+	tk.MustExec(fmt.Sprintf("admin alter task %d priority = 10", id))
+	task, err := taskMgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 10, task.Priority)
+
+	// only the priority of pending task can be modified.
+	require.NoError(t, taskMgr.SwitchTaskStep(ctx, task, proto.TaskStateRunning, proto.StepOne, nil))
+	tk.MustGetErrMsg(fmt.Sprintf("admin alter task %d priority = 1", id),
+		fmt.Sprintf("task %d is in running state, only the priority of pending task can be modified", id))
+	task, err = taskMgr.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 10, task.Priority)
+
+	// the statement requires SUPER privilege.
+	tk.MustExec("create user 'u1'@'%'")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	require.ErrorContains(t, tk1.ExecToErr(fmt.Sprintf("admin alter task %d priority = 1", id)), "privilege check for 'Super' fail")
+}
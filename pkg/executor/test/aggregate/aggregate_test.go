@@ -252,6 +252,44 @@ func TestAggInDisk(t *testing.T) {
 	tk.MustQuery("select /*+ HASH_AGG() */ count(c) from t group by c1;").Check(testkit.Rows())
 }
 
+func TestHashAggPartialResult(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set global tidb_enable_tmp_storage_on_oom = off")
+	defer tk.MustExec("set global tidb_enable_tmp_storage_on_oom = on")
+	oomAction := tk.MustQuery("select @@global.tidb_mem_oom_action").Rows()[0][0].(string)
+	tk.MustExec("set global tidb_mem_oom_action = 'CANCEL'")
+	defer tk.MustExec(fmt.Sprintf("set global tidb_mem_oom_action = '%s'", oomAction))
+	tk.MustExec("create table t(a int)")
+	sql := "insert into t values (0)"
+	for i := 1; i <= 200; i++ {
+		sql += fmt.Sprintf(",(%v)", i)
+	}
+	tk.MustExec(sql)
+
+	query := "select /*+ HASH_AGG() */ t1.a, t2.a, count(*) from t t1 join t t2 group by t1.a, t2.a"
+	for _, concurrency := range []int{1, 5} {
+		tk.MustExec(fmt.Sprintf("set tidb_hashagg_partial_concurrency = %d", concurrency))
+		tk.MustExec(fmt.Sprintf("set tidb_hashagg_final_concurrency = %d", concurrency))
+		tk.MustExec("set tidb_mem_quota_query = 1048576")
+		tk.MustExec("set tidb_enable_hashagg_partial_result = off")
+		err := tk.QueryToErr(query)
+		require.ErrorContains(t, err, "exceeding the allowed memory limit for a single SQL query")
+
+		tk.MustExec("set tidb_enable_hashagg_partial_result = on")
+		rows := tk.MustQuery(query).Rows()
+		require.NotEmpty(t, rows)
+		require.Less(t, len(rows), 201*201)
+		tk.MustQuery("show warnings").Check(testkit.Rows("Warning 8179 The memory quota of the query is exceeded, hash aggregation stops reading its input and the result is approximate"))
+
+		// The full result is returned when the quota is not exceeded.
+		tk.MustExec("set tidb_mem_quota_query = default")
+		require.Len(t, tk.MustQuery(query).Rows(), 201*201)
+		tk.MustQuery("show warnings").Check(testkit.Rows())
+	}
+}
+
 func TestRandomPanicConsume(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -111,6 +111,42 @@ PARTITION BY RANGE ( a ) (
 	})
 }
 
+func TestDistAnalyzePartitionTable(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@global.tidb_enable_dist_analyze = on")
+	defer tk.MustExec("set @@global.tidb_enable_dist_analyze = default")
+	testkit.WithPruneMode(tk, variable.Dynamic, func() {
+		tk.MustExec("use test")
+		tk.MustExec("set @@tidb_analyze_version=2")
+		tk.MustExec(`create table t (a int, b int, primary key(a), index idx(b))
+partition by range (a) (partition p0 values less than (11), partition p1 values less than (21))`)
+		for i := 1; i < 21; i++ {
+			tk.MustExec("insert into t values (?, ?)", i, i%5)
+		}
+		tk.MustExec("analyze table t with 1 topn, 2 buckets")
+
+		tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+		require.NoError(t, err)
+		tblInfo := tbl.Meta()
+		tk.MustQuery("select state from (select task_key, state from mysql.tidb_global_task union "+
+			"select task_key, state from mysql.tidb_global_task_history) t where task_key like ?",
+			fmt.Sprintf("analyze/%d/%%", tblInfo.ID)).Check(testkit.Rows("succeed"))
+		// the options of the statement are used by both the partitions and
+		// the global stats.
+		tk.MustQuery("select buckets, topn from mysql.analyze_options where table_id = ?", tblInfo.ID).
+			Check(testkit.Rows("2 1"))
+		for _, id := range []int64{tblInfo.ID, tblInfo.Partition.Definitions[0].ID, tblInfo.Partition.Definitions[1].ID} {
+			tk.MustQuery("select count(*) from mysql.stats_top_n where table_id = ? and is_index = 1", id).
+				Check(testkit.Rows("1"))
+		}
+		require.NoError(t, dom.StatsHandle().Update(dom.InfoSchema()))
+		globalStats := dom.StatsHandle().GetTableStats(tblInfo)
+		require.False(t, globalStats.Pseudo)
+		require.Equal(t, int64(20), globalStats.RealtimeCount)
+	})
+}
+
 func TestAnalyzeReplicaReadFollower(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -2668,6 +2668,42 @@ func TestGlobalMemoryControl2(t *testing.T) {
 	runtime.GC()
 }
 
+func TestServerMemoryQueue(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	defer func() {
+		tk.MustExec("set global tidb_server_memory_limit = DEFAULT")
+		tk.MustExec("set global tidb_server_memory_queue_watermark = DEFAULT")
+		tk.MustExec("set global tidb_server_memory_queue_cost_threshold = DEFAULT")
+		tk.MustExec("set global tidb_server_memory_queue_timeout = DEFAULT")
+	}()
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int)")
+	tk.MustExec("insert into t values (1), (2)")
+
+	tk.MustExec("set global tidb_server_memory_limit = '512MB'")
+	tk.MustExec("set global tidb_server_memory_queue_cost_threshold = 1")
+	tk.MustExec("set global tidb_server_memory_queue_timeout = '200ms'")
+	tk.MustQuery("select @@global.tidb_server_memory_queue_watermark, @@global.tidb_server_memory_queue_cost_threshold, @@global.tidb_server_memory_queue_timeout").
+		Check(testkit.Rows("0 1 200ms"))
+	// The watermark is about 53KB, the memory usage is always above it.
+	tk.MustExec("set global tidb_server_memory_queue_watermark = 0.0001")
+	err := tk.ExecToErr("select * from t")
+	require.True(t, exeerrors.ErrStmtMemoryQueueTimeout.Equal(err), err)
+	err = tk.ExecToErr("delete from t where a > 1")
+	require.True(t, exeerrors.ErrStmtMemoryQueueTimeout.Equal(err), err)
+	// INSERT ... VALUES has no plan cost, and the other statements are not queued.
+	tk.MustExec("insert into t values (3)")
+	tk.MustQuery("show tables").Check(testkit.Rows("t"))
+
+	// The statements cheaper than the threshold are not queued.
+	tk.MustExec("set global tidb_server_memory_queue_cost_threshold = 1e15")
+	tk.MustQuery("select * from t").Sort().Check(testkit.Rows("1", "2", "3"))
+	tk.MustExec("set global tidb_server_memory_queue_cost_threshold = 1")
+	tk.MustExec("set global tidb_server_memory_queue_watermark = 0")
+	tk.MustQuery("select * from t").Sort().Check(testkit.Rows("1", "2", "3"))
+}
+
 func TestSignalCheckpointForSort(t *testing.T) {
 	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/executor/sortexec/SignalCheckpointForSort", `return(true)`))
 	defer func() {
@@ -2928,3 +2964,22 @@ func TestDecimalDivPrecisionIncrement(t *testing.T) {
 	tk.MustExec("set div_precision_increment = 10")
 	tk.MustQuery("select avg(a/b) from t").Check(testkit.Rows("1.21428571428571428550"))
 }
+
+func TestDistChecksumTable(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, c int, primary key(id), key(c))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	local := tk.MustQuery("admin checksum table t").Rows()
+
+	tk.MustExec("set @@global.tidb_enable_dist_checksum = on")
+	defer tk.MustExec("set @@global.tidb_enable_dist_checksum = default")
+	tk.MustQuery("admin checksum table t").Check(local)
+
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tk.MustQuery("select state from (select task_key, state from mysql.tidb_global_task union "+
+		"select task_key, state from mysql.tidb_global_task_history) t where task_key like ?",
+		fmt.Sprintf("checksum/%d/%%", tbl.Meta().ID)).Check(testkit.Rows("succeed"))
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
@@ -899,3 +900,47 @@ func TestIssue49033(t *testing.T) {
 	require.EqualError(t, err, "testIssue49033")
 	require.NoError(t, rs.Close())
 }
+
+func TestJoinDiskQuota(t *testing.T) {
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.TempStoragePath = t.TempDir()
+	})
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/executor/join/testRowContainerSpill", "return(true)"))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/executor/join/testRowContainerSpill"))
+	}()
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	defer tk.MustExec("set global tidb_mem_oom_action = default")
+	tk.MustExec("set global tidb_mem_oom_action = 'LOG'")
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("insert into t values (1, 1)")
+	for i := 0; i < 6; i++ {
+		tk.MustExec("insert into t select * from t")
+	}
+	tk.MustExec("set tidb_mem_quota_query = 1")
+	sql := "select /*+ HASH_JOIN(t1, t2) */ * from t t1 join t t2 on t1.a = t2.a"
+
+	// no disk quota by default.
+	require.Len(t, tk.MustQuery(sql).Rows(), 4096)
+	require.Greater(t, tk.Session().GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), int64(0))
+	rows := tk.MustQuery("explain analyze " + sql).Rows()
+	require.NotEqual(t, "DiskQuota", rows[len(rows)-1][0])
+
+	tk.MustExec("set tidb_disk_quota_query = 1048576")
+	require.Len(t, tk.MustQuery(sql).Rows(), 4096)
+	rows = tk.MustQuery("explain analyze " + sql).Rows()
+	summary := rows[len(rows)-1]
+	require.Equal(t, "DiskQuota", summary[0])
+	require.Contains(t, summary[5], "quota:1024 KB, max_used:")
+	require.NotEqual(t, "0 Bytes", summary[len(summary)-1])
+
+	tk.MustExec("set tidb_disk_quota_query = 1")
+	err := tk.QueryToErr(sql)
+	require.True(t, exeerrors.ErrDiskExceedForQuery.Equal(err), err)
+	// the session is not affected by the killed statement.
+	tk.MustExec("set tidb_disk_quota_query = default")
+	require.Len(t, tk.MustQuery(sql).Rows(), 4096)
+}
@@ -0,0 +1,108 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadremotetest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/testkit/testfailpoint"
+)
+
+func (s *mockGCSSuite) TestDetachedLoadData() {
+	testfailpoint.Enable(s.T(), "github.com/pingcap/tidb/pkg/executor/loadDataJobUpdateInterval", "return(100)")
+	s.tk.MustExec("DROP DATABASE IF EXISTS load_detached;")
+	s.tk.MustExec("CREATE DATABASE load_detached;")
+	s.tk.MustExec("CREATE TABLE load_detached.t (i INT PRIMARY KEY, s varchar(32));")
+	s.server.CreateObject(fakestorage.Object{
+		ObjectAttrs: fakestorage.ObjectAttrs{
+			BucketName: "test-load-detached",
+			Name:       "t.csv",
+		},
+		Content: []byte("1,a\n2,b\n3,c\n"),
+	})
+	sql := fmt.Sprintf(`LOAD DATA INFILE 'gs://test-load-detached/t.csv?endpoint=%s' INTO TABLE load_detached.t
+		FIELDS TERMINATED BY ',' WITH DETACHED`, gcsEndpoint)
+
+	// the job finishes in the background.
+	rows := s.tk.MustQuery(sql).Rows()
+	s.Len(rows, 1)
+	jobID := rows[0][0].(string)
+	s.Eventually(func() bool {
+		rows := s.tk.MustQuery("SHOW LOAD DATA JOB " + jobID).Rows()
+		return rows[0][3] == "finished"
+	}, 10*time.Second, 100*time.Millisecond)
+	rows = s.tk.MustQuery("SHOW LOAD DATA JOB " + jobID).Rows()
+	s.Equal("gs://test-load-detached/t.csv?endpoint="+gcsEndpoint, rows[0][1])
+	s.Equal("`load_detached`.`t`", rows[0][2])
+	s.Equal("3", rows[0][4])
+	s.Equal("Records: 3  Deleted: 0  Skipped: 0  Warnings: 0", rows[0][5])
+	s.tk.MustQuery("SELECT * FROM load_detached.t").Sort().Check(testkit.Rows("1 a", "2 b", "3 c"))
+	err := s.tk.ExecToErr("CANCEL LOAD DATA JOB " + jobID)
+	s.ErrorContains(err, "The current job status cannot perform the operation")
+
+	// the changes of a canceled job are rolled back.
+	s.tk.MustExec("TRUNCATE TABLE load_detached.t")
+	testfailpoint.Enable(s.T(), "github.com/pingcap/tidb/pkg/executor/BeforeCommitWork", "sleep(1000)")
+	jobID = s.tk.MustQuery(sql).Rows()[0][0].(string)
+	s.tk.MustExec("CANCEL LOAD DATA JOB " + jobID)
+	s.Eventually(func() bool {
+		rows := s.tk.MustQuery("SHOW LOAD DATA JOB " + jobID).Rows()
+		return rows[0][5] != "<nil>"
+	}, 10*time.Second, 100*time.Millisecond)
+	rows = s.tk.MustQuery("SHOW LOAD DATA JOB " + jobID).Rows()
+	s.Equal("canceled", rows[0][3])
+	s.Contains(rows[0][5], "context canceled")
+	s.tk.MustQuery("SELECT * FROM load_detached.t").Check(testkit.Rows())
+	testfailpoint.Disable(s.T(), "github.com/pingcap/tidb/pkg/executor/BeforeCommitWork")
+
+	// a wrong path fails the statement rather than the job.
+	err = s.tk.QueryToErr(fmt.Sprintf(`LOAD DATA INFILE 'gs://wrong-bucket/t.csv?endpoint=%s'
+		INTO TABLE load_detached.t WITH DETACHED`, gcsEndpoint))
+	s.ErrorContains(err, "Failed to read source files")
+	err = s.tk.ExecToErr("LOAD DATA LOCAL INFILE '/tmp/t.csv' INTO TABLE load_detached.t WITH DETACHED")
+	s.ErrorContains(err, "Unsupported option for LOAD DATA LOCAL INFILE: detached")
+	err = s.tk.QueryToErr("SHOW LOAD DATA JOB 100000")
+	s.ErrorContains(err, "Job ID 100000 doesn't exist")
+
+	// users can only see and cancel their own jobs without SUPER privilege.
+	s.tk.MustExec("CREATE USER 'load_detached'@'%'")
+	s.tk.MustExec("GRANT INSERT ON load_detached.* TO 'load_detached'@'%'")
+	tk2 := testkit.NewTestKit(s.T(), s.store)
+	s.Require().NoError(tk2.Session().Auth(&auth.UserIdentity{Username: "load_detached", Hostname: "%"}, nil, nil, nil))
+	tk2.MustQuery("SHOW LOAD DATA JOBS").Check(testkit.Rows())
+	err = tk2.QueryToErr("SHOW LOAD DATA JOB " + jobID)
+	s.ErrorContains(err, "SUPER privilege(s) for this operation")
+	err = tk2.ExecToErr("CANCEL LOAD DATA JOB " + jobID)
+	s.ErrorContains(err, "SUPER privilege(s) for this operation")
+	s.Len(s.tk.MustQuery("SHOW LOAD DATA JOBS WHERE State = 'canceled'").Rows(), 1)
+
+	// the running jobs of the exited instances and the jobs which stop recording
+	// their progress are failed.
+	instance := s.tk.MustQuery("SELECT instance FROM mysql.tidb_load_data_jobs LIMIT 1").Rows()[0][0].(string)
+	s.tk.MustExec(`INSERT INTO mysql.tidb_load_data_jobs (id, table_schema, table_name, data_source, created_by, state, instance)
+		VALUES (100, 'load_detached', 't', 'gs://a/b', 'root@%', 'running', 'exited:4000'),
+		(101, 'load_detached', 't', 'gs://a/b', 'root@%', 'running', ?),
+		(102, 'load_detached', 't', 'gs://a/b', 'root@%', 'running', ?)`, instance, instance)
+	s.tk.MustExec("UPDATE mysql.tidb_load_data_jobs SET update_time = DATE_SUB(NOW(6), INTERVAL 1 HOUR) WHERE id = 101")
+	s.tk.MustQuery("SHOW LOAD DATA JOBS WHERE Job_ID >= 100").CheckAt([]int{0, 3, 5}, testkit.RowsWithSep("|",
+		"100|failed|the TiDB instance running the job has exited",
+		"101|failed|the TiDB instance running the job has exited",
+		"102|running|<nil>",
+	))
+}
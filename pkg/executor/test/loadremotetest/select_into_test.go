@@ -0,0 +1,85 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadremotetest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func (s *mockGCSSuite) TestSelectIntoOutfile() {
+	s.server.CreateBucketWithOpts(fakestorage.CreateBucketOpts{Name: "test-select-into"})
+	s.tk.MustExec("DROP DATABASE IF EXISTS select_into;")
+	s.tk.MustExec("CREATE DATABASE select_into;")
+	s.tk.MustExec("CREATE TABLE select_into.t (i INT PRIMARY KEY, u BIGINT UNSIGNED, d DOUBLE, s VARCHAR(32), dt DATETIME);")
+	s.tk.MustExec(`INSERT INTO select_into.t VALUES (1, 18446744073709551615, 1.5, 'a,"b''', '2024-01-02 03:04:05'),
+		(2, NULL, NULL, NULL, NULL)`)
+	uriOf := func(file string) string {
+		return fmt.Sprintf("gs://test-select-into/dir/%s?endpoint=%s", file, gcsEndpoint)
+	}
+
+	// the compressed csv file is loaded back into another table.
+	sql := fmt.Sprintf("SELECT * FROM select_into.t ORDER BY i INTO OUTFILE '%s' FORMAT 'csv' WITH compression='gzip'", uriOf("t.csv.gz"))
+	s.tk.MustExec(sql)
+	s.Equal(uint64(2), s.tk.Session().GetSessionVars().StmtCtx.AffectedRows())
+	s.tk.MustExec("CREATE TABLE select_into.t2 LIKE select_into.t")
+	s.tk.MustExec(fmt.Sprintf(`LOAD DATA INFILE '%s' INTO TABLE select_into.t2 FIELDS TERMINATED BY ',' ENCLOSED BY '"'`, uriOf("t.csv.gz")))
+	s.tk.MustQuery("SELECT * FROM select_into.t2").Sort().Check(testkit.Rows(
+		"1 18446744073709551615 1.5 a,\"b' 2024-01-02 03:04:05", "2 <nil> <nil> <nil> <nil>"))
+	err := s.tk.ExecToErr(sql)
+	s.ErrorContains(err, fmt.Sprintf("File '%s' already exists", uriOf("t.csv.gz")))
+
+	s.tk.MustExec(fmt.Sprintf("TABLE select_into.t ORDER BY i INTO OUTFILE '%s' FORMAT 'sql'", uriOf("t.sql")))
+	object, err := s.server.GetObject("test-select-into", "dir/t.sql")
+	s.NoError(err)
+	s.Equal("INSERT INTO `t` VALUES\n(1,18446744073709551615,1.5,'a,\\\"b\\'','2024-01-02 03:04:05'),\n(2,NULL,NULL,NULL,NULL);\n",
+		string(object.Content))
+
+	s.tk.MustExec(fmt.Sprintf("SELECT i, u, d, s FROM select_into.t ORDER BY i INTO OUTFILE '%s' FORMAT 'parquet' WITH compression='snappy'",
+		uriOf("t.parquet")))
+	object, err = s.server.GetObject("test-select-into", "dir/t.parquet")
+	s.NoError(err)
+	ctx := context.Background()
+	store := storage.NewMemStorage()
+	s.NoError(store.WriteFile(ctx, "/t.parquet", object.Content))
+	r, err := store.Open(ctx, "/t.parquet", nil)
+	s.NoError(err)
+	parser, err := mydump.NewParquetParser(ctx, store, r, "/t.parquet")
+	s.NoError(err)
+	defer func() {
+		s.NoError(parser.Close())
+	}()
+	s.Equal([]string{"i", "u", "d", "s"}, parser.Columns())
+	s.NoError(parser.ReadRow())
+	row := parser.LastRow().Row
+	s.Equal(int64(1), row[0].GetInt64())
+	s.Equal(uint64(18446744073709551615), row[1].GetUint64())
+	s.Equal(1.5, row[2].GetFloat64())
+	s.Equal("a,\"b'", row[3].GetString())
+	s.NoError(parser.ReadRow())
+	row = parser.LastRow().Row
+	s.Equal(int64(2), row[0].GetInt64())
+	for _, d := range row[1:] {
+		s.True(d.IsNull())
+	}
+
+	err = s.tk.ExecToErr(fmt.Sprintf("SELECT 1 INTO OUTFILE 'gs://test-select-into/?endpoint=%s'", gcsEndpoint))
+	s.ErrorContains(err, "The URI of outfile is invalid. Reason: the file name is empty")
+}
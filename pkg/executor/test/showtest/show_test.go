@@ -890,7 +890,7 @@ func TestShowClusterConfig(t *testing.T) {
 func TestShowConfig(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
-	rows := tk.MustQuery("show variables like '%config%'").Rows()
+	rows := tk.MustQuery("show variables like 'tidb_config'").Rows()
 	require.Equal(t, 1, len(rows))
 	configValue := rows[0][1].(string)
 
@@ -18,10 +18,13 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -684,3 +687,63 @@ func TestKillStmt(t *testing.T) {
 	tk.MustExecToErr("kill rand()", "Invalid operation. Please use 'KILL TIDB [CONNECTION | QUERY] [connectionID | CONNECTION_ID()]' instead")
 	// remote kill is tested in `tests/globalkilltest`
 }
+
+func TestKillQueryByDigest(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	sv := server.CreateMockServer(t, store)
+	sv.SetDomain(dom)
+	defer sv.Close()
+
+	conn1 := server.CreateMockConn(t, sv)
+	tk := testkit.NewTestKitWithSession(t, store, conn1.Context().Session)
+	conn2 := server.CreateMockConn(t, sv)
+	tk2 := testkit.NewTestKitWithSession(t, store, conn2.Context().Session)
+
+	originCfg := config.GetGlobalConfig()
+	newCfg := *originCfg
+	newCfg.EnableGlobalKill = false
+	config.StoreGlobalConfig(&newCfg)
+	defer func() {
+		config.StoreGlobalConfig(originCfg)
+	}()
+
+	// the statements are dispatched as COM_QUERY in the server.
+	atomic.StoreUint32(&tk2.Session().GetSessionVars().CommandValue, uint32(mysql.ComQuery))
+	sql := "select sleep(100)"
+	_, digest := parser.NormalizeDigest(sql)
+	done := make(chan struct{})
+	go func() {
+		// sleep returns 1 if it's interrupted.
+		tk2.MustQuery(sql).Check(testkit.Rows("1"))
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		pi, ok := sv.GetProcessInfo(conn2.ID())
+		return ok && pi.Digest == digest.String()
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// the statements of other digests are not killed.
+	tk.MustExec("kill query digest 'e5796985ccafe2f71126ed6c0ac939ffa015a8c0744a24b7aee6d587103fd2f7'")
+	select {
+	case <-done:
+		require.FailNow(t, "the query should not be killed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	tk.MustExec(fmt.Sprintf("kill query digest '%s'", digest.String()))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 Only the queries on the current TiDB instance are killed because global kill is disabled"))
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "the query is not killed")
+	}
+	// the connection is still alive.
+	tk2.MustQuery("select 1").Check(testkit.Rows("1"))
+	// killing by digest with global kill is tested in `tests/globalkilltest`
+
+	// killing by digest requires CONNECTION_ADMIN.
+	tk.MustExec("create user 'u1'@'%'")
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk2.MustGetErrMsg(fmt.Sprintf("kill query digest '%s'", digest.String()),
+		"[planner:1227]Access denied; you need (at least one of) the SUPER or CONNECTION_ADMIN privilege(s) for this operation")
+}
@@ -54,6 +54,8 @@ const (
 	ClusterTableMemoryUsageOpsHistory = "CLUSTER_MEMORY_USAGE_OPS_HISTORY"
 	// ClusterTableTiDBIndexUsage is a table to show the usage stats of indexes across the whole cluster.
 	ClusterTableTiDBIndexUsage = "CLUSTER_TIDB_INDEX_USAGE"
+	// ClusterTableMemoryUsageByModule is the memory usage of each module of tidb cluster.
+	ClusterTableMemoryUsageByModule = "CLUSTER_MEMORY_USAGE_BY_MODULE"
 )
 
 // memTableToAllTiDBClusterTables means add memory table to cluster table that will send cop request to all TiDB nodes.
@@ -69,6 +71,7 @@ var memTableToAllTiDBClusterTables = map[string]string{
 	TableMemoryUsage:              ClusterTableMemoryUsage,
 	TableMemoryUsageOpsHistory:    ClusterTableMemoryUsageOpsHistory,
 	TableTiDBIndexUsage:           ClusterTableTiDBIndexUsage,
+	TableMemoryUsageByModule:      ClusterTableMemoryUsageByModule,
 }
 
 // memTableToDDLOwnerClusterTables means add memory table to cluster table that will send cop request to DDL owner node.
@@ -874,6 +874,11 @@ var MetricTableMap = map[string]MetricTableDef{
 		PromQL:  `sum(rate(resource_manager_resource_unit_read_request_unit_sum{type=~"|tp"}[$RANGE_DURATION])) + sum(rate(resource_manager_resource_unit_write_request_unit_sum{type=~"|tp"}[$RANGE_DURATION]))`,
 		Comment: "The Total RU consumption per second",
 	},
+	"resource_manager_resource_unit_by_group": {
+		PromQL:  `sum(rate(resource_manager_resource_unit_read_request_unit_sum{type=~"|tp",$LABEL_CONDITIONS}[$RANGE_DURATION])) by (name) + sum(rate(resource_manager_resource_unit_write_request_unit_sum{type=~"|tp",$LABEL_CONDITIONS}[$RANGE_DURATION])) by (name)`,
+		Labels:  []string{"name"},
+		Comment: "The RU consumption per second of each resource group",
+	},
 	"tikv_engine_size": {
 		PromQL:  `sum(tikv_engine_size_bytes{$LABEL_CONDITIONS}) by (instance, type, db)`,
 		Labels:  []string{"instance", "type", "db"},
@@ -30,6 +30,10 @@ import (
 	"github.com/pingcap/tidb/pkg/util/set"
 )
 
+// MetricsQueryTableName is the name of the metric table which runs arbitrary
+// PromQL, the PromQL is specified by the `promql` column in the where clause.
+const MetricsQueryTableName = "metrics_query"
+
 const (
 	promQLQuantileKey       = "$QUANTILE"
 	promQLLabelConditionKey = "$LABEL_CONDITIONS"
@@ -52,6 +56,17 @@ func init() {
 		tableInfo.MaxColumnID = int64(len(tableInfo.Columns))
 		tableInfo.MaxIndexID = int64(len(tableInfo.Indices))
 	}
+	queryTableInfo := buildTableMeta(MetricsQueryTableName, []columnInfo{
+		{name: "time", tp: mysql.TypeDatetime, size: 19, deflt: "CURRENT_TIMESTAMP"},
+		{name: "promql", tp: mysql.TypeVarchar, size: 4096},
+		{name: "labels", tp: mysql.TypeVarchar, size: 4096},
+		{name: "value", tp: mysql.TypeDouble, size: 22},
+	})
+	queryTableInfo.ID = tableID
+	queryTableInfo.Comment = "Run arbitrary PromQL, e.g. select * from metrics_query where promql = 'up' and time > now() - interval 5 minute"
+	queryTableInfo.DBID = dbID
+	queryTableInfo.MaxColumnID = int64(len(queryTableInfo.Columns))
+	metricTables = append(metricTables, queryTableInfo)
 	dbInfo := &model.DBInfo{
 		ID:      dbID,
 		Name:    model.NewCIStr(util.MetricSchemaName.O),
@@ -73,7 +88,7 @@ type MetricTableDef struct {
 // IsMetricTable uses to checks whether the table is a metric table.
 func IsMetricTable(lowerTableName string) bool {
 	_, ok := MetricTableMap[lowerTableName]
-	return ok
+	return ok || lowerTableName == MetricsQueryTableName
 }
 
 // GetMetricTableDef gets the metric table define.
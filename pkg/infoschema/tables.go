@@ -215,6 +215,16 @@ const (
 	TableKeywords = "KEYWORDS"
 	// TableTiDBIndexUsage is a table to show the usage stats of indexes in the current instance.
 	TableTiDBIndexUsage = "TIDB_INDEX_USAGE"
+	// TableClusterCapacity is the RU capacity of the cluster estimated by hardware.
+	TableClusterCapacity = "CLUSTER_CAPACITY"
+	// TableResourceGroupsRuntime is the runtime status of resource groups.
+	TableResourceGroupsRuntime = "RESOURCE_GROUPS_RUNTIME"
+	// TableTiDBGlobalTasks is the list of distributed tasks.
+	TableTiDBGlobalTasks = "TIDB_GLOBAL_TASKS"
+	// TableTiDBBackgroundSubtasks is the list of subtasks of distributed tasks.
+	TableTiDBBackgroundSubtasks = "TIDB_BACKGROUND_SUBTASKS"
+	// TableMemoryUsageByModule is the memory usage of each module of tidb instance.
+	TableMemoryUsageByModule = "MEMORY_USAGE_BY_MODULE"
 )
 
 const (
@@ -336,6 +346,12 @@ var tableIDMap = map[string]int64{
 	TableKeywords:                        autoid.InformationSchemaDBID + 92,
 	TableTiDBIndexUsage:                  autoid.InformationSchemaDBID + 93,
 	ClusterTableTiDBIndexUsage:           autoid.InformationSchemaDBID + 94,
+	TableClusterCapacity:                 autoid.InformationSchemaDBID + 95,
+	TableResourceGroupsRuntime:           autoid.InformationSchemaDBID + 96,
+	TableTiDBGlobalTasks:                 autoid.InformationSchemaDBID + 97,
+	TableTiDBBackgroundSubtasks:          autoid.InformationSchemaDBID + 98,
+	TableMemoryUsageByModule:             autoid.InformationSchemaDBID + 99,
+	ClusterTableMemoryUsageByModule:      autoid.InformationSchemaDBID + 100,
 }
 
 // columnInfo represents the basic column information of all kinds of INFORMATION_SCHEMA tables
@@ -854,6 +870,8 @@ var tableProcesslistCols = []columnInfo{
 	{name: "TxnStart", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag, deflt: ""},
 	{name: "RESOURCE_GROUP", tp: mysql.TypeVarchar, size: resourcegroup.MaxGroupNameLength, flag: mysql.NotNullFlag, deflt: ""},
 	{name: "SESSION_ALIAS", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag, deflt: ""},
+	{name: "RU", tp: mysql.TypeDouble, size: 22, flag: mysql.NotNullFlag, deflt: 0},
+	{name: "THROTTLED", tp: mysql.TypeTiny, size: 1, flag: mysql.NotNullFlag, deflt: 0},
 }
 
 var tableTiDBIndexesCols = []columnInfo{
@@ -1642,6 +1660,12 @@ var tableMemoryUsageOpsHistoryCols = []columnInfo{
 	{name: "SQL_TEXT", tp: mysql.TypeVarchar, size: 256},
 }
 
+var tableMemoryUsageByModuleCols = []columnInfo{
+	{name: "MODULE", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "MEMORY_CURRENT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+	{name: "MEMORY_MAX_USED", tp: mysql.TypeLonglong, size: 21},
+}
+
 var tableResourceGroupsCols = []columnInfo{
 	{name: "NAME", tp: mysql.TypeVarchar, size: resourcegroup.MaxGroupNameLength, flag: mysql.NotNullFlag},
 	{name: "RU_PER_SEC", tp: mysql.TypeVarchar, size: 21},
@@ -1702,6 +1726,55 @@ var tableTiDBIndexUsage = []columnInfo{
 	{name: "LAST_ACCESS_TIME", tp: mysql.TypeDatetime, size: 21},
 }
 
+var tableClusterCapacityCols = []columnInfo{
+	{name: "WORKLOAD", tp: mysql.TypeVarchar, size: 20, flag: mysql.NotNullFlag},
+	{name: "RU_CAPACITY", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+}
+
+var tableResourceGroupsRuntimeCols = []columnInfo{
+	{name: "NAME", tp: mysql.TypeVarchar, size: resourcegroup.MaxGroupNameLength, flag: mysql.NotNullFlag},
+	{name: "RU_PER_SEC", tp: mysql.TypeVarchar, size: 21},
+	{name: "BURSTABLE", tp: mysql.TypeVarchar, size: 3},
+	{name: "TOKENS", tp: mysql.TypeDouble, size: 22},
+	{name: "CONSUMED_RU", tp: mysql.TypeDouble, size: 22},
+	{name: "CONSUMED_RU_PER_SEC", tp: mysql.TypeDouble, size: 22},
+}
+
+var tableTiDBGlobalTasksCols = []columnInfo{
+	{name: "ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+	{name: "TASK_KEY", tp: mysql.TypeVarchar, size: 256},
+	{name: "TYPE", tp: mysql.TypeVarchar, size: 256},
+	{name: "STATE", tp: mysql.TypeVarchar, size: 64},
+	{name: "STEP", tp: mysql.TypeVarchar, size: 64},
+	{name: "PRIORITY", tp: mysql.TypeLonglong, size: 21},
+	{name: "CONCURRENCY", tp: mysql.TypeLonglong, size: 21},
+	{name: "TARGET_SCOPE", tp: mysql.TypeVarchar, size: 256},
+	{name: "SCHEDULER_ID", tp: mysql.TypeVarchar, size: 261},
+	{name: "CREATE_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "START_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "STATE_UPDATE_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "ROW_COUNT", tp: mysql.TypeLonglong, size: 21},
+	{name: "BYTES", tp: mysql.TypeLonglong, size: 21},
+	{name: "PROGRESS", tp: mysql.TypeDouble, size: 22},
+	{name: "ERROR", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+}
+
+var tableTiDBBackgroundSubtasksCols = []columnInfo{
+	{name: "ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+	{name: "TASK_ID", tp: mysql.TypeLonglong, size: 21},
+	{name: "TYPE", tp: mysql.TypeVarchar, size: 256},
+	{name: "STEP", tp: mysql.TypeVarchar, size: 64},
+	{name: "ORDINAL", tp: mysql.TypeLonglong, size: 21},
+	{name: "STATE", tp: mysql.TypeVarchar, size: 64},
+	{name: "EXEC_ID", tp: mysql.TypeVarchar, size: 261},
+	{name: "CONCURRENCY", tp: mysql.TypeLonglong, size: 21},
+	{name: "CREATE_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "START_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "STATE_UPDATE_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "SUMMARY", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "ERROR", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+}
+
 // GetShardingInfo returns a nil or description string for the sharding information of given TableInfo.
 // The returned description string may be:
 //   - "NOT_SHARDED": for tables that SHARD_ROW_ID_BITS is not specified.
@@ -2342,12 +2415,17 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableUserAttributes:                     tableUserAttributesCols,
 	TableMemoryUsage:                        tableMemoryUsageCols,
 	TableMemoryUsageOpsHistory:              tableMemoryUsageOpsHistoryCols,
+	TableMemoryUsageByModule:                tableMemoryUsageByModuleCols,
 	TableResourceGroups:                     tableResourceGroupsCols,
 	TableRunawayWatches:                     tableRunawayWatchListCols,
 	TableCheckConstraints:                   tableCheckConstraintsCols,
 	TableTiDBCheckConstraints:               tableTiDBCheckConstraintsCols,
 	TableKeywords:                           tableKeywords,
 	TableTiDBIndexUsage:                     tableTiDBIndexUsage,
+	TableClusterCapacity:                    tableClusterCapacityCols,
+	TableResourceGroupsRuntime:              tableResourceGroupsRuntimeCols,
+	TableTiDBGlobalTasks:                    tableTiDBGlobalTasksCols,
+	TableTiDBBackgroundSubtasks:             tableTiDBBackgroundSubtasksCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocators, meta *model.TableInfo) (table.Table, error) {
@@ -539,6 +539,9 @@ type Request struct {
 	// ResponseIterator.Next is called. If concurrency is greater than 1, the request will be
 	// sent to multiple storage units concurrently.
 	Concurrency int
+	// AdaptiveConcurrency indicates whether the concurrency is adapted to the queueing latency of the
+	// storage, Concurrency is the upper limit then.
+	AdaptiveConcurrency bool
 	// IsolationLevel is the isolation level, default is SI.
 	IsolationLevel IsoLevel
 	// Priority is the priority of this KV request, its value may be PriorityNormal/PriorityLow/PriorityHigh.
@@ -599,6 +602,8 @@ type Request struct {
 	TiKVClientReadTimeout uint64
 
 	RunawayChecker *resourcegroup.RunawayChecker
+	// StmtRUQuota is the max RU the statement can consume, 0 means no limit.
+	StmtRUQuota uint64
 
 	// ConnID stores the session connection id.
 	ConnID uint64
@@ -114,6 +114,8 @@ const (
 	SizeLimits
 	// SessionID marks the connection id, for logging and tracing.
 	SessionID
+	// StmtRUQuota sets the max RU the statement can consume, 0 means no limit.
+	StmtRUQuota
 )
 
 // TxnSizeLimits is the argument type for `SizeLimits` option
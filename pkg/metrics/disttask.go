@@ -28,6 +28,9 @@ const (
 	lblTaskID     = "task_id"
 	lblSubTaskID  = "subtask_id"
 	lblExecID     = "exec_id"
+	lblTaskStep   = "step"
+	lblTaskState  = "state"
+	lblStorageOp  = "op"
 )
 
 // status for task
@@ -45,6 +48,18 @@ var (
 	DistTaskStartTimeGauge *prometheus.GaugeVec
 	// DistTaskUsedSlotsGauge is the gauge of used slots on executor node.
 	DistTaskUsedSlotsGauge *prometheus.GaugeVec
+	// DistTaskScheduleDuration is the histogram of the time a task waits before
+	// it's scheduled.
+	DistTaskScheduleDuration *prometheus.HistogramVec
+	// DistTaskSubtaskDuration is the histogram of the time used to run a subtask.
+	DistTaskSubtaskDuration *prometheus.HistogramVec
+	// DistTaskSubtaskRetryCounter is the counter of retried subtasks.
+	DistTaskSubtaskRetryCounter *prometheus.CounterVec
+	// DistTaskFinishedCounter is the counter of finished tasks by their final state.
+	DistTaskFinishedCounter *prometheus.CounterVec
+	// DistTaskStorageFailureCounter is the counter of failed task storage
+	// operations in scheduler.
+	DistTaskStorageFailureCounter *prometheus.CounterVec
 )
 
 // InitDistTaskMetrics initializes disttask metrics.
@@ -71,6 +86,43 @@ func InitDistTaskMetrics() {
 			Name:      "used_slots",
 			Help:      "Gauge of used slots on a executor node.",
 		}, []string{"service_scope"})
+	DistTaskScheduleDuration = NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "disttask",
+			Name:      "schedule_duration_seconds",
+			Help:      "Bucketed histogram of the time a task waits before it's scheduled.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 20), // 10ms ~ 1.5hours
+		}, []string{lblTaskType})
+	DistTaskSubtaskDuration = NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "disttask",
+			Name:      "subtask_duration_seconds",
+			Help:      "Bucketed histogram of the time used to run a subtask.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 24), // 10ms ~ 24hours
+		}, []string{lblTaskType, lblTaskStep})
+	DistTaskSubtaskRetryCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "disttask",
+			Name:      "subtask_retry_total",
+			Help:      "Counter of retried subtasks.",
+		}, []string{lblTaskType})
+	DistTaskFinishedCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "disttask",
+			Name:      "finished_task_total",
+			Help:      "Counter of finished tasks by their final state.",
+		}, []string{lblTaskType, lblTaskState})
+	DistTaskStorageFailureCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "disttask",
+			Name:      "storage_failure_total",
+			Help:      "Counter of failed task storage operations in scheduler.",
+		}, []string{lblTaskType, lblStorageOp})
 }
 
 // UpdateMetricsForAddTask update metrics when a task is added
@@ -80,10 +132,15 @@ func UpdateMetricsForAddTask(task *proto.TaskBase) {
 }
 
 // UpdateMetricsForScheduleTask update metrics when a task is added
-func UpdateMetricsForScheduleTask(id int64, taskType proto.TaskType) {
+func UpdateMetricsForScheduleTask(task *proto.TaskBase) {
+	taskType, id := task.Type, task.ID
 	DistTaskGauge.WithLabelValues(taskType.String(), WaitingStatus).Dec()
 	DistTaskStartTimeGauge.DeleteLabelValues(taskType.String(), WaitingStatus, fmt.Sprint(id))
 	DistTaskStartTimeGauge.WithLabelValues(taskType.String(), SchedulingStatus, fmt.Sprint(id)).SetToCurrentTime()
+	// tasks which have been scheduled before owner change are not counted.
+	if task.State == proto.TaskStatePending {
+		DistTaskScheduleDuration.WithLabelValues(taskType.String()).Observe(time.Since(task.CreateTime).Seconds())
+	}
 }
 
 // UpdateMetricsForRunTask update metrics when a task starts running
@@ -97,4 +154,5 @@ func UpdateMetricsForRunTask(task *proto.Task) {
 func UpdateMetricsForFinishTask(task *proto.Task) {
 	DistTaskGauge.WithLabelValues(task.Type.String(), RunningStatus).Dec()
 	DistTaskGauge.WithLabelValues(task.Type.String(), CompletedStatus).Inc()
+	DistTaskFinishedCounter.WithLabelValues(task.Type.String(), task.State.String()).Inc()
 }
@@ -39,6 +39,9 @@ var (
 
 	// MppCoordinatorLatency records latencies of mpp coordinator operations.
 	MppCoordinatorLatency *prometheus.HistogramVec
+
+	// StmtMemoryQueueLength records the number of statements queued because of the memory usage.
+	StmtMemoryQueueLength prometheus.Gauge
 )
 
 // InitExecutorMetrics initializes excutor metrics.
@@ -101,4 +104,12 @@ func InitExecutorMetrics() {
 			Help:      "Bucketed histogram of processing time (ms) of mpp coordinator operations.",
 			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 28), // 1ms ~ 1.5days
 		}, []string{LblType})
+
+	StmtMemoryQueueLength = NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "tidb",
+			Subsystem: "executor",
+			Name:      "statement_memory_queue_length",
+			Help:      "Number of statements waiting for the memory usage of the instance to drop below the watermark.",
+		})
 }
@@ -31,6 +31,12 @@ var (
 
 	MetaHistogram          *prometheus.HistogramVec
 	ResetAutoIDConnCounter prometheus.Counter
+
+	PrefixScan   = "scan"
+	PrefixDelete = "delete"
+
+	PrefixHelperHistogram   *prometheus.HistogramVec
+	PrefixHelperKeysCounter *prometheus.CounterVec
 )
 
 // InitMetaMetrics initializes meta metrics.
@@ -60,4 +66,21 @@ func InitMetaMetrics() {
 			Name:      "autoid_client_conn_reset_total",
 			Help:      "Counter of resetting autoid client connection.",
 		})
+
+	PrefixHelperHistogram = NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "meta",
+			Name:      "prefix_operation_duration_seconds",
+			Help:      "Bucketed histogram of processing time (s) of scanning or deleting keys with a prefix.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 29), // 0.5ms ~ 1.5days
+		}, []string{LblType})
+
+	PrefixHelperKeysCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "meta",
+			Name:      "prefix_operation_keys_total",
+			Help:      "Counter of keys scanned or deleted with a prefix.",
+		}, []string{LblType})
 }
@@ -160,6 +160,8 @@ func RegisterMetrics() {
 	prometheus.MustRegister(LoadSchemaCounter)
 	prometheus.MustRegister(LoadSchemaDuration)
 	prometheus.MustRegister(MetaHistogram)
+	prometheus.MustRegister(PrefixHelperHistogram)
+	prometheus.MustRegister(PrefixHelperKeysCounter)
 	prometheus.MustRegister(NewSessionHistogram)
 	prometheus.MustRegister(OwnerHandleSyncerHistogram)
 	prometheus.MustRegister(PanicCounter)
@@ -188,6 +190,7 @@ func RegisterMetrics() {
 	prometheus.MustRegister(OngoingTxnDurationHistogram)
 	prometheus.MustRegister(MppCoordinatorStats)
 	prometheus.MustRegister(MppCoordinatorLatency)
+	prometheus.MustRegister(StmtMemoryQueueLength)
 	prometheus.MustRegister(TimeJumpBackCounter)
 	prometheus.MustRegister(TransactionDuration)
 	prometheus.MustRegister(StatementDeadlockDetectDuration)
@@ -221,6 +224,8 @@ func RegisterMetrics() {
 	prometheus.MustRegister(TopSQLIgnoredCounter)
 	prometheus.MustRegister(TopSQLReportDurationHistogram)
 	prometheus.MustRegister(TopSQLReportDataHistogram)
+	prometheus.MustRegister(TopSQLResourceGroupCPUCounter)
+	prometheus.MustRegister(TopSQLResourceGroupRUCounter)
 	prometheus.MustRegister(PDAPIExecutionHistogram)
 	prometheus.MustRegister(PDAPIRequestCounter)
 	prometheus.MustRegister(CPUProfileCounter)
@@ -271,7 +276,14 @@ func RegisterMetrics() {
 	prometheus.MustRegister(DistTaskGauge)
 	prometheus.MustRegister(DistTaskStartTimeGauge)
 	prometheus.MustRegister(DistTaskUsedSlotsGauge)
+	prometheus.MustRegister(DistTaskScheduleDuration)
+	prometheus.MustRegister(DistTaskSubtaskDuration)
+	prometheus.MustRegister(DistTaskSubtaskRetryCounter)
+	prometheus.MustRegister(DistTaskFinishedCounter)
+	prometheus.MustRegister(DistTaskStorageFailureCounter)
 	prometheus.MustRegister(RunawayCheckerCounter)
+	prometheus.MustRegister(ResourceGroupAdmissionRejectedCounter)
+	prometheus.MustRegister(InternalRUCounter)
 	prometheus.MustRegister(GlobalSortWriteToCloudStorageDuration)
 	prometheus.MustRegister(GlobalSortWriteToCloudStorageRate)
 	prometheus.MustRegister(GlobalSortReadFromCloudStorageDuration)
@@ -16,8 +16,13 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,3 +30,26 @@ func TestRetLabel(t *testing.T) {
 	require.Equal(t, opSucc, RetLabel(nil))
 	require.Equal(t, opFailed, RetLabel(errors.New("test error")))
 }
+
+func TestDistTaskMetrics(t *testing.T) {
+	InitDistTaskMetrics()
+	task := &proto.TaskBase{ID: 1, Type: proto.TaskTypeExample, State: proto.TaskStatePending, CreateTime: time.Now()}
+	UpdateMetricsForAddTask(task)
+	UpdateMetricsForScheduleTask(task)
+	require.Equal(t, 1, testutil.CollectAndCount(DistTaskScheduleDuration))
+	// task scheduled again after owner change isn't observed.
+	task.State = proto.TaskStateRunning
+	UpdateMetricsForScheduleTask(task)
+	require.Equal(t, uint64(1), getHistogramSampleCount(t, DistTaskScheduleDuration.WithLabelValues(proto.TaskTypeExample.String())))
+
+	UpdateMetricsForFinishTask(&proto.Task{TaskBase: proto.TaskBase{ID: 1, Type: proto.TaskTypeExample, State: proto.TaskStateSucceed}})
+	UpdateMetricsForFinishTask(&proto.Task{TaskBase: proto.TaskBase{ID: 2, Type: proto.TaskTypeExample, State: proto.TaskStateFailed}})
+	require.Equal(t, float64(1), testutil.ToFloat64(DistTaskFinishedCounter.WithLabelValues(proto.TaskTypeExample.String(), proto.TaskStateSucceed.String())))
+	require.Equal(t, float64(1), testutil.ToFloat64(DistTaskFinishedCounter.WithLabelValues(proto.TaskTypeExample.String(), proto.TaskStateFailed.String())))
+}
+
+func getHistogramSampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	m := &dto.Metric{}
+	require.NoError(t, observer.(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
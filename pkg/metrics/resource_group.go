@@ -19,7 +19,9 @@ import "github.com/prometheus/client_golang/prometheus"
 // Metrics
 // Query duration by query is QueryDurationHistogram in `server.go`.
 var (
-	RunawayCheckerCounter *prometheus.CounterVec
+	RunawayCheckerCounter                 *prometheus.CounterVec
+	ResourceGroupAdmissionRejectedCounter *prometheus.CounterVec
+	InternalRUCounter                     *prometheus.CounterVec
 )
 
 // InitResourceGroupMetrics initializes resource group metrics.
@@ -31,4 +33,20 @@ func InitResourceGroupMetrics() {
 			Name:      "query_runaway_check",
 			Help:      "Counter of query triggering runaway check.",
 		}, []string{LblResourceGroup, LblType, LblAction})
+
+	ResourceGroupAdmissionRejectedCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "server",
+			Name:      "resource_group_admission_rejected",
+			Help:      "Counter of statements rejected because the resource group has been throttled too long.",
+		}, []string{LblResourceGroup})
+
+	InternalRUCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "server",
+			Name:      "internal_request_unit",
+			Help:      "Counter of request units consumed by internal restricted SQLs.",
+		}, []string{LblResourceGroup, LblType})
 }
@@ -21,6 +21,8 @@ var (
 	TopSQLIgnoredCounter          *prometheus.CounterVec
 	TopSQLReportDurationHistogram *prometheus.HistogramVec
 	TopSQLReportDataHistogram     *prometheus.HistogramVec
+	TopSQLResourceGroupCPUCounter *prometheus.CounterVec
+	TopSQLResourceGroupRUCounter  *prometheus.CounterVec
 )
 
 // InitTopSQLMetrics initializes top-sql metrics.
@@ -50,4 +52,20 @@ func InitTopSQLMetrics() {
 			Help:      "Bucket histogram of reporting records/sql/plan count to the top-sql agent.",
 			Buckets:   prometheus.ExponentialBuckets(1, 2, 20), // 1 ~ 524288
 		}, []string{LblType})
+
+	TopSQLResourceGroupCPUCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "topsql",
+			Name:      "resource_group_cpu_time_ms",
+			Help:      "Counter of the cpu time (ms) consumed by the SQLs of each resource group collected by top-sql.",
+		}, []string{LblResourceGroup})
+
+	TopSQLResourceGroupRUCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "topsql",
+			Name:      "resource_group_request_unit",
+			Help:      "Counter of the request units consumed by the SQLs of each resource group collected by top-sql.",
+		}, []string{LblResourceGroup})
 }
@@ -2195,6 +2195,7 @@ const (
 	ResourceBurstableOpiton
 	ResourceGroupRunaway
 	ResourceGroupBackground
+	ResourceGroupAdmissionTimeout
 )
 
 func (n *ResourceGroupOption) Restore(ctx *format.RestoreCtx) error {
@@ -2257,6 +2258,10 @@ func (n *ResourceGroupOption) Restore(ctx *format.RestoreCtx) error {
 		} else {
 			ctx.WritePlain("NULL")
 		}
+	case ResourceGroupAdmissionTimeout:
+		ctx.WriteKeyWord("ADMISSION_TIMEOUT ")
+		ctx.WritePlain("= ")
+		ctx.WriteString(n.StrValue)
 	default:
 		return errors.Errorf("invalid ResourceGroupOption: %d", n.Tp)
 	}
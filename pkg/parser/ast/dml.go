@@ -3051,6 +3051,11 @@ const (
 	ShowCreateProcedure
 	ShowBinlogStatus
 	ShowReplicaStatus
+	ShowTaskHistory
+	// ShowTasks is used by ADMIN SHOW TASKS, it's not a SHOW statement.
+	ShowTasks
+	ShowLoadDataJobs
+	ShowBindingEvolveTasks
 )
 
 const (
@@ -3099,7 +3104,9 @@ type ShowStmt struct {
 	ShowProfileArgs  *int64 // Used for `SHOW PROFILE` syntax
 	ShowProfileLimit *Limit // Used for `SHOW PROFILE` syntax
 
-	ImportJobID *int64 // Used for `SHOW IMPORT JOB <ID>` syntax
+	ImportJobID   *int64 // Used for `SHOW IMPORT JOB <ID>` syntax
+	TaskID        int64  // Used for `SHOW TASK <ID> HISTORY` syntax
+	LoadDataJobID *int64 // Used for `SHOW LOAD DATA JOB <ID>` syntax
 }
 
 // Restore implements Node interface.
@@ -3318,6 +3325,18 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteKeyWord("IMPORT JOBS")
 			restoreShowLikeOrWhereOpt()
 		}
+	case ShowLoadDataJobs:
+		if n.LoadDataJobID != nil {
+			ctx.WriteKeyWord("LOAD DATA JOB ")
+			ctx.WritePlainf("%d", *n.LoadDataJobID)
+		} else {
+			ctx.WriteKeyWord("LOAD DATA JOBS")
+			restoreShowLikeOrWhereOpt()
+		}
+	case ShowTaskHistory:
+		ctx.WriteKeyWord("TASK ")
+		ctx.WritePlainf("%d", n.TaskID)
+		ctx.WriteKeyWord(" HISTORY")
 	// ShowTargetFilterable
 	default:
 		switch n.Tp {
@@ -3393,6 +3412,8 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteKeyWord("BINDINGS")
 		case ShowBindingCacheStatus:
 			ctx.WriteKeyWord("BINDING_CACHE STATUS")
+		case ShowBindingEvolveTasks:
+			ctx.WriteKeyWord("BINDING EVOLVE TASKS")
 		case ShowPumpStatus:
 			ctx.WriteKeyWord("PUMP STATUS")
 		case ShowDrainerStatus:
@@ -3504,7 +3525,7 @@ func (n *ShowStmt) NeedLimitRSRow() bool {
 		//    ShowCreateSequence, ShowCreatePlacementPolicy, ShowConfig, ShowStatsExtended,
 		//    ShowStatsMeta, ShowStatsHistograms, ShowStatsTopN, ShowStatsBuckets, ShowStatsHealthy
 		//    ShowHistogramsInFlight, ShowColumnStatsUsage, ShowBindings, ShowBindingCacheStatus,
-		//    ShowPumpStatus, ShowDrainerStatus, ShowAnalyzeStatus, ShowRegions, ShowBuiltins,
+		//    ShowBindingEvolveTasks, ShowPumpStatus, ShowDrainerStatus, ShowAnalyzeStatus, ShowRegions, ShowBuiltins,
 		//    ShowTableNextRowId, ShowBackups, ShowRestores, ShowImports, ShowCreateImport, ShowPlacement
 		//    ShowPlacementForDatabase, ShowPlacementForTable, ShowPlacementForPartition, ShowPlacementLabels
 		//
@@ -3622,8 +3643,10 @@ type SelectIntoOption struct {
 
 	Tp         SelectIntoType
 	FileName   string
+	Format     *string
 	FieldsInfo *FieldsClause
 	LinesInfo  *LinesClause
+	Options    []*LoadDataOpt
 }
 
 // Restore implements Node interface.
@@ -3635,6 +3658,10 @@ func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
 
 	ctx.WriteKeyWord("INTO OUTFILE ")
 	ctx.WriteString(n.FileName)
+	if n.Format != nil {
+		ctx.WriteKeyWord(" FORMAT ")
+		ctx.WriteString(*n.Format)
+	}
 	if n.FieldsInfo != nil {
 		if err := n.FieldsInfo.Restore(ctx); err != nil {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.FieldsInfo")
@@ -3645,6 +3672,18 @@ func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.LinesInfo")
 		}
 	}
+	if len(n.Options) > 0 {
+		ctx.WriteKeyWord(" WITH")
+		for i, option := range n.Options {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			ctx.WritePlain(" ")
+			if err := option.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore SelectInto.Options")
+			}
+		}
+	}
 	return nil
 }
 
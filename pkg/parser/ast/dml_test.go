@@ -538,6 +538,31 @@ func TestImportActions(t *testing.T) {
 	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
 }
 
+func TestLoadDataActions(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			sourceSQL: "cancel load data job 123",
+			expectSQL: "CANCEL LOAD DATA JOB 123",
+		},
+		{
+			sourceSQL: "show load data jobs",
+			expectSQL: "SHOW LOAD DATA JOBS",
+		},
+		{
+			sourceSQL: "show load data job 123",
+			expectSQL: "SHOW LOAD DATA JOB 123",
+		},
+		{
+			sourceSQL: "show load data jobs where aa > 1",
+			expectSQL: "SHOW LOAD DATA JOBS WHERE `aa`>1",
+		},
+	}
+	extractNodeFunc := func(node Node) Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
+
 func TestImportIntoRestore(t *testing.T) {
 	testCases := []NodeRestoreTestCase{
 		{
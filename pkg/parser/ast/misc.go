@@ -985,6 +985,32 @@ func (n *FlushStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// FetchResultStmt is a statement to fetch the next batch of a result set, which is split into
+// batches by tidb_result_batch_size.
+// FETCH NEXT FROM 'token'
+type FetchResultStmt struct {
+	stmtNode
+
+	Token string
+}
+
+// Restore implements Node interface.
+func (n *FetchResultStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("FETCH NEXT FROM ")
+	ctx.WriteString(n.Token)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *FetchResultStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*FetchResultStmt)
+	return v.Leave(n)
+}
+
 // KillStmt is a statement to kill a query or connection.
 type KillStmt struct {
 	stmtNode
@@ -1005,6 +1031,8 @@ type KillStmt struct {
 	TiDBExtension bool
 
 	Expr ExprNode
+	// SQLDigest is set by "KILL QUERY DIGEST 'digest'", which terminates all the running statements of the SQL digest.
+	SQLDigest string
 }
 
 // Restore implements Node interface.
@@ -1016,7 +1044,10 @@ func (n *KillStmt) Restore(ctx *format.RestoreCtx) error {
 	if n.Query {
 		ctx.WriteKeyWord(" QUERY")
 	}
-	if n.Expr != nil {
+	if n.SQLDigest != "" {
+		ctx.WriteKeyWord(" DIGEST ")
+		ctx.WriteString(n.SQLDigest)
+	} else if n.Expr != nil {
 		ctx.WriteKeyWord(" ")
 		if err := n.Expr.Restore(ctx); err != nil {
 			return errors.Trace(err)
@@ -2337,6 +2368,12 @@ const (
 	AdminSetBDRRole
 	AdminShowBDRRole
 	AdminUnsetBDRRole
+	AdminCancelTask
+	AdminCleanupTaskHistory
+	AdminShowTasks
+	AdminReloadClusterConfig
+	AdminCalibrateCostModel
+	AdminAlterTaskPriority
 )
 
 // HandleRange represents a range where handle value >= Begin and < End.
@@ -2395,6 +2432,7 @@ const (
 	StatementScopeSession
 	StatementScopeInstance
 	StatementScopeGlobal
+	StatementScopeCluster
 )
 
 // ShowSlowType defines the type for SlowSlow statement.
@@ -2476,6 +2514,8 @@ type AdminStmt struct {
 	StatementScope StatementScope
 	LimitSimple    LimitSimple
 	BDRRole        BDRRole
+	// Priority is the new priority of the task, it's used by ADMIN ALTER TASK.
+	Priority int64
 }
 
 // Restore implements Node interface.
@@ -2515,6 +2555,14 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 				return errors.Annotate(err, "An error occurred while restore ShowStmt.Where")
 			}
 		}
+	case AdminShowTasks:
+		ctx.WriteKeyWord("SHOW TASKS")
+		if n.Where != nil {
+			ctx.WriteKeyWord(" WHERE ")
+			if err := n.Where.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore AdminStmt.Where")
+			}
+		}
 	case AdminShowNextRowID:
 		ctx.WriteKeyWord("SHOW ")
 		if err := restoreTables(); err != nil {
@@ -2567,6 +2615,16 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 	case AdminCancelDDLJobs:
 		ctx.WriteKeyWord("CANCEL DDL JOBS ")
 		restoreJobIDs()
+	case AdminCancelTask:
+		ctx.WriteKeyWord("CANCEL TASK ")
+		restoreJobIDs()
+	case AdminCleanupTaskHistory:
+		ctx.WriteKeyWord("CLEANUP TASK HISTORY")
+	case AdminAlterTaskPriority:
+		ctx.WriteKeyWord("ALTER TASK ")
+		restoreJobIDs()
+		ctx.WriteKeyWord(" PRIORITY ")
+		ctx.WritePlainf("= %d", n.Priority)
 	case AdminPauseDDLJobs:
 		ctx.WriteKeyWord("PAUSE DDL JOBS ")
 		restoreJobIDs()
@@ -2618,6 +2676,10 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("RELOAD BINDINGS")
 	case AdminReloadStatistics:
 		ctx.WriteKeyWord("RELOAD STATS_EXTENDED")
+	case AdminReloadClusterConfig:
+		ctx.WriteKeyWord("RELOAD CLUSTER_CONFIG")
+	case AdminCalibrateCostModel:
+		ctx.WriteKeyWord("CALIBRATE COST MODEL")
 	case AdminFlushPlanCache:
 		if n.StatementScope == StatementScopeSession {
 			ctx.WriteKeyWord("FLUSH SESSION PLAN_CACHE")
@@ -2625,6 +2687,8 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteKeyWord("FLUSH INSTANCE PLAN_CACHE")
 		} else if n.StatementScope == StatementScopeGlobal {
 			ctx.WriteKeyWord("FLUSH GLOBAL PLAN_CACHE")
+		} else if n.StatementScope == StatementScopeCluster {
+			ctx.WriteKeyWord("FLUSH CLUSTER PLAN_CACHE")
 		}
 	case AdminSetBDRRole:
 		switch n.BDRRole {
@@ -3645,6 +3709,34 @@ func (n *ImportIntoActionStmt) Restore(ctx *format.RestoreCtx) error {
 	return nil
 }
 
+type LoadDataActionTp string
+
+const (
+	LoadDataCancel LoadDataActionTp = "cancel"
+)
+
+// LoadDataActionStmt represent CANCEL LOAD DATA JOB statement.
+type LoadDataActionStmt struct {
+	stmtNode
+
+	Tp    LoadDataActionTp
+	JobID int64
+}
+
+func (n *LoadDataActionStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+func (n *LoadDataActionStmt) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp != LoadDataCancel {
+		return errors.Errorf("invalid LOAD DATA action type: %s", n.Tp)
+	}
+	ctx.WriteKeyWord("CANCEL LOAD DATA JOB ")
+	ctx.WritePlainf("%d", n.JobID)
+	return nil
+}
+
 // Ident is the table identifier composed of schema name and table name.
 type Ident struct {
 	Schema model.CIStr
@@ -3929,11 +4021,16 @@ type CalibrateResourceStmt struct {
 	stmtNode
 	DynamicCalibrateResourceOptionList []*DynamicCalibrateResourceOption
 	Tp                                 CalibrateResourceType
+	// Groups indicates recommending the RU_PER_SEC of every resource group instead of the cluster capacity.
+	Groups bool
 }
 
 // Restore implements Node interface.
 func (n *CalibrateResourceStmt) Restore(ctx *format.RestoreCtx) error {
 	ctx.WriteKeyWord("CALIBRATE RESOURCE")
+	if n.Groups {
+		ctx.WriteKeyWord(" GROUPS")
+	}
 	if err := n.Tp.Restore(ctx); err != nil {
 		return errors.Annotate(err, "An error occurred while restore CalibrateResourceStmt.CalibrateResourceType")
 	}
@@ -160,6 +160,7 @@ var tokenMap = map[string]int{
 	"ACTION":                   action,
 	"ADD":                      add,
 	"ADDDATE":                  addDate,
+	"ADMISSION_TIMEOUT":        admissionTimeout,
 	"ADMIN":                    admin,
 	"ADVISE":                   advise,
 	"AFTER":                    after,
@@ -248,6 +249,7 @@ var tokenMap = map[string]int{
 	"CLIENT_ERRORS_SUMMARY":    clientErrorsSummary,
 	"CLOSE":                    close,
 	"CLUSTER":                  cluster,
+	"CLUSTER_CONFIG":           clusterConfig,
 	"CLUSTERED":                clustered,
 	"CMSKETCH":                 cmSketch,
 	"COALESCE":                 coalesce,
@@ -276,6 +278,7 @@ var tokenMap = map[string]int{
 	"COOLDOWN":                 cooldown,
 	"COPY":                     copyKwd,
 	"CORRELATION":              correlation,
+	"COST":                     cost,
 	"CPU":                      cpu,
 	"CREATE":                   create,
 	"CROSS":                    cross,
@@ -550,6 +553,7 @@ var tokenMap = map[string]int{
 	"MINVALUE":                 minValue,
 	"MOD":                      mod,
 	"MODE":                     mode,
+	"MODEL":                    modelKwd,
 	"MODIFY":                   modify,
 	"MONTH":                    month,
 	"NAMES":                    names,
@@ -802,6 +806,8 @@ var tokenMap = map[string]int{
 	"SYSTEM":                   system,
 	"SYSTEM_TIME":              systemTime,
 	"TARGET":                   target,
+	"TASK":                     task,
+	"TASKS":                    tasks,
 	"TASK_TYPES":               taskTypes,
 	"TABLE_CHECKSUM":           tableChecksum,
 	"TABLE":                    tableKwd,
@@ -2027,6 +2027,9 @@ type ResourceGroupSettings struct {
 	BurstLimit       int64                            `json:"burst_limit"`
 	Runaway          *ResourceGroupRunawaySettings    `json:"runaway"`
 	Background       *ResourceGroupBackgroundSettings `json:"background"`
+	// AdmissionTimeoutMs is the max duration in milliseconds that the resource group can be throttled before
+	// new statements are rejected, 0 means statements are always admitted.
+	AdmissionTimeoutMs uint64 `json:"admission_timeout_ms"`
 }
 
 // NewResourceGroupSettings creates a new ResourceGroupSettings.
@@ -2100,6 +2103,9 @@ func (p *ResourceGroupSettings) String() string {
 	if p.Background != nil {
 		fmt.Fprintf(sb, ", BACKGROUND=(TASK_TYPES='%s')", strings.Join(p.Background.JobTypes, ","))
 	}
+	if p.AdmissionTimeoutMs > 0 {
+		writeSettingDurationToBuilder(sb, "ADMISSION_TIMEOUT", time.Duration(p.AdmissionTimeoutMs)*time.Millisecond, separatorFn)
+	}
 
 	return sb.String()
 }
@@ -694,6 +694,7 @@ import (
 
 	/* The following tokens belong to NotKeywordToken. Notice: make sure these tokens are contained in NotKeywordToken. */
 	addDate               "ADDDATE"
+	admissionTimeout      "ADMISSION_TIMEOUT"
 	approxCountDistinct   "APPROX_COUNT_DISTINCT"
 	approxPercentile      "APPROX_PERCENTILE"
 	background            "BACKGROUND"
@@ -705,9 +706,11 @@ import (
 	briefType             "BRIEF"
 	burstable             "BURSTABLE"
 	cast                  "CAST"
+	clusterConfig         "CLUSTER_CONFIG"
 	constraints           "CONSTRAINTS"
 	cooldown              "COOLDOWN"
 	copyKwd               "COPY"
+	cost                  "COST"
 	curDate               "CURDATE"
 	curTime               "CURTIME"
 	dateAdd               "DATE_ADD"
@@ -748,6 +751,7 @@ import (
 	medium                "MEDIUM"
 	metadata              "METADATA"
 	min                   "MIN"
+	modelKwd              "MODEL"
 	next_row_id           "NEXT_ROW_ID"
 	now                   "NOW"
 	optRuleBlacklist      "OPT_RULE_BLACKLIST"
@@ -782,6 +786,8 @@ import (
 	sum                   "SUM"
 	survivalPreferences   "SURVIVAL_PREFERENCES"
 	target                "TARGET"
+	task                  "TASK"
+	tasks                 "TASKS"
 	taskTypes             "TASK_TYPES"
 	tidbJson              "TIDB_JSON"
 	timeDuration          "DURATION"
@@ -1000,6 +1006,7 @@ import (
 	ExecuteStmt                "Execute statement"
 	ExplainStmt                "EXPLAIN statement"
 	ExplainableStmt            "explainable statement"
+	FetchResultStmt            "Fetch result statement"
 	FlushStmt                  "Flush statement"
 	FlashbackTableStmt         "Flashback table statement"
 	FlashbackToTimestampStmt   "Flashback cluster statement"
@@ -1060,6 +1067,7 @@ import (
 	HelpStmt                   "HELP statement"
 	ShardableStmt              "Shardable statement that can be used in non-transactional DMLs"
 	CancelImportStmt           "CANCEL IMPORT JOB statement"
+	CancelLoadDataStmt         "CANCEL LOAD DATA JOB statement"
 	ProcedureUnlabeledBlock    "The statement block without label in procedure"
 	ProcedureBlockContent      "The statement block in procedure expressed with 'Begin ... End'"
 	SimpleWhenThen             "Procedure case when then"
@@ -1214,6 +1222,7 @@ import (
 	LinesTerminated                        "Lines terminated by"
 	LoadDataSetSpecOpt                     "Optional load data specification"
 	LoadDataOptionListOpt                  "Optional load data option list"
+	SelectIntoOptionListOpt                "Optional SELECT INTO OUTFILE option list"
 	LoadDataOptionList                     "Load data option list"
 	LoadDataOption                         "Load data option"
 	LoadDataSetList                        "Load data specifications"
@@ -1557,6 +1566,7 @@ import (
 	FirstOrNext       "FIRST or NEXT"
 	RowOrRows         "ROW or ROWS"
 	Replica           "{REPLICA | SLAVE}"
+	CalibrateGroups   "GROUPS keyword of CALIBRATE RESOURCE GROUPS"
 
 %type	<ident>
 	Identifier                      "identifier or unreserved keyword"
@@ -1903,6 +1913,15 @@ DirectResourceGroupOption:
 	{
 		$$ = &ast.ResourceGroupOption{Tp: ast.ResourceGroupBackground, BackgroundOptions: nil}
 	}
+|	"ADMISSION_TIMEOUT" EqOpt stringLit
+	{
+		_, err := time.ParseDuration($3)
+		if err != nil {
+			yylex.AppendError(yylex.Errorf("The ADMISSION_TIMEOUT option is not a valid duration: %s", err.Error()))
+			return 1
+		}
+		$$ = &ast.ResourceGroupOption{Tp: ast.ResourceGroupAdmissionTimeout, StrValue: $3}
+	}
 
 ResourceGroupBackgroundOptionList:
 	DirectResourceGroupBackgroundOption
@@ -5928,6 +5947,15 @@ CancelImportStmt:
 		}
 	}
 
+CancelLoadDataStmt:
+	"CANCEL" "LOAD" "DATA" "JOB" Int64Num
+	{
+		$$ = &ast.LoadDataActionStmt{
+			Tp:    ast.LoadDataCancel,
+			JobID: $5.(int64),
+		}
+	}
+
 Expression:
 	singleAtIdentifier assignmentEq Expression %prec assignmentEq
 	{
@@ -7133,6 +7161,12 @@ NotKeywordToken:
 |	"QUERY_LIMIT"
 |	"BACKGROUND"
 |	"TASK_TYPES"
+|	"ADMISSION_TIMEOUT"
+|	"TASK"
+|	"TASKS"
+|	"CLUSTER_CONFIG"
+|	"COST"
+|	"MODEL"
 |	"UNLIMITED"
 
 /************************************************************************************
@@ -10115,17 +10149,19 @@ SelectStmtIntoOption:
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	"INTO" "OUTFILE" stringLit FormatOpt Fields Lines SelectIntoOptionListOpt
 	{
 		x := &ast.SelectIntoOption{
 			Tp:       ast.SelectIntoOutfile,
 			FileName: $3,
-		}
-		if $4 != nil {
-			x.FieldsInfo = $4.(*ast.FieldsClause)
+			Format:   $4.(*string),
+			Options:  $7.([]*ast.LoadDataOpt),
 		}
 		if $5 != nil {
-			x.LinesInfo = $5.(*ast.LinesClause)
+			x.FieldsInfo = $5.(*ast.FieldsClause)
+		}
+		if $6 != nil {
+			x.LinesInfo = $6.(*ast.LinesClause)
 		}
 
 		$$ = x
@@ -11029,6 +11065,22 @@ AdminStmt:
 		}
 		$$ = stmt
 	}
+|	"ADMIN" "SHOW" "TASKS" WhereClauseOptional
+	{
+		stmt := &ast.AdminStmt{Tp: ast.AdminShowTasks}
+		if $4 != nil {
+			stmt.Where = $4.(ast.ExprNode)
+		}
+		$$ = stmt
+	}
+|	"ADMIN" "SHOW" "BACKGROUND" "TASKS" WhereClauseOptional
+	{
+		stmt := &ast.AdminStmt{Tp: ast.AdminShowTasks}
+		if $5 != nil {
+			stmt.Where = $5.(ast.ExprNode)
+		}
+		$$ = stmt
+	}
 |	"ADMIN" "SHOW" TableName "NEXT_ROW_ID"
 	{
 		$$ = &ast.AdminStmt{
@@ -11090,6 +11142,27 @@ AdminStmt:
 			JobIDs: $5.([]int64),
 		}
 	}
+|	"ADMIN" "CANCEL" "TASK" NumList
+	{
+		$$ = &ast.AdminStmt{
+			Tp:     ast.AdminCancelTask,
+			JobIDs: $4.([]int64),
+		}
+	}
+|	"ADMIN" "ALTER" "TASK" Int64Num "PRIORITY" EqOpt Int64Num
+	{
+		$$ = &ast.AdminStmt{
+			Tp:       ast.AdminAlterTaskPriority,
+			JobIDs:   []int64{$4.(int64)},
+			Priority: $7.(int64),
+		}
+	}
+|	"ADMIN" "CLEANUP" "TASK" "HISTORY"
+	{
+		$$ = &ast.AdminStmt{
+			Tp: ast.AdminCleanupTaskHistory,
+		}
+	}
 |	"ADMIN" "PAUSE" "DDL" "JOBS" NumList
 	{
 		$$ = &ast.AdminStmt{
@@ -11202,6 +11275,18 @@ AdminStmt:
 			Tp: ast.AdminReloadStatistics,
 		}
 	}
+|	"ADMIN" "RELOAD" "CLUSTER_CONFIG"
+	{
+		$$ = &ast.AdminStmt{
+			Tp: ast.AdminReloadClusterConfig,
+		}
+	}
+|	"ADMIN" "CALIBRATE" "COST" "MODEL"
+	{
+		$$ = &ast.AdminStmt{
+			Tp: ast.AdminCalibrateCostModel,
+		}
+	}
 |	"ADMIN" "FLUSH" StatementScope "PLAN_CACHE"
 	{
 		$$ = &ast.AdminStmt{
@@ -11482,6 +11567,21 @@ ShowStmt:
 			ImportJobID: &v,
 		}
 	}
+|	"SHOW" "LOAD" "DATA" "JOB" Int64Num
+	{
+		v := $5.(int64)
+		$$ = &ast.ShowStmt{
+			Tp:            ast.ShowLoadDataJobs,
+			LoadDataJobID: &v,
+		}
+	}
+|	"SHOW" "TASK" Int64Num "HISTORY"
+	{
+		$$ = &ast.ShowStmt{
+			Tp:     ast.ShowTaskHistory,
+			TaskID: $3.(int64),
+		}
+	}
 |	"SHOW" "CREATE" "PROCEDURE" TableName
 	{
 		$$ = &ast.ShowStmt{
@@ -11727,6 +11827,12 @@ ShowTargetFilterable:
 			Tp: ast.ShowBindingCacheStatus,
 		}
 	}
+|	"BINDING" "EVOLVE" "TASKS"
+	{
+		$$ = &ast.ShowStmt{
+			Tp: ast.ShowBindingEvolveTasks,
+		}
+	}
 |	"PROCEDURE" "STATUS"
 	{
 		$$ = &ast.ShowStmt{
@@ -11832,6 +11938,10 @@ ShowTargetFilterable:
 	{
 		$$ = &ast.ShowStmt{Tp: ast.ShowImportJobs}
 	}
+|	"LOAD" "DATA" "JOBS"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowLoadDataJobs}
+	}
 
 ShowLikeOrWhereOpt:
 	{
@@ -11879,6 +11989,10 @@ StatementScope:
 	{
 		$$ = ast.StatementScopeSession
 	}
+|	"CLUSTER"
+	{
+		$$ = ast.StatementScopeCluster
+	}
 
 OptFull:
 	{
@@ -11908,6 +12022,12 @@ Replica:
 	"REPLICA"
 |	"SLAVE"
 
+FetchResultStmt:
+	"FETCH" "NEXT" "FROM" stringLit
+	{
+		$$ = &ast.FetchResultStmt{Token: $4}
+	}
+
 FlushStmt:
 	"FLUSH" NoWriteToBinLogAliasOpt FlushOption
 	{
@@ -12090,6 +12210,7 @@ Statement:
 |	DropStatisticsStmt
 |	DropStatsStmt
 |	DropBindingStmt
+|	FetchResultStmt
 |	FlushStmt
 |	FlashbackTableStmt
 |	FlashbackToTimestampStmt
@@ -12151,6 +12272,7 @@ Statement:
 |	NonTransactionalDMLStmt
 |	OptimizeTableStmt
 |	CancelImportStmt
+|	CancelLoadDataStmt
 
 TraceableStmt:
 	DeleteFromStmt
@@ -14669,6 +14791,16 @@ LoadDataOptionListOpt:
 		$$ = $2.([]*ast.LoadDataOpt)
 	}
 
+SelectIntoOptionListOpt:
+	%prec lowerThanWith
+	{
+		$$ = []*ast.LoadDataOpt{}
+	}
+|	"WITH" LoadDataOptionList
+	{
+		$$ = $2.([]*ast.LoadDataOpt)
+	}
+
 LoadDataOptionList:
 	LoadDataOption
 	{
@@ -14680,11 +14812,11 @@ LoadDataOptionList:
 	}
 
 LoadDataOption:
-	identifier
+	Identifier
 	{
 		$$ = &ast.LoadDataOpt{Name: strings.ToLower($1)}
 	}
-|	identifier "=" SignedLiteral
+|	Identifier "=" SignedLiteral
 	{
 		$$ = &ast.LoadDataOpt{Name: strings.ToLower($1), Value: $3.(ast.ExprNode)}
 	}
@@ -14895,6 +15027,14 @@ KillStmt:
 			TiDBExtension: $1.(bool),
 		}
 	}
+|	KillOrKillTiDB "QUERY" "DIGEST" stringLit
+	{
+		$$ = &ast.KillStmt{
+			Query:         true,
+			TiDBExtension: $1.(bool),
+			SQLDigest:     $4,
+		}
+	}
 |	KillOrKillTiDB BuiltinFunction
 	{
 		$$ = &ast.KillStmt{
@@ -16099,13 +16239,30 @@ DropProcedureStmt:
  *
  * Calibrate Resource Statement
  *
- * CALIBRATE RESOURCE
+ * CALIBRATE RESOURCE [GROUPS]
  *******************************************************************/
 CalibrateResourceStmt:
 	"CALIBRATE" "RESOURCE" CalibrateOption
 	{
 		$$ = $3.(*ast.CalibrateResourceStmt)
 	}
+|	"CALIBRATE" "RESOURCE" CalibrateGroups CalibrateOption
+	{
+		stmt := $4.(*ast.CalibrateResourceStmt)
+		stmt.Groups = true
+		$$ = stmt
+	}
+
+// GROUPS is only a keyword when window functions are enabled.
+CalibrateGroups:
+	"GROUPS"
+|	identifier
+	{
+		if strings.ToLower($1) != "groups" {
+			yylex.AppendError(yylex.Errorf("Unknown option %s for CALIBRATE RESOURCE", $1))
+			return 1
+		}
+	}
 
 CalibrateOption:
 	{
@@ -483,6 +483,29 @@ func TestAdminStmt(t *testing.T) {
 		{"admin checksum table t1, t2;", true, "ADMIN CHECKSUM TABLE `t1`, `t2`"},
 		{"admin cancel ddl jobs 1", true, "ADMIN CANCEL DDL JOBS 1"},
 		{"admin cancel ddl jobs 1, 2", true, "ADMIN CANCEL DDL JOBS 1, 2"},
+		{"admin cancel task 1", true, "ADMIN CANCEL TASK 1"},
+		{"admin cancel task 1, 2", true, "ADMIN CANCEL TASK 1, 2"},
+		{"admin cancel task", false, ""},
+		{"admin alter task 1 priority = 10", true, "ADMIN ALTER TASK 1 PRIORITY = 10"},
+		{"admin alter task 1 priority 10", true, "ADMIN ALTER TASK 1 PRIORITY = 10"},
+		{"admin alter task 1, 2 priority = 10", false, ""},
+		{"admin alter task 1 priority", false, ""},
+		{"admin reload cluster_config", true, "ADMIN RELOAD CLUSTER_CONFIG"},
+		{"create table cluster_config (cluster_config int)", true, "CREATE TABLE `cluster_config` (`cluster_config` INT)"},
+		{"admin calibrate cost model", true, "ADMIN CALIBRATE COST MODEL"},
+		{"admin calibrate cost", false, ""},
+		{"create table cost (model int)", true, "CREATE TABLE `cost` (`model` INT)"},
+		{"admin show tasks", true, "ADMIN SHOW TASKS"},
+		{"admin show background tasks", true, "ADMIN SHOW TASKS"},
+		{"admin show tasks where state = 'running'", true, "ADMIN SHOW TASKS WHERE `state`=_UTF8MB4'running'"},
+		{"admin show background tasks where type = 'ImportInto' and task_id > 10", true, "ADMIN SHOW TASKS WHERE `type`=_UTF8MB4'ImportInto' AND `task_id`>10"},
+		{"admin show tasks next_row_id", true, "ADMIN SHOW `tasks` NEXT_ROW_ID"},
+		{"create table tasks (tasks int)", true, "CREATE TABLE `tasks` (`tasks` INT)"},
+		{"admin cleanup task history", true, "ADMIN CLEANUP TASK HISTORY"},
+		{"show task 1 history", true, "SHOW TASK 1 HISTORY"},
+		{"show task history", false, ""},
+		{"admin cleanup task", false, ""},
+		{"create table task (task int)", true, "CREATE TABLE `task` (`task` INT)"},
 		{"admin pause ddl jobs 1, 3", true, "ADMIN PAUSE DDL JOBS 1, 3"},
 		{"admin pause ddl jobs 5", true, "ADMIN PAUSE DDL JOBS 5"},
 		{"admin pause ddl jobs", false, "ADMIN PAUSE DDL JOBS"},
@@ -510,6 +533,7 @@ func TestAdminStmt(t *testing.T) {
 		// Test for 'admin flush plan_cache'
 		{"admin flush instance plan_cache", true, "ADMIN FLUSH INSTANCE PLAN_CACHE"},
 		{"admin flush session plan_cache", true, "ADMIN FLUSH SESSION PLAN_CACHE"},
+		{"admin flush cluster plan_cache", true, "ADMIN FLUSH CLUSTER PLAN_CACHE"},
 		// We do not support the global level. We will check it in the later.
 		{"admin flush global plan_cache", true, "ADMIN FLUSH GLOBAL PLAN_CACHE"},
 		// for BDR
@@ -790,6 +814,11 @@ func TestDMLStmt(t *testing.T) {
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' optionally enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
+		{"select a from t into outfile 's3://bucket/result.csv' format 'csv'", true, "SELECT `a` FROM `t` INTO OUTFILE 's3://bucket/result.csv' FORMAT 'csv'"},
+		{"select a from t into outfile 's3://bucket/result.csv.gz' format 'csv' fields terminated by '|' with compression='gzip'", true, "SELECT `a` FROM `t` INTO OUTFILE 's3://bucket/result.csv.gz' FORMAT 'csv' FIELDS TERMINATED BY '|' WITH compression=_UTF8MB4'gzip'"},
+		{"select a from t into outfile 'gcs://bucket/result.parquet' format 'parquet' with compression='snappy'", true, "SELECT `a` FROM `t` INTO OUTFILE 'gcs://bucket/result.parquet' FORMAT 'parquet' WITH compression=_UTF8MB4'snappy'"},
+		{"table t into outfile 'azure://bucket/result.sql' format 'sql'", true, "TABLE `t` INTO OUTFILE 'azure://bucket/result.sql' FORMAT 'sql'"},
+		{"select a from t into outfile '/tmp/result.txt' with compression='zstd'", true, "SELECT `a` FROM `t` INTO OUTFILE '/tmp/result.txt' WITH compression=_UTF8MB4'zstd'"},
 
 		// from join
 		{"SELECT * from t1, t2, t3", true, "SELECT * FROM ((`t1`) JOIN `t2`) JOIN `t3`"},
@@ -1127,6 +1156,10 @@ AAAAAAAAAAAA5gm5Mg==
 		{"calibrate resource workload oltp_read_only", true, "CALIBRATE RESOURCE WORKLOAD OLTP_READ_ONLY"},
 		{"calibrate resource workload oltp_write_only", true, "CALIBRATE RESOURCE WORKLOAD OLTP_WRITE_ONLY"},
 		{"calibrate resource workload = oltp_read_write START_TIME '2023-04-01 13:00:00'", false, ""},
+		{"calibrate resource groups", true, "CALIBRATE RESOURCE GROUPS"},
+		{"calibrate resource groups workload oltp_read_only", true, "CALIBRATE RESOURCE GROUPS WORKLOAD OLTP_READ_ONLY"},
+		{"calibrate resource groups START_TIME '2023-04-01 13:00:00' DURATION '20m'", true, "CALIBRATE RESOURCE GROUPS START_TIME _UTF8MB4'2023-04-01 13:00:00' DURATION '20m'"},
+		{"calibrate resource groups rg1", false, ""},
 
 		// for query watch
 		{"query watch add SQL DIGEST b13858789fce00208f9a262c99621b7045f4869807cd4e6568008ae7ca19a377 ", true, "QUERY WATCH ADD SQL DIGEST `b13858789fce00208f9a262c99621b7045f4869807cd4e6568008ae7ca19a377`"},
@@ -1265,6 +1298,8 @@ func TestDBAStmt(t *testing.T) {
 		{"show drainer status", true, "SHOW DRAINER STATUS"},
 		// for show binding_cache status
 		{"show binding_cache status", true, "SHOW BINDING_CACHE STATUS"},
+		{"show binding evolve tasks", true, "SHOW BINDING EVOLVE TASKS"},
+		{"show binding evolve tasks where status = 'accepted'", true, "SHOW BINDING EVOLVE TASKS WHERE `status`=_UTF8MB4'accepted'"},
 		{"show analyze status", true, "SHOW ANALYZE STATUS"},
 		{"show analyze status where table_name = 't'", true, "SHOW ANALYZE STATUS WHERE `table_name`=_UTF8MB4't'"},
 		{"show analyze status where table_name like '%'", true, "SHOW ANALYZE STATUS WHERE `table_name` LIKE _UTF8MB4'%'"},
@@ -1403,6 +1438,12 @@ func TestDBAStmt(t *testing.T) {
 		{"flush slow logs", true, "FLUSH SLOW LOGS"},
 		{"flush client_errors_summary", true, "FLUSH CLIENT_ERRORS_SUMMARY"},
 
+		// for fetch result statement
+		{"fetch next from '3'", true, "FETCH NEXT FROM '3'"},
+		{"fetch next from \"3\"", true, "FETCH NEXT FROM '3'"},
+		{"fetch next from", false, ""},
+		{"fetch next from 3", false, ""},
+
 		// for change statement
 		{"change pump to node_state ='paused' for node_id '127.0.0.1:8250'", true, "CHANGE PUMP TO NODE_STATE ='paused' FOR NODE_ID '127.0.0.1:8250'"},
 		{"change drainer to node_state ='paused' for node_id '127.0.0.1:8249'", true, "CHANGE DRAINER TO NODE_STATE ='paused' FOR NODE_ID '127.0.0.1:8249'"},
@@ -3829,6 +3870,8 @@ func TestDDL(t *testing.T) {
 		{"create resource group x ru_per_sec=1000 background (task_types='br,lightning')", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, BACKGROUND = (TASK_TYPES = 'br,lightning')"},
 		{`create resource group x ru_per_sec=1000 QUERY_LIMIT (EXEC_ELAPSED "10s" ACTION COOLDOWN WATCH EXACT DURATION='10m')  background (task_types 'br,lightning')`, true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s' ACTION = COOLDOWN WATCH = EXACT DURATION = '10m'), BACKGROUND = (TASK_TYPES = 'br,lightning')"},
 		{`create resource group x ru_per_sec=1000 QUERY_LIMIT (EXEC_ELAPSED "10s" ACTION COOLDOWN WATCH PLAN DURATION='10m')  background (task_types 'br,lightning')`, true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s' ACTION = COOLDOWN WATCH = PLAN DURATION = '10m'), BACKGROUND = (TASK_TYPES = 'br,lightning')"},
+		{"create resource group x ru_per_sec=1000 admission_timeout='5s'", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, ADMISSION_TIMEOUT = '5s'"},
+		{"create resource group x ru_per_sec=1000 admission_timeout='5s' admission_timeout='10s'", false, ""},
 		// This case is expected in parser test but not in actual ddl job.
 		{"create resource group x ru_per_sec=1000 QUERY_LIMIT = (EXEC_ELAPSED '10s')", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s')"},
 		{"create resource group x ru_per_sec=1000 QUERY=(EXEC_ELAPSED '10s')", false, ""},
@@ -3870,6 +3913,9 @@ func TestDDL(t *testing.T) {
 		{"alter resource group x background NULL", true, "ALTER RESOURCE GROUP `x` BACKGROUND = NULL"},
 		{"alter resource group default priority=low background = ( task_types \"ttl\" )", true, "ALTER RESOURCE GROUP `default` PRIORITY = LOW, BACKGROUND = (TASK_TYPES = 'ttl')"},
 		{"alter resource group default burstable background ( task_types = 'a,b,c' )", true, "ALTER RESOURCE GROUP `default` BURSTABLE = TRUE, BACKGROUND = (TASK_TYPES = 'a,b,c')"},
+		{"alter resource group x admission_timeout = '10s'", true, "ALTER RESOURCE GROUP `x` ADMISSION_TIMEOUT = '10s'"},
+		{"alter resource group x admission_timeout '0s'", true, "ALTER RESOURCE GROUP `x` ADMISSION_TIMEOUT = '0s'"},
+		{"alter resource group x admission_timeout = '10'", false, ""},
 
 		{"drop resource group x;", true, "DROP RESOURCE GROUP `x`"},
 		{"drop resource group DEFAULT;", true, "DROP RESOURCE GROUP `DEFAULT`"},
@@ -5754,6 +5800,9 @@ func TestSessionManage(t *testing.T) {
 		{"kill tidb 23123", true, "KILL TIDB 23123"},
 		{"kill tidb connection 23123", true, "KILL TIDB 23123"},
 		{"kill tidb query 23123", true, "KILL TIDB QUERY 23123"},
+		{"kill query digest 'e5796985ccafe2f71126ed6c0ac939ffa015a8c0744a24b7aee6d587103fd2f7'", true, "KILL QUERY DIGEST 'e5796985ccafe2f71126ed6c0ac939ffa015a8c0744a24b7aee6d587103fd2f7'"},
+		{"kill tidb query digest 'e5796985ccafe2f71126ed6c0ac939ffa015a8c0744a24b7aee6d587103fd2f7'", true, "KILL TIDB QUERY DIGEST 'e5796985ccafe2f71126ed6c0ac939ffa015a8c0744a24b7aee6d587103fd2f7'"},
+		{"kill digest 'e5796985ccafe2f71126ed6c0ac939ffa015a8c0744a24b7aee6d587103fd2f7'", false, ""},
 		{"show processlist", true, "SHOW PROCESSLIST"},
 		{"show full processlist", true, "SHOW FULL PROCESSLIST"},
 		{"shutdown", true, "SHUTDOWN"},
@@ -6787,6 +6836,14 @@ func (checker *nodeTextCleaner) Enter(in ast.Node) (out ast.Node, skipChildren b
 		node.Tp.CleanElemIsBinaryLit()
 	case *ast.PartitionOptions:
 		cleanPartition(node)
+	case *ast.SelectStmt:
+		if node.SelectIntoOpt != nil {
+			for _, opt := range node.SelectIntoOpt.Options {
+				if opt.Value != nil {
+					opt.Value.Accept(checker)
+				}
+			}
+		}
 	}
 	return in, false
 }
@@ -0,0 +1,184 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinality
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sync"
+
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/hack"
+	"github.com/pingcap/tidb/pkg/util/kvcache"
+)
+
+const (
+	// feedbackCapacity is the max number of statements kept in the feedback store.
+	feedbackCapacity = 1024
+	// feedbackMinRatio and feedbackMinRowCountDiff decide whether the estimated row count is far from the actual one.
+	feedbackMinRatio        = 10
+	feedbackMinRowCountDiff = 100
+)
+
+// GlobalFeedbackStore is the feedback store of the instance.
+var GlobalFeedbackStore = NewFeedbackStore(feedbackCapacity)
+
+type feedbackKey string
+
+// Hash implements kvcache.Key interface.
+func (k feedbackKey) Hash() []byte {
+	return hack.Slice(string(k))
+}
+
+// FeedbackRowCount is the actual row count observed by the executor, with the table it's read from and the version
+// of the table stats used to estimate it.
+type FeedbackRowCount struct {
+	RowCount     float64
+	TableID      int64
+	StatsVersion uint64
+}
+
+// FeedbackStore keeps the actual row counts observed by the executor for the statements whose estimated row counts
+// are far from the actual ones. The row counts are grouped by the FeedbackStmtKey of the statement, and keyed by
+// FeedbackTableKey or FeedbackIndexKey in a statement. The optimizer uses them instead of the estimated row counts
+// when the same statement is optimized again with the same table stats.
+type FeedbackStore struct {
+	mu    sync.Mutex
+	cache *kvcache.SimpleLRUCache
+}
+
+// NewFeedbackStore creates a FeedbackStore which keeps the row counts of at most capacity statements.
+func NewFeedbackStore(capacity uint) *FeedbackStore {
+	return &FeedbackStore{cache: kvcache.NewSimpleLRUCache(capacity, 0, 0)}
+}
+
+// Update records the actual row counts of the statement. The row counts recorded before for the other keys of the
+// statement are kept.
+func (s *FeedbackStore) Update(stmtKey string, rowCounts map[string]FeedbackRowCount) {
+	if len(rowCounts) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.cache.Get(feedbackKey(stmtKey)); ok {
+		for key, rowCount := range v.(map[string]FeedbackRowCount) {
+			if _, ok := rowCounts[key]; !ok {
+				rowCounts[key] = rowCount
+			}
+		}
+	}
+	s.cache.Put(feedbackKey(stmtKey), rowCounts)
+}
+
+// Get returns the actual row count recorded for the key of the statement. The row count recorded with another
+// version of the table stats is removed and not returned, since it may be wrong after the stats are updated.
+func (s *FeedbackStore) Get(stmtKey, key string, statsVersion uint64) (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.cache.Get(feedbackKey(stmtKey))
+	if !ok {
+		return 0, false
+	}
+	rowCounts := v.(map[string]FeedbackRowCount)
+	rowCount, ok := rowCounts[key]
+	if !ok {
+		return 0, false
+	}
+	if rowCount.StatsVersion != statsVersion {
+		s.putWithout(stmtKey, rowCounts, func(rc FeedbackRowCount) bool {
+			return rc.TableID == rowCount.TableID && rc.StatsVersion != statsVersion
+		})
+		return 0, false
+	}
+	return rowCount.RowCount, true
+}
+
+// DeleteTable removes the row counts read from the table, it's called when the table is analyzed.
+func (s *FeedbackStore) DeleteTable(tableID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys, values := s.cache.Keys(), s.cache.Values()
+	for i, k := range keys {
+		s.putWithout(string(k.(feedbackKey)), values[i].(map[string]FeedbackRowCount), func(rc FeedbackRowCount) bool {
+			return rc.TableID == tableID
+		})
+	}
+}
+
+// putWithout replaces the row counts of the statement with the ones not matched by the remove function, the
+// statement is deleted if there are no row counts left. The caller should hold the lock.
+func (s *FeedbackStore) putWithout(stmtKey string, rowCounts map[string]FeedbackRowCount, remove func(FeedbackRowCount) bool) {
+	// the map may be read by Get right now, so copy it instead of updating it in place.
+	kept := make(map[string]FeedbackRowCount, len(rowCounts))
+	for key, rowCount := range rowCounts {
+		if !remove(rowCount) {
+			kept[key] = rowCount
+		}
+	}
+	if len(kept) == len(rowCounts) {
+		return
+	}
+	if len(kept) == 0 {
+		s.cache.Delete(feedbackKey(stmtKey))
+		return
+	}
+	s.cache.Put(feedbackKey(stmtKey), kept)
+}
+
+// Len returns the number of statements in the store.
+func (s *FeedbackStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Size()
+}
+
+// Reset removes all the row counts in the store.
+func (s *FeedbackStore) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.DeleteAll()
+}
+
+// FeedbackStmtKey returns the key of the row counts of a statement. The row counts depend on the constants and the
+// parameters in the statement, so they're a part of the key besides the digest of the statement.
+func FeedbackStmtKey(digest, stmtText string, params []types.Datum) string {
+	hasher := sha256.New()
+	hasher.Write(hack.Slice(stmtText))
+	for _, param := range params {
+		hasher.Write([]byte{0})
+		hasher.Write(hack.Slice(param.String()))
+	}
+	return digest + ":" + hex.EncodeToString(hasher.Sum(nil))
+}
+
+// FeedbackTableKey returns the key of the row count read from a table in a statement.
+func FeedbackTableKey(db, table string) string {
+	return db + "." + table
+}
+
+// FeedbackIndexKey returns the key of the row count read from the ranges of an index in a statement, tableKey is the
+// FeedbackTableKey of the table of the index.
+func FeedbackIndexKey(tableKey, index string) string {
+	return tableKey + ":" + index
+}
+
+// IsFeedbackNeeded checks whether the estimated row count is far enough from the actual one to be recorded.
+func IsFeedbackNeeded(estRows, actRows float64) bool {
+	if math.Abs(estRows-actRows) < feedbackMinRowCountDiff {
+		return false
+	}
+	return math.Max(estRows, actRows) >= feedbackMinRatio*math.Max(math.Min(estRows, actRows), 1)
+}
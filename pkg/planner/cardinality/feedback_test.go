@@ -0,0 +1,91 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinality_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedbackStore(t *testing.T) {
+	s := cardinality.NewFeedbackStore(2)
+	tableKey := cardinality.FeedbackTableKey("test", "t")
+	indexKey := cardinality.FeedbackIndexKey(tableKey, "ia")
+	s.Update("d1", map[string]cardinality.FeedbackRowCount{tableKey: {RowCount: 100, TableID: 1, StatsVersion: 10}})
+	s.Update("d1", map[string]cardinality.FeedbackRowCount{indexKey: {RowCount: 1000, TableID: 1, StatsVersion: 10}})
+	// the row counts of the other keys are kept.
+	rowCount, ok := s.Get("d1", tableKey, 10)
+	require.True(t, ok)
+	require.Equal(t, 100.0, rowCount)
+	rowCount, ok = s.Get("d1", indexKey, 10)
+	require.True(t, ok)
+	require.Equal(t, 1000.0, rowCount)
+	s.Update("d1", map[string]cardinality.FeedbackRowCount{tableKey: {RowCount: 200, TableID: 1, StatsVersion: 10}})
+	rowCount, ok = s.Get("d1", tableKey, 10)
+	require.True(t, ok)
+	require.Equal(t, 200.0, rowCount)
+	_, ok = s.Get("d2", tableKey, 10)
+	require.False(t, ok)
+
+	// the row counts recorded with another version of the stats are removed.
+	_, ok = s.Get("d1", tableKey, 11)
+	require.False(t, ok)
+	_, ok = s.Get("d1", indexKey, 10)
+	require.False(t, ok)
+	require.Equal(t, 0, s.Len())
+
+	// the row counts of the analyzed table are removed.
+	otherKey := cardinality.FeedbackTableKey("test", "t2")
+	s.Update("d1", map[string]cardinality.FeedbackRowCount{
+		tableKey: {RowCount: 100, TableID: 1, StatsVersion: 10},
+		otherKey: {RowCount: 100, TableID: 2, StatsVersion: 10},
+	})
+	s.Update("d2", map[string]cardinality.FeedbackRowCount{tableKey: {RowCount: 100, TableID: 1, StatsVersion: 10}})
+	s.DeleteTable(1)
+	require.Equal(t, 1, s.Len())
+	_, ok = s.Get("d1", tableKey, 10)
+	require.False(t, ok)
+	_, ok = s.Get("d1", otherKey, 10)
+	require.True(t, ok)
+
+	// the least recently used statement is evicted.
+	s.Update("d2", map[string]cardinality.FeedbackRowCount{tableKey: {RowCount: 1}})
+	s.Update("d3", map[string]cardinality.FeedbackRowCount{tableKey: {RowCount: 1}})
+	require.Equal(t, 2, s.Len())
+	_, ok = s.Get("d1", otherKey, 10)
+	require.False(t, ok)
+	s.Reset()
+	require.Equal(t, 0, s.Len())
+}
+
+func TestFeedbackStmtKey(t *testing.T) {
+	key := cardinality.FeedbackStmtKey("d1", "SELECT * FROM `test`.`t` WHERE `a`<100", nil)
+	require.Equal(t, key, cardinality.FeedbackStmtKey("d1", "SELECT * FROM `test`.`t` WHERE `a`<100", nil))
+	require.NotEqual(t, key, cardinality.FeedbackStmtKey("d1", "SELECT * FROM `test`.`t` WHERE `a`<50", nil))
+	key = cardinality.FeedbackStmtKey("d1", "SELECT * FROM `test`.`t` WHERE `a`<?", []types.Datum{types.NewIntDatum(100)})
+	require.NotEqual(t, key, cardinality.FeedbackStmtKey("d1", "SELECT * FROM `test`.`t` WHERE `a`<?", []types.Datum{types.NewIntDatum(50)}))
+}
+
+func TestIsFeedbackNeeded(t *testing.T) {
+	require.False(t, cardinality.IsFeedbackNeeded(10, 10))
+	require.False(t, cardinality.IsFeedbackNeeded(1, 99))
+	require.True(t, cardinality.IsFeedbackNeeded(0, 100))
+	require.True(t, cardinality.IsFeedbackNeeded(1000, 100))
+	require.False(t, cardinality.IsFeedbackNeeded(1000, 200))
+	require.True(t, cardinality.IsFeedbackNeeded(0.1, 1900))
+}
@@ -0,0 +1,234 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+)
+
+// CollectCardinalityFeedback returns the actual row counts of the tables and the index ranges read by the plan, whose
+// estimated row counts are far from the actual ones. The keys of the row counts are built by
+// cardinality.FeedbackTableKey and cardinality.FeedbackIndexKey.
+func CollectCardinalityFeedback(p base.Plan, statsColl *execdetails.RuntimeStatsColl) map[string]cardinality.FeedbackRowCount {
+	if statsColl == nil {
+		return nil
+	}
+	switch x := p.(type) {
+	case *Explain:
+		if !x.Analyze {
+			return nil
+		}
+		return CollectCardinalityFeedback(x.TargetPlan, statsColl)
+	case *Insert:
+		p = x.SelectPlan
+	case *Update:
+		p = x.SelectPlan
+	case *Delete:
+		p = x.SelectPlan
+	}
+	physicalPlan, ok := p.(base.PhysicalPlan)
+	if !ok {
+		return nil
+	}
+	c := &cardinalityFeedbackCollector{
+		statsColl: statsColl,
+		rowCounts: make(map[string]cardinality.FeedbackRowCount),
+		tableKeys: make(map[string]string),
+		tableRefs: make(map[string]int),
+	}
+	c.collect(physicalPlan, true)
+	for key := range c.rowCounts {
+		// the row counts can't be told apart if the table is read several times with the same name.
+		if c.tableRefs[c.tableKeys[key]] > 1 {
+			delete(c.rowCounts, key)
+		}
+	}
+	return c.rowCounts
+}
+
+type cardinalityFeedbackCollector struct {
+	statsColl *execdetails.RuntimeStatsColl
+	rowCounts map[string]cardinality.FeedbackRowCount
+	// tableKeys is the table key of each key in rowCounts.
+	tableKeys map[string]string
+	// tableRefs is the times each table key is read in the plan.
+	tableRefs map[string]int
+}
+
+// collect collects the row counts of the readers in the plan. The row counts are only recorded if the readers are
+// read to the end, which is indicated by complete.
+func (c *cardinalityFeedbackCollector) collect(p base.PhysicalPlan, complete bool) {
+	children := p.Children()
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		c.collectReader(x, complete && x.StoreType == kv.TiKV, x.TablePlans)
+		return
+	case *PhysicalIndexReader:
+		c.collectReader(x, complete, x.IndexPlans)
+		return
+	case *PhysicalIndexLookUpReader:
+		c.collectReader(x, complete && x.PushedLimit == nil, x.IndexPlans, x.TablePlans)
+		return
+	case *PhysicalIndexMergeReader:
+		copPlans := make([][]base.PhysicalPlan, 0, len(x.PartialPlans)+1)
+		copPlans = append(copPlans, x.PartialPlans...)
+		c.collectReader(x, complete && x.PushedLimit == nil, append(copPlans, x.TablePlans)...)
+		return
+	case *PhysicalLimit, *PhysicalMaxOneRow, *PhysicalMergeJoin:
+		// they may stop reading their children before the end.
+		complete = false
+	case *PhysicalApply:
+		// the estimated row counts of the inner side are for one row of the outer side.
+		c.collect(children[0], complete)
+		c.collect(children[1], false)
+		return
+	case *PhysicalIndexJoin, *PhysicalIndexHashJoin, *PhysicalIndexMergeJoin:
+		innerIdx := p.(interface{ getInnerChildIdx() int }).getInnerChildIdx()
+		c.collect(children[1-innerIdx], complete)
+		c.collect(children[innerIdx], false)
+		return
+	case *PhysicalHashJoin:
+		// the probe side is not read to the end if the build side is empty.
+		for _, child := range children {
+			if !c.statsColl.ExistsRootStats(child.ID()) || c.statsColl.GetRootStats(child.ID()).GetActRows() == 0 {
+				complete = false
+			}
+		}
+	}
+	for _, child := range children {
+		c.collect(child, complete)
+	}
+}
+
+func (c *cardinalityFeedbackCollector) collectReader(reader base.PhysicalPlan, complete bool, copPlans ...[]base.PhysicalPlan) {
+	var tableKey string
+	var tblInfo *model.TableInfo
+	var indexScans []*PhysicalIndexScan
+	for _, plans := range copPlans {
+		for _, p := range plans {
+			switch x := p.(type) {
+			case *PhysicalTableScan:
+				tableKey = cardinalityFeedbackTableKey(x.DBName, x.TableAsName, x.Table)
+				tblInfo = x.Table
+				complete = complete && !x.isPartition
+			case *PhysicalIndexScan:
+				tableKey = cardinalityFeedbackTableKey(x.DBName, x.TableAsName, x.Table)
+				tblInfo = x.Table
+				complete = complete && !x.isPartition
+				indexScans = append(indexScans, x)
+			case *PhysicalSelection, *PhysicalProjection:
+			default:
+				// the row count of the reader is not the row count of the table.
+				complete = false
+			}
+		}
+	}
+	if tableKey == "" {
+		return
+	}
+	c.tableRefs[tableKey]++
+	if !complete || !c.statsColl.ExistsRootStats(reader.ID()) {
+		return
+	}
+	statsVersion := cardinalityFeedbackStatsVersion(reader.SCtx(), tblInfo)
+	actRows := float64(c.statsColl.GetRootStats(reader.ID()).GetActRows())
+	if cardinality.IsFeedbackNeeded(reader.StatsInfo().RowCount, actRows) {
+		c.record(tableKey, tableKey, cardinality.FeedbackRowCount{RowCount: actRows, TableID: tblInfo.ID, StatsVersion: statsVersion})
+	}
+	if _, isIndexMerge := reader.(*PhysicalIndexMergeReader); isIndexMerge {
+		return
+	}
+	for _, is := range indexScans {
+		if !c.statsColl.ExistsCopStats(is.ID()) {
+			continue
+		}
+		actRows := float64(c.statsColl.GetCopStats(is.ID()).GetActRows())
+		if cardinality.IsFeedbackNeeded(is.StatsInfo().RowCount, actRows) {
+			c.record(cardinality.FeedbackIndexKey(tableKey, is.Index.Name.L), tableKey,
+				cardinality.FeedbackRowCount{RowCount: actRows, TableID: tblInfo.ID, StatsVersion: statsVersion})
+		}
+	}
+}
+
+func (c *cardinalityFeedbackCollector) record(key, tableKey string, rowCount cardinality.FeedbackRowCount) {
+	c.rowCounts[key] = rowCount
+	c.tableKeys[key] = tableKey
+}
+
+// cardinalityFeedbackStatsVersion returns the version of the stats of the table, the feedback recorded with another
+// version is not used, since the stats are updated after the feedback is recorded.
+func cardinalityFeedbackStatsVersion(sctx base.PlanContext, tblInfo *model.TableInfo) uint64 {
+	statsHandle := domain.GetDomain(sctx).StatsHandle()
+	if statsHandle == nil {
+		return 0
+	}
+	return statsHandle.GetTableStats(tblInfo).Version
+}
+
+func cardinalityFeedbackTableKey(db model.CIStr, tableAsName *model.CIStr, tbl *model.TableInfo) string {
+	if tableAsName != nil && tableAsName.L != "" {
+		return cardinality.FeedbackTableKey(db.L, tableAsName.L)
+	}
+	return cardinality.FeedbackTableKey(db.L, tbl.Name.L)
+}
+
+// getCardinalityFeedback returns the actual row count recorded for the key of the DataSource by the cardinality
+// feedback of the statement.
+func (ds *DataSource) getCardinalityFeedback(getKey func(tableKey string) string) (float64, bool) {
+	stmtKey := ds.SCtx().GetSessionVars().StmtCtx.CardinalityFeedbackKey
+	// the DataSource of a partition is not recorded, see collectReader.
+	if stmtKey == "" || ds.PartitionDefIdx != nil {
+		return 0, false
+	}
+	return cardinality.GlobalFeedbackStore.Get(stmtKey, getKey(cardinalityFeedbackTableKey(ds.DBName, ds.TableAsName, ds.TableInfo)),
+		cardinalityFeedbackStatsVersion(ds.SCtx(), ds.TableInfo))
+}
+
+// adjustStatsByCardinalityFeedback uses the actual row count recorded by the cardinality feedback as the row count
+// of the DataSource.
+func (ds *DataSource) adjustStatsByCardinalityFeedback() {
+	rowCount, ok := ds.getCardinalityFeedback(func(tableKey string) string { return tableKey })
+	if !ok || ds.TableStats.RowCount <= 0 {
+		return
+	}
+	stats := ds.TableStats.Scale(min(rowCount/ds.TableStats.RowCount, 1))
+	stats.RowCount = rowCount
+	ds.SetStats(stats)
+}
+
+// adjustIndexPathByCardinalityFeedback uses the actual row count recorded by the cardinality feedback as the row
+// count read from the ranges of the index path.
+func (ds *DataSource) adjustIndexPathByCardinalityFeedback(path *util.AccessPath) {
+	rowCount, ok := ds.getCardinalityFeedback(func(tableKey string) string {
+		return cardinality.FeedbackIndexKey(tableKey, path.Index.Name.L)
+	})
+	if !ok {
+		return
+	}
+	// the rows of the DataSource are a part of the rows read from the index.
+	rowCount = max(rowCount, ds.StatsInfo().RowCount)
+	if path.CountAfterAccess > 0 {
+		path.CountAfterIndex = min(path.CountAfterIndex*rowCount/path.CountAfterAccess, rowCount)
+	} else {
+		path.CountAfterIndex = rowCount
+	}
+	path.CountAfterAccess = rowCount
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardinalityFeedback(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	cardinality.GlobalFeedbackStore.Reset()
+	defer cardinality.GlobalFeedbackStore.Reset()
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, key ia(a))")
+	// most rows are in a small range of a, which can't be told by the histogram of one bucket.
+	vals := make([]string, 0, 2000)
+	for i := 0; i < 2000; i++ {
+		a := i % 100
+		if i%20 == 0 {
+			a = (i + 1) * 1000
+		}
+		vals = append(vals, fmt.Sprintf("(%d, %d)", a, i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(vals, ", "))
+	tk.MustExec("analyze table t with 1 buckets, 0 topn")
+
+	explain := "explain format='brief' select * from t where a < 100"
+	badPlan := tk.MustQuery(explain).Rows()
+	tk.MustQuery(explain).CheckContain("IndexLookUp")
+	// the feedback is not recorded if it's disabled.
+	tk.MustQuery("select count(*) from (select * from t where a < 100) t1").Check(testkit.Rows("1900"))
+	tk.MustQuery("select * from t where a < 100")
+	tk.MustQuery(explain).Check(badPlan)
+
+	tk.MustExec("set @@tidb_enable_cardinality_feedback = 1")
+	// the feedback is not recorded if the rows are not read to the end.
+	tk.MustQuery("select * from t where a < 100 limit 10")
+	tk.MustQuery(explain).Check(badPlan)
+	tk.MustQuery("select * from t where a < 100")
+	tk.MustQuery(explain).Check(testkit.Rows(
+		"TableReader 1900.00 root  data:Selection",
+		"└─Selection 1900.00 cop[tikv]  lt(test.t.a, 100)",
+		"  └─TableFullScan 2000.00 cop[tikv] table:t keep order:false"))
+	// the feedback is only used by the same statement.
+	tk.MustQuery("explain format='brief' select * from t where a < 50").CheckContain("IndexLookUp")
+	tk.MustQuery("explain format='brief' select * from t t1 where a < 100").CheckContain("IndexLookUp")
+
+	tk.MustExec("set @@tidb_enable_cardinality_feedback = 0")
+	tk.MustQuery(explain).Check(badPlan)
+
+	// the feedback of the prepared statement is recorded for the parameters.
+	tk.MustExec("set @@tidb_enable_cardinality_feedback = 1")
+	tk.MustExec("set @@tidb_enable_prepared_plan_cache = 0")
+	cardinality.GlobalFeedbackStore.Reset()
+	tk.MustExec("prepare stmt from 'select * from t where a < ?'")
+	tk.MustExec("set @a = 100")
+	tk.MustQuery("execute stmt using @a")
+	tk.MustQuery("execute stmt using @a")
+	require.Equal(t, 1, cardinality.GlobalFeedbackStore.Len())
+	tk.MustExec("set @a = 50")
+	tk.MustQuery("execute stmt using @a")
+	require.Equal(t, 2, cardinality.GlobalFeedbackStore.Len())
+
+	// the feedback of the table is removed once it's analyzed.
+	tk.MustExec("analyze table t with 1 buckets, 0 topn")
+	require.Equal(t, 0, cardinality.GlobalFeedbackStore.Len())
+	tk.MustQuery(explain).Check(badPlan)
+	tk.MustExec("set @@tidb_enable_cardinality_feedback = 0")
+
+	// EXPLAIN ANALYZE records the feedback of the statement it runs.
+	cardinality.GlobalFeedbackStore.Reset()
+	tk.MustExec("set @@tidb_enable_cardinality_feedback = 1")
+	tk.MustQuery("explain analyze select * from t where a < 100")
+	tk.MustQuery(explain).CheckContain("TableFullScan")
+	require.Equal(t, 1, cardinality.GlobalFeedbackStore.Len())
+	// the feedback is not recorded if the table is read several times with the same name.
+	tk.MustQuery("select * from t where a < 100 and b in (select b from t where a < 100)")
+	require.Equal(t, 1, cardinality.GlobalFeedbackStore.Len())
+}
@@ -18,8 +18,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -131,6 +133,13 @@ type CancelDDLJobs struct {
 	JobIDs []int64
 }
 
+// CancelTask represents a cancel distributed task plan.
+type CancelTask struct {
+	baseSchemaProducer
+
+	TaskIDs []int64
+}
+
 // PauseDDLJobs indicates a plan to pause the Running DDL Jobs.
 type PauseDDLJobs struct {
 	baseSchemaProducer
@@ -303,6 +312,28 @@ func (s *Simple) MemoryUsage() (sum int64) {
 	return
 }
 
+// ResultBatchFetcher fetches the rest rows of the results which are returned in batches by
+// tidb_result_batch_size, it's implemented by the server and stored in the session by ResultBatchFetcherKey.
+type ResultBatchFetcher interface {
+	// ResultColumns returns the output names and the field types of the result of the token, ok is false if
+	// the result doesn't exist.
+	ResultColumns(token string) (names types.NameSlice, fieldTypes []*types.FieldType, ok bool)
+	// FetchRows appends at most maxRows rows of the result of the token to chk, and returns whether all the
+	// rows of the result are fetched. The result is released once all its rows are fetched.
+	FetchRows(token string, chk *chunk.Chunk, maxRows int) (bool, error)
+}
+
+// resultBatchFetcherKeyType is a dummy type to avoid naming collision in context.
+type resultBatchFetcherKeyType int
+
+// String defines a Stringer function for debugging and pretty printing.
+func (resultBatchFetcherKeyType) String() string {
+	return "result_batch_fetcher"
+}
+
+// ResultBatchFetcherKey is the key of the ResultBatchFetcher in the session.
+const ResultBatchFetcherKey resultBatchFetcherKeyType = 0
+
 // PhysicalSimpleWrapper is a wrapper of `Simple` to implement physical plan interface.
 //
 //	Used for simple statements executing in coprocessor.
@@ -567,6 +598,11 @@ type LoadData struct {
 	Options            []*LoadDataOpt
 
 	GenCols InsertGeneratedColumns
+
+	// Detached is true if the statement runs as a background job with the DETACHED option.
+	Detached bool
+	// JobStmt is the statement without the DETACHED option that the background job runs.
+	JobStmt string
 }
 
 // LoadDataOpt represents load data option.
@@ -681,9 +717,11 @@ type DDL struct {
 type SelectInto struct {
 	baseSchemaProducer
 
-	TargetPlan base.Plan
-	IntoOpt    *ast.SelectIntoOption
+	TargetPlan  base.Plan
+	TargetNames types.NameSlice
+	IntoOpt     *ast.SelectIntoOption
 	LineFieldsInfo
+	Options []*LoadDataOpt
 }
 
 // LineFieldsInfo used in load-data/select-into/index-advise stmt.
@@ -878,7 +916,7 @@ func (e *Explain) RenderResult() error {
 					pp.SCtx().GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("factor costs: %v", string(data)))
 
 					// output cost factor weights for cost calibration
-					factors := defaultVer2Factors.tolist()
+					factors := getVer2Factors().tolist()
 					weights := make(map[string]float64)
 					for _, factor := range factors {
 						if factorCost, ok := trace.GetFactorCosts()[factor.Name]; ok && factor.Value > 0 {
@@ -958,19 +996,107 @@ func (e *Explain) explainFlatPlanInRowFormat(flat *FlatPhysicalPlan) {
 	if flat == nil || len(flat.Main) == 0 || flat.InExplain {
 		return
 	}
-	for _, flatOp := range flat.Main {
-		e.explainFlatOpInRowFormat(flatOp)
+	withTimeBreakdown := e.Analyze && e.SCtx().GetSessionVars().ExplainAnalyzeTimeBreakdown
+	explainTree := func(tree FlatPlanTree) []*execdetails.TimeBreakdown {
+		var breakdowns []*execdetails.TimeBreakdown
+		if withTimeBreakdown {
+			breakdowns = e.timeBreakdowns(tree)
+		}
+		for i, flatOp := range tree {
+			var breakdown *execdetails.TimeBreakdown
+			if breakdowns != nil {
+				breakdown = breakdowns[i]
+			}
+			e.explainFlatOpInRowFormat(flatOp, breakdown)
+		}
+		return breakdowns
 	}
+	mainBreakdowns := explainTree(flat.Main)
 	for _, cte := range flat.CTEs {
-		for _, flatOp := range cte {
-			e.explainFlatOpInRowFormat(flatOp)
-		}
+		explainTree(cte)
 	}
 	for _, subQ := range flat.ScalarSubQueries {
-		for _, flatOp := range subQ {
-			e.explainFlatOpInRowFormat(flatOp)
+		explainTree(subQ)
+	}
+	if len(mainBreakdowns) > 0 && mainBreakdowns[0] != nil {
+		e.appendTimeBreakdownSummary(*mainBreakdowns[0])
+	}
+	if e.Analyze && e.SCtx().GetSessionVars().DiskQuotaQuery > 0 {
+		e.appendDiskQuotaSummary()
+	}
+}
+
+// timeBreakdowns returns the time breakdown of the subtree of each root
+// operator in tree, the result is nil for the operators without runtime stats.
+func (e *Explain) timeBreakdowns(tree FlatPlanTree) []*execdetails.TimeBreakdown {
+	runtimeStatsColl := e.RuntimeStatsColl
+	if runtimeStatsColl == nil {
+		runtimeStatsColl = e.SCtx().GetSessionVars().StmtCtx.RuntimeStatsColl
+		if runtimeStatsColl == nil {
+			return nil
 		}
 	}
+	breakdowns := make([]*execdetails.TimeBreakdown, len(tree))
+	var visit func(idx int) execdetails.StorageTime
+	visit = func(idx int) execdetails.StorageTime {
+		var st execdetails.StorageTime
+		flatOp := tree[idx]
+		for _, childIdx := range flatOp.ChildrenIdx {
+			st.Merge(visit(childIdx))
+		}
+		planID := flatOp.Origin.ID()
+		if !runtimeStatsColl.ExistsRootStats(planID) {
+			return st
+		}
+		rootStats := runtimeStatsColl.GetRootStats(planID)
+		st.Merge(rootStats.StorageTime())
+		if basic, _ := rootStats.MergeStats(); basic != nil && flatOp.IsRoot {
+			breakdown := execdetails.NewTimeBreakdown(time.Duration(basic.GetTime()), st)
+			breakdowns[idx] = &breakdown
+		}
+		return st
+	}
+	visit(0)
+	return breakdowns
+}
+
+// appendTimeBreakdownSummary appends a row of the time breakdown of the whole
+// plan after the operators.
+func (e *Explain) appendTimeBreakdownSummary(breakdown execdetails.TimeBreakdown) {
+	infoIdx := slices.IndexFunc(e.names, func(name *types.FieldName) bool {
+		return name.ColName.L == "execution info"
+	})
+	if infoIdx < 0 {
+		return
+	}
+	row := make([]string, len(e.names))
+	row[0] = "TimeBreakdown"
+	row[infoIdx] = breakdown.String()
+	e.Rows = append(e.Rows, row)
+}
+
+// appendDiskQuotaSummary appends a row of the disk usage of the whole plan
+// against tidb_disk_quota_query after the operators.
+func (e *Explain) appendDiskQuotaSummary() {
+	infoIdx := slices.IndexFunc(e.names, func(name *types.FieldName) bool {
+		return name.ColName.L == "execution info"
+	})
+	diskIdx := slices.IndexFunc(e.names, func(name *types.FieldName) bool {
+		return name.ColName.L == "disk"
+	})
+	if infoIdx < 0 || diskIdx < 0 {
+		return
+	}
+	tracker := e.SCtx().GetSessionVars().StmtCtx.DiskTracker
+	if tracker == nil {
+		return
+	}
+	row := make([]string, len(e.names))
+	row[0] = "DiskQuota"
+	row[infoIdx] = fmt.Sprintf("quota:%s, max_used:%s",
+		memory.FormatBytes(tracker.GetBytesLimit()), memory.FormatBytes(tracker.MaxConsumed()))
+	row[diskIdx] = memory.FormatBytes(tracker.MaxConsumed())
+	e.Rows = append(e.Rows, row)
 }
 
 func (e *Explain) explainFlatPlanInJSONFormat(flat *FlatPhysicalPlan) (encodes []*ExplainInfoForEncode) {
@@ -1008,7 +1134,7 @@ func (e *Explain) explainOpRecursivelyInJSONFormat(flatOp *FlatOperator, flats F
 	return cur
 }
 
-func (e *Explain) explainFlatOpInRowFormat(flatOp *FlatOperator) {
+func (e *Explain) explainFlatOpInRowFormat(flatOp *FlatOperator, breakdown *execdetails.TimeBreakdown) {
 	taskTp := ""
 	if flatOp.IsRoot {
 		taskTp = "root"
@@ -1018,7 +1144,7 @@ func (e *Explain) explainFlatOpInRowFormat(flatOp *FlatOperator) {
 	textTreeExplainID := texttree.PrettyIdentifier(flatOp.Origin.ExplainID().String()+flatOp.Label.String(),
 		flatOp.TextTreeIndent,
 		flatOp.IsLastChild)
-	e.prepareOperatorInfo(flatOp.Origin, taskTp, textTreeExplainID)
+	e.prepareOperatorInfo(flatOp.Origin, taskTp, textTreeExplainID, breakdown)
 }
 
 func getRuntimeInfoStr(ctx base.PlanContext, p base.Plan, runtimeStatsColl *execdetails.RuntimeStatsColl) (actRows, analyzeInfo, memoryInfo, diskInfo string) {
@@ -1077,7 +1203,8 @@ func getRuntimeInfo(ctx base.PlanContext, p base.Plan, runtimeStatsColl *execdet
 
 // prepareOperatorInfo generates the following information for every plan:
 // operator id, estimated rows, task type, access object and other operator info.
-func (e *Explain) prepareOperatorInfo(p base.Plan, taskType, id string) {
+// The time breakdown is appended to the execution info if it's not nil.
+func (e *Explain) prepareOperatorInfo(p base.Plan, taskType, id string, breakdown *execdetails.TimeBreakdown) {
 	if p.ExplainID().String() == "_0" {
 		return
 	}
@@ -1094,6 +1221,12 @@ func (e *Explain) prepareOperatorInfo(p base.Plan, taskType, id string) {
 			row = append(row, costFormula)
 		}
 		actRows, analyzeInfo, memoryInfo, diskInfo := getRuntimeInfoStr(e.SCtx(), p, e.RuntimeStatsColl)
+		if breakdown != nil {
+			if len(analyzeInfo) > 0 {
+				analyzeInfo += ", "
+			}
+			analyzeInfo += breakdown.String()
+		}
 		row = append(row, actRows, taskType, accessObject, analyzeInfo, operatorInfo, memoryInfo, diskInfo)
 	} else {
 		row = []string{id, estRows}
@@ -1444,3 +1577,8 @@ func IsAutoCommitTxn(vars *variable.SessionVars) bool {
 type AdminShowBDRRole struct {
 	baseSchemaProducer
 }
+
+// CalibrateCostModel represents a plan to calibrate the factors of the cost model.
+type CalibrateCostModel struct {
+	baseSchemaProducer
+}
@@ -1051,7 +1051,7 @@ func (er *expressionRewriter) handleExistSubquery(ctx context.Context, planCtx *
 		semiJoinRewrite = false
 	}
 
-	if b.disableSubQueryPreprocessing || len(coreusage.ExtractCorrelatedCols4LogicalPlan(np)) > 0 || hasCTEConsumerInSubPlan(np) {
+	if !b.preprocessSubQuery(np) {
 		planCtx.plan, er.err = b.buildSemiApply(planCtx.plan, np, nil, er.asScalar, v.Not, semiJoinRewrite, noDecorrelate)
 		if er.err != nil || !er.asScalar {
 			return v, true
@@ -1281,7 +1281,7 @@ func (er *expressionRewriter) handleScalarSubquery(ctx context.Context, planCtx
 		noDecorrelate = false
 	}
 
-	if planCtx.builder.disableSubQueryPreprocessing || len(coreusage.ExtractCorrelatedCols4LogicalPlan(np)) > 0 || hasCTEConsumerInSubPlan(np) {
+	if !planCtx.builder.preprocessSubQuery(np) {
 		planCtx.plan = planCtx.builder.buildApplyWithJoinType(planCtx.plan, np, LeftOuterJoin, noDecorrelate)
 		if np.Schema().Len() > 1 {
 			newCols := make([]expression.Expression, 0, np.Schema().Len())
@@ -1373,6 +1373,17 @@ func (er *expressionRewriter) handleScalarSubquery(ctx context.Context, planCtx
 	return v, true
 }
 
+// preprocessSubQuery checks whether the sub-query np can be evaluated during the rewriting, then its result is used
+// as constants in the plan. The sub-query is kept in the plan if it's correlated or contains CTEs. It's also kept for
+// the statement that uses the plan cache, because the plan with its result can't be reused by later executions.
+func (b *PlanBuilder) preprocessSubQuery(np base.LogicalPlan) bool {
+	if b.disableSubQueryPreprocessing || len(coreusage.ExtractCorrelatedCols4LogicalPlan(np)) > 0 || hasCTEConsumerInSubPlan(np) {
+		return false
+	}
+	vars := b.ctx.GetSessionVars()
+	return !vars.StmtCtx.UseCache() || !vars.EnablePlanCacheForSubquery || !vars.EnablePlanCacheForUncorrelatedSubquery
+}
+
 func hasCTEConsumerInSubPlan(p base.LogicalPlan) bool {
 	if _, ok := p.(*LogicalCTE); ok {
 		return true
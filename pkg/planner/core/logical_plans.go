@@ -2202,7 +2202,9 @@ type ShowContents struct {
 	Extended    bool       // Used for `show extended columns from ...`
 	Limit       *ast.Limit // Used for limit Result Set row number.
 
-	ImportJobID *int64 // Used for SHOW LOAD DATA JOB <jobID>
+	ImportJobID   *int64 // Used for SHOW IMPORT JOB <jobID>
+	TaskID        int64  // Used for SHOW TASK <ID> HISTORY
+	LoadDataJobID *int64 // Used for SHOW LOAD DATA JOB <jobID>
 }
 
 const emptyShowContentsSize = int64(unsafe.Sizeof(ShowContents{}))
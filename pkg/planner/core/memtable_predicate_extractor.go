@@ -771,6 +771,13 @@ type ClusterLogTableExtractor struct {
 	// 2. SELECT * FROM cluster_log WHERE message regexp '.*'
 	Patterns  []string
 	LogLevels set.StringSet
+	// Limit is the max number of the log messages to retrieve, 0 means no limit. It's only set if the
+	// retrieved messages are not filtered or reordered by TiDB, so the remote streams can be closed
+	// once enough messages are retrieved.
+	// e.g:
+	// 1. SELECT * FROM cluster_log WHERE ... LIMIT 10
+	// 2. SELECT * FROM cluster_log WHERE ... ORDER BY time LIMIT 10
+	Limit uint64
 }
 
 // Extract implements the MemTablePredicateExtractor Extract interface
@@ -835,6 +842,9 @@ func (e *ClusterLogTableExtractor) ExplainInfo(pp base.PhysicalPlan) string {
 	if len(e.LogLevels) > 0 {
 		fmt.Fprintf(r, "log_levels:[%s], ", extractStringFromStringSet(e.LogLevels))
 	}
+	if e.Limit > 0 {
+		fmt.Fprintf(r, "limit:%d, ", e.Limit)
+	}
 
 	// remove the last ", " in the message info
 	s := r.String()
@@ -1082,6 +1092,9 @@ func (e *MetricTableExtractor) ExplainInfo(pp base.PhysicalPlan) string {
 
 // GetMetricTablePromQL uses to get the promQL of metric table.
 func (e *MetricTableExtractor) GetMetricTablePromQL(sctx base.PlanContext, lowerTableName string) string {
+	if lowerTableName == infoschema.MetricsQueryTableName {
+		return e.GetPassthroughPromQL()
+	}
 	quantiles := e.Quantiles
 	def, err := infoschema.GetMetricTableDef(lowerTableName)
 	if err != nil {
@@ -1101,6 +1114,20 @@ func (e *MetricTableExtractor) GetMetricTablePromQL(sctx base.PlanContext, lower
 	return buf.String()
 }
 
+// GetPassthroughPromQL returns the PromQL specified by the `promql` column of
+// the metrics_query table, it's empty if the PromQL isn't specified or more
+// than one PromQL are specified.
+func (e *MetricTableExtractor) GetPassthroughPromQL() string {
+	promQLs := e.LabelConditions["promql"]
+	if len(promQLs) != 1 {
+		return ""
+	}
+	for promQL := range promQLs {
+		return promQL
+	}
+	return ""
+}
+
 // MetricSummaryTableExtractor is used to extract some predicates of metrics_schema tables.
 type MetricSummaryTableExtractor struct {
 	extractHelper
@@ -252,6 +252,7 @@ func TestClusterLogTableExtractor(t *testing.T) {
 		startTime, endTime int64
 		patterns           []string
 		level              set.StringSet
+		limit              uint64
 	}{
 		{
 			sql:       "select * from information_schema.cluster_log",
@@ -516,6 +517,39 @@ func TestClusterLogTableExtractor(t *testing.T) {
 			instances: set.NewStringSet(),
 			level:     set.NewStringSet("debug", "error"),
 		},
+		{
+			sql:       "select * from information_schema.cluster_log where level = 'error' limit 10",
+			nodeTypes: set.NewStringSet(),
+			instances: set.NewStringSet(),
+			level:     set.NewStringSet("error"),
+			limit:     10,
+		},
+		{
+			sql:       "select * from information_schema.cluster_log where level = 'error' order by time limit 5, 10",
+			nodeTypes: set.NewStringSet(),
+			instances: set.NewStringSet(),
+			level:     set.NewStringSet("error"),
+			limit:     15,
+		},
+		{
+			sql:       "select * from information_schema.cluster_log where level = 'error' order by time desc limit 10",
+			nodeTypes: set.NewStringSet(),
+			instances: set.NewStringSet(),
+			level:     set.NewStringSet("error"),
+		},
+		{
+			sql:       "select * from information_schema.cluster_log where level = 'error' order by type limit 10",
+			nodeTypes: set.NewStringSet(),
+			instances: set.NewStringSet(),
+			level:     set.NewStringSet("error"),
+		},
+		{
+			// the limit can't be pushed down if the messages are filtered after retrieving.
+			sql:       "select * from information_schema.cluster_log where level = 'error' and length(message) > 10 limit 10",
+			nodeTypes: set.NewStringSet(),
+			instances: set.NewStringSet(),
+			level:     set.NewStringSet("error"),
+		},
 	}
 	for _, ca := range cases {
 		logicalMemTable := getLogicalMemTable(t, dom, se, parser, ca.sql)
@@ -535,6 +569,7 @@ func TestClusterLogTableExtractor(t *testing.T) {
 		if len(ca.level) > 0 {
 			require.EqualValues(t, ca.level, clusterConfigExtractor.LogLevels, "SQL: %v", ca.sql)
 		}
+		require.Equal(t, ca.limit, clusterConfigExtractor.Limit, "SQL: %v", ca.sql)
 	}
 }
 
@@ -25,6 +25,9 @@ import (
 	"github.com/pingcap/tidb/pkg/util/syncutil"
 )
 
+// GlobalPlanCacheMemoryTracker tracks the memory usage of the plan caches of all sessions.
+var GlobalPlanCacheMemoryTracker = memory.NewTracker(memory.LabelForPreparedPlanCache, -1)
+
 // planCacheEntry wraps Key and Value. It's the value of list.Element.
 type planCacheEntry struct {
 	PlanKey   string
@@ -151,6 +154,7 @@ func (l *LRUPlanCache) DeleteAll() {
 	// update metrics
 	if l.sctx.GetSessionVars().EnablePreparedPlanCacheMemoryMonitor {
 		core_metrics.GetPlanCacheInstanceMemoryUsage().Sub(float64(l.memoryUsageTotal))
+		GlobalPlanCacheMemoryTracker.Consume(-l.memoryUsageTotal)
 	}
 	core_metrics.GetPlanCacheInstanceNumCounter().Sub(float64(l.size))
 
@@ -259,17 +263,20 @@ func (l *LRUPlanCache) updateInstanceMetric(in, out *planCacheEntry) {
 		return
 	}
 
+	var delta int64
 	if in != nil && out != nil { // replace plan
+		delta = in.MemoryUsage() - out.MemoryUsage()
 		core_metrics.GetPlanCacheInstanceMemoryUsage().Sub(float64(out.MemoryUsage()))
 		core_metrics.GetPlanCacheInstanceMemoryUsage().Add(float64(in.MemoryUsage()))
-		l.memoryUsageTotal += in.MemoryUsage() - out.MemoryUsage()
 	} else if in != nil { // put plan
+		delta = in.MemoryUsage()
 		core_metrics.GetPlanCacheInstanceMemoryUsage().Add(float64(in.MemoryUsage()))
-		l.memoryUsageTotal += in.MemoryUsage()
 	} else { // delete plan
+		delta = -out.MemoryUsage()
 		core_metrics.GetPlanCacheInstanceMemoryUsage().Sub(float64(out.MemoryUsage()))
-		l.memoryUsageTotal -= out.MemoryUsage()
 	}
+	l.memoryUsageTotal += delta
+	GlobalPlanCacheMemoryTracker.Consume(delta)
 }
 
 // updateInstancePlanNum update the plan num
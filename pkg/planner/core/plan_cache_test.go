@@ -517,20 +517,22 @@ func TestPlanCacheWithSubquery(t *testing.T) {
 	tk.MustExec("create table t(a int, b int)")
 
 	testCases := []struct {
-		sql            string
-		params         []int
-		cacheAble      string
-		isDecorrelated bool
+		sql       string
+		params    []int
+		cacheAble string
 	}{
-		{"select * from t t1 where exists (select 1 from t t2 where t2.b < t1.b and t2.b < ?)", []int{1}, "1", false},      // exist
-		{"select * from t t1 where t1.a in (select a from t t2 where t2.b < ?)", []int{1}, "1", false},                     // in
-		{"select * from t t1 where t1.a > (select max(a) from t t2 where t2.b < t1.b and t2.b < ?)", []int{1}, "0", false}, // scala
-		{"select * from t t1 where t1.a > (select 1 from t t2 where t2.b<?)", []int{1}, "0", true},                         // uncorrelated
-		{"select * from t t1 where exists (select b from t t2 where t1.a = t2.a and t2.b<? limit 1)", []int{1}, "1", false},
-		{"select * from t t1 where exists (select b from t t2 where t1.a = t2.a and t2.b<? limit ?)", []int{1, 1}, "1", false},
+		{"select * from t t1 where exists (select 1 from t t2 where t2.b < t1.b and t2.b < ?)", []int{1}, "1"},      // exist
+		{"select * from t t1 where t1.a in (select a from t t2 where t2.b < ?)", []int{1}, "1"},                     // in
+		{"select * from t t1 where t1.a > (select max(a) from t t2 where t2.b < t1.b and t2.b < ?)", []int{1}, "0"}, // scala
+		{"select * from t t1 where t1.a > (select 1 from t t2 where t2.b<?)", []int{1}, "1"},                        // uncorrelated
+		{"select * from t t1 where exists (select 1 from t t2 where t2.b<?)", []int{1}, "1"},                        // uncorrelated exist
+		{"select * from t t1 where exists (select b from t t2 where t1.a = t2.a and t2.b<? limit 1)", []int{1}, "1"},
+		{"select * from t t1 where exists (select b from t t2 where t1.a = t2.a and t2.b<? limit ?)", []int{1, 1}, "1"},
 	}
 
 	// switch on
+	tk.MustQuery("select @@session.tidb_enable_plan_cache_for_uncorrelated_subquery").Check(testkit.Rows("0"))
+	tk.MustExec("set @@session.tidb_enable_plan_cache_for_uncorrelated_subquery = 1")
 	for _, testCase := range testCases {
 		tk.MustExec(fmt.Sprintf("prepare stmt from '%s'", testCase.sql))
 		var using []string
@@ -544,13 +546,38 @@ func TestPlanCacheWithSubquery(t *testing.T) {
 		tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows(testCase.cacheAble))
 		if testCase.cacheAble == "0" {
 			tk.MustExec("execute stmt using " + strings.Join(using, ", "))
-			if testCase.isDecorrelated {
-				tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip prepared plan-cache: query has uncorrelated sub-queries is un-cacheable"))
-			} else {
-				tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip prepared plan-cache: PhysicalApply plan is un-cacheable"))
-			}
+			tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip prepared plan-cache: PhysicalApply plan is un-cacheable"))
 		}
 	}
+
+	// the uncorrelated sub-queries are evaluated during optimization if the switch is off
+	tk.MustExec("prepare stmt from 'select * from t t1 where t1.a > (select 1 from t t2 where t2.b<?)'")
+	tk.MustExec("set @a0 = 1")
+	tk.MustExec("execute stmt using @a0")
+	tk.MustExec("execute stmt using @a0")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustExec("set @@session.tidb_enable_plan_cache_for_uncorrelated_subquery = 0")
+	tk.MustExec("execute stmt using @a0")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustExec("execute stmt using @a0")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip prepared plan-cache: query has uncorrelated sub-queries is un-cacheable"))
+	tk.MustExec("set @@session.tidb_enable_plan_cache_for_uncorrelated_subquery = 1")
+
+	// the cached plan evaluates the uncorrelated sub-queries with the new parameters
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("prepare stmt from 'select a from t t1 where t1.a > (select max(b) from t t2 where t2.b<?)'")
+	tk.MustExec("set @a0 = 2")
+	tk.MustQuery("execute stmt using @a0").Sort().Check(testkit.Rows("2", "3"))
+	tk.MustExec("set @a0 = 3")
+	tk.MustQuery("execute stmt using @a0").Check(testkit.Rows("3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustExec("prepare stmt from 'select a from t t1 where exists (select 1 from t t2 where t2.b>?)'")
+	tk.MustExec("set @a0 = 2")
+	tk.MustQuery("execute stmt using @a0").Sort().Check(testkit.Rows("1", "2", "3"))
+	tk.MustExec("set @a0 = 3")
+	tk.MustQuery("execute stmt using @a0").Check(testkit.Rows())
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
 	// switch off
 	tk.MustExec("set @@session.tidb_enable_plan_cache_for_subquery = 0")
 	for _, testCase := range testCases {
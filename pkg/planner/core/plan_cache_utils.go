@@ -386,6 +386,9 @@ type PlanCacheMatchOpts struct {
 
 	// Below are some variables that can affect the plan
 	ForeignKeyChecks bool
+	// EnablePlanCacheForUncorrelatedSubquery is only set for the query with sub queries, the uncorrelated
+	// sub queries are evaluated during optimization if it's false, which makes the plan un-cacheable.
+	EnablePlanCacheForUncorrelatedSubquery bool
 }
 
 // PlanCacheQueryFeatures records all query features which may affect plan selection.
@@ -530,11 +533,12 @@ func GetMatchOpts(sctx sessionctx.Context, is infoschema.InfoSchema, stmt *PlanC
 	}
 
 	return &PlanCacheMatchOpts{
-		LimitOffsetAndCount: limitOffsetAndCount,
-		HasSubQuery:         stmt.QueryFeatures.hasSubquery,
-		StatsVersionHash:    statsVerHash,
-		ParamTypes:          parseParamTypes(sctx, params),
-		ForeignKeyChecks:    sctx.GetSessionVars().ForeignKeyChecks,
+		LimitOffsetAndCount:                    limitOffsetAndCount,
+		HasSubQuery:                            stmt.QueryFeatures.hasSubquery,
+		StatsVersionHash:                       statsVerHash,
+		ParamTypes:                             parseParamTypes(sctx, params),
+		ForeignKeyChecks:                       sctx.GetSessionVars().ForeignKeyChecks,
+		EnablePlanCacheForUncorrelatedSubquery: stmt.QueryFeatures.hasSubquery && sctx.GetSessionVars().EnablePlanCacheForUncorrelatedSubquery,
 	}
 }
 
@@ -704,5 +708,8 @@ func matchCachedPlan(sctx sessionctx.Context, value *PlanCacheValue, matchOpts *
 	if value.matchOpts.ForeignKeyChecks != matchOpts.ForeignKeyChecks {
 		return false
 	}
+	if value.matchOpts.EnablePlanCacheForUncorrelatedSubquery != matchOpts.EnablePlanCacheForUncorrelatedSubquery {
+		return false
+	}
 	return true
 }
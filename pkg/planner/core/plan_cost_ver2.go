@@ -17,6 +17,8 @@ package core
 import (
 	"fmt"
 	"math"
+	"sync/atomic"
+	"time"
 
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/expression/aggregation"
@@ -367,7 +369,7 @@ func (p *PhysicalSort) GetPlanCostVer2(taskType property.TaskType, option *optim
 	rowSize := getAvgRowSize(p.StatsInfo(), p.Schema().Columns)
 	cpuFactor := getTaskCPUFactorVer2(p, taskType)
 	memFactor := getTaskMemFactorVer2(p, taskType)
-	diskFactor := defaultVer2Factors.TiDBDisk
+	diskFactor := getVer2Factors().TiDBDisk
 	oomUseTmpStorage := variable.EnableTmpStorageOnOOM.Load()
 	memQuota := p.SCtx().GetSessionVars().MemTracker.GetBytesLimit()
 	spill := taskType == property.RootTaskType && // only TiDB can spill
@@ -918,6 +920,12 @@ func (c costVer2Factors) tolist() (l []costusage.CostVer2Factor) {
 		c.TiDB2KVNet, c.TiDB2FlashNet, c.TiFlashMPPNet, c.TiDBMem, c.TiKVMem, c.TiFlashMem, c.TiDBDisk, c.TiDBRequest)
 }
 
+func (c *costVer2Factors) pointers() []*costusage.CostVer2Factor {
+	return []*costusage.CostVer2Factor{&c.TiDBTemp, &c.TiKVScan, &c.TiKVDescScan, &c.TiFlashScan, &c.TiDBCPU, &c.TiKVCPU,
+		&c.TiFlashCPU, &c.TiDB2KVNet, &c.TiDB2FlashNet, &c.TiFlashMPPNet, &c.TiDBMem, &c.TiKVMem, &c.TiFlashMem, &c.TiDBDisk,
+		&c.TiDBRequest}
+}
+
 var defaultVer2Factors = costVer2Factors{
 	TiDBTemp:      costusage.CostVer2Factor{Name: "tidb_temp_table_factor", Value: 0.00},
 	TiKVScan:      costusage.CostVer2Factor{Name: "tikv_scan_factor", Value: 40.70},
@@ -936,38 +944,123 @@ var defaultVer2Factors = costVer2Factors{
 	TiDBRequest:   costusage.CostVer2Factor{Name: "tidb_request_factor", Value: 6000000.00},
 }
 
+// calibratedVer2Factors is the factors loaded from mysql.tidb_cost_model_factors, which are calibrated by
+// `ADMIN CALIBRATE COST MODEL` on any instance. The default factors are used if it's nil.
+var calibratedVer2Factors atomic.Pointer[costVer2Factors]
+
+// SetCalibratedCostFactors sets the factors of the cost model ver2 by their names, the factors not in the map
+// keep the default values.
+func SetCalibratedCostFactors(factors map[string]float64) {
+	if len(factors) == 0 {
+		calibratedVer2Factors.Store(nil)
+		return
+	}
+	c := defaultVer2Factors
+	for _, factor := range c.pointers() {
+		if v, ok := factors[factor.Name]; ok {
+			factor.Value = v
+		}
+	}
+	calibratedVer2Factors.Store(&c)
+}
+
+func getVer2Factors() *costVer2Factors {
+	if c := calibratedVer2Factors.Load(); c != nil {
+		return c
+	}
+	return &defaultVer2Factors
+}
+
+// CostCalibrationTimes is the execution time of the queries that `ADMIN CALIBRATE COST MODEL` runs on a table of
+// Rows rows, each row is RowSize bytes.
+type CostCalibrationTimes struct {
+	Rows    float64
+	RowSize float64
+	// Base is the time of a query reading no rows, it's taken as the fixed overhead of the other queries.
+	Base time.Duration
+	// Count is the time of `count(*)` pushed down to TiKV: scan and agg in TiKV.
+	Count time.Duration
+	// Filter is the time of `count(*)` with a filter pushed down to TiKV: scan, filter and agg in TiKV.
+	Filter time.Duration
+	// Read is the time of reading all the rows: scan in TiKV and transfer to TiDB.
+	Read time.Duration
+	// Sort is the time of reading and sorting all the rows: scan in TiKV, transfer to TiDB and sort in TiDB.
+	Sort time.Duration
+}
+
+// CalibrateCostFactors calculates the CPU, scan and network factors of the cost model ver2 from the execution time,
+// by inverting the formulas used to estimate the cost of these queries. The factors are in nanoseconds, as the
+// default factors are. The factors that can't be calculated, because the time differences are covered by noise,
+// are not returned.
+func CalibrateCostFactors(t CostCalibrationTimes) []costusage.CostVer2Factor {
+	defaults := defaultVer2Factors
+	rows := math.Max(t.Rows, 2)
+	logRowSize := math.Log2(math.Max(t.RowSize, 2))
+	var factors []costusage.CostVer2Factor
+	add := func(factor costusage.CostVer2Factor, value float64) {
+		factor.Value = math.Round(value*100) / 100
+		factors = append(factors, factor)
+	}
+
+	// sort in TiDB: rows*log2(rows)*tidb-cpu + rows*row-size*mem
+	if cpu := (float64(t.Sort-t.Read) - rows*t.RowSize*defaults.TiDBMem.Value) / (rows * math.Log2(rows)); cpu > 0 {
+		add(defaults.TiDBCPU, cpu)
+	}
+	// filter in TiKV: rows*tikv-cpu more than count
+	cpu := float64(t.Filter-t.Count) / rows
+	if cpu > 0 {
+		add(defaults.TiKVCPU, cpu)
+	} else {
+		cpu = defaults.TiKVCPU.Value
+	}
+	// count: rows*log2(row-size)*scan + rows*tikv-cpu
+	scan := (float64(t.Count-t.Base) - rows*cpu) / (rows * logRowSize)
+	if scan > 0 {
+		add(defaults.TiKVScan, scan)
+		add(defaults.TiKVDescScan, scan*defaults.TiKVDescScan.Value/defaults.TiKVScan.Value)
+	} else {
+		scan = defaults.TiKVScan.Value
+	}
+	// read: rows*log2(row-size)*scan + rows*row-size*net
+	net := (float64(t.Read-t.Base) - rows*logRowSize*scan) / (rows * math.Max(t.RowSize, 1))
+	if net > 0 {
+		add(defaults.TiDB2KVNet, net)
+	}
+	return factors
+}
+
 func getTaskCPUFactorVer2(_ base.PhysicalPlan, taskType property.TaskType) costusage.CostVer2Factor {
 	switch taskType {
 	case property.RootTaskType: // TiDB
-		return defaultVer2Factors.TiDBCPU
+		return getVer2Factors().TiDBCPU
 	case property.MppTaskType: // TiFlash
-		return defaultVer2Factors.TiFlashCPU
+		return getVer2Factors().TiFlashCPU
 	default: // TiKV
-		return defaultVer2Factors.TiKVCPU
+		return getVer2Factors().TiKVCPU
 	}
 }
 
 func getTaskMemFactorVer2(_ base.PhysicalPlan, taskType property.TaskType) costusage.CostVer2Factor {
 	switch taskType {
 	case property.RootTaskType: // TiDB
-		return defaultVer2Factors.TiDBMem
+		return getVer2Factors().TiDBMem
 	case property.MppTaskType: // TiFlash
-		return defaultVer2Factors.TiFlashMem
+		return getVer2Factors().TiFlashMem
 	default: // TiKV
-		return defaultVer2Factors.TiKVMem
+		return getVer2Factors().TiKVMem
 	}
 }
 
 func getTaskScanFactorVer2(p base.PhysicalPlan, storeType kv.StoreType, taskType property.TaskType) costusage.CostVer2Factor {
 	if isTemporaryTable(getTableInfo(p)) {
-		return defaultVer2Factors.TiDBTemp
+		return getVer2Factors().TiDBTemp
 	}
 	if storeType == kv.TiFlash {
-		return defaultVer2Factors.TiFlashScan
+		return getVer2Factors().TiFlashScan
 	}
 	switch taskType {
 	case property.MppTaskType: // TiFlash
-		return defaultVer2Factors.TiFlashScan
+		return getVer2Factors().TiFlashScan
 	default: // TiKV
 		var desc bool
 		if indexScan, ok := p.(*PhysicalIndexScan); ok {
@@ -977,32 +1070,32 @@ func getTaskScanFactorVer2(p base.PhysicalPlan, storeType kv.StoreType, taskType
 			desc = tableScan.Desc
 		}
 		if desc {
-			return defaultVer2Factors.TiKVDescScan
+			return getVer2Factors().TiKVDescScan
 		}
-		return defaultVer2Factors.TiKVScan
+		return getVer2Factors().TiKVScan
 	}
 }
 
 func getTaskNetFactorVer2(p base.PhysicalPlan, _ property.TaskType) costusage.CostVer2Factor {
 	if isTemporaryTable(getTableInfo(p)) {
-		return defaultVer2Factors.TiDBTemp
+		return getVer2Factors().TiDBTemp
 	}
 	if _, ok := p.(*PhysicalExchangeReceiver); ok { // TiFlash MPP
-		return defaultVer2Factors.TiFlashMPPNet
+		return getVer2Factors().TiFlashMPPNet
 	}
 	if tblReader, ok := p.(*PhysicalTableReader); ok {
 		if _, isMPP := tblReader.tablePlan.(*PhysicalExchangeSender); isMPP { // TiDB to TiFlash with mpp protocol
-			return defaultVer2Factors.TiDB2FlashNet
+			return getVer2Factors().TiDB2FlashNet
 		}
 	}
-	return defaultVer2Factors.TiDB2KVNet
+	return getVer2Factors().TiDB2KVNet
 }
 
 func getTaskRequestFactorVer2(p base.PhysicalPlan, _ property.TaskType) costusage.CostVer2Factor {
 	if isTemporaryTable(getTableInfo(p)) {
-		return defaultVer2Factors.TiDBTemp
+		return getVer2Factors().TiDBTemp
 	}
-	return defaultVer2Factors.TiDBRequest
+	return getVer2Factors().TiDBRequest
 }
 
 func isTemporaryTable(tbl *model.TableInfo) bool {
@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/planner"
@@ -160,3 +161,51 @@ func BenchmarkGetPlanCost(b *testing.B) {
 		_, _ = core.GetPlanCost(phyPlan, property.RootTaskType, optimizetrace.NewDefaultPlanCostOption().WithCostFlag(costusage.CostFlagRecalculate))
 	}
 }
+
+func TestCalibrateCostFactors(t *testing.T) {
+	const rows, rowSize = 10000.0, 144.0
+	tidbCPU, tikvCPU, scan, net, mem := 50.0, 30.0, 60.0, 8.0, 0.2
+	base := 2 * time.Millisecond
+	count := base + time.Duration(rows*math.Log2(rowSize)*scan+rows*tikvCPU)
+	filter := count + time.Duration(rows*tikvCPU)
+	read := base + time.Duration(rows*math.Log2(rowSize)*scan+rows*rowSize*net)
+	sort := read + time.Duration(rows*math.Log2(rows)*tidbCPU+rows*rowSize*mem)
+
+	factors := core.CalibrateCostFactors(core.CostCalibrationTimes{
+		Rows: rows, RowSize: rowSize, Base: base, Count: count, Filter: filter, Read: read, Sort: sort})
+	values := make(map[string]float64, len(factors))
+	for _, factor := range factors {
+		values[factor.Name] = factor.Value
+	}
+	require.Len(t, values, 5)
+	require.InDelta(t, tidbCPU, values["tidb_cpu_factor"], 0.01)
+	require.InDelta(t, tikvCPU, values["tikv_cpu_factor"], 0.01)
+	require.InDelta(t, scan, values["tikv_scan_factor"], 0.01)
+	require.InDelta(t, scan*61.05/40.7, values["tikv_desc_scan_factor"], 0.01)
+	require.InDelta(t, net, values["tidb_kv_net_factor"], 0.01)
+
+	// the factors covered by noise are not calibrated.
+	factors = core.CalibrateCostFactors(core.CostCalibrationTimes{
+		Rows: rows, RowSize: rowSize, Base: base, Count: base, Filter: base, Read: base, Sort: base})
+	require.Empty(t, factors)
+}
+
+func TestCostModelVer2CalibratedFactors(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t (a int primary key, b int)`)
+	tk.MustExec("insert into t values (1, 1)")
+	tk.MustExec("set @@tidb_cost_model_version=2")
+	defer core.SetCalibratedCostFactors(nil)
+
+	scanFormula := func() string {
+		rs := tk.MustQuery("explain analyze format=true_card_cost select * from t where a>0").Rows()
+		return rs[len(rs)-1][3].(string)
+	}
+	require.Contains(t, scanFormula(), "tikv_scan_factor(40.7)")
+	core.SetCalibratedCostFactors(map[string]float64{"tikv_scan_factor": 55.5, "unknown_factor": 1})
+	require.Contains(t, scanFormula(), "tikv_scan_factor(55.5)")
+	core.SetCalibratedCostFactors(nil)
+	require.Contains(t, scanFormula(), "tikv_scan_factor(40.7)")
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/opcode"
@@ -546,7 +547,8 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (base.Plan, erro
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.AlterRangeStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
+		*ast.ImportIntoActionStmt, *ast.LoadDataActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt,
+		*ast.FetchResultStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1400,6 +1402,16 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (base.P
 		p := &CancelDDLJobs{JobIDs: as.JobIDs}
 		p.setSchemaAndNames(buildCancelDDLJobsFields())
 		ret = p
+	case ast.AdminShowTasks:
+		// reuse the SHOW plan, so the WHERE clause is applied the same way.
+		ret, err = b.buildShow(ctx, &ast.ShowStmt{Tp: ast.ShowTasks, Where: as.Where})
+		if err != nil {
+			return nil, err
+		}
+	case ast.AdminCancelTask:
+		p := &CancelTask{TaskIDs: as.JobIDs}
+		p.setSchemaAndNames(buildCancelTaskFields())
+		ret = p
 	case ast.AdminPauseDDLJobs:
 		p := &PauseDDLJobs{JobIDs: as.JobIDs}
 		p.setSchemaAndNames(buildPauseDDLJobsFields())
@@ -1442,24 +1454,24 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (base.P
 	case ast.AdminCaptureBindings:
 		return &SQLBindPlan{SQLBindOp: OpCaptureBindings}, nil
 	case ast.AdminEvolveBindings:
-		var err error
-		// The 'baseline evolution' only work in the test environment before the feature is GA.
-		if !config.CheckTableBeforeDrop {
-			err = errors.Errorf("Cannot enable baseline evolution feature, it is not generally available now")
-		}
-		return &SQLBindPlan{SQLBindOp: OpEvolveBindings}, err
+		return &SQLBindPlan{SQLBindOp: OpEvolveBindings}, nil
 	case ast.AdminReloadBindings:
 		return &SQLBindPlan{SQLBindOp: OpReloadBindings}, nil
 	case ast.AdminReloadStatistics:
 		return &Simple{Statement: as}, nil
 	case ast.AdminFlushPlanCache:
 		return &Simple{Statement: as}, nil
-	case ast.AdminSetBDRRole, ast.AdminUnsetBDRRole:
+	case ast.AdminSetBDRRole, ast.AdminUnsetBDRRole, ast.AdminCleanupTaskHistory, ast.AdminReloadClusterConfig,
+		ast.AdminAlterTaskPriority:
 		ret = &Simple{Statement: as}
 	case ast.AdminShowBDRRole:
 		p := &AdminShowBDRRole{}
 		p.setSchemaAndNames(buildAdminShowBDRRoleFields())
 		ret = p
+	case ast.AdminCalibrateCostModel:
+		p := &CalibrateCostModel{}
+		p.setSchemaAndNames(buildCalibrateCostModelFields())
+		ret = p
 	default:
 		return nil, plannererrors.ErrUnsupportedType.GenWithStack("Unsupported ast.AdminStmt(%T) for buildAdmin", as)
 	}
@@ -2971,6 +2983,14 @@ func buildCancelDDLJobsFields() (*expression.Schema, types.NameSlice) {
 	return buildCommandOnDDLJobsFields()
 }
 
+func buildCancelTaskFields() (*expression.Schema, types.NameSlice) {
+	schema := newColumnsWithNames(2)
+	schema.Append(buildColumnWithName("", "TASK_ID", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "RESULT", mysql.TypeVarchar, 128))
+
+	return schema.col2Schema(), schema.names
+}
+
 func buildPauseDDLJobsFields() (*expression.Schema, types.NameSlice) {
 	return buildCommandOnDDLJobsFields()
 }
@@ -2985,6 +3005,13 @@ func buildAdminShowBDRRoleFields() (*expression.Schema, types.NameSlice) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildCalibrateCostModelFields() (*expression.Schema, types.NameSlice) {
+	schema := newColumnsWithNames(2)
+	schema.Append(buildColumnWithName("", "FACTOR_NAME", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "FACTOR_VALUE", mysql.TypeDouble, 22))
+	return schema.col2Schema(), schema.names
+}
+
 func buildShowBackupMetaSchema() (*expression.Schema, types.NameSlice) {
 	names := []string{"Database", "Table", "Total_kvs", "Total_bytes", "Time_range_start", "Time_range_end"}
 	ftypes := []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeDatetime, mysql.TypeDatetime}
@@ -3044,6 +3071,17 @@ func buildCalibrateResourceSchema() (*expression.Schema, types.NameSlice) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildCalibrateResourceGroupsSchema() (*expression.Schema, types.NameSlice) {
+	longlongSize, _ := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLonglong)
+	schema := newColumnsWithNames(4)
+	schema.Append(buildColumnWithName("", "RESOURCE_GROUP", mysql.TypeVarchar, 32))
+	schema.Append(buildColumnWithName("", "RU_PER_SEC", mysql.TypeLonglong, longlongSize))
+	schema.Append(buildColumnWithName("", "CONSUMED_RU_PER_SEC", mysql.TypeLonglong, longlongSize))
+	schema.Append(buildColumnWithName("", "RECOMMENDED_RU_PER_SEC", mysql.TypeLonglong, longlongSize))
+
+	return schema.col2Schema(), schema.names
+}
+
 func buildAddQueryWatchSchema() (*expression.Schema, types.NameSlice) {
 	longlongSize, _ := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLonglong)
 	cols := newColumnsWithNames(1)
@@ -3052,6 +3090,27 @@ func buildAddQueryWatchSchema() (*expression.Schema, types.NameSlice) {
 	return cols.col2Schema(), cols.names
 }
 
+// buildFetchResultSchema builds the schema of `FETCH NEXT FROM 'token'`, which is the schema of the result of the
+// token kept in the server.
+func (b *PlanBuilder) buildFetchResultSchema(token string) (*expression.Schema, types.NameSlice, error) {
+	fetcher, ok := b.ctx.Value(ResultBatchFetcherKey).(ResultBatchFetcher)
+	if !ok {
+		return nil, nil, errors.Annotatef(mysql.NewErr(mysql.ErrSpCursorNotOpen), "result token %s", token)
+	}
+	names, fieldTypes, ok := fetcher.ResultColumns(token)
+	if !ok {
+		return nil, nil, errors.Annotatef(mysql.NewErr(mysql.ErrSpCursorNotOpen), "result token %s", token)
+	}
+	cols := make([]*expression.Column, 0, len(fieldTypes))
+	for _, ft := range fieldTypes {
+		cols = append(cols, &expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  ft,
+		})
+	}
+	return expression.NewSchema(cols...), names, nil
+}
+
 func buildColumnWithName(tableName, name string, tp byte, size int) (*expression.Column, *types.FieldName) {
 	cs, cl := types.DefaultCharsetForType(tp)
 	flag := mysql.UnsignedFlag
@@ -3133,6 +3192,8 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 			Extended:              show.Extended,
 			Limit:                 show.Limit,
 			ImportJobID:           show.ImportJobID,
+			TaskID:                show.TaskID,
+			LoadDataJobID:         show.LoadDataJobID,
 		},
 	}.Init(b.ctx)
 	isView := false
@@ -3187,6 +3248,9 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 	case ast.ShowRestores:
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESTORE_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESTORE_ADMIN"}, false, err)
+	case ast.ShowTaskHistory:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "",
+			plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER"))
 	case ast.ShowTableNextRowId:
 		p := &ShowNextRowID{TableName: show.Table}
 		p.setSchemaAndNames(buildShowNextRowID())
@@ -3312,11 +3376,21 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (base.
 	case *ast.CalibrateResourceStmt:
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESOURCE_GROUP_ADMIN"}, false, err)
-		p.setSchemaAndNames(buildCalibrateResourceSchema())
+		if raw.Groups {
+			p.setSchemaAndNames(buildCalibrateResourceGroupsSchema())
+		} else {
+			p.setSchemaAndNames(buildCalibrateResourceSchema())
+		}
 	case *ast.AddQueryWatchStmt:
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESOURCE_GROUP_ADMIN"}, false, err)
 		p.setSchemaAndNames(buildAddQueryWatchSchema())
+	case *ast.FetchResultStmt:
+		schema, names, err := b.buildFetchResultSchema(raw.Token)
+		if err != nil {
+			return nil, err
+		}
+		p.setSchemaAndNames(schema, names)
 	case *ast.DropQueryWatchStmt:
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESOURCE_GROUP_ADMIN"}, false, err)
@@ -3341,7 +3415,17 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (base.
 		// If you have the SUPER privilege, you can kill all threads and statements unless SEM is enabled.
 		// In which case you require RESTRICTED_CONNECTION_ADMIN to kill connections that belong to RESTRICTED_USER_ADMIN users.
 		sm := b.ctx.GetSessionManager()
-		if sm != nil {
+		if raw.SQLDigest != "" {
+			// Killing by SQL digest terminates the statements of all users.
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or CONNECTION_ADMIN")
+			b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"CONNECTION_ADMIN"}, false, err)
+			// The matched statements might belong to restricted users, so
+			// RESTRICTED_CONNECTION_ADMIN is required when SEM is enabled.
+			if sem.IsEnabled() {
+				err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("RESTRICTED_CONNECTION_ADMIN")
+				b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESTRICTED_CONNECTION_ADMIN"}, false, err)
+			}
+		} else if sm != nil {
 			if pi, ok := sm.GetProcessInfo(raw.ConnectionID); ok {
 				loginUser := b.ctx.GetSessionVars().User
 				if pi.User != loginUser.Username {
@@ -4130,9 +4214,35 @@ func (b *PlanBuilder) buildLoadData(ctx context.Context, ld *ast.LoadDataStmt) (
 	mockTablePlan.SetOutputNames(names)
 
 	p.GenCols, err = b.resolveGeneratedColumns(ctx, tableInPlan.Cols(), nil, mockTablePlan)
-	return p, err
+	if err != nil {
+		return nil, err
+	}
+
+	for _, opt := range ld.Options {
+		if opt.Name == loadDataDetachedOption {
+			p.Detached = true
+		}
+	}
+	if p.Detached {
+		if ld.FileLocRef == ast.FileLocClient {
+			return nil, exeerrors.ErrLoadDataLocalUnsupportedOption.FastGenByArgs(loadDataDetachedOption)
+		}
+		jobStmt := *ld
+		jobStmt.Options = nil
+		var sb strings.Builder
+		if err = jobStmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return nil, err
+		}
+		p.JobStmt = sb.String()
+		outputSchema, outputFields := convert2OutputSchemasAndNames([]string{"Job_ID"}, []byte{mysql.TypeLonglong})
+		p.setSchemaAndNames(outputSchema, outputFields)
+	}
+	return p, nil
 }
 
+// loadDataDetachedOption is the option to run LOAD DATA as a background job.
+const loadDataDetachedOption = "detached"
+
 var (
 	importIntoSchemaNames = []string{"Job_ID", "Data_Source", "Target_Table", "Table_ID",
 		"Phase", "Status", "Source_File_Size", "Imported_Rows",
@@ -5022,15 +5132,29 @@ func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt)
 	if err != nil {
 		return nil, err
 	}
-	targetPlan, _, err := OptimizeAstNode(ctx, sctx, sel, b.is)
+	targetPlan, names, err := OptimizeAstNode(ctx, sctx, sel, b.is)
 	if err != nil {
 		return nil, err
 	}
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	options := make([]*LoadDataOpt, 0, len(selectIntoInfo.Options))
+	for _, opt := range selectIntoInfo.Options {
+		intoOpt := LoadDataOpt{Name: opt.Name}
+		if opt.Value != nil {
+			intoOpt.Value, _, err = b.rewrite(ctx, opt.Value, mockTablePlan, nil, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+		options = append(options, &intoOpt)
+	}
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
 	return &SelectInto{
 		TargetPlan:     targetPlan,
 		IntoOpt:        selectIntoInfo,
+		TargetNames:    names,
 		LineFieldsInfo: NewLineFieldsInfo(selectIntoInfo.FieldsInfo, selectIntoInfo.LinesInfo),
+		Options:        options,
 	}, nil
 }
 
@@ -5260,6 +5384,9 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 	case ast.ShowBindingCacheStatus:
 		names = []string{"bindings_in_cache", "bindings_in_table", "memory_usage", "memory_quota"}
 		ftypes = []byte{mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar}
+	case ast.ShowBindingEvolveTasks:
+		names = []string{"Original_sql", "Default_db", "Bind_sql", "Status", "Current_plan_time", "Candidate_plan_time", "Message", "Create_time", "Update_time", "Sql_digest"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeDouble, mysql.TypeDouble, mysql.TypeVarchar, mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeVarchar}
 	case ast.ShowAnalyzeStatus:
 		names = []string{"Table_schema", "Table_name", "Partition_name", "Job_info", "Processed_rows", "Start_time",
 			"End_time", "State", "Fail_reason", "Instance", "Process_ID", "Remaining_seconds", "Progress", "Estimated_total_rows"}
@@ -5283,6 +5410,21 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 	case ast.ShowImportJobs:
 		names = importIntoSchemaNames
 		ftypes = importIntoSchemaFTypes
+	case ast.ShowLoadDataJobs:
+		names = []string{"Job_ID", "Data_Source", "Target_Table", "State", "Loaded_Rows", "Result_Message",
+			"Create_Time", "Update_Time", "End_Time", "Created_By", "Instance"}
+		ftypes = []byte{mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong,
+			mysql.TypeVarchar, mysql.TypeTimestamp, mysql.TypeTimestamp, mysql.TypeTimestamp, mysql.TypeVarchar,
+			mysql.TypeVarchar}
+	case ast.ShowTaskHistory:
+		names = []string{"Event_ID", "Type", "Exec_ID", "Message", "Create_Time"}
+		ftypes = []byte{mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeDatetime}
+	case ast.ShowTasks:
+		names = []string{"Task_ID", "Task_Key", "Type", "State", "Step", "Priority", "Concurrency",
+			"Create_Time", "Start_Time", "State_Update_Time", "Progress", "Error"}
+		ftypes = []byte{mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar,
+			mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeDatetime,
+			mysql.TypeDouble, mysql.TypeVarchar}
 	}
 	return convert2OutputSchemasAndNames(names, ftypes)
 }
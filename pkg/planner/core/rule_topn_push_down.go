@@ -65,6 +65,38 @@ func (p *LogicalCTE) PushDownTopN(topNLogicalPlan base.LogicalPlan, opt *optimiz
 	return p
 }
 
+// PushDownTopN implements the LogicalPlan interface.
+func (p *LogicalMemTable) PushDownTopN(topNLogicalPlan base.LogicalPlan, opt *optimizetrace.LogicalOptimizeOp) base.LogicalPlan {
+	var topN *LogicalTopN
+	if topNLogicalPlan != nil {
+		topN = topNLogicalPlan.(*LogicalTopN)
+	}
+	if topN == nil {
+		return p
+	}
+	// The cluster log is merged in time order from the remote streams, so the limit can be pushed down to
+	// stop retrieving if there's no order or the order is by time. The TopN is kept to guarantee the result.
+	if e, ok := p.Extractor.(*ClusterLogTableExtractor); ok && !e.SkipRequest && p.isOrderedByLogTime(topN.ByItems) {
+		e.Limit = topN.Count + topN.Offset
+	}
+	return topN.setChild(p, opt)
+}
+
+func (p *LogicalMemTable) isOrderedByLogTime(byItems []*util.ByItems) bool {
+	if len(byItems) == 0 {
+		return true
+	}
+	if len(byItems) > 1 || byItems[0].Desc {
+		return false
+	}
+	col, ok := byItems[0].Expr.(*expression.Column)
+	if !ok {
+		return false
+	}
+	idx := p.Schema().ColumnIndex(col)
+	return idx >= 0 && p.OutputNames()[idx].ColName.L == "time"
+}
+
 // setChild set p as topn's child.
 func (lt *LogicalTopN) setChild(p base.LogicalPlan, opt *optimizetrace.LogicalOptimizeOp) base.LogicalPlan {
 	// Remove this TopN if its child is a TableDual.
@@ -138,6 +138,10 @@ func TestRandomFlushPlanCache(t *testing.T) {
 				session2PC = "0"
 				err = tk2.ExecToErr("admin flush session plan_cache;")
 				require.NoError(t, err)
+			} else if randNum == 3 {
+				session1PC, session2PC = "0", "0"
+				err = tk.ExecToErr("admin flush cluster plan_cache;")
+				require.NoError(t, err)
 			}
 
 			for _, execStmt := range execStmts {
@@ -153,6 +157,15 @@ func TestRandomFlushPlanCache(t *testing.T) {
 		require.NoError(t, err)
 	}
 
+	// flushing the cluster plan cache also flushes the plan cache of the local instance.
+	tk.MustExec("execute stmt1")
+	tk2.MustExec("execute stmt1")
+	tk.MustExec("admin flush cluster plan_cache")
+	tk.MustExec("execute stmt1")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk2.MustExec("execute stmt1")
+	tk2.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
 	err = tk.ExecToErr("admin flush global plan_cache;")
 	require.EqualError(t, err, "Do not support the 'admin flush global scope.'")
 }
@@ -24,11 +24,13 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/bindinfo"
+	"github.com/pingcap/tidb/pkg/bindinfo/norm"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
 	"github.com/pingcap/tidb/pkg/planner/cascades"
 	pctx "github.com/pingcap/tidb/pkg/planner/context"
 	"github.com/pingcap/tidb/pkg/planner/core"
@@ -42,6 +44,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/hint"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	utilparser "github.com/pingcap/tidb/pkg/util/parser"
 	"github.com/pingcap/tidb/pkg/util/topsql"
 	"go.uber.org/zap"
 )
@@ -230,6 +233,18 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 	if err := pctx.AdviseTxnWarmup(); err != nil {
 		return nil, nil, err
 	}
+	if sessVars.EnableCardinalityFeedback && !sessVars.InRestrictedSQL && useCardinalityFeedback(node) {
+		// the digest for bindings is used, which skips EXPLAIN, so EXPLAIN shows the plan using the same feedback.
+		// The digest is empty for the statement inside EXPLAIN, whose key is got from the EXPLAIN already.
+		if _, digest := norm.NormalizeStmtForBinding(node.(ast.StmtNode), norm.WithSpecifiedDB(sessVars.CurrentDB)); digest != "" {
+			stmtNode := node.(ast.StmtNode)
+			for explain, ok := stmtNode.(*ast.ExplainStmt); ok; explain, ok = stmtNode.(*ast.ExplainStmt) {
+				stmtNode = explain.Stmt
+			}
+			stmtText := utilparser.RestoreWithDefaultDB(stmtNode, sessVars.CurrentDB, "")
+			sessVars.StmtCtx.CardinalityFeedbackKey = cardinality.FeedbackStmtKey(digest, stmtText, sessVars.PlanCacheParams.AllParamValues())
+		}
+	}
 
 	enableUseBinding := sessVars.UsePlanBaselines
 	stmtNode, isStmtNode := node.(ast.StmtNode)
@@ -354,10 +369,12 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 
 	// Add a baseline evolution task if:
 	// 1. the returned plan is from bindings;
-	// 2. the query is a select statement;
+	// 2. the query is a select statement without locking read, which can be run in the verification;
 	// 3. the original binding contains no read_from_storage hint;
 	// 4. the plan when ignoring bindings contains no tiflash hint;
-	// 5. the pending verified binding has not been added already;
+	// 5. the plan when ignoring bindings is different from the plan of the bindings;
+	// 6. the binding is a global binding, and the query is not an internal one.
+	// The tasks already added are ignored when they are saved to the storage.
 	savedStmtHints := sessVars.StmtCtx.StmtHints
 	defer func() {
 		sessVars.StmtCtx.StmtHints = savedStmtHints
@@ -365,7 +382,8 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 	if sessVars.EvolvePlanBaselines && bestPlanFromBind != nil &&
 		sessVars.SelectLimit == math.MaxUint64 { // do not evolve this query if sql_select_limit is enabled
 		// Check bestPlanFromBind firstly to avoid nil stmtNode.
-		if _, ok := stmtNode.(*ast.SelectStmt); ok && !bindings[0].Hint.ContainTableHint(hint.HintReadFromStorage) {
+		if sel, ok := stmtNode.(*ast.SelectStmt); ok && (sel.LockInfo == nil || sel.LockInfo.LockType == ast.SelectLockNone) &&
+			!bindings[0].Hint.ContainTableHint(hint.HintReadFromStorage) {
 			sessVars.StmtCtx.StmtHints = originStmtHints
 			defPlan, _, _, err := optimize(ctx, pctx, node, is)
 			if err != nil {
@@ -378,6 +396,13 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 					return bestPlan, names, nil
 				}
 			}
+			if scope == metrics.ScopeGlobal && !sessVars.InRestrictedSQL {
+				_, defPlanDigest := core.NormalizePlan(defPlan)
+				_, bindPlanDigest := core.NormalizePlan(bestPlanFromBind)
+				if defPlanDigest.String() != bindPlanDigest.String() {
+					handleEvolveTasks(pctx, chosenBinding, stmtNode, hint.RestoreOptimizerHints(defPlanHints), defPlanDigest.String())
+				}
+			}
 		}
 	}
 
@@ -588,6 +613,41 @@ func handleInvalidBinding(ctx context.Context, sctx pctx.PlanContext, level stri
 	globalHandle.AddInvalidGlobalBinding(binding)
 }
 
+// handleEvolveTasks adds the plan generated without the bindings as an evolve task of the binding, which is
+// verified in the background and replaces the binding if it runs faster.
+func handleEvolveTasks(sctx pctx.PlanContext, binding bindinfo.Binding, stmtNode ast.StmtNode, planHint, planDigest string) {
+	sessVars := sctx.GetSessionVars()
+	// GenerateBindingSQL removes the hints of the statement, restore them after the BindSQL is generated.
+	originHints := hint.CollectHint(stmtNode)
+	defer hint.BindHint(stmtNode, originHints)
+	bindSQL := bindinfo.GenerateBindingSQL(stmtNode, planHint, false, utilparser.GetDefaultDB(stmtNode, sessVars.CurrentDB))
+	if bindSQL == "" {
+		return
+	}
+	charset, collation := sessVars.GetCharsetInfo()
+	domain.GetDomain(sctx).BindHandle().AddEvolveTask(bindinfo.Binding{
+		OriginalSQL: binding.OriginalSQL,
+		Db:          binding.Db,
+		BindSQL:     bindSQL,
+		Charset:     charset,
+		Collation:   collation,
+		PlanDigest:  planDigest,
+	})
+}
+
+// useCardinalityFeedback checks whether the statement reads tables and can use the cardinality feedback.
+func useCardinalityFeedback(node ast.Node) bool {
+	switch x := node.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+		return true
+	case *ast.InsertStmt:
+		return x.Select != nil
+	case *ast.ExplainStmt:
+		return useCardinalityFeedback(x.Stmt)
+	}
+	return false
+}
+
 // setVarHintChecker checks whether the variable name in set_var hint is valid.
 func setVarHintChecker(varName, hint string) (ok bool, warning error) {
 	sysVar := variable.GetSysVar(varName)
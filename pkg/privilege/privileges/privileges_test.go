@@ -1214,6 +1214,30 @@ func TestSecurityEnhancedModeInfoschema(t *testing.T) {
 	tk.MustQuery(`SELECT COUNT(*) FROM information_schema.cluster_info WHERE status_address IS NULL`).Check(testkit.Rows("0"))
 }
 
+func TestSecurityEnhancedModeKillQueryDigest(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("CREATE USER uroot1, uroot2")
+	tk.MustExec("GRANT CONNECTION_ADMIN ON *.* to uroot1")
+	tk.MustExec("GRANT CONNECTION_ADMIN, RESTRICTED_CONNECTION_ADMIN ON *.* to uroot2")
+	digest := "e5796985ccafe2f71126ed6c0ac939ffa015a8c0744a24b7aee6d587103fd2f7"
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "uroot1", Hostname: "localhost"}, nil, nil, nil))
+	tk1.MustExec("KILL QUERY DIGEST '" + digest + "'")
+
+	sem.Enable()
+	defer sem.Disable()
+
+	// the statements of restricted users might be killed by digest.
+	err := tk1.ExecToErr("KILL QUERY DIGEST '" + digest + "'")
+	require.EqualError(t, err, "[planner:1227]Access denied; you need (at least one of) the RESTRICTED_CONNECTION_ADMIN privilege(s) for this operation")
+
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "uroot2", Hostname: "localhost"}, nil, nil, nil))
+	tk2.MustExec("KILL QUERY DIGEST '" + digest + "'")
+}
+
 func TestSecurityEnhancedLocalBackupRestore(t *testing.T) {
 	store := createStoreAndPrepareDB(t)
 
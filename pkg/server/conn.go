@@ -2065,7 +2065,13 @@ func (cc *clientConn) handleStmt(
 		}
 		cc.ctx.GetSessionVars().SQLKiller.InWriteResultSet.Store(true)
 		defer cc.ctx.GetSessionVars().SQLKiller.InWriteResultSet.Store(false)
-		if retryable, err := cc.writeResultSet(ctx, rs, false, status, 0); err != nil {
+		// The rows are returned in batches if tidb_result_batch_size is set, except in multi-statements.
+		// The result of FETCH is limited by the executor, so it isn't split again.
+		batchSize := 0
+		if _, ok := stmt.(*ast.FetchResultStmt); !ok && !cc.ctx.GetSessionVars().InMultiStmts {
+			batchSize = cc.ctx.GetSessionVars().ResultBatchSize
+		}
+		if retryable, err := cc.writeResultSet(ctx, rs, false, status, batchSize); err != nil {
 			return retryable, err
 		}
 		return false, nil
@@ -2251,6 +2257,7 @@ func (cc *clientConn) writeResultSet(ctx context.Context, rs resultset.ResultSet
 		recoverdErr, ok := r.(error)
 		if !ok || !(exeerrors.ErrMemoryExceedForQuery.Equal(recoverdErr) ||
 			exeerrors.ErrMemoryExceedForInstance.Equal(recoverdErr) ||
+			exeerrors.ErrDiskExceedForQuery.Equal(recoverdErr) ||
 			exeerrors.ErrQueryInterrupted.Equal(recoverdErr) ||
 			exeerrors.ErrMaxExecTimeExceeded.Equal(recoverdErr)) {
 			panic(r)
@@ -2272,6 +2279,12 @@ func (cc *clientConn) writeResultSet(ctx context.Context, rs resultset.ResultSet
 		}
 		return false, cc.flush(ctx)
 	}
+	if !binary && fetchSize > 0 {
+		if retryable, err := cc.writeChunksInBatches(ctx, rs, serverStatus, fetchSize); err != nil {
+			return retryable, err
+		}
+		return false, cc.flush(ctx)
+	}
 	if retryable, err := cc.writeChunks(ctx, rs, binary, serverStatus); err != nil {
 		return retryable, err
 	}
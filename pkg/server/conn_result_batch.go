@@ -0,0 +1,255 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/server/internal/column"
+	"github.com/pingcap/tidb/pkg/server/internal/resultset"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+// maxBatchedResults is the max number of results kept in a connection, the oldest result is closed when
+// a new result is kept beyond it.
+const maxBatchedResults = 8
+
+// batchedResult is the rest rows of a result set returned to the text protocol clients in batches of
+// tidb_result_batch_size rows. Like the cursor of the binary protocol, the rows are kept in a row container
+// which spills to disk under memory pressure, so the session can run other statements before the client
+// fetches the rest rows by `FETCH NEXT FROM 'token'`.
+type batchedResult struct {
+	id           uint64
+	names        types.NameSlice
+	fieldTypes   []*types.FieldType
+	rowContainer *chunk.RowContainer
+	reader       chunk.RowContainerReader
+}
+
+func (r *batchedResult) exhausted() bool {
+	return r.reader.Current() == r.reader.End()
+}
+
+func (r *batchedResult) close() error {
+	r.reader.Close()
+	r.rowContainer.GetMemTracker().Detach()
+	r.rowContainer.GetDiskTracker().Detach()
+	return r.rowContainer.Close()
+}
+
+var _ plannercore.ResultBatchFetcher = &TiDBContext{}
+
+// ResultColumns implements the plannercore.ResultBatchFetcher interface.
+func (tc *TiDBContext) ResultColumns(token string) (types.NameSlice, []*types.FieldType, bool) {
+	r, ok := tc.batchedResults[token]
+	if !ok {
+		return nil, nil, false
+	}
+	return r.names, r.fieldTypes, true
+}
+
+// FetchRows implements the plannercore.ResultBatchFetcher interface.
+func (tc *TiDBContext) FetchRows(token string, chk *chunk.Chunk, maxRows int) (bool, error) {
+	r, ok := tc.batchedResults[token]
+	if !ok {
+		return true, errors.Annotatef(mysql.NewErr(mysql.ErrSpCursorNotOpen), "result token %s", token)
+	}
+	for i := 0; i < maxRows && !r.exhausted(); i++ {
+		chk.AppendRow(r.reader.Current())
+		r.reader.Next()
+	}
+	err := r.reader.Error()
+	if err != nil || r.exhausted() {
+		if errClose := tc.closeBatchedResult(token); err == nil {
+			err = errClose
+		}
+		return true, err
+	}
+	return false, nil
+}
+
+// storeBatchedResult stores the result and returns the token to fetch it, the oldest result is closed if
+// there are already maxBatchedResults results in the context.
+func (tc *TiDBContext) storeBatchedResult(r *batchedResult) string {
+	if len(tc.batchedResults) >= maxBatchedResults {
+		var oldest string
+		for token, result := range tc.batchedResults {
+			if oldest == "" || result.id < tc.batchedResults[oldest].id {
+				oldest = token
+			}
+		}
+		terror.Log(tc.closeBatchedResult(oldest))
+	}
+	tc.lastResultID++
+	r.id = tc.lastResultID
+	token := strconv.FormatUint(r.id, 10)
+	tc.batchedResults[token] = r
+	return token
+}
+
+// closeBatchedResult closes the result of the token and removes it from the context.
+func (tc *TiDBContext) closeBatchedResult(token string) error {
+	r, ok := tc.batchedResults[token]
+	if !ok {
+		return nil
+	}
+	delete(tc.batchedResults, token)
+	return r.close()
+}
+
+// newBatchedResult creates a batchedResult whose rows are kept in a row container tracked by the session.
+func newBatchedResult(vars *variable.SessionVars, columns []*column.Info, fieldTypes []*types.FieldType) *batchedResult {
+	// NOTE: chunk should not be allocated from the connection allocator, which will reset after executing this command
+	// but the rows are still needed in the following FETCH command.
+	rowContainer := chunk.NewRowContainer(fieldTypes, vars.MaxChunkSize)
+	rowContainer.GetMemTracker().AttachTo(vars.MemTracker)
+	rowContainer.GetMemTracker().SetLabel(memory.LabelForCursorFetch)
+	rowContainer.GetDiskTracker().AttachTo(vars.DiskTracker)
+	rowContainer.GetDiskTracker().SetLabel(memory.LabelForCursorFetch)
+	if variable.EnableTmpStorageOnOOM.Load() {
+		action := memory.NewActionWithPriority(rowContainer.ActionSpill(), memory.DefCursorFetchSpillPriority)
+		vars.MemTracker.FallbackOldAndSetNewAction(action)
+	}
+	names := make(types.NameSlice, 0, len(columns))
+	for _, col := range columns {
+		names = append(names, &types.FieldName{
+			DBName:      model.NewCIStr(col.Schema),
+			OrigTblName: model.NewCIStr(col.OrgTable),
+			TblName:     model.NewCIStr(col.Table),
+			OrigColName: model.NewCIStr(col.OrgName),
+			ColName:     model.NewCIStr(col.Name),
+		})
+	}
+	return &batchedResult{
+		names:        names,
+		fieldTypes:   fieldTypes,
+		rowContainer: rowContainer,
+	}
+}
+
+// writeChunksInBatches writes the first batchSize rows of the result set to the client as they are read, and
+// keeps the rest rows in the session if there are any. The token to fetch the rest rows is set to
+// tidb_last_result_token.
+// The first return value indicates whether error occurs at the first call of ResultSet.Next.
+func (cc *clientConn) writeChunksInBatches(ctx context.Context, rs resultset.ResultSet, serverStatus uint16, batchSize int) (_ bool, err error) {
+	vars := cc.ctx.GetSessionVars()
+	vars.LastResultToken = ""
+	var (
+		stmtDetail *execdetails.StmtExecDetails
+		start      time.Time
+		r          *batchedResult
+	)
+	if stmtDetailRaw := ctx.Value(execdetails.StmtExecDetailKey); stmtDetailRaw != nil {
+		//nolint:forcetypeassert
+		stmtDetail = stmtDetailRaw.(*execdetails.StmtExecDetails)
+	}
+	defer func() {
+		if err != nil && r != nil {
+			terror.Log(r.close())
+		}
+	}()
+
+	data := cc.alloc.AllocWithLen(4, 1024)
+	req := rs.NewChunk(nil)
+	gotColumnInfo := false
+	firstNext := true
+	written := 0
+	for {
+		if err = rs.Next(ctx, req); err != nil {
+			return firstNext, err
+		}
+		if stmtDetail != nil {
+			start = time.Now()
+		}
+		if !gotColumnInfo {
+			// We need to call Next before we get columns.
+			// Otherwise, we will get incorrect columns info.
+			if err = cc.writeColumnInfo(rs.Columns()); err != nil {
+				return false, err
+			}
+			if cc.capability&mysql.ClientDeprecateEOF == 0 {
+				// metadata only needs EOF marker for old clients without ClientDeprecateEOF
+				if err = cc.writeEOF(ctx, serverStatus); err != nil {
+					return false, err
+				}
+			}
+			gotColumnInfo = true
+		}
+		rowCount := req.NumRows()
+		if rowCount == 0 {
+			break
+		}
+		firstNext = false
+		// the rows of the first batch are written at once, only the rest rows are kept.
+		begin := min(rowCount, batchSize-written)
+		for i := 0; i < begin; i++ {
+			data = data[0:4]
+			data, err = column.DumpTextRow(data, rs.Columns(), req.GetRow(i), cc.rsEncoder)
+			if err != nil {
+				return false, err
+			}
+			if err = cc.writePacket(data); err != nil {
+				return false, err
+			}
+		}
+		written += begin
+		if begin < rowCount {
+			if r == nil {
+				if err = cc.flush(ctx); err != nil {
+					return false, err
+				}
+				r = newBatchedResult(vars, rs.Columns(), rs.FieldTypes())
+			}
+			rest := req
+			if begin > 0 {
+				rest = chunk.New(rs.FieldTypes(), rowCount-begin, rowCount-begin)
+				rest.Append(req, begin, rowCount)
+			} else {
+				req = rs.NewChunk(nil)
+			}
+			if err = r.rowContainer.Add(rest); err != nil {
+				return false, err
+			}
+		}
+		if stmtDetail != nil {
+			stmtDetail.WriteSQLRespDuration += time.Since(start)
+		}
+	}
+	if r != nil {
+		r.reader = chunk.NewRowContainerReader(r.rowContainer)
+		vars.LastResultToken = cc.ctx.storeBatchedResult(r)
+		// the result is owned by the context now.
+		r = nil
+	}
+	if stmtDetail != nil {
+		start = time.Now()
+	}
+	err = cc.writeEOF(ctx, serverStatus)
+	if stmtDetail != nil {
+		stmtDetail.WriteSQLRespDuration += time.Since(start)
+	}
+	return false, err
+}
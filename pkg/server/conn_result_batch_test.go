@@ -0,0 +1,113 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultBatch(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	srv := CreateMockServer(t, store)
+	srv.SetDomain(dom)
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := CreateMockConn(t, srv).(*mockConn)
+	out := new(bytes.Buffer)
+	c.pkt.ResetBufWriter(out)
+	c.capability |= mysql.ClientDeprecateEOF | mysql.ClientProtocol41
+	tk := testkit.NewTestKitWithSession(t, store, c.Context().Session)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int primary key)")
+	tk.MustExec("insert into t values (1), (2), (3), (4), (5), (6), (7), (8)")
+
+	// query returns the rows of the single column result written by the last command.
+	query := func(sql string) []string {
+		out.Reset()
+		require.NoError(t, c.Dispatch(ctx, append([]byte{mysql.ComQuery}, sql...)))
+		var packets [][]byte
+		for raw := out.Bytes(); len(raw) > 0; {
+			length := int(raw[0]) | int(raw[1])<<8 | int(raw[2])<<16
+			packets = append(packets, raw[4:4+length])
+			raw = raw[4+length:]
+		}
+		// skip the column count, the column definition and the EOF.
+		rows := make([]string, 0, len(packets)-3)
+		for _, p := range packets[2 : len(packets)-1] {
+			rows = append(rows, string(p[1:]))
+		}
+		return rows
+	}
+
+	tk.MustExec("set @@tidb_result_batch_size = 3")
+	require.Equal(t, []string{"1", "2", "3"}, query("select a from t order by a"))
+	tk.MustQuery("select @@tidb_last_result_token").Check(testkit.Rows("1"))
+
+	// other statements can run before the rest rows are fetched.
+	tk.MustExec("insert into t values (9)")
+	require.Equal(t, []string{"9"}, query("select count(*) from t"))
+	tk.MustQuery("select @@tidb_last_result_token").Check(testkit.Rows(""))
+
+	require.Equal(t, []string{"4", "5", "6"}, query("fetch next from '1'"))
+	// FETCH runs as a normal statement.
+	require.Equal(t, "fetch next from '1'", tk.Session().GetSessionVars().StmtCtx.OriginalSQL)
+	tk.MustQuery("select @@tidb_last_result_token").Check(testkit.Rows("1"))
+	require.Equal(t, []string{"7", "8"}, query("fetch next from '1'"))
+	tk.MustQuery("select @@tidb_last_result_token").Check(testkit.Rows(""))
+	// the result is closed once all its rows are fetched.
+	require.Error(t, c.Dispatch(ctx, append([]byte{mysql.ComQuery}, "fetch next from '1'"...)))
+
+	// the rest rows are all returned if tidb_result_batch_size is 0.
+	require.Equal(t, []string{"1", "2", "3"}, query("select a from t where a < 5 order by a"))
+	token := tk.MustQuery("select @@tidb_last_result_token").Rows()[0][0].(string)
+	require.NotEqual(t, "", token)
+	tk.MustExec("set @@tidb_result_batch_size = 0")
+	require.Equal(t, []string{"4"}, query("fetch next from '"+token+"'"))
+	require.Equal(t, []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}, query("select a from t order by a"))
+	require.Len(t, c.Context().batchedResults, 0)
+
+	// the oldest results are closed when too many results are kept.
+	tk.MustExec("set @@tidb_result_batch_size = 1")
+	tokens := make([]string, 0, maxBatchedResults+1)
+	for i := 0; i <= maxBatchedResults; i++ {
+		require.Equal(t, []string{"1"}, query("select a from t order by a"))
+		tokens = append(tokens, tk.MustQuery("select @@tidb_last_result_token").Rows()[0][0].(string))
+	}
+	require.Len(t, c.Context().batchedResults, maxBatchedResults)
+	require.Error(t, c.Dispatch(ctx, append([]byte{mysql.ComQuery}, "fetch next from '"+tokens[0]+"'"...)))
+	require.Equal(t, []string{"2"}, query("fetch next from '"+tokens[1]+"'"))
+
+	// the results are released when the connection is reset.
+	require.NoError(t, c.Dispatch(ctx, []byte{mysql.ComResetConnection}))
+	require.Len(t, c.Context().batchedResults, 0)
+	require.Error(t, c.Dispatch(ctx, append([]byte{mysql.ComQuery}, "fetch next from '"+tokens[1]+"'"...)))
+	tk = testkit.NewTestKitWithSession(t, store, c.Context().Session)
+	tk.MustExec("use test")
+
+	// the results are released when the connection is closed.
+	tk.MustExec("set @@tidb_result_batch_size = 1")
+	require.Equal(t, []string{"1"}, query("select a from t order by a"))
+	require.Len(t, c.Context().batchedResults, 1)
+	require.Greater(t, tk.Session().GetSessionVars().MemTracker.BytesConsumed(), int64(0))
+	require.NoError(t, c.Context().Close())
+	require.Len(t, c.Context().batchedResults, 0)
+}
@@ -337,25 +337,11 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 		defer cc.rsEncoder.Clean()
 		// fetch all results of the resultSet, and stored them locally, so that the future `FETCH` command can read
 		// the rows directly to avoid running executor and accessing shared params/variables in the session
-		// NOTE: chunk should not be allocated from the connection allocator, which will reset after executing this command
-		// but the rows are still needed in the following FETCH command.
-
-		// create the row container to manage spill
 		// this `rowContainer` will be released when the statement (or the connection) is closed.
-		rowContainer := chunk.NewRowContainer(crs.FieldTypes(), vars.MaxChunkSize)
-		rowContainer.GetMemTracker().AttachTo(vars.MemTracker)
-		rowContainer.GetMemTracker().SetLabel(memory.LabelForCursorFetch)
-		rowContainer.GetDiskTracker().AttachTo(vars.DiskTracker)
-		rowContainer.GetDiskTracker().SetLabel(memory.LabelForCursorFetch)
-		if variable.EnableTmpStorageOnOOM.Load() {
-			failpoint.Inject("testCursorFetchSpill", func(val failpoint.Value) {
-				if val, ok := val.(bool); val && ok {
-					actionSpill := rowContainer.ActionSpillForTest()
-					defer actionSpill.WaitForTest()
-				}
-			})
-			action := memory.NewActionWithPriority(rowContainer.ActionSpill(), memory.DefCursorFetchSpillPriority)
-			vars.MemTracker.FallbackOldAndSetNewAction(action)
+		var rowContainer *chunk.RowContainer
+		rowContainer, _, err = fetchAllIntoRowContainer(ctx, vars, crs)
+		if err != nil {
+			return false, err
 		}
 		defer func() {
 			if err != nil {
@@ -369,23 +355,6 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 			}
 		}()
 
-		for {
-			chk := crs.NewChunk(nil)
-
-			if err = crs.Next(ctx, chk); err != nil {
-				return false, err
-			}
-			rowCount := chk.NumRows()
-			if rowCount == 0 {
-				break
-			}
-
-			err = rowContainer.Add(chk)
-			if err != nil {
-				return false, err
-			}
-		}
-
 		reader := chunk.NewRowContainerReader(rowContainer)
 		crs.StoreRowContainerReader(reader)
 		stmt.StoreResultSet(crs)
@@ -424,6 +393,52 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 	return false, nil
 }
 
+// fetchAllIntoRowContainer reads all the rows of the result set into a new row container, which spills to disk
+// under memory pressure if tidb_enable_tmp_storage_on_oom is on. The second return value indicates whether the
+// error occurs at the first call of ResultSet.Next.
+func fetchAllIntoRowContainer(ctx context.Context, vars *variable.SessionVars, rs resultset.ResultSet) (*chunk.RowContainer, bool, error) {
+	// NOTE: chunk should not be allocated from the connection allocator, which will reset after executing this command
+	// but the rows are still needed in the following FETCH command.
+	rowContainer := chunk.NewRowContainer(rs.FieldTypes(), vars.MaxChunkSize)
+	rowContainer.GetMemTracker().AttachTo(vars.MemTracker)
+	rowContainer.GetMemTracker().SetLabel(memory.LabelForCursorFetch)
+	rowContainer.GetDiskTracker().AttachTo(vars.DiskTracker)
+	rowContainer.GetDiskTracker().SetLabel(memory.LabelForCursorFetch)
+	if variable.EnableTmpStorageOnOOM.Load() {
+		failpoint.Inject("testCursorFetchSpill", func(val failpoint.Value) {
+			if val, ok := val.(bool); val && ok {
+				actionSpill := rowContainer.ActionSpillForTest()
+				defer actionSpill.WaitForTest()
+			}
+		})
+		action := memory.NewActionWithPriority(rowContainer.ActionSpill(), memory.DefCursorFetchSpillPriority)
+		vars.MemTracker.FallbackOldAndSetNewAction(action)
+	}
+
+	firstNext := true
+	var err error
+	for {
+		chk := rs.NewChunk(nil)
+		if err = rs.Next(ctx, chk); err != nil {
+			break
+		}
+		firstNext = false
+		if chk.NumRows() == 0 {
+			return rowContainer, false, nil
+		}
+		if err = rowContainer.Add(chk); err != nil {
+			break
+		}
+	}
+	rowContainer.GetMemTracker().Detach()
+	rowContainer.GetDiskTracker().Detach()
+	if errCloseRowContainer := rowContainer.Close(); errCloseRowContainer != nil {
+		logutil.Logger(ctx).Error("Fail to close rowContainer in error handler. May cause resource leak",
+			zap.NamedError("original-error", err), zap.NamedError("close-error", errCloseRowContainer))
+	}
+	return nil, firstNext, err
+}
+
 func (cc *clientConn) handleStmtFetch(ctx context.Context, data []byte) (err error) {
 	cc.ctx.GetSessionVars().StartTime = time.Now()
 	cc.ctx.GetSessionVars().ClearAlloc(nil, false)
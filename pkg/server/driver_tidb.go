@@ -59,6 +59,10 @@ func NewTiDBDriver(store kv.Storage) *TiDBDriver {
 type TiDBContext struct {
 	sessiontypes.Session
 	stmts map[int]*TiDBStatement
+	// batchedResults are the results split into batches by tidb_result_batch_size, which are waiting for the
+	// client to fetch the rest rows.
+	batchedResults map[string]*batchedResult
+	lastResultID   uint64
 }
 
 // TiDBStatement implements PreparedStatement.
@@ -252,10 +256,12 @@ func (qd *TiDBDriver) OpenCtx(connID uint64, capability uint32, collation uint8,
 	se.SetClientCapability(capability)
 	se.SetConnectionID(connID)
 	tc := &TiDBContext{
-		Session: se,
-		stmts:   make(map[int]*TiDBStatement),
+		Session:        se,
+		stmts:          make(map[int]*TiDBStatement),
+		batchedResults: make(map[string]*batchedResult),
 	}
 	se.SetSessionStatesHandler(sessionstates.StatePrepareStmt, tc)
+	se.SetValue(core.ResultBatchFetcherKey, tc)
 	se.SetExtensions(extensions)
 	return tc, nil
 }
@@ -309,6 +315,9 @@ func (tc *TiDBContext) Close() error {
 	for _, v := range tc.stmts {
 		terror.Call(v.Close)
 	}
+	for token := range tc.batchedResults {
+		terror.Log(tc.closeBatchedResult(token))
+	}
 
 	tc.Session.Close()
 	return nil
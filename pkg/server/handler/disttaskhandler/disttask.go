@@ -0,0 +1,316 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disttaskhandler
+
+import (
+	"crypto/sha1" // #nosec G505
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/server/handler"
+	"github.com/pingcap/tidb/pkg/session"
+	"github.com/pingcap/tidb/pkg/util/fastrand"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// OpSubmit submits a task.
+	OpSubmit = "submit"
+	// OpShow shows a task.
+	OpShow = "show"
+	// OpCancel cancels a task.
+	OpCancel = "cancel"
+)
+
+// SubmitRequest is the request body to submit a task.
+type SubmitRequest struct {
+	Key         string          `json:"key"`
+	Type        string          `json:"type"`
+	Concurrency int             `json:"concurrency"`
+	TargetScope string          `json:"target_scope"`
+	Meta        json.RawMessage `json:"meta"`
+}
+
+// TaskInfo is the task returned by the handler.
+type TaskInfo struct {
+	ID          int64     `json:"id"`
+	Key         string    `json:"key"`
+	Type        string    `json:"type"`
+	State       string    `json:"state"`
+	Step        string    `json:"step"`
+	Concurrency int       `json:"concurrency"`
+	TargetScope string    `json:"target_scope"`
+	CreateTime  time.Time `json:"create_time"`
+	Error       string    `json:"error,omitempty"`
+}
+
+func newTaskInfo(task *proto.Task) *TaskInfo {
+	info := &TaskInfo{
+		ID:          task.ID,
+		Key:         task.Key,
+		Type:        task.Type.String(),
+		State:       task.State.String(),
+		Step:        proto.Step2Str(task.Type, task.Step),
+		Concurrency: task.Concurrency,
+		TargetScope: task.TargetScope,
+		CreateTime:  task.CreateTime,
+	}
+	if task.Error != nil {
+		info.Error = task.Error.Error()
+	}
+	return info
+}
+
+// submittableTaskTypes are the task types which can be submitted by the
+// TaskHandler, the value validates the meta of the submitted task.
+var submittableTaskTypes = struct {
+	sync.RWMutex
+	m map[proto.TaskType]func(meta []byte) error
+}{
+	m: make(map[proto.TaskType]func(meta []byte) error),
+}
+
+// RegisterSubmittableTaskType allows the tasks of taskType to be submitted
+// through the TaskHandler, validate is called with the meta of the task before
+// it's submitted. Task types which are not registered, such as backfill and
+// IMPORT INTO, can't be submitted by the handler.
+func RegisterSubmittableTaskType(taskType proto.TaskType, validate func(meta []byte) error) {
+	submittableTaskTypes.Lock()
+	defer submittableTaskTypes.Unlock()
+	submittableTaskTypes.m[taskType] = validate
+}
+
+// UnregisterSubmittableTaskType disallows the tasks of taskType to be submitted
+// through the TaskHandler.
+func UnregisterSubmittableTaskType(taskType proto.TaskType) {
+	submittableTaskTypes.Lock()
+	defer submittableTaskTypes.Unlock()
+	delete(submittableTaskTypes.m, taskType)
+}
+
+func getTaskMetaValidator(taskType proto.TaskType) (func(meta []byte) error, bool) {
+	submittableTaskTypes.RLock()
+	defer submittableTaskTypes.RUnlock()
+	validate, ok := submittableTaskTypes.m[taskType]
+	return validate, ok
+}
+
+// TaskHandler is used to submit, show and cancel tasks of the distributed
+// task framework through the status server, so tools can use the framework
+// without SQL. The requests are authenticated by the HTTP basic auth with a
+// TiDB user which has the SUPER privilege, only the task types registered by
+// RegisterSubmittableTaskType can be submitted.
+//
+//	POST /disttask/task/submit with a SubmitRequest body.
+//	GET  /disttask/task/show?key={key} or /disttask/task/show?id={id}
+//	POST /disttask/task/cancel?key={key}
+type TaskHandler struct {
+	store kv.Storage
+}
+
+// NewTaskHandler creates a new TaskHandler.
+func NewTaskHandler(store kv.Storage) *TaskHandler {
+	return &TaskHandler{store: store}
+}
+
+// ServeHTTP handles request of tasks.
+func (h TaskHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if code, err := h.authenticate(req); err != nil {
+		logutil.Logger(req.Context()).Warn("dist task request is rejected", zap.Error(err))
+		if code == http.StatusUnauthorized {
+			w.Header().Set("WWW-Authenticate", `Basic realm="tidb"`)
+		}
+		w.WriteHeader(code)
+		_, err = w.Write([]byte(err.Error()))
+		terror.Log(errors.Trace(err))
+		return
+	}
+	op := mux.Vars(req)[handler.Operation]
+	req = req.WithContext(kv.WithInternalSourceType(req.Context(), kv.InternalDistTask))
+	var (
+		data any
+		err  error
+	)
+	switch op {
+	case OpSubmit:
+		data, err = h.submit(req)
+	case OpShow:
+		data, err = h.show(req)
+	case OpCancel:
+		data, err = h.cancel(req)
+	default:
+		err = errors.Errorf("wrong operation:%s", op)
+	}
+	if err != nil {
+		logutil.Logger(req.Context()).Info("dist task operation failed",
+			zap.String("operation", op), zap.Error(err))
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteData(w, data)
+}
+
+// authenticate checks the user and password of the HTTP basic auth like a
+// MySQL connection from the remote address, and the user must have the SUPER
+// privilege. It returns the HTTP status code to respond if it fails.
+func (h TaskHandler) authenticate(req *http.Request) (int, error) {
+	userName, password, ok := req.BasicAuth()
+	if !ok {
+		return http.StatusUnauthorized, errors.New("the request must be authenticated by the HTTP basic auth")
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	se, err := session.CreateSession(h.store)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	defer se.Close()
+
+	user := &auth.UserIdentity{Username: userName, Hostname: host}
+	identity, err := se.MatchIdentity(userName, host)
+	if err != nil {
+		return http.StatusUnauthorized, err
+	}
+	plugin, err := se.AuthPluginForUser(identity)
+	if err != nil {
+		return http.StatusUnauthorized, err
+	}
+	var salt, authentication []byte
+	switch plugin {
+	// the plugin is empty if the user has no password.
+	case "", mysql.AuthNativePassword:
+		salt = fastrand.Buf(20)
+		if password != "" {
+			authentication = scramblePassword(salt, password)
+		}
+	case mysql.AuthCachingSha2Password, mysql.AuthTiDBSM3Password:
+		authentication = []byte(password)
+	default:
+		return http.StatusUnauthorized, errors.Errorf("authentication plugin %s is not supported by the HTTP API", plugin)
+	}
+	if err = se.Auth(user, authentication, salt, nil); err != nil {
+		return http.StatusUnauthorized, err
+	}
+	checker := privilege.GetPrivilegeManager(se)
+	if checker != nil && !checker.RequestVerification(se.GetSessionVars().ActiveRoles, "", "", "", mysql.SuperPriv) {
+		return http.StatusForbidden, errors.Errorf("user %s doesn't have the SUPER privilege", user)
+	}
+	return http.StatusOK, nil
+}
+
+// scramblePassword computes the auth data of mysql_native_password from the
+// password and salt, like a MySQL client does.
+func scramblePassword(salt []byte, password string) []byte {
+	stage1 := auth.Sha1Hash([]byte(password))
+	stage2 := auth.Sha1Hash(stage1)
+	crypt := sha1.New() // #nosec G401
+	crypt.Write(salt)
+	crypt.Write(stage2)
+	scramble := crypt.Sum(nil)
+	for i := range scramble {
+		scramble[i] ^= stage1[i]
+	}
+	return scramble
+}
+
+func (TaskHandler) submit(req *http.Request) (*TaskInfo, error) {
+	if req.Method != http.MethodPost {
+		return nil, errors.Errorf("This API only support POST method")
+	}
+	var r SubmitRequest
+	if err := json.NewDecoder(req.Body).Decode(&r); err != nil {
+		return nil, errors.Annotate(err, "invalid request body")
+	}
+	if r.Key == "" || r.Type == "" {
+		return nil, errors.Errorf("key and type must be specified")
+	}
+	validate, ok := getTaskMetaValidator(proto.TaskType(r.Type))
+	if !ok {
+		return nil, errors.Errorf("task type %s can't be submitted by the HTTP API", r.Type)
+	}
+	if err := validate(r.Meta); err != nil {
+		return nil, errors.Annotate(err, "invalid task meta")
+	}
+	if r.Concurrency <= 0 {
+		return nil, errors.Errorf("concurrency must be positive, got %d", r.Concurrency)
+	}
+	task, err := handle.SubmitTask(req.Context(), r.Key, proto.TaskType(r.Type), r.Concurrency, r.TargetScope, r.Meta)
+	if err != nil {
+		return nil, err
+	}
+	logutil.Logger(req.Context()).Info("submit dist task by http API",
+		zap.Int64("task-id", task.ID), zap.String("task-key", task.Key), zap.String("type", r.Type))
+	return newTaskInfo(task), nil
+}
+
+func (TaskHandler) show(req *http.Request) (*TaskInfo, error) {
+	if req.Method != http.MethodGet {
+		return nil, errors.Errorf("This API only support GET method")
+	}
+	taskMgr, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	var task *proto.Task
+	if idStr := req.FormValue("id"); idStr != "" {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid task id")
+		}
+		task, err = taskMgr.GetTaskByIDWithHistory(req.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+	} else if key := req.FormValue("key"); key != "" {
+		if task, err = taskMgr.GetTaskByKeyWithHistory(req.Context(), key); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, errors.Errorf("id or key must be specified")
+	}
+	return newTaskInfo(task), nil
+}
+
+func (TaskHandler) cancel(req *http.Request) (string, error) {
+	if req.Method != http.MethodPost {
+		return "", errors.Errorf("This API only support POST method")
+	}
+	key := req.FormValue("key")
+	if key == "" {
+		return "", errors.Errorf("key must be specified")
+	}
+	if err := handle.CancelTask(req.Context(), key); err != nil {
+		return "", err
+	}
+	logutil.Logger(req.Context()).Info("cancel dist task by http API", zap.String("task-key", key))
+	return "success!", nil
+}
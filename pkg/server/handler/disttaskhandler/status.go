@@ -0,0 +1,95 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disttaskhandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/server/handler"
+	"github.com/pingcap/tidb/pkg/session"
+)
+
+// SchedulerStatus is the health of the scheduler manager returned by the
+// status handler.
+type SchedulerStatus struct {
+	// Owner is true if the node is the DDL owner, only the owner runs the
+	// scheduler manager, the other fields are empty if it's false.
+	Owner            bool       `json:"owner"`
+	RunningTasks     int        `json:"running_tasks"`
+	LastScheduleTime *time.Time `json:"last_schedule_time,omitempty"`
+	StorageError     string     `json:"storage_error,omitempty"`
+	StorageErrorTime *time.Time `json:"storage_error_time,omitempty"`
+	Healthy          bool       `json:"healthy"`
+}
+
+// StatusHandler shows the health of the scheduler manager of the distributed
+// task framework, it responds 503 if the scheduler manager runs on this node
+// and it fails to access the storage since the last time tasks are scheduled,
+// so it can be used as a probe.
+//
+//	GET /disttask/status
+type StatusHandler struct {
+	store kv.Storage
+}
+
+// NewStatusHandler creates a new StatusHandler.
+func NewStatusHandler(store kv.Storage) *StatusHandler {
+	return &StatusHandler{store: store}
+}
+
+// ServeHTTP handles request of the scheduler status.
+func (h StatusHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		handler.WriteError(w, errors.Errorf("This API only support GET method"))
+		return
+	}
+	dom, err := session.GetDomain(h.store)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	status := &SchedulerStatus{Healthy: true}
+	if mgr := dom.DistTaskSchedulerManager(); mgr != nil && dom.DDL().OwnerManager().IsOwner() {
+		s := mgr.Status()
+		status.Owner = true
+		status.RunningTasks = s.RunningTasks
+		status.Healthy = s.Healthy()
+		if !s.LastScheduleTime.IsZero() {
+			status.LastScheduleTime = &s.LastScheduleTime
+		}
+		if s.LastStorageErr != nil {
+			status.StorageError = s.LastStorageErr.Error()
+			status.StorageErrorTime = &s.LastStorageErrTime
+		}
+	}
+	if status.Healthy {
+		handler.WriteData(w, status)
+		return
+	}
+	js, err := json.MarshalIndent(status, "", " ")
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	w.Header().Set(handler.HeaderContentType, handler.ContentTypeJSON)
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, err = w.Write(js)
+	terror.Log(errors.Trace(err))
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/executor/mppcoordmanager"
@@ -49,6 +50,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core"
 	server2 "github.com/pingcap/tidb/pkg/server"
 	"github.com/pingcap/tidb/pkg/server/handler"
+	"github.com/pingcap/tidb/pkg/server/handler/disttaskhandler"
 	"github.com/pingcap/tidb/pkg/server/handler/optimizor"
 	"github.com/pingcap/tidb/pkg/server/handler/tikvhandler"
 	"github.com/pingcap/tidb/pkg/server/internal/testserverclient"
@@ -1513,3 +1515,99 @@ func TestIssue52608(t *testing.T) {
 	require.Equal(t, on, true)
 	require.Equal(t, addr[:10], "127.0.0.1:")
 }
+
+func TestDistTaskHandler(t *testing.T) {
+	ts := createBasicHTTPHandlerTestSuite()
+	ts.startServer(t)
+	defer ts.stopServer(t)
+
+	disttaskhandler.RegisterSubmittableTaskType(proto.TaskTypeExample, func(meta []byte) error {
+		m := make(map[string]any)
+		return json.Unmarshal(meta, &m)
+	})
+	defer disttaskhandler.UnregisterSubmittableTaskType(proto.TaskTypeExample)
+
+	db, err := sql.Open("mysql", ts.GetDSN())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	dbt := testkit.NewDBTestKit(t, db)
+	dbt.MustExec("create user 'dt_user'@'%' identified by 'pwd1'")
+	dbt.MustExec("create user 'dt_admin'@'%' identified by 'pwd2'")
+	dbt.MustExec("grant super on *.* to 'dt_admin'@'%'")
+
+	doRequest := func(method, path, user, password, body string) *http.Response {
+		req, err := http.NewRequest(method, ts.StatusURL(path), bytes.NewBufferString(body))
+		require.NoError(t, err)
+		if user != "" {
+			req.SetBasicAuth(user, password)
+		}
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	checkStatus := func(resp *http.Response, code int) {
+		require.Equal(t, code, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	}
+	readTask := func(resp *http.Response) *disttaskhandler.TaskInfo {
+		defer func() {
+			require.NoError(t, resp.Body.Close())
+		}()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		info := &disttaskhandler.TaskInfo{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(info))
+		return info
+	}
+
+	// the request must be authenticated by a user with the SUPER privilege.
+	body := `{"key": "k1", "type": "Example", "concurrency": 1, "meta": {"a": 1}}`
+	resp, err := ts.PostStatus("/disttask/task/submit", "application/json", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	require.Equal(t, `Basic realm="tidb"`, resp.Header.Get("WWW-Authenticate"))
+	checkStatus(resp, http.StatusUnauthorized)
+	checkStatus(doRequest(http.MethodPost, "/disttask/task/submit", "dt_admin", "wrong", body), http.StatusUnauthorized)
+	checkStatus(doRequest(http.MethodPost, "/disttask/task/submit", "dt_user", "pwd1", body), http.StatusForbidden)
+	checkStatus(doRequest(http.MethodPost, "/disttask/task/cancel?key=k1", "dt_user", "pwd1", ""), http.StatusForbidden)
+
+	// submit only support POST method.
+	checkStatus(doRequest(http.MethodGet, "/disttask/task/submit", "root", "", ""), http.StatusBadRequest)
+
+	// unknown task type.
+	checkStatus(doRequest(http.MethodPost, "/disttask/task/submit", "root", "",
+		`{"key": "k1", "type": "unknown", "concurrency": 1, "meta": {"a": 1}}`), http.StatusBadRequest)
+	// task type which is not allowed to be submitted.
+	checkStatus(doRequest(http.MethodPost, "/disttask/task/submit", "root", "",
+		`{"key": "k1", "type": "backfill", "concurrency": 1, "meta": {"a": 1}}`), http.StatusBadRequest)
+	// invalid task meta.
+	checkStatus(doRequest(http.MethodPost, "/disttask/task/submit", "root", "",
+		`{"key": "k1", "type": "Example", "concurrency": 1, "meta": [1]}`), http.StatusBadRequest)
+
+	task := readTask(doRequest(http.MethodPost, "/disttask/task/submit", "dt_admin", "pwd2", body))
+	require.Equal(t, "k1", task.Key)
+	require.Equal(t, "Example", task.Type)
+	require.Equal(t, "pending", task.State)
+
+	// duplicated key.
+	checkStatus(doRequest(http.MethodPost, "/disttask/task/submit", "root", "", body), http.StatusBadRequest)
+
+	require.Equal(t, task.ID, readTask(doRequest(http.MethodGet, "/disttask/task/show?key=k1", "root", "", "")).ID)
+	require.Equal(t, "k1", readTask(doRequest(http.MethodGet, fmt.Sprintf("/disttask/task/show?id=%d", task.ID), "root", "", "")).Key)
+
+	checkStatus(doRequest(http.MethodPost, "/disttask/task/cancel?key=k1", "root", "", ""), http.StatusOK)
+	require.Equal(t, "cancelling", readTask(doRequest(http.MethodGet, "/disttask/task/show?key=k1", "root", "", "")).State)
+
+	checkStatus(doRequest(http.MethodGet, "/disttask/task/unknown", "root", "", ""), http.StatusBadRequest)
+
+	resp, err = ts.FetchStatus("/disttask/status")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	status := &disttaskhandler.SchedulerStatus{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(status))
+	require.NoError(t, resp.Body.Close())
+	require.True(t, status.Healthy)
+}
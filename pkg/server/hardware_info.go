@@ -0,0 +1,97 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/kvproto/pkg/diagnosticspb"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sys/linux"
+	"github.com/shirou/gopsutil/v3/disk"
+	"go.uber.org/zap"
+)
+
+// getExtraHardwareInfo returns the hardware info which is not collected by
+// sysutil, i.e. the NUMA topology and the IO capability of the disks.
+func getExtraHardwareInfo() []*diagnosticspb.ServerInfoItem {
+	counters, err := disk.IOCounters()
+	if err != nil {
+		logutil.BgLogger().Warn("failed to get disk io counters", zap.Error(err))
+	}
+	return extraHardwareInfo(linux.NUMANodeRoot, linux.BlockDeviceRoot, counters)
+}
+
+func extraHardwareInfo(numaRoot, blockRoot string, counters map[string]disk.IOCountersStat) []*diagnosticspb.ServerInfoItem {
+	var items []*diagnosticspb.ServerInfoItem
+	nodes, err := linux.ReadNUMANodes(numaRoot)
+	if err != nil {
+		logutil.BgLogger().Warn("failed to read numa nodes", zap.Error(err))
+	}
+	for _, node := range nodes {
+		items = append(items, &diagnosticspb.ServerInfoItem{
+			Tp:   "numa",
+			Name: fmt.Sprintf("node%d", node.ID),
+			Pairs: []*diagnosticspb.ServerInfoPair{
+				{Key: "cpu-list", Value: node.CPUList},
+				{Key: "cpu-logical-cores", Value: strconv.Itoa(node.CPUCount)},
+				{Key: "memory-capacity", Value: strconv.FormatUint(node.MemTotal, 10)},
+			},
+		})
+	}
+
+	for name, c := range counters {
+		// skip the virtual devices, the partitions are skipped by ReadBlockQueue
+		// since they are not under blockRoot.
+		if strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram") {
+			continue
+		}
+		q, err := linux.ReadBlockQueue(blockRoot, name)
+		if err != nil {
+			continue
+		}
+		pairs := []*diagnosticspb.ServerInfoPair{
+			{Key: "rotational", Value: strconv.FormatBool(q.Rotational)},
+			{Key: "queue-depth", Value: strconv.FormatUint(q.QueueDepth, 10)},
+			{Key: "logical-block-size", Value: strconv.FormatUint(q.LogicalBlockSize, 10)},
+			{Key: "max-io-size", Value: strconv.FormatUint(q.MaxIOSize, 10)},
+		}
+		// The IO capability is measured by the counters since boot. The rates are
+		// averaged over the time the disk is busy, so they are the lower bound of
+		// what the disk can sustain rather than the current load.
+		if c.IoTime > 0 {
+			busySec := float64(c.IoTime) / 1000
+			pairs = append(pairs,
+				&diagnosticspb.ServerInfoPair{Key: "busy-iops", Value: fmt.Sprintf("%.2f", float64(c.ReadCount+c.WriteCount)/busySec)},
+				&diagnosticspb.ServerInfoPair{Key: "busy-read-bytes-per-sec", Value: fmt.Sprintf("%.2f", float64(c.ReadBytes)/busySec)},
+				&diagnosticspb.ServerInfoPair{Key: "busy-write-bytes-per-sec", Value: fmt.Sprintf("%.2f", float64(c.WriteBytes)/busySec)},
+			)
+		}
+		if c.ReadCount > 0 {
+			pairs = append(pairs, &diagnosticspb.ServerInfoPair{Key: "avg-read-latency-us", Value: fmt.Sprintf("%.2f", float64(c.ReadTime)*1000/float64(c.ReadCount))})
+		}
+		if c.WriteCount > 0 {
+			pairs = append(pairs, &diagnosticspb.ServerInfoPair{Key: "avg-write-latency-us", Value: fmt.Sprintf("%.2f", float64(c.WriteTime)*1000/float64(c.WriteCount))})
+		}
+		items = append(items, &diagnosticspb.ServerInfoItem{
+			Tp:    "disk-io",
+			Name:  name,
+			Pairs: pairs,
+		})
+	}
+	return items
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtraHardwareInfo(t *testing.T) {
+	numaRoot := filepath.Join(t.TempDir(), "node")
+	blockRoot := filepath.Join(t.TempDir(), "block")
+	writeFile := func(path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	writeFile(filepath.Join(numaRoot, "node0", "cpulist"), "0-7\n")
+	writeFile(filepath.Join(numaRoot, "node0", "meminfo"), "Node 0 MemTotal:       1024 kB\n")
+	for name, val := range map[string]string{
+		"rotational":         "1",
+		"nr_requests":        "64",
+		"logical_block_size": "512",
+		"max_sectors_kb":     "1280",
+	} {
+		writeFile(filepath.Join(blockRoot, "sda", "queue", name), val+"\n")
+	}
+
+	items := extraHardwareInfo(numaRoot, blockRoot, map[string]disk.IOCountersStat{
+		"sda": {
+			Name: "sda", ReadCount: 3000, WriteCount: 1000, ReadBytes: 4096000, WriteBytes: 2048000,
+			ReadTime: 1500, WriteTime: 4000, IoTime: 2000,
+		},
+		// partitions and virtual devices are skipped.
+		"sda1":  {Name: "sda1", ReadCount: 1, IoTime: 1},
+		"loop0": {Name: "loop0", ReadCount: 1, IoTime: 1},
+	})
+	got := make(map[string]map[string]string)
+	for _, item := range items {
+		pairs := make(map[string]string)
+		for _, p := range item.Pairs {
+			pairs[p.Key] = p.Value
+		}
+		got[item.Tp+"/"+item.Name] = pairs
+	}
+	require.Equal(t, map[string]map[string]string{
+		"numa/node0": {
+			"cpu-list":          "0-7",
+			"cpu-logical-cores": "8",
+			"memory-capacity":   "1048576",
+		},
+		"disk-io/sda": {
+			"rotational":               "true",
+			"queue-depth":              "64",
+			"logical-block-size":       "512",
+			"max-io-size":              "1310720",
+			"busy-iops":                "2000.00",
+			"busy-read-bytes-per-sec":  "2048000.00",
+			"busy-write-bytes-per-sec": "1024000.00",
+			"avg-read-latency-us":      "500.00",
+			"avg-write-latency-us":     "4000.00",
+		},
+	}, got)
+}
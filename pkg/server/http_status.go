@@ -45,6 +45,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/server/handler"
+	"github.com/pingcap/tidb/pkg/server/handler/disttaskhandler"
 	"github.com/pingcap/tidb/pkg/server/handler/optimizor"
 	"github.com/pingcap/tidb/pkg/server/handler/tikvhandler"
 	"github.com/pingcap/tidb/pkg/server/handler/ttlhandler"
@@ -255,6 +256,11 @@ func (s *Server) startHTTPServer() {
 	// HTTP path for upgrade operations.
 	router.Handle("/upgrade/{op}", handler.NewClusterUpgradeHandler(tikvHandlerTool.Store.(kv.Storage))).Name("upgrade operations")
 
+	// HTTP path for submitting, showing and cancelling tasks of the distributed task framework.
+	router.Handle("/disttask/task/{op}", disttaskhandler.NewTaskHandler(tikvHandlerTool.Store.(kv.Storage))).Name("DistTask")
+	// HTTP path for the health of the scheduler manager of the distributed task framework.
+	router.Handle("/disttask/status", disttaskhandler.NewStatusHandler(tikvHandlerTool.Store.(kv.Storage))).Name("DistTaskStatus")
+
 	if s.cfg.Store == "tikv" {
 		// HTTP path for tikv.
 		router.Handle("/tables/{db}/{table}/regions", tikvhandler.NewTableHandler(tikvHandlerTool, tikvhandler.OpTableRegions))
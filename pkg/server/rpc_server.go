@@ -15,9 +15,11 @@
 package server
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
 	"time"
 
@@ -92,6 +94,26 @@ type rpcServer struct {
 	sm  util.SessionManager
 }
 
+// ServerInfo implements the DiagnosticsServer interface, it appends the NUMA
+// topology and the IO capability of the disks to the hardware info.
+func (s *rpcServer) ServerInfo(ctx context.Context, req *diagnosticspb.ServerInfoRequest) (*diagnosticspb.ServerInfoResponse, error) {
+	resp, err := s.DiagnosticsServer.ServerInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Tp != diagnosticspb.ServerInfoType_HardwareInfo && req.Tp != diagnosticspb.ServerInfoType_All {
+		return resp, nil
+	}
+	resp.Items = append(resp.Items, getExtraHardwareInfo()...)
+	slices.SortStableFunc(resp.Items, func(a, b *diagnosticspb.ServerInfoItem) int {
+		if a.Tp != b.Tp {
+			return cmp.Compare(a.Tp, b.Tp)
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return resp, nil
+}
+
 // Coprocessor implements the TiKVServer interface.
 func (s *rpcServer) Coprocessor(ctx context.Context, in *coprocessor.Request) (resp *coprocessor.Response, err error) {
 	resp = &coprocessor.Response{}
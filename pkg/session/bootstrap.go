@@ -455,6 +455,60 @@ const (
 		PRIMARY KEY (id),
 		KEY (update_time)
 	);`
+
+	// CreateLoadDataJobs stores the detached LOAD DATA jobs.
+	CreateLoadDataJobs = `CREATE TABLE IF NOT EXISTS mysql.tidb_load_data_jobs (
+		id BIGINT(64) NOT NULL AUTO_INCREMENT,
+		create_time TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		update_time TIMESTAMP(6) NULL DEFAULT NULL,
+		end_time TIMESTAMP(6) NULL DEFAULT NULL,
+		table_schema VARCHAR(64) NOT NULL,
+		table_name VARCHAR(64) NOT NULL,
+		data_source TEXT NOT NULL,
+		created_by VARCHAR(300) NOT NULL,
+		state ENUM('running', 'finished', 'failed', 'canceled') NOT NULL,
+		loaded_rows BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		result_message TEXT DEFAULT NULL,
+		instance VARCHAR(512) NOT NULL comment 'address of the TiDB instance running the job',
+		PRIMARY KEY (id),
+		KEY (created_by),
+		KEY (state)
+	);`
+
+	// CreateCostModelFactors stores the factors of the cost model calibrated by ADMIN CALIBRATE COST MODEL.
+	CreateCostModelFactors = `CREATE TABLE IF NOT EXISTS mysql.tidb_cost_model_factors (
+		factor_name VARCHAR(64) NOT NULL,
+		factor_value DOUBLE NOT NULL,
+		update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (factor_name)
+	);`
+
+	// CreateCostModelCalibrationData stores the rows read by the queries of ADMIN CALIBRATE COST MODEL.
+	CreateCostModelCalibrationData = `CREATE TABLE IF NOT EXISTS mysql.tidb_cost_model_calibration_data (
+		calibration_id BIGINT NOT NULL,
+		id BIGINT NOT NULL,
+		pad VARCHAR(256) NOT NULL,
+		PRIMARY KEY (calibration_id, id) CLUSTERED
+	);`
+
+	// CreateBindEvolveTasks stores the plans to be verified by the baseline evolution and their results.
+	CreateBindEvolveTasks = `CREATE TABLE IF NOT EXISTS mysql.tidb_binding_evolve_tasks (
+		sql_digest VARCHAR(64) NOT NULL,
+		plan_digest VARCHAR(64) NOT NULL,
+		original_sql TEXT NOT NULL,
+		bind_sql TEXT NOT NULL,
+		default_db TEXT NOT NULL,
+		charset TEXT NOT NULL,
+		collation TEXT NOT NULL,
+		status VARCHAR(16) NOT NULL,
+		current_plan_time DOUBLE,
+		candidate_plan_time DOUBLE,
+		message TEXT,
+		create_time TIMESTAMP(3) NOT NULL,
+		update_time TIMESTAMP(3) NOT NULL,
+		PRIMARY KEY (sql_digest, plan_digest),
+		KEY (status, create_time)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
 	// CreateAdvisoryLocks stores the advisory locks (get_lock, release_lock).
 	CreateAdvisoryLocks = `CREATE TABLE IF NOT EXISTS mysql.advisory_locks (
 		lock_name VARCHAR(64) NOT NULL PRIMARY KEY
@@ -584,6 +638,7 @@ const (
 		concurrency INT(11),
 		step INT(11),
 		target_scope VARCHAR(256) DEFAULT "",
+		dependencies VARCHAR(1024) DEFAULT "",
 		error BLOB,
 		key(state),
       	UNIQUE KEY task_key(task_key)
@@ -605,11 +660,36 @@ const (
 		concurrency INT(11),
 		step INT(11),
 		target_scope VARCHAR(256) DEFAULT "",
+		dependencies VARCHAR(1024) DEFAULT "",
 		error BLOB,
 		key(state),
       	UNIQUE KEY task_key(task_key)
 	);`
 
+	// CreateGlobalTaskEvent is a table about the event history of global task,
+	// such as state changes, subtask assignments, errors and retries.
+	CreateGlobalTaskEvent = `CREATE TABLE IF NOT EXISTS mysql.tidb_global_task_event (
+		id BIGINT(20) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		task_id BIGINT(20) NOT NULL,
+		type VARCHAR(64) NOT NULL,
+		exec_id VARCHAR(261) DEFAULT "",
+		message TEXT,
+		create_time TIMESTAMP(6) DEFAULT CURRENT_TIMESTAMP(6),
+		key(task_id),
+		key(create_time)
+	);`
+
+	// CreateGlobalTaskMetaChunk is a table about the chunks of large task and
+	// subtask meta which can't be stored in one row.
+	CreateGlobalTaskMetaChunk = `CREATE TABLE IF NOT EXISTS mysql.tidb_global_task_meta_chunk (
+		chunk_key VARCHAR(64) NOT NULL,
+		seq INT NOT NULL,
+		task_id BIGINT(20) NOT NULL,
+		data LONGBLOB,
+		PRIMARY KEY(chunk_key, seq) NONCLUSTERED,
+		key(task_id)
+	);`
+
 	// CreateDistFrameworkMeta create a system table that distributed task framework use to store meta information
 	CreateDistFrameworkMeta = `CREATE TABLE IF NOT EXISTS mysql.dist_framework_meta (
         host VARCHAR(261) NOT NULL PRIMARY KEY,
@@ -669,6 +749,27 @@ const (
 		KEY (resource_group)
 	);`
 
+	// CreateBatchDMLJob stores the progress and the errors of the non-transactional DML statements.
+	CreateBatchDMLJob = `CREATE TABLE IF NOT EXISTS mysql.tidb_batch_dml_job (
+		id BIGINT(64) UNSIGNED NOT NULL AUTO_INCREMENT,
+		update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		table_schema CHAR(64) NOT NULL DEFAULT '',
+		table_name CHAR(64) NOT NULL DEFAULT '',
+		shard_column CHAR(64) NOT NULL DEFAULT '',
+		job_info TEXT NOT NULL,
+		total_jobs BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		finished_jobs BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		failed_jobs BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		start_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		end_time TIMESTAMP NULL DEFAULT NULL,
+		state ENUM('running', 'finished', 'failed', 'canceled') NOT NULL,
+		last_error TEXT,
+		instance VARCHAR(512) NOT NULL comment 'address of the TiDB instance executing the statement',
+		process_id BIGINT(64) UNSIGNED comment 'ID of the process executing the statement',
+		PRIMARY KEY (id),
+		KEY (update_time)
+	);`
+
 	// CreateImportJobs is a table that IMPORT INTO uses.
 	CreateImportJobs = `CREATE TABLE IF NOT EXISTS mysql.tidb_import_jobs (
 		id bigint(64) NOT NULL AUTO_INCREMENT,
@@ -1101,11 +1202,43 @@ const (
 	// version 199
 	//   sets `tidb_resource_control_strict_mode` to off when a cluster upgrades from some version lower than v8.2.
 	version199 = 199
+
+	// version 200
+	//   add column `retry_count` to `mysql.tidb_background_subtask` and `mysql.tidb_background_subtask_history`.
+	version200 = 200
+
+	// version 201
+	//   add column `dependencies` to `mysql.tidb_global_task` and `mysql.tidb_global_task_history`.
+	version201 = 201
+
+	// version 202
+	//   add `mysql.tidb_global_task_event`.
+	version202 = 202
+
+	// version 203
+	//   add `mysql.tidb_global_task_meta_chunk`.
+	version203 = 203
+
+	// version 204
+	//   add `mysql.tidb_batch_dml_job`.
+	version204 = 204
+
+	// version 205
+	//   add `mysql.tidb_load_data_jobs`.
+	version205 = 205
+
+	// version 206
+	//   add `mysql.tidb_cost_model_factors` and `mysql.tidb_cost_model_calibration_data`.
+	version206 = 206
+
+	// version 207
+	//   add `mysql.tidb_binding_evolve_tasks`.
+	version207 = 207
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version199
+var currentBootstrapVersion int64 = version207
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1270,6 +1403,14 @@ var (
 		upgradeToVer197,
 		upgradeToVer198,
 		upgradeToVer199,
+		upgradeToVer200,
+		upgradeToVer201,
+		upgradeToVer202,
+		upgradeToVer203,
+		upgradeToVer204,
+		upgradeToVer205,
+		upgradeToVer206,
+		upgradeToVer207,
 	}
 )
 
@@ -3046,6 +3187,67 @@ func upgradeToVer199(s sessiontypes.Session, ver int64) {
 	initGlobalVariableIfNotExists(s, variable.TiDBResourceControlStrictMode, variable.Off)
 }
 
+func upgradeToVer200(s sessiontypes.Session, ver int64) {
+	if ver >= version200 {
+		return
+	}
+
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_background_subtask ADD COLUMN `retry_count` INT DEFAULT 0 AFTER `summary`", infoschema.ErrColumnExists)
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_background_subtask_history ADD COLUMN `retry_count` INT DEFAULT 0 AFTER `summary`", infoschema.ErrColumnExists)
+}
+
+func upgradeToVer201(s sessiontypes.Session, ver int64) {
+	if ver >= version201 {
+		return
+	}
+
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_global_task ADD COLUMN `dependencies` VARCHAR(1024) DEFAULT '' AFTER `target_scope`", infoschema.ErrColumnExists)
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_global_task_history ADD COLUMN `dependencies` VARCHAR(1024) DEFAULT '' AFTER `target_scope`", infoschema.ErrColumnExists)
+}
+
+func upgradeToVer202(s sessiontypes.Session, ver int64) {
+	if ver >= version202 {
+		return
+	}
+	mustExecute(s, CreateGlobalTaskEvent)
+}
+
+func upgradeToVer203(s sessiontypes.Session, ver int64) {
+	if ver >= version203 {
+		return
+	}
+	mustExecute(s, CreateGlobalTaskMetaChunk)
+}
+
+func upgradeToVer204(s sessiontypes.Session, ver int64) {
+	if ver >= version204 {
+		return
+	}
+	mustExecute(s, CreateBatchDMLJob)
+}
+
+func upgradeToVer205(s sessiontypes.Session, ver int64) {
+	if ver >= version205 {
+		return
+	}
+	mustExecute(s, CreateLoadDataJobs)
+}
+
+func upgradeToVer206(s sessiontypes.Session, ver int64) {
+	if ver >= version206 {
+		return
+	}
+	mustExecute(s, CreateCostModelFactors)
+	mustExecute(s, CreateCostModelCalibrationData)
+}
+
+func upgradeToVer207(s sessiontypes.Session, ver int64) {
+	if ver >= version207 {
+		return
+	}
+	mustExecute(s, CreateBindEvolveTasks)
+}
+
 // initGlobalVariableIfNotExists initialize a global variable with specific val if it does not exist.
 func initGlobalVariableIfNotExists(s sessiontypes.Session, name string, val any) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBootstrap)
@@ -3176,6 +3378,10 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateGlobalTask)
 	// Create tidb_global_task_history table
 	mustExecute(s, CreateGlobalTaskHistory)
+	// Create tidb_global_task_event table
+	mustExecute(s, CreateGlobalTaskEvent)
+	// Create tidb_global_task_meta_chunk table
+	mustExecute(s, CreateGlobalTaskMetaChunk)
 	// Create tidb_import_jobs
 	mustExecute(s, CreateImportJobs)
 	// create runaway_watch
@@ -3190,6 +3396,15 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateDistFrameworkMeta)
 	// create request_unit_by_group
 	mustExecute(s, CreateRequestUnitByGroupTable)
+	// create tidb_batch_dml_job
+	mustExecute(s, CreateBatchDMLJob)
+	// create tidb_load_data_jobs
+	mustExecute(s, CreateLoadDataJobs)
+	// create tidb_cost_model_factors and tidb_cost_model_calibration_data
+	mustExecute(s, CreateCostModelFactors)
+	mustExecute(s, CreateCostModelCalibrationData)
+	// create tidb_binding_evolve_tasks
+	mustExecute(s, CreateBindEvolveTasks)
 	// create `sys` schema
 	mustExecute(s, CreateSysSchema)
 	// create `sys.schema_unused_indexes` view
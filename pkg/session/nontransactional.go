@@ -18,12 +18,16 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net"
+	"strconv"
 	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/format"
@@ -113,7 +117,12 @@ func HandleNonTransactionalDML(ctx context.Context, stmt *ast.NonTransactionalDM
 		return nil, err
 	}
 
-	splitStmts, err := runJobs(ctx, jobs, stmt, tableName, se, stmt.DMLStmt.WhereExpr())
+	var progress *batchDMLProgress
+	if stmt.DryRun == ast.NoDryRun {
+		progress = newBatchDMLProgress(se, stmt, tableName, len(jobs))
+	}
+	splitStmts, err := runJobs(ctx, jobs, stmt, tableName, se, stmt.DMLStmt.WhereExpr(), progress)
+	progress.finish(se, err)
 	if err != nil {
 		return nil, err
 	}
@@ -255,7 +264,7 @@ func checkReadClauses(limit *ast.Limit, order *ast.OrderByClause) error {
 
 // single-threaded worker. work on the key range [start, end]
 func runJobs(ctx context.Context, jobs []job, stmt *ast.NonTransactionalDMLStmt,
-	tableName *ast.TableName, se sessiontypes.Session, originalCondition ast.ExprNode) ([]string, error) {
+	tableName *ast.TableName, se sessiontypes.Session, originalCondition ast.ExprNode, progress *batchDMLProgress) ([]string, error) {
 	// prepare for the construction of statement
 	var shardColumnRefer *ast.ResultField
 	var shardColumnType types.FieldType
@@ -317,6 +326,7 @@ func runJobs(ctx context.Context, jobs []job, stmt *ast.NonTransactionalDMLStmt,
 			splitStmts = append(splitStmts, splitStmt)
 		} else {
 			doOneJob(ctx, &jobs[i], len(jobs), stmtBuildInfo, se, false)
+			progress.update(se, &jobs[i])
 		}
 
 		// if the first job failed, there is a large chance that all jobs will fail. So return early.
@@ -845,3 +855,124 @@ func buildExecuteResults(ctx context.Context, jobs []job, maxChunkSize int, reda
 	return nil, fmt.Errorf("%d/%d jobs failed in the non-transactional DML: %s, ...(more in logs)",
 		len(failedJobs), len(jobs), errStr[:min(500, len(errStr)-1)])
 }
+
+// batchDMLProgress records the progress and the errors of a non-transactional DML in mysql.tidb_batch_dml_job,
+// so users can check them while the statement is running or after it fails. Failing to record them doesn't fail
+// the statement.
+type batchDMLProgress struct {
+	id       uint64
+	finished int
+	failed   int
+}
+
+// textMaxLength is the max length of the TEXT columns in mysql.tidb_batch_dml_job.
+const textMaxLength = 65535
+
+// newBatchDMLProgress inserts a running job into mysql.tidb_batch_dml_job, it returns nil if it fails.
+func newBatchDMLProgress(se sessiontypes.Session, stmt *ast.NonTransactionalDMLStmt, tableName *ast.TableName, totalJobs int) *batchDMLProgress {
+	var instance string
+	serverInfo, err := infosync.GetServerInfo()
+	if err != nil {
+		logutil.BgLogger().Warn("failed to get server info", zap.Error(err))
+		instance = "unknown"
+	} else {
+		instance = net.JoinHostPort(serverInfo.IP, strconv.Itoa(int(serverInfo.Port)))
+	}
+	jobInfo := parser.Normalize(stmt.Text(), se.GetSessionVars().EnableRedactLog)
+	if len(jobInfo) > textMaxLength {
+		jobInfo = jobInfo[:textMaxLength]
+	}
+
+	p := &batchDMLProgress{}
+	err = callWithSysSession(se, func(exec sqlexec.RestrictedSQLExecutor) error {
+		const insertJob = `INSERT INTO mysql.tidb_batch_dml_job (table_schema, table_name, shard_column, job_info, total_jobs, state, instance, process_id)
+			VALUES (%?, %?, %?, %?, %?, 'running', %?, %?)`
+		if _, err := execBatchDMLJobSQL(exec, insertJob, tableName.Schema.O, tableName.Name.O, stmt.ShardColumn.Name.O,
+			jobInfo, totalJobs, instance, se.GetSessionVars().ConnectionID); err != nil {
+			return err
+		}
+		rows, err := execBatchDMLJobSQL(exec, "SELECT LAST_INSERT_ID()")
+		if err != nil {
+			return err
+		}
+		p.id = rows[0].GetUint64(0)
+		return nil
+	})
+	if err != nil {
+		logutil.BgLogger().Warn("failed to insert non-transactional DML job", zap.Error(err))
+		return nil
+	}
+	return p
+}
+
+// update records the result of a finished job.
+func (p *batchDMLProgress) update(se sessiontypes.Session, job *job) {
+	if p == nil {
+		return
+	}
+	p.finished++
+	var err error
+	if job.err == nil {
+		err = callWithSysSession(se, func(exec sqlexec.RestrictedSQLExecutor) error {
+			_, err := execBatchDMLJobSQL(exec, "UPDATE mysql.tidb_batch_dml_job SET finished_jobs = %? WHERE id = %?", p.finished, p.id)
+			return err
+		})
+	} else {
+		p.failed++
+		lastError := fmt.Sprintf("job id: %d, %s", job.jobID, job.err.Error())
+		if len(lastError) > textMaxLength {
+			lastError = lastError[:textMaxLength]
+		}
+		err = callWithSysSession(se, func(exec sqlexec.RestrictedSQLExecutor) error {
+			_, err := execBatchDMLJobSQL(exec, "UPDATE mysql.tidb_batch_dml_job SET finished_jobs = %?, failed_jobs = %?, last_error = %? WHERE id = %?",
+				p.finished, p.failed, lastError, p.id)
+			return err
+		})
+	}
+	if err != nil {
+		logutil.BgLogger().Warn("failed to update non-transactional DML job", zap.Uint64("id", p.id), zap.Error(err))
+	}
+}
+
+// finish records the final state of the statement, runErr is the error returned by runJobs.
+func (p *batchDMLProgress) finish(se sessiontypes.Session, runErr error) {
+	if p == nil {
+		return
+	}
+	state := "finished"
+	switch {
+	case errors.Cause(runErr) == context.Canceled:
+		state = "canceled"
+	case runErr != nil || p.failed > 0:
+		state = "failed"
+	}
+	err := callWithSysSession(se, func(exec sqlexec.RestrictedSQLExecutor) error {
+		_, err := execBatchDMLJobSQL(exec, "UPDATE mysql.tidb_batch_dml_job SET state = %?, end_time = NOW() WHERE id = %?", state, p.id)
+		return err
+	})
+	if err != nil {
+		logutil.BgLogger().Warn("failed to finish non-transactional DML job", zap.Uint64("id", p.id), zap.Error(err))
+	}
+}
+
+// callWithSysSession calls f with a session from the system session pool.
+func callWithSysSession(se sessiontypes.Session, f func(exec sqlexec.RestrictedSQLExecutor) error) error {
+	pool := domain.GetDomain(se).SysSessionPool()
+	res, err := pool.Get()
+	if err != nil {
+		return err
+	}
+	if err = f(res.(sqlexec.RestrictedSQLExecutor)); err != nil {
+		// don't recycle the session, which may be in an unexpected state.
+		res.Close()
+		return err
+	}
+	pool.Put(res)
+	return nil
+}
+
+func execBatchDMLJobSQL(exec sqlexec.RestrictedSQLExecutor, sql string, args ...any) ([]chunk.Row, error) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, []sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession}, sql, args...)
+	return rows, err
+}
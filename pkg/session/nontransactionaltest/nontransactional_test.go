@@ -252,6 +252,39 @@ func TestNonTransactionalDMLErrorMessage(t *testing.T) {
 	)
 }
 
+func TestNonTransactionalDMLProgress(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int primary key, b int)")
+	for i := 0; i < 10; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, %d)", i, i))
+	}
+	const query = "select table_schema, table_name, shard_column, job_info, total_jobs, finished_jobs, failed_jobs, state, " +
+		"last_error, end_time is not null from mysql.tidb_batch_dml_job order by id desc limit 1"
+
+	tk.MustQuery("batch on a limit 3 update t set b = b + 1").Check(testkit.Rows("4 all succeeded"))
+	tk.MustQuery(query).Check(testkit.Rows("test t a batch on a limit 3 update t set b = b + 1 4 4 0 finished <nil> 1"))
+
+	// dry run statements are not recorded.
+	tk.MustQuery("batch on a limit 3 dry run delete from t")
+	tk.MustQuery("select count(*) from mysql.tidb_batch_dml_job").Check(testkit.Rows("1"))
+
+	tk.MustExec("set @@tidb_nontransactional_ignore_error=1")
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/session/batchDMLError", `2*return(false)->return(true)`))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/session/batchDMLError"))
+	}()
+	require.ErrorContains(t, tk.ExecToErr("batch on a limit 3 update t set b = b + 1"), "2/4 jobs failed")
+	tk.MustQuery(query).Check(testkit.Rows("test t a batch on a limit 3 update t set b = b + 1 4 4 2 failed job id: 4, injected batch(non-transactional) DML error 1"))
+
+	tk.MustExec("set @@tidb_nontransactional_ignore_error=0")
+	tk.MustExec("set @@tidb_redact_log=on")
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/session/batchDMLError", `1*return(false)->return(true)`))
+	require.Error(t, tk.ExecToErr("batch on a limit 3 delete from t where b > 5"))
+	tk.MustQuery(query).Check(testkit.Rows("test t a batch on `a` limit ? delete from `t` where `b` > ? 2 2 1 failed job id: 2, injected batch(non-transactional) DML error 1"))
+}
+
 func TestNonTransactionalWithCheckConstraint(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
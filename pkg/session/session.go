@@ -44,12 +44,15 @@ import (
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/ddl/placement"
 	distsqlctx "github.com/pingcap/tidb/pkg/distsql/context"
+	"github.com/pingcap/tidb/pkg/disttask/analyze"
+	"github.com/pingcap/tidb/pkg/disttask/checksum"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
 	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
 	"github.com/pingcap/tidb/pkg/disttask/importinto"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/executor"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -1785,7 +1788,8 @@ func (s *session) ExecRestrictedStmt(ctx context.Context, stmtNode ast.StmtNode,
 	metrics.SessionRestrictedSQLCounter.Inc()
 	ctx = context.WithValue(ctx, execdetails.StmtExecDetailKey, &execdetails.StmtExecDetails{})
 	ctx = context.WithValue(ctx, tikvutil.ExecDetailsKey, &tikvutil.ExecDetails{})
-	ctx = context.WithValue(ctx, tikvutil.RUDetailsCtxKey, tikvutil.NewRUDetails())
+	ctx, ruDetails := withInternalRUDetails(ctx, execOption.UseCurSession)
+	defer recordInternalRU(ruDetails)
 	rs, err := se.ExecuteStmt(ctx, stmtNode)
 	if err != nil {
 		se.sessionVars.StmtCtx.AppendError(err)
@@ -1811,6 +1815,32 @@ func (s *session) ExecRestrictedStmt(ctx context.Context, stmtNode ast.StmtNode,
 	return rows, rs.Fields(), err
 }
 
+// withInternalRUDetails attaches a new RUDetails to ctx to account the RU of an internal restricted SQL. A SQL run
+// with ExecOptionUseCurSession for a user statement is accounted to the user statement instead, whose RUDetails is
+// already in ctx, and nil is returned.
+func withInternalRUDetails(ctx context.Context, useCurSession bool) (context.Context, *tikvutil.RUDetails) {
+	if useCurSession && ctx.Value(tikvutil.RUDetailsCtxKey) != nil {
+		return ctx, nil
+	}
+	ruDetails := tikvutil.NewRUDetails()
+	return context.WithValue(ctx, tikvutil.RUDetailsCtxKey, ruDetails), ruDetails
+}
+
+// recordInternalRU reports the RU consumed by an internal restricted SQL as the pseudo internal resource group,
+// so the overhead of internal SQLs can be taken into account in capacity planning. It does nothing if ruDetails
+// is nil, see withInternalRUDetails.
+func recordInternalRU(ruDetails *tikvutil.RUDetails) {
+	if ruDetails == nil {
+		return
+	}
+	rru, wru := ruDetails.RRU(), ruDetails.WRU()
+	// the mock store doesn't report RU consumption, simulate it in tests.
+	failpoint.Inject("mockInternalRRU", func(val failpoint.Value) {
+		rru += float64(val.(int))
+	})
+	resourcegroup.RecordInternalRU(rru, wru)
+}
+
 // ExecRestrictedStmt4Test wrapper `(s *session) ExecRestrictedStmt` for test.
 func ExecRestrictedStmt4Test(ctx context.Context, s types.Session,
 	stmtNode ast.StmtNode, opts ...sqlexec.OptionFuncAlias) (
@@ -1844,6 +1874,10 @@ func (s *session) useCurrentSession(execOption sqlexec.ExecOption) (*session, fu
 	if len(execOption.PartitionPruneMode) > 0 {
 		s.sessionVars.PartitionPruneMode.Store(execOption.PartitionPruneMode)
 	}
+	prevRequestSourceType := s.sessionVars.ExplicitRequestSourceType
+	if len(execOption.ExplicitRequestSourceType) > 0 {
+		s.sessionVars.ExplicitRequestSourceType = execOption.ExplicitRequestSourceType
+	}
 	prevSQL := s.sessionVars.StmtCtx.OriginalSQL
 	prevStmtType := s.sessionVars.StmtCtx.StmtType
 	prevTables := s.sessionVars.StmtCtx.Tables
@@ -1856,6 +1890,7 @@ func (s *session) useCurrentSession(execOption sqlexec.ExecOption) (*session, fu
 		s.sessionVars.SnapshotInfoschema = orgSnapshotInfoSchema
 		s.sessionVars.SnapshotTS = orgSnapshotTS
 		s.sessionVars.PartitionPruneMode.Store(prePruneMode)
+		s.sessionVars.ExplicitRequestSourceType = prevRequestSourceType
 		s.sessionVars.StmtCtx.OriginalSQL = prevSQL
 		s.sessionVars.StmtCtx.StmtType = prevStmtType
 		s.sessionVars.StmtCtx.Tables = prevTables
@@ -1905,6 +1940,11 @@ func (s *session) getInternalSession(execOption sqlexec.ExecOption) (*session, f
 		se.sessionVars.PartitionPruneMode.Store(execOption.PartitionPruneMode)
 	}
 
+	prevRequestSourceType := se.sessionVars.ExplicitRequestSourceType
+	if len(execOption.ExplicitRequestSourceType) > 0 {
+		se.sessionVars.ExplicitRequestSourceType = execOption.ExplicitRequestSourceType
+	}
+
 	return se, func() {
 		se.sessionVars.AnalyzeVersion = prevStatsVer
 		se.sessionVars.EnableAnalyzeSnapshot = prevAnalyzeSnapshot
@@ -1920,6 +1960,7 @@ func (s *session) getInternalSession(execOption sqlexec.ExecOption) (*session, f
 			}
 		}
 		se.sessionVars.PartitionPruneMode.Store(prePruneMode)
+		se.sessionVars.ExplicitRequestSourceType = prevRequestSourceType
 		se.sessionVars.OptimizerUseInvisibleIndexes = false
 		se.sessionVars.SkipMissingPartitionStats = preSkipStats
 		se.sessionVars.InspectionTableCache = nil
@@ -1954,6 +1995,7 @@ func (s *session) withRestrictedSQLExecutor(ctx context.Context, opts []sqlexec.
 }
 
 func (s *session) ExecRestrictedSQL(ctx context.Context, opts []sqlexec.OptionFuncAlias, sql string, params ...any) ([]chunk.Row, []*ast.ResultField, error) {
+	useCurSession := sqlexec.GetExecOption(opts).UseCurSession
 	return s.withRestrictedSQLExecutor(ctx, opts, func(ctx context.Context, se *session) ([]chunk.Row, []*ast.ResultField, error) {
 		stmt, err := se.ParseWithParams(ctx, sql, params...)
 		if err != nil {
@@ -1964,6 +2006,8 @@ func (s *session) ExecRestrictedSQL(ctx context.Context, opts []sqlexec.OptionFu
 		metrics.SessionRestrictedSQLCounter.Inc()
 		ctx = context.WithValue(ctx, execdetails.StmtExecDetailKey, &execdetails.StmtExecDetails{})
 		ctx = context.WithValue(ctx, tikvutil.ExecDetailsKey, &tikvutil.ExecDetails{})
+		ctx, ruDetails := withInternalRUDetails(ctx, useCurSession)
+		defer recordInternalRU(ruDetails)
 		rs, err := se.ExecuteInternalStmt(ctx, stmt)
 		if err != nil {
 			se.sessionVars.StmtCtx.AppendError(err)
@@ -2020,6 +2064,9 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	if err := executor.ResetContextOfStmt(s, stmtNode); err != nil {
 		return nil, err
 	}
+	if ruDetails, ok := ctx.Value(tikvutil.RUDetailsCtxKey).(*tikvutil.RUDetails); ok {
+		sessVars.StmtCtx.RUDetails = ruDetails
+	}
 	if execStmt, ok := stmtNode.(*ast.ExecuteStmt); ok {
 		if binParam, ok := execStmt.BinaryArgs.([]param.BinaryParam); ok {
 			args, err := param.ExecArgs(s.GetSessionVars().StmtCtx.TypeCtx(), binParam)
@@ -2034,6 +2081,7 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	cmdByte := byte(atomic.LoadUint32(&s.GetSessionVars().CommandValue))
 	if topsqlstate.TopSQLEnabled() {
 		s.sessionVars.StmtCtx.IsSQLRegistered.Store(true)
+		ctx = topsql.AttachResourceGroup(ctx, sessVars.StmtCtx.ResourceGroupName)
 		ctx = topsql.AttachAndRegisterSQLInfo(ctx, normalizedSQL, digest, s.sessionVars.InRestrictedSQL)
 	}
 	if sessVars.InPlanReplayer {
@@ -2582,6 +2630,7 @@ func (s *session) GetDistSQLCtx() *distsqlctx.DistSQLContext {
 			TiFlashQuerySpillRatio:               vars.TiFlashQuerySpillRatio,
 
 			DistSQLConcurrency:            vars.DistSQLScanConcurrency(),
+			AdaptiveConcurrency:           vars.EnableAdaptiveDistSQLConcurrency,
 			ReplicaReadType:               vars.GetReplicaRead(),
 			WeakConsistency:               sc.WeakConsistency,
 			RCCheckTS:                     sc.RCCheckTS,
@@ -2599,6 +2648,7 @@ func (s *session) GetDistSQLCtx() *distsqlctx.DistSQLContext {
 			LoadBasedReplicaReadThreshold: vars.LoadBasedReplicaReadThreshold,
 			RunawayChecker:                sc.RunawayChecker,
 			TiKVClientReadTimeout:         vars.GetTiKVClientReadTimeout(),
+			StmtRUQuota:                   vars.StmtRUQuota,
 
 			ReplicaClosestReadThreshold: vars.ReplicaClosestReadThreshold,
 			ConnectionID:                vars.ConnectionID,
@@ -3364,19 +3414,43 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 	}
 
 	// initiate disttask framework components which need a store
-	scheduler.RegisterSchedulerFactory(
+	if err = scheduler.RegisterSchedulerFactory(
 		proto.ImportInto,
 		func(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
 			return importinto.NewImportScheduler(ctx, task, param, store.(kv.StorageWithPD))
 		},
-	)
+	); err != nil {
+		return nil, err
+	}
+	scheduler.RegisterMaxConcurrentTaskOfType(proto.ImportInto, importinto.MaxConcurrentTasks)
+	// with local sort, each node running the import step keeps the sorted KV
+	// data in its temp dir and checks its disk quota, keep the subtasks on those
+	// nodes instead of spreading them to the nodes joining in the middle.
+	scheduler.RegisterSubtaskAffinity(proto.ImportInto, true)
+	scheduler.RegisterResourceEstimator(proto.ImportInto, importinto.EstimateSubtaskResource)
 	taskexecutor.RegisterTaskType(
 		proto.ImportInto,
 		func(ctx context.Context, id string, task *proto.Task, table taskexecutor.TaskTable) taskexecutor.TaskExecutor {
 			return importinto.NewImportExecutor(ctx, id, task, table, store)
 		},
+		taskexecutor.WithMaxConcurrentSubtasks(importinto.MaxConcurrentSubtasksPerNode),
+	)
+	if err = scheduler.RegisterSchedulerFactory(proto.Analyze, analyze.NewScheduler); err != nil {
+		return nil, err
+	}
+	taskexecutor.RegisterTaskType(proto.Analyze, analyze.NewTaskExecutor)
+	if err = scheduler.RegisterSchedulerFactory(proto.Checksum, checksum.NewScheduler); err != nil {
+		return nil, err
+	}
+	taskexecutor.RegisterTaskType(
+		proto.Checksum,
+		func(ctx context.Context, id string, task *proto.Task, table taskexecutor.TaskTable) taskexecutor.TaskExecutor {
+			return checksum.NewTaskExecutor(ctx, id, task, table, store)
+		},
 	)
 
+	scheduler.RegisterResourceHeadroomGetter(executor.DistTaskResourceHeadroom)
+
 	analyzeConcurrencyQuota := int(config.GetGlobalConfig().Performance.AnalyzePartitionConcurrencyQuota)
 	concurrency := config.GetGlobalConfig().Performance.StatsLoadConcurrency
 	if concurrency == 0 {
@@ -3459,6 +3533,14 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 	if err != nil {
 		return nil, err
 	}
+	loadCostModelFactors := func(sctx sessionctx.Context) error {
+		return executor.LoadCostModelFactors(ctx, sctx)
+	}
+	// the default factors are used if the calibrated ones can't be loaded, which shouldn't stop the instance.
+	if err = loadCostModelFactors(ses[5]); err != nil {
+		logutil.BgLogger().Warn("load the calibrated cost model factors failed, the default factors are used", zap.Error(err))
+	}
+	dom.WatchCostModelFactors(loadCostModelFactors)
 
 	planReplayerWorkerCnt := config.GetGlobalConfig().Performance.PlanReplayerDumpWorkerConcurrency
 	planReplayerWorkersSctx := make([]sessionctx.Context, planReplayerWorkerCnt)
@@ -3535,6 +3617,9 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 		return s
 	}
 	dom.StartTTLJobManager()
+	if err = ttlworker.RegisterDistTask(dom.SysSessionPool()); err != nil {
+		return nil, err
+	}
 
 	analyzeCtxs, err := createSessions(store, analyzeConcurrencyQuota)
 	if err != nil {
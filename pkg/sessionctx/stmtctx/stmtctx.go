@@ -48,6 +48,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/topsql/stmtstats"
 	"github.com/pingcap/tidb/pkg/util/tracing"
 	"github.com/tikv/client-go/v2/tikvrpc"
+	tikvutil "github.com/tikv/client-go/v2/util"
 	atomic2 "go.uber.org/atomic"
 	"golang.org/x/exp/maps"
 	"golang.org/x/sync/singleflight"
@@ -231,6 +232,8 @@ type StatementContext struct {
 	NotFillCache bool
 	MemTracker   *memory.Tracker
 	DiskTracker  *disk.Tracker
+	// RUDetails is the RU consumption of the statement, it's nil if the statement is not from a client.
+	RUDetails *tikvutil.RUDetails
 	// per statement resource group name
 	// hint /* +ResourceGroup(name) */ can change the statement group name
 	ResourceGroupName   string
@@ -250,6 +253,9 @@ type StatementContext struct {
 	// BindSQL used to construct the key for plan cache. It records the binding used by the stmt.
 	// If the binding is not used by the stmt, the value is empty
 	BindSQL string
+	// CardinalityFeedbackKey is the key of the statement used to record and look up the cardinality feedback.
+	// If the cardinality feedback is not used by the stmt, the value is empty.
+	CardinalityFeedbackKey string
 
 	// The several fields below are mainly for some diagnostic features, like stmt summary and slow query.
 	// We cache the values here to avoid calculating them multiple times.
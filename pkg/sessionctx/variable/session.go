@@ -704,6 +704,8 @@ type SessionVars struct {
 	Concurrency
 	MemQuota
 	BatchSize
+	// DiskQuotaQuery defines the disk quota for the data spilled by a query, 0 means no limit.
+	DiskQuotaQuery int64
 	// DMLBatchSize indicates the number of rows batch-committed for a statement.
 	// It will be used when using LOAD DATA or BatchInsert or BatchDelete is on.
 	DMLBatchSize        int
@@ -853,6 +855,10 @@ type SessionVars struct {
 
 	ExplainNonEvaledSubQuery bool
 
+	// ExplainAnalyzeTimeBreakdown indicates whether EXPLAIN ANALYZE splits the time of
+	// the operators into the time spent in TiDB, TiKV, network and queueing.
+	ExplainAnalyzeTimeBreakdown bool
+
 	// MultiStatementMode permits incorrect client library usage. Not recommended to be turned on.
 	MultiStatementMode int
 
@@ -1329,6 +1335,10 @@ type SessionVars struct {
 	// EnablePaging indicates whether enable paging in coprocessor requests.
 	EnablePaging bool
 
+	// EnableAdaptiveDistSQLConcurrency indicates whether the concurrency of coprocessor requests
+	// is adapted to the queueing latency in TiKV, with tidb_distsql_scan_concurrency as the upper limit.
+	EnableAdaptiveDistSQLConcurrency bool
+
 	// EnableLegacyInstanceScope says if SET SESSION can be used to set an instance
 	// scope variable. The default is TRUE.
 	EnableLegacyInstanceScope bool
@@ -1342,6 +1352,10 @@ type SessionVars struct {
 	// EnableParallelHashaggSpill indicates if parallel hash agg could spill.
 	EnableParallelHashaggSpill bool
 
+	// EnableHashAggPartialResult indicates whether hash agg stops reading its child and returns an
+	// approximate result instead of cancelling the query when the memory quota is exceeded.
+	EnableHashAggPartialResult bool
+
 	// SysdateIsNow indicates whether Sysdate is an alias of Now function
 	SysdateIsNow bool
 	// EnableMutationChecker indicates whether to check data consistency for mutations
@@ -1416,6 +1430,14 @@ type SessionVars struct {
 	// EnablePlanCacheForSubquery controls whether the prepare statement with sub query can be cached
 	EnablePlanCacheForSubquery bool
 
+	// EnablePlanCacheForUncorrelatedSubquery controls whether the uncorrelated sub queries of the prepare statement
+	// are kept in the plan instead of being evaluated during optimization, so the plan can be cached.
+	EnablePlanCacheForUncorrelatedSubquery bool
+
+	// EnableCardinalityFeedback indicates whether to record and use the actual row counts of the tables whose
+	// estimated row counts are far from the actual ones.
+	EnableCardinalityFeedback bool
+
 	// EnableNonPreparedPlanCache indicates whether to enable non-prepared plan cache.
 	EnableNonPreparedPlanCache bool
 
@@ -1459,6 +1481,14 @@ type SessionVars struct {
 	// LastPlanReplayerToken indicates the last plan replayer token
 	LastPlanReplayerToken string
 
+	// ResultBatchSize is the max number of rows returned by a query through the text protocol, the rest rows
+	// are kept in the server and can be fetched by `FETCH NEXT FROM 'token'`. 0 means no limit.
+	ResultBatchSize int
+
+	// LastResultToken is the token to fetch the rest rows of the last query, it's empty if all the rows
+	// have been returned.
+	LastResultToken string
+
 	// InPlanReplayer means we are now executing a statement for a PLAN REPLAYER SQL.
 	// Note that PLAN REPLAYER CAPTURE is not included here.
 	InPlanReplayer bool
@@ -1518,6 +1548,9 @@ type SessionVars struct {
 	// NOTE: all statement relate operation should use StmtCtx.ResourceGroupName instead.
 	ResourceGroupName string
 
+	// StmtRUQuota is the max RU a statement can consume, 0 means no limit.
+	StmtRUQuota uint64
+
 	// PessimisticTransactionFairLocking controls whether fair locking for pessimistic transaction
 	// is enabled.
 	PessimisticTransactionFairLocking bool
@@ -1565,6 +1598,9 @@ type SessionVars struct {
 	// FastCheckTable is used to control whether fast check table is enabled.
 	FastCheckTable bool
 
+	// CheckTableConcurrency is the concurrency of admin check table.
+	CheckTableConcurrency int
+
 	// HypoIndexes are for the Index Advisor.
 	HypoIndexes map[string]map[string]map[string]*model.IndexInfo // dbName -> tblName -> idxName -> idxInfo
 
@@ -2075,6 +2111,7 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		ResourceGroupName:             resourcegroup.DefaultResourceGroupName,
 		DefaultCollationForUTF8MB4:    mysql.DefaultCollationName,
 		GroupConcatMaxLen:             DefGroupConcatMaxLen,
+		CheckTableConcurrency:         DefCheckTableConcurrency,
 	}
 	vars.status.Store(uint32(mysql.ServerStatusAutocommit))
 	vars.StmtCtx.ResourceGroupName = resourcegroup.DefaultResourceGroupName
@@ -242,11 +242,19 @@ var defaultSysVars = []*SysVar{
 		s.ExplainNonEvaledSubQuery = TiDBOptOn(val)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBExplainAnalyzeTimeBreakdown, Value: BoolToOnOff(DefTiDBExplainAnalyzeTimeBreakdown), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.ExplainAnalyzeTimeBreakdown = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: ScopeSession, Name: TiDBOptWriteRowID, Value: BoolToOnOff(DefOptWriteRowID), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.AllowWriteRowID = TiDBOptOn(val)
 		return nil
 	}},
 	{Scope: ScopeSession, Name: TiDBChecksumTableConcurrency, Value: strconv.Itoa(DefChecksumTableConcurrency), Type: TypeInt, MinValue: 1, MaxValue: MaxConfigurableConcurrency},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBCheckTableConcurrency, Value: strconv.Itoa(DefCheckTableConcurrency), Type: TypeInt, MinValue: 1, MaxValue: MaxConfigurableConcurrency, SetSession: func(s *SessionVars, val string) error {
+		s.CheckTableConcurrency = tidbOptPositiveInt32(val, DefCheckTableConcurrency)
+		return nil
+	}},
 	{Scope: ScopeSession, Name: TiDBBatchInsert, Value: BoolToOnOff(DefBatchInsert), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.BatchInsert = TiDBOptOn(val)
 		return nil
@@ -416,6 +424,11 @@ var defaultSysVars = []*SysVar{
 			return s.LastPlanReplayerToken, nil
 		},
 	},
+	{Scope: ScopeSession, Name: TiDBLastResultToken, Value: "", ReadOnly: true,
+		GetSession: func(s *SessionVars) (string, error) {
+			return s.LastResultToken, nil
+		},
+	},
 	{Scope: ScopeSession, Name: TiDBUseAlloc, Value: BoolToOnOff(DefTiDBUseAlloc), Type: TypeBool, ReadOnly: true, GetSession: func(s *SessionVars) (string, error) {
 		return BoolToOnOff(s.preUseChunkAlloc), nil
 	}},
@@ -804,6 +817,12 @@ var defaultSysVars = []*SysVar{
 	{Scope: ScopeGlobal, Name: TiDBEvolvePlanTaskMaxTime, Value: strconv.Itoa(DefTiDBEvolvePlanTaskMaxTime), Type: TypeInt, MinValue: -1, MaxValue: math.MaxInt64},
 	{Scope: ScopeGlobal, Name: TiDBEvolvePlanTaskStartTime, Value: DefTiDBEvolvePlanTaskStartTime, Type: TypeTime},
 	{Scope: ScopeGlobal, Name: TiDBEvolvePlanTaskEndTime, Value: DefTiDBEvolvePlanTaskEndTime, Type: TypeTime},
+	{Scope: ScopeGlobal, Name: TiDBEvolvePlanTaskResourceGroup, Value: DefTiDBEvolvePlanTaskResourceGroup, Type: TypeStr, Validation: func(vars *SessionVars, normalizedValue string, originalValue string, scope ScopeFlag) (string, error) {
+		if normalizedValue == "" {
+			return normalizedValue, ErrWrongValueForVar.GenWithStackByArgs(TiDBEvolvePlanTaskResourceGroup, originalValue)
+		}
+		return strings.ToLower(normalizedValue), nil
+	}},
 	{Scope: ScopeGlobal, Name: TiDBStoreLimit, Value: strconv.FormatInt(atomic.LoadInt64(&config.GetGlobalConfig().TiKVClient.StoreLimit), 10), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt64, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 		return strconv.FormatInt(tikvstore.StoreLimit.Load(), 10), nil
 	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
@@ -1116,6 +1135,28 @@ var defaultSysVars = []*SysVar{
 			return nil
 		},
 	},
+	{Scope: ScopeGlobal, Name: TiDBServerMemoryQueueWatermark, Value: strconv.FormatFloat(DefTiDBServerMemoryQueueWatermark, 'f', -1, 64), Type: TypeFloat, MinValue: 0, MaxValue: 1, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		ServerMemoryQueueWatermark.Store(tidbOptFloat64(val, DefTiDBServerMemoryQueueWatermark))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.FormatFloat(ServerMemoryQueueWatermark.Load(), 'f', -1, 64), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBServerMemoryQueueCostThreshold, Value: strconv.FormatFloat(DefTiDBServerMemoryQueueCostThreshold, 'f', -1, 64), Type: TypeFloat, MinValue: 0, MaxValue: math.MaxUint64, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		ServerMemoryQueueCostThreshold.Store(tidbOptFloat64(val, DefTiDBServerMemoryQueueCostThreshold))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.FormatFloat(ServerMemoryQueueCostThreshold.Load(), 'f', -1, 64), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBServerMemoryQueueTimeout, Value: DefTiDBServerMemoryQueueTimeout.String(), Type: TypeDuration, MinValue: int64(time.Millisecond), MaxValue: uint64(time.Hour), SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		ServerMemoryQueueTimeout.Store(d)
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return ServerMemoryQueueTimeout.Load().String(), nil
+	}},
 	{Scope: ScopeGlobal, Name: TiDBServerMemoryLimitGCTrigger, Value: strconv.FormatFloat(DefTiDBServerMemoryLimitGCTrigger, 'f', -1, 64), Type: TypeStr,
 		GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 			return strconv.FormatFloat(gctuner.GlobalMemoryLimitTuner.GetPercentage(), 'f', -1, 64), nil
@@ -1366,6 +1407,62 @@ var defaultSysVars = []*SysVar{
 	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 		return BoolToOnOff(EnableDistTask.Load()), nil
 	}},
+	{Scope: ScopeGlobal, Name: TiDBEnableDistAnalyze, Value: BoolToOnOff(DefTiDBEnableDistAnalyze), Type: TypeBool, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		EnableDistAnalyze.Store(TiDBOptOn(val))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return BoolToOnOff(EnableDistAnalyze.Load()), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBEnableDistChecksum, Value: BoolToOnOff(DefTiDBEnableDistChecksum), Type: TypeBool, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		EnableDistChecksum.Store(TiDBOptOn(val))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return BoolToOnOff(EnableDistChecksum.Load()), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDistTaskDispatchConcurrency, Value: strconv.Itoa(DefTiDBDistTaskDispatchConcurrency), Type: TypeUnsigned, MinValue: 1, MaxValue: 256, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DistTaskDispatchConcurrency.Store(int32(TidbOptInt64(val, DefTiDBDistTaskDispatchConcurrency)))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.Itoa(int(DistTaskDispatchConcurrency.Load())), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDistTaskCheckInterval, Value: DefTiDBDistTaskCheckInterval.String(), Type: TypeDuration, MinValue: int64(10 * time.Millisecond), MaxValue: uint64(time.Minute), SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		DistTaskCheckInterval.Store(d)
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return DistTaskCheckInterval.Load().String(), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDistTaskSubtaskConcurrency, Value: strconv.Itoa(DefTiDBDistTaskSubtaskConcurrency), Type: TypeUnsigned, MinValue: 0, MaxValue: 1024, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DistTaskSubtaskConcurrency.Store(int32(TidbOptInt64(val, DefTiDBDistTaskSubtaskConcurrency)))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.Itoa(int(DistTaskSubtaskConcurrency.Load())), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDistTaskHistoryRetention, Value: DefTiDBDistTaskHistoryRetention.String(), Type: TypeDuration, MinValue: int64(time.Hour), MaxValue: uint64(365 * 24 * time.Hour), SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		DistTaskHistoryRetention.Store(d)
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return DistTaskHistoryRetention.Load().String(), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDistTaskMaxMetaSize, Value: strconv.Itoa(DefTiDBDistTaskMaxMetaSize), Type: TypeUnsigned, MinValue: 1 << 10, MaxValue: 64 << 20, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DistTaskMaxMetaSize.Store(TidbOptInt64(val, DefTiDBDistTaskMaxMetaSize))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.FormatInt(DistTaskMaxMetaSize.Load(), 10), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBEnableDistTaskMetaEncoding, Value: BoolToOnOff(DefTiDBEnableDistTaskMetaEncoding), Type: TypeBool, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		EnableDistTaskMetaEncoding.Store(TiDBOptOn(val))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return BoolToOnOff(EnableDistTaskMetaEncoding.Load()), nil
+	}},
 	{Scope: ScopeGlobal, Name: TiDBEnableFastCreateTable, Value: BoolToOnOff(DefTiDBEnableFastCreateTable), Type: TypeBool, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
 		if EnableFastCreateTable.Load() != TiDBOptOn(val) {
 			err := SwitchFastCreateTable(TiDBOptOn(val))
@@ -1377,6 +1474,16 @@ var defaultSysVars = []*SysVar{
 	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 		return BoolToOnOff(EnableFastCreateTable.Load()), nil
 	}},
+	{Scope: ScopeGlobal, Name: TiDBClusterConfigCacheTTL, Value: DefTiDBClusterConfigCacheTTL.String(), Type: TypeDuration, MinValue: 0, MaxValue: uint64(time.Hour), SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		ClusterConfigCacheTTL.Store(d)
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return ClusterConfigCacheTTL.Load().String(), nil
+	}},
 	{Scope: ScopeGlobal, Name: TiDBEnableNoopVariables, Value: BoolToOnOff(DefTiDBEnableNoopVariables), Type: TypeEnum, PossibleValues: []string{Off, On}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
 		EnableNoopVariables.Store(TiDBOptOn(val))
 		return nil
@@ -1851,6 +1958,10 @@ var defaultSysVars = []*SysVar{
 		s.distSQLScanConcurrency = tidbOptPositiveInt32(val, DefDistSQLScanConcurrency)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableAdaptiveDistSQLConcurrency, Value: BoolToOnOff(DefTiDBEnableAdaptiveDistSQLConcurrency), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableAdaptiveDistSQLConcurrency = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBAnalyzeDistSQLScanConcurrency, Value: strconv.Itoa(DefAnalyzeDistSQLScanConcurrency), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt32, SetSession: func(s *SessionVars, val string) error {
 		s.analyzeDistSQLScanConcurrency = tidbOptPositiveInt32(val, DefAnalyzeDistSQLScanConcurrency)
 		return nil
@@ -2392,6 +2503,12 @@ var defaultSysVars = []*SysVar{
 			return nil
 		},
 	},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableHashAggPartialResult, Value: BoolToOnOff(DefTiDBEnableHashAggPartialResult), Type: TypeBool,
+		SetSession: func(s *SessionVars, val string) error {
+			s.EnableHashAggPartialResult = TiDBOptOn(val)
+			return nil
+		},
+	},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableMutationChecker, Hidden: true,
 		Value: BoolToOnOff(DefTiDBEnableMutationChecker), Type: TypeBool,
 		SetSession: func(s *SessionVars, val string) error {
@@ -2461,6 +2578,14 @@ var defaultSysVars = []*SysVar{
 		}
 		return normalizedValue, nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBDiskQuotaQuery, Value: strconv.Itoa(DefTiDBDiskQuotaQuery), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt64, SetSession: func(s *SessionVars, val string) error {
+		s.DiskQuotaQuery = TidbOptInt64(val, DefTiDBDiskQuotaQuery)
+		return nil
+	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBResultBatchSize, Value: strconv.Itoa(DefTiDBResultBatchSize), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt32, SetSession: func(s *SessionVars, val string) error {
+		s.ResultBatchSize = TidbOptInt(val, DefTiDBResultBatchSize)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBNonTransactionalIgnoreError, Value: BoolToOnOff(DefTiDBBatchDMLIgnoreError), Type: TypeBool,
 		SetSession: func(s *SessionVars, val string) error {
 			s.NonTransactionalIgnoreError = TiDBOptOn(val)
@@ -2779,6 +2904,12 @@ var defaultSysVars = []*SysVar{
 			return strconv.Itoa(int(TTLDeleteWorkerCount.Load())), nil
 		},
 	},
+	{Scope: ScopeGlobal, Name: TiDBTTLEnableDistTask, Value: BoolToOnOff(DefTiDBTTLEnableDistTask), Type: TypeBool, SetGlobal: func(ctx context.Context, vars *SessionVars, s string) error {
+		TTLEnableDistTask.Store(TiDBOptOn(s))
+		return nil
+	}, GetGlobal: func(ctx context.Context, vars *SessionVars) (string, error) {
+		return BoolToOnOff(TTLEnableDistTask.Load()), nil
+	}},
 	{Scope: ScopeGlobal, Name: TiDBEnableResourceControl, Value: BoolToOnOff(DefTiDBEnableResourceControl), Type: TypeBool, SetGlobal: func(ctx context.Context, vars *SessionVars, s string) error {
 		if TiDBOptOn(s) != EnableResourceControl.Load() {
 			EnableResourceControl.Store(TiDBOptOn(s))
@@ -2799,6 +2930,10 @@ var defaultSysVars = []*SysVar{
 	}, GetGlobal: func(ctx context.Context, vars *SessionVars) (string, error) {
 		return BoolToOnOff(EnableResourceControlStrictMode.Load()), nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBStmtRUQuota, Value: strconv.Itoa(DefTiDBStmtRUQuota), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxInt64, SetSession: func(s *SessionVars, val string) error {
+		s.StmtRUQuota = TidbOptUint64(val, DefTiDBStmtRUQuota)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBPessimisticTransactionFairLocking, Value: BoolToOnOff(DefTiDBPessimisticTransactionFairLocking), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.PessimisticTransactionFairLocking = TiDBOptOn(val)
 		return nil
@@ -2825,6 +2960,14 @@ var defaultSysVars = []*SysVar{
 		s.EnablePlanCacheForSubquery = TiDBOptOn(val)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnablePlanCacheForUncorrelatedSubquery, Value: BoolToOnOff(DefTiDBEnablePlanCacheForUncorrelatedSubquery), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnablePlanCacheForUncorrelatedSubquery = TiDBOptOn(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableCardinalityFeedback, Value: BoolToOnOff(DefTiDBEnableCardinalityFeedback), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableCardinalityFeedback = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBOptEnableLateMaterialization, Value: BoolToOnOff(DefTiDBOptEnableLateMaterialization), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.EnableLateMaterialization = TiDBOptOn(val)
 		return nil
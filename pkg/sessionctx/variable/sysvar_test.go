@@ -1632,6 +1632,77 @@ func TestTiDBLowResTSOUpdateInterval(t *testing.T) {
 	require.Equal(t, "1000", val)
 }
 
+func TestTiDBDistTaskSchedulerVars(t *testing.T) {
+	vars := NewSessionVars(nil)
+	mock := NewMockGlobalAccessor4Tests()
+	mock.SessionVars = vars
+	vars.GlobalVarsAccessor = mock
+	t.Cleanup(func() {
+		DistTaskDispatchConcurrency.Store(DefTiDBDistTaskDispatchConcurrency)
+		DistTaskCheckInterval.Store(DefTiDBDistTaskCheckInterval)
+		DistTaskSubtaskConcurrency.Store(DefTiDBDistTaskSubtaskConcurrency)
+		DistTaskHistoryRetention.Store(DefTiDBDistTaskHistoryRetention)
+		DistTaskMaxMetaSize.Store(DefTiDBDistTaskMaxMetaSize)
+		EnableDistTaskMetaEncoding.Store(DefTiDBEnableDistTaskMetaEncoding)
+	})
+
+	sv := GetSysVar(TiDBDistTaskDispatchConcurrency)
+	val, err := mock.GetGlobalSysVar(TiDBDistTaskDispatchConcurrency)
+	require.NoError(t, err)
+	require.Equal(t, "16", val)
+	val, err = sv.Validate(vars, "0", ScopeGlobal)
+	require.NoError(t, err)
+	require.Equal(t, "1", val)
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), TiDBDistTaskDispatchConcurrency, "4"))
+	require.Equal(t, int32(4), DistTaskDispatchConcurrency.Load())
+
+	sv = GetSysVar(TiDBDistTaskCheckInterval)
+	val, err = mock.GetGlobalSysVar(TiDBDistTaskCheckInterval)
+	require.NoError(t, err)
+	require.Equal(t, "500ms", val)
+	val, err = sv.Validate(vars, "1h", ScopeGlobal)
+	require.NoError(t, err)
+	require.Equal(t, "1m0s", val)
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), TiDBDistTaskCheckInterval, "2s"))
+	require.Equal(t, 2*time.Second, DistTaskCheckInterval.Load())
+
+	sv = GetSysVar(TiDBDistTaskSubtaskConcurrency)
+	val, err = mock.GetGlobalSysVar(TiDBDistTaskSubtaskConcurrency)
+	require.NoError(t, err)
+	require.Equal(t, "0", val)
+	val, err = sv.Validate(vars, "2000", ScopeGlobal)
+	require.NoError(t, err)
+	require.Equal(t, "1024", val)
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), TiDBDistTaskSubtaskConcurrency, "8"))
+	require.Equal(t, int32(8), DistTaskSubtaskConcurrency.Load())
+
+	sv = GetSysVar(TiDBDistTaskHistoryRetention)
+	val, err = mock.GetGlobalSysVar(TiDBDistTaskHistoryRetention)
+	require.NoError(t, err)
+	require.Equal(t, "336h0m0s", val)
+	val, err = sv.Validate(vars, "1m", ScopeGlobal)
+	require.NoError(t, err)
+	require.Equal(t, "1h0m0s", val)
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), TiDBDistTaskHistoryRetention, "72h"))
+	require.Equal(t, 72*time.Hour, DistTaskHistoryRetention.Load())
+
+	sv = GetSysVar(TiDBDistTaskMaxMetaSize)
+	val, err = mock.GetGlobalSysVar(TiDBDistTaskMaxMetaSize)
+	require.NoError(t, err)
+	require.Equal(t, "4194304", val)
+	val, err = sv.Validate(vars, "1", ScopeGlobal)
+	require.NoError(t, err)
+	require.Equal(t, "1024", val)
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), TiDBDistTaskMaxMetaSize, "1048576"))
+	require.Equal(t, int64(1<<20), DistTaskMaxMetaSize.Load())
+
+	val, err = mock.GetGlobalSysVar(TiDBEnableDistTaskMetaEncoding)
+	require.NoError(t, err)
+	require.Equal(t, Off, val)
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), TiDBEnableDistTaskMetaEncoding, On))
+	require.True(t, EnableDistTaskMetaEncoding.Load())
+}
+
 func TestSetEnableColumnTrackingAndPersistAnalyzeOptions(t *testing.T) {
 	vars := NewSessionVars(nil)
 	mock := NewMockGlobalAccessor4Tests()
@@ -72,6 +72,10 @@ const (
 
 	TiDBOptExplainNoEvaledSubQuery = "tidb_opt_enable_non_eval_scalar_subquery"
 
+	// TiDBExplainAnalyzeTimeBreakdown is used to indicate whether EXPLAIN ANALYZE outputs the time
+	// breakdown of TiDB CPU, TiKV CPU, network and queueing for each operator subtree.
+	TiDBExplainAnalyzeTimeBreakdown = "tidb_explain_analyze_time_breakdown"
+
 	// TiDBBCJThresholdSize is used to limit the size of small table for mpp broadcast join.
 	// Its unit is bytes, if the size of small table is larger than it, we will not use bcj.
 	TiDBBCJThresholdSize = "tidb_broadcast_join_threshold_size"
@@ -98,6 +102,12 @@ const (
 	// scanned concurrently, with the cost of higher system performance impact.
 	TiDBChecksumTableConcurrency = "tidb_checksum_table_concurrency"
 
+	// TiDBCheckTableConcurrency is used to speed up the ADMIN CHECK TABLE
+	// statement, the indices are checked concurrently, and the rows of the
+	// table are split into handle ranges to be checked concurrently if the
+	// table has fewer indices than the concurrency.
+	TiDBCheckTableConcurrency = "tidb_check_table_concurrency"
+
 	// TiDBCurrentTS is used to get the current transaction timestamp.
 	// It is read-only.
 	TiDBCurrentTS = "tidb_current_ts"
@@ -114,6 +124,9 @@ const (
 	// TiDBLastPlanReplayerToken is used to get the last plan replayer token within the current session
 	TiDBLastPlanReplayerToken = "tidb_last_plan_replayer_token"
 
+	// TiDBLastResultToken is used to get the token to fetch the rest rows of the last query within the current session.
+	TiDBLastResultToken = "tidb_last_result_token"
+
 	// TiDBConfig is a read-only variable that shows the config of the current server.
 	TiDBConfig = "tidb_config"
 
@@ -141,6 +154,11 @@ const (
 	TiDBMemQuotaQuery = "tidb_mem_quota_query" // Bytes.
 	// TiDBMemQuotaApplyCache controls the memory quota of a query.
 	TiDBMemQuotaApplyCache = "tidb_mem_quota_apply_cache"
+	// TiDBDiskQuotaQuery controls the disk quota for the data spilled by a query.
+	TiDBDiskQuotaQuery = "tidb_disk_quota_query" // Bytes.
+
+	// TiDBResultBatchSize controls the max number of rows returned by a query through the text protocol.
+	TiDBResultBatchSize = "tidb_result_batch_size"
 
 	// TiDBGeneralLog is used to log every query in the server in info level.
 	TiDBGeneralLog = "tidb_general_log"
@@ -220,6 +238,8 @@ const (
 	TiDBEvolvePlanTaskStartTime = "tidb_evolve_plan_task_start_time"
 	// TiDBEvolvePlanTaskEndTime is the end time of evolution task.
 	TiDBEvolvePlanTaskEndTime = "tidb_evolve_plan_task_end_time"
+	// TiDBEvolvePlanTaskResourceGroup is the resource group used by the executions of evolution task.
+	TiDBEvolvePlanTaskResourceGroup = "tidb_evolve_plan_task_resource_group"
 
 	// TiDBSlowLogThreshold is used to set the slow log threshold in the server.
 	TiDBSlowLogThreshold = "tidb_slow_log_threshold"
@@ -298,6 +318,11 @@ const (
 	// If the query has a LIMIT clause, high concurrency makes the system do much more work than needed.
 	TiDBDistSQLScanConcurrency = "tidb_distsql_scan_concurrency"
 
+	// TiDBEnableAdaptiveDistSQLConcurrency indicates whether the concurrency of a distsql scan task is adapted
+	// to the queueing latency of the coprocessor tasks in TiKV. When it is on, a distsql scan task starts with
+	// a small concurrency and ramps it up to tidb_distsql_scan_concurrency while TiKV doesn't queue the tasks.
+	TiDBEnableAdaptiveDistSQLConcurrency = "tidb_enable_adaptive_distsql_concurrency"
+
 	// TiDBAnalyzeDistSQLScanConcurrency is used to set the concurrency of a distsql scan task for analyze statement.
 	TiDBAnalyzeDistSQLScanConcurrency = "tidb_analyze_distsql_scan_concurrency"
 
@@ -899,6 +924,14 @@ const (
 	// TiDBEnablePlanCacheForSubquery controls whether prepare statement with subquery can be cached
 	TiDBEnablePlanCacheForSubquery = "tidb_enable_plan_cache_for_subquery"
 
+	// TiDBEnablePlanCacheForUncorrelatedSubquery controls whether the uncorrelated sub-queries of a prepare statement
+	// are kept in the plan instead of being evaluated during optimization, so the plan can be cached.
+	TiDBEnablePlanCacheForUncorrelatedSubquery = "tidb_enable_plan_cache_for_uncorrelated_subquery"
+
+	// TiDBEnableCardinalityFeedback controls whether to record the actual row counts of the tables whose estimated row
+	// counts are far from the actual ones, and use them to estimate the row counts of the same statement later.
+	TiDBEnableCardinalityFeedback = "tidb_enable_cardinality_feedback"
+
 	// TiDBOptEnableLateMaterialization indicates whether to enable late materialization
 	TiDBOptEnableLateMaterialization = "tidb_opt_enable_late_materialization"
 	// TiDBLoadBasedReplicaReadThreshold is the wait duration threshold to enable replica read automatically.
@@ -942,6 +975,10 @@ const (
 	// TiDBEnableParallelHashaggSpill is the name of the `tidb_enable_parallel_hashagg_spill` system variable
 	TiDBEnableParallelHashaggSpill = "tidb_enable_parallel_hashagg_spill"
 
+	// TiDBEnableHashAggPartialResult indicates whether hash aggregation returns an approximate result
+	// built from the rows read so far instead of cancelling the query when the memory quota is exceeded.
+	TiDBEnableHashAggPartialResult = "tidb_enable_hashagg_partial_result"
+
 	// TiDBTxnEntrySizeLimit indicates the max size of a entry in membuf.
 	TiDBTxnEntrySizeLimit = "tidb_txn_entry_size_limit"
 
@@ -1010,8 +1047,32 @@ const (
 	TiDBMaxAutoAnalyzeTime = "tidb_max_auto_analyze_time"
 	// TiDBEnableDistTask indicates whether to enable the distributed execute background tasks(For example DDL, Import etc).
 	TiDBEnableDistTask = "tidb_enable_dist_task"
+	// TiDBEnableDistAnalyze indicates whether to analyze the partitions of a partitioned table on the distributed task framework.
+	TiDBEnableDistAnalyze = "tidb_enable_dist_analyze"
+	// TiDBEnableDistChecksum indicates whether to compute the checksum of ADMIN CHECKSUM TABLE on the distributed task framework.
+	TiDBEnableDistChecksum = "tidb_enable_dist_checksum"
+	// TiDBDistTaskDispatchConcurrency indicates the max number of distributed tasks scheduled concurrently.
+	TiDBDistTaskDispatchConcurrency = "tidb_dist_task_dispatch_concurrency"
+	// TiDBDistTaskCheckInterval indicates the interval for the scheduler of a distributed task to check its subtasks.
+	TiDBDistTaskCheckInterval = "tidb_dist_task_check_interval"
+	// TiDBDistTaskSubtaskConcurrency indicates the max number of slots used to run
+	// subtasks on each TiDB node, 0 means the CPU count of the node.
+	TiDBDistTaskSubtaskConcurrency = "tidb_dist_task_subtask_concurrency"
+	// TiDBDistTaskHistoryRetention indicates how long the finished distributed tasks and
+	// subtasks are kept in the history tables.
+	TiDBDistTaskHistoryRetention = "tidb_dist_task_history_retention"
+	// TiDBDistTaskMaxMetaSize indicates the max size of the meta stored in one row
+	// of the distributed task tables, larger meta is split into chunks.
+	TiDBDistTaskMaxMetaSize = "tidb_dist_task_max_meta_size"
+	// TiDBEnableDistTaskMetaEncoding indicates whether to compress the large meta of the
+	// distributed tasks and split it into chunks. The meta in the new format can't be read
+	// by old versions, so it should be enabled after all the instances are upgraded.
+	TiDBEnableDistTaskMetaEncoding = "tidb_enable_dist_task_meta_encoding"
 	// TiDBEnableFastCreateTable indicates whether to enable the fast create table feature.
 	TiDBEnableFastCreateTable = "tidb_enable_fast_create_table"
+	// TiDBClusterConfigCacheTTL indicates how long the config fetched from each instance
+	// is cached for information_schema.cluster_config and SHOW CONFIG, 0 means no cache.
+	TiDBClusterConfigCacheTTL = "tidb_cluster_config_cache_ttl"
 	// TiDBGenerateBinaryPlan indicates whether binary plan should be generated in slow log and statements summary.
 	TiDBGenerateBinaryPlan = "tidb_generate_binary_plan"
 	// TiDBEnableGCAwareMemoryTrack indicates whether to turn-on GC-aware memory track.
@@ -1035,6 +1096,13 @@ const (
 	TiDBServerMemoryLimitSessMinSize = "tidb_server_memory_limit_sess_min_size"
 	// TiDBServerMemoryLimitGCTrigger indicates the gc percentage of the TiDBServerMemoryLimit.
 	TiDBServerMemoryLimitGCTrigger = "tidb_server_memory_limit_gc_trigger"
+	// TiDBServerMemoryQueueWatermark indicates the ratio of TiDBServerMemoryLimit above which the expensive statements
+	// are queued before they start, 0 means the statements are never queued.
+	TiDBServerMemoryQueueWatermark = "tidb_server_memory_queue_watermark"
+	// TiDBServerMemoryQueueCostThreshold indicates the minimal plan cost of the statements to be queued.
+	TiDBServerMemoryQueueCostThreshold = "tidb_server_memory_queue_cost_threshold"
+	// TiDBServerMemoryQueueTimeout indicates how long a statement can be queued before it fails.
+	TiDBServerMemoryQueueTimeout = "tidb_server_memory_queue_timeout"
 	// TiDBEnableGOGCTuner is to enable GOGC tuner. it can tuner GOGC
 	TiDBEnableGOGCTuner = "tidb_enable_gogc_tuner"
 	// TiDBGOGCTunerThreshold is to control the threshold of GOGC tuner.
@@ -1061,6 +1129,8 @@ const (
 	TiDBTTLScanWorkerCount = "tidb_ttl_scan_worker_count"
 	// TiDBTTLDeleteWorkerCount indicates the count of the delete workers in each TiDB node
 	TiDBTTLDeleteWorkerCount = "tidb_ttl_delete_worker_count"
+	// TiDBTTLEnableDistTask indicates whether to delete the expired rows of new ttl jobs on the distributed task framework
+	TiDBTTLEnableDistTask = "tidb_ttl_enable_dist_task"
 	// PasswordReuseHistory limit a few passwords to reuse.
 	PasswordReuseHistory = "password_history"
 	// PasswordReuseTime limit how long passwords can be reused.
@@ -1074,6 +1144,9 @@ const (
 	// TiDBResourceControlStrictMode indicates whether resource control strict mode is enabled.
 	// When strict mode is enabled, user need certain privilege to change session or statement resource group.
 	TiDBResourceControlStrictMode = "tidb_resource_control_strict_mode"
+	// TiDBStmtRUQuota indicates the max RU a statement can consume, the statement is aborted
+	// once it exceeds the quota. 0 means no limit.
+	TiDBStmtRUQuota = "tidb_stmt_ru_quota"
 	// TiDBStmtSummaryEnablePersistent indicates whether to enable file persistence for stmtsummary.
 	TiDBStmtSummaryEnablePersistent = "tidb_stmt_summary_enable_persistent"
 	// TiDBStmtSummaryFilename indicates the file name written by stmtsummary.
@@ -1182,6 +1255,7 @@ const (
 	DefIndexJoinBatchSize                          = 25000
 	DefIndexLookupSize                             = 20000
 	DefDistSQLScanConcurrency                      = 15
+	DefTiDBEnableAdaptiveDistSQLConcurrency        = false
 	DefAnalyzeDistSQLScanConcurrency               = 4
 	DefBuildStatsConcurrency                       = 2
 	DefBuildSamplingStatsConcurrency               = 2
@@ -1191,6 +1265,7 @@ const (
 	DefAutoIncrementIncrement                      = 1
 	DefAutoIncrementOffset                         = 1
 	DefChecksumTableConcurrency                    = 4
+	DefCheckTableConcurrency                       = 3
 	DefSkipUTF8Check                               = false
 	DefSkipASCIICheck                              = false
 	DefOptAggPushDown                              = false
@@ -1347,6 +1422,7 @@ const (
 	DefTiDBStatsLoadPseudoTimeout                  = true
 	DefSysdateIsNow                                = false
 	DefTiDBEnableParallelHashaggSpill              = true
+	DefTiDBEnableHashAggPartialResult              = false
 	DefTiDBEnableMutationChecker                   = false
 	DefTiDBTxnAssertionLevel                       = AssertionOffStr
 	DefTiDBIgnorePreparedCacheCloseStmt            = false
@@ -1357,11 +1433,14 @@ const (
 	DefTiDB3StageDistinctAgg                       = true
 	DefTiDB3StageMultiDistinctAgg                  = false
 	DefTiDBOptExplainEvaledSubquery                = false
+	DefTiDBExplainAnalyzeTimeBreakdown             = false
 	DefTiDBReadStaleness                           = 0
 	DefTiDBGCMaxWaitTime                           = 24 * 60 * 60
 	DefMaxAllowedPacket                     uint64 = 67108864
 	DefTiDBEnableBatchDML                          = false
 	DefTiDBMemQuotaQuery                           = 1073741824 // 1GB
+	DefTiDBDiskQuotaQuery                          = 0
+	DefTiDBResultBatchSize                         = 0
 	DefTiDBStatsCacheMemQuota                      = 0
 	MaxTiDBStatsCacheMemQuota                      = 1024 * 1024 * 1024 * 1024 // 1TB
 	DefTiDBQueryLogMaxLen                          = 4096
@@ -1379,7 +1458,16 @@ const (
 	DefTiDBEnablePrepPlanCacheMemoryMonitor        = true
 	DefTiDBPrepPlanCacheMemoryGuardRatio           = 0.1
 	DefTiDBEnableDistTask                          = true
+	DefTiDBEnableDistAnalyze                       = false
+	DefTiDBEnableDistChecksum                      = false
+	DefTiDBDistTaskDispatchConcurrency             = 16
+	DefTiDBDistTaskCheckInterval                   = 500 * time.Millisecond
+	DefTiDBDistTaskSubtaskConcurrency              = 0
+	DefTiDBDistTaskHistoryRetention                = 14 * 24 * time.Hour
+	DefTiDBDistTaskMaxMetaSize                     = 4 << 20
+	DefTiDBEnableDistTaskMetaEncoding              = false
 	DefTiDBEnableFastCreateTable                   = false
+	DefTiDBClusterConfigCacheTTL                   = time.Duration(0)
 	DefTiDBSimplifiedMetrics                       = false
 	DefTiDBEnablePaging                            = true
 	DefTiFlashFineGrainedShuffleStreamCount        = 0
@@ -1418,6 +1506,9 @@ const (
 	DefTiDBServerMemoryLimitSessMinSize          = 128 << 20
 	DefTiDBMergePartitionStatsConcurrency        = 1
 	DefTiDBServerMemoryLimitGCTrigger            = 0.7
+	DefTiDBServerMemoryQueueWatermark            = 0.0
+	DefTiDBServerMemoryQueueCostThreshold        = 1e7
+	DefTiDBServerMemoryQueueTimeout              = 30 * time.Second
 	DefTiDBEnableGOGCTuner                       = true
 	// DefTiDBGOGCTunerThreshold is to limit TiDBGOGCTunerThreshold.
 	DefTiDBGOGCTunerThreshold                 float64 = 0.6
@@ -1449,9 +1540,11 @@ const (
 	DefTiDBTTLJobScheduleWindowEndTime                = "23:59 +0000"
 	DefTiDBTTLScanWorkerCount                         = 4
 	DefTiDBTTLDeleteWorkerCount                       = 4
+	DefTiDBTTLEnableDistTask                          = false
 	DefaultExchangeCompressionMode                    = kv.ExchangeCompressionModeUnspecified
 	DefTiDBEnableResourceControl                      = true
 	DefTiDBResourceControlStrictMode                  = true
+	DefTiDBStmtRUQuota                                = 0
 	DefTiDBPessimisticTransactionFairLocking          = false
 	DefTiDBEnablePlanCacheForParamLimit               = true
 	DefTiFlashComputeDispatchPolicy                   = tiflashcompute.DispatchPolicyConsistentHashStr
@@ -1493,6 +1586,12 @@ const (
 	DefTiDBDMLType                                    = "STANDARD"
 	DefGroupConcatMaxLen                              = uint64(1024)
 	DefDefaultWeekFormat                              = "0"
+	// DefTiDBEnablePlanCacheForUncorrelatedSubquery is the default value of TiDBEnablePlanCacheForUncorrelatedSubquery.
+	DefTiDBEnablePlanCacheForUncorrelatedSubquery = false
+	// DefTiDBEnableCardinalityFeedback is the default value of TiDBEnableCardinalityFeedback.
+	DefTiDBEnableCardinalityFeedback = false
+	// DefTiDBEvolvePlanTaskResourceGroup is the default value of TiDBEvolvePlanTaskResourceGroup.
+	DefTiDBEvolvePlanTaskResourceGroup = "default"
 )
 
 // Process global variables.
@@ -1538,7 +1637,19 @@ var (
 	// variables for plan cache
 	PreparedPlanCacheMemoryGuardRatio = atomic.NewFloat64(DefTiDBPrepPlanCacheMemoryGuardRatio)
 	EnableDistTask                    = atomic.NewBool(DefTiDBEnableDistTask)
+	EnableDistAnalyze                 = atomic.NewBool(DefTiDBEnableDistAnalyze)
+	EnableDistChecksum                = atomic.NewBool(DefTiDBEnableDistChecksum)
+	DistTaskDispatchConcurrency       = atomic.NewInt32(DefTiDBDistTaskDispatchConcurrency)
+	DistTaskCheckInterval             = atomic.NewDuration(DefTiDBDistTaskCheckInterval)
+	DistTaskSubtaskConcurrency        = atomic.NewInt32(DefTiDBDistTaskSubtaskConcurrency)
+	DistTaskHistoryRetention          = atomic.NewDuration(DefTiDBDistTaskHistoryRetention)
+	DistTaskMaxMetaSize               = atomic.NewInt64(DefTiDBDistTaskMaxMetaSize)
+	EnableDistTaskMetaEncoding        = atomic.NewBool(DefTiDBEnableDistTaskMetaEncoding)
 	EnableFastCreateTable             = atomic.NewBool(DefTiDBEnableFastCreateTable)
+	ClusterConfigCacheTTL             = atomic.NewDuration(DefTiDBClusterConfigCacheTTL)
+	ServerMemoryQueueWatermark        = atomic.NewFloat64(DefTiDBServerMemoryQueueWatermark)
+	ServerMemoryQueueCostThreshold    = atomic.NewFloat64(DefTiDBServerMemoryQueueCostThreshold)
+	ServerMemoryQueueTimeout          = atomic.NewDuration(DefTiDBServerMemoryQueueTimeout)
 	DDLForce2Queue                    = atomic.NewBool(false)
 	EnableNoopVariables               = atomic.NewBool(DefTiDBEnableNoopVariables)
 	EnableMDL                         = atomic.NewBool(false)
@@ -1580,6 +1691,7 @@ var (
 	)
 	TTLScanWorkerCount              = atomic.NewInt32(DefTiDBTTLScanWorkerCount)
 	TTLDeleteWorkerCount            = atomic.NewInt32(DefTiDBTTLDeleteWorkerCount)
+	TTLEnableDistTask               = atomic.NewBool(DefTiDBTTLEnableDistTask)
 	PasswordHistory                 = atomic.NewInt64(DefPasswordReuseHistory)
 	PasswordReuseInterval           = atomic.NewInt64(DefPasswordReuseTime)
 	IsSandBoxModeEnabled            = atomic.NewBool(false)
@@ -546,6 +546,7 @@ func (p *baseTxnContextProvider) SetOptionsBeforeCommit(
 
 	// set resource tagger again for internal tasks separated in different transactions
 	txn.SetOption(kv.ResourceGroupTagger, sessVars.StmtCtx.GetResourceGroupTagger())
+	txn.SetOption(kv.StmtRUQuota, sessVars.StmtRUQuota)
 
 	// Get the related table or partition IDs.
 	relatedPhysicalTables := sessVars.TxnCtx.TableDeltaMap
@@ -0,0 +1,154 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package copr
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/coprocessor"
+)
+
+const (
+	// adaptiveInitConcurrency is the concurrency that an adaptive copIterator starts with.
+	adaptiveInitConcurrency = 2
+	// adaptiveQueueWaitLow and adaptiveQueueWaitHigh are the thresholds of the time a cop task
+	// waits in the TiKV read pool. The concurrency is increased by one when the wait time is below
+	// adaptiveQueueWaitLow, and halved when it is above adaptiveQueueWaitHigh.
+	adaptiveQueueWaitLow  = 2 * time.Millisecond
+	adaptiveQueueWaitHigh = 20 * time.Millisecond
+	// maxConcurrencyHistory is the max number of concurrency changes kept for the runtime stats.
+	maxConcurrencyHistory = 16
+)
+
+// ConcurrencyChange records a change of the concurrency of an adaptive copIterator.
+type ConcurrencyChange struct {
+	// Elapsed is the duration since the copIterator is opened.
+	Elapsed     time.Duration
+	Concurrency int
+}
+
+// adaptiveConcurrency limits the number of cop tasks that the workers of a copIterator handle
+// at the same time. The limit starts from adaptiveInitConcurrency and ramps up to the concurrency
+// of the request while TiKV handles the tasks without queueing them, and backs off when the tasks
+// queue in the TiKV read pool, so a statement doesn't keep piling tasks on a busy TiKV.
+type adaptiveConcurrency struct {
+	maxConcurrency int
+	start          time.Time
+
+	mu struct {
+		sync.Mutex
+		cond    *sync.Cond
+		limit   int
+		running int
+		closed  bool
+		history []ConcurrencyChange
+	}
+}
+
+func newAdaptiveConcurrency(maxConcurrency int) *adaptiveConcurrency {
+	c := &adaptiveConcurrency{
+		maxConcurrency: maxConcurrency,
+		start:          time.Now(),
+	}
+	c.mu.cond = sync.NewCond(&c.mu.Mutex)
+	c.mu.limit = min(adaptiveInitConcurrency, maxConcurrency)
+	c.mu.history = []ConcurrencyChange{{Concurrency: c.mu.limit}}
+	return c
+}
+
+// acquire blocks until the worker is allowed to handle a task.
+// It returns false if the copIterator is closed.
+func (c *adaptiveConcurrency) acquire() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for !c.mu.closed && c.mu.running >= c.mu.limit {
+		c.mu.cond.Wait()
+	}
+	if c.mu.closed {
+		return false
+	}
+	c.mu.running++
+	return true
+}
+
+// release is called when the worker finishes a task.
+func (c *adaptiveConcurrency) release() {
+	c.mu.Lock()
+	c.mu.running--
+	c.mu.Unlock()
+	c.mu.cond.Signal()
+}
+
+// observe adjusts the concurrency according to the time the task of resp waited in the TiKV read pool.
+func (c *adaptiveConcurrency) observe(resp *coprocessor.Response) {
+	waitTime := copQueueWaitTime(resp)
+	c.mu.Lock()
+	limit := c.mu.limit
+	if waitTime > adaptiveQueueWaitHigh {
+		limit = max(limit/2, 1)
+	} else if waitTime < adaptiveQueueWaitLow {
+		limit = min(limit+1, c.maxConcurrency)
+	}
+	if limit == c.mu.limit {
+		c.mu.Unlock()
+		return
+	}
+	increased := limit > c.mu.limit
+	c.mu.limit = limit
+	if len(c.mu.history) == maxConcurrencyHistory {
+		// Keep the initial concurrency and the latest changes.
+		c.mu.history = append(c.mu.history[:1], c.mu.history[2:]...)
+	}
+	c.mu.history = append(c.mu.history, ConcurrencyChange{Elapsed: time.Since(c.start), Concurrency: limit})
+	c.mu.Unlock()
+	if increased {
+		c.mu.cond.Broadcast()
+	}
+}
+
+// close wakes up all the waiting workers, acquire always returns false after it.
+func (c *adaptiveConcurrency) close() {
+	c.mu.Lock()
+	c.mu.closed = true
+	c.mu.Unlock()
+	c.mu.cond.Broadcast()
+}
+
+// getHistory returns the changes of the concurrency.
+func (c *adaptiveConcurrency) getHistory() []ConcurrencyChange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	history := make([]ConcurrencyChange, len(c.mu.history))
+	copy(history, c.mu.history)
+	return history
+}
+
+// copQueueWaitTime returns the time the cop task waited in the TiKV read pool. The responses
+// without the time detail, such as the ones that hit the coprocessor cache, are taken as not queued.
+func copQueueWaitTime(resp *coprocessor.Response) time.Duration {
+	if detailV2 := resp.GetExecDetailsV2(); detailV2 != nil {
+		if timeDetail := detailV2.GetTimeDetailV2(); timeDetail != nil {
+			return time.Duration(timeDetail.GetWaitWallTimeNs())
+		}
+		if timeDetail := detailV2.GetTimeDetail(); timeDetail != nil {
+			return time.Duration(timeDetail.GetWaitWallTimeMs()) * time.Millisecond
+		}
+	}
+	if timeDetail := resp.GetExecDetails().GetTimeDetail(); timeDetail != nil {
+		return time.Duration(timeDetail.GetWaitWallTimeMs()) * time.Millisecond
+	}
+	return 0
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package copr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/coprocessor"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveConcurrency(t *testing.T) {
+	respWithWait := func(wait time.Duration) *coprocessor.Response {
+		return &coprocessor.Response{ExecDetailsV2: &kvrpcpb.ExecDetailsV2{
+			TimeDetailV2: &kvrpcpb.TimeDetailV2{WaitWallTimeNs: uint64(wait)},
+		}}
+	}
+	limit := func(c *adaptiveConcurrency) int {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.mu.limit
+	}
+
+	c := newAdaptiveConcurrency(4)
+	require.Equal(t, 2, limit(c))
+	require.True(t, c.acquire())
+	require.True(t, c.acquire())
+
+	// The third worker waits until the concurrency is ramped up.
+	acquired := make(chan bool)
+	go func() {
+		acquired <- c.acquire()
+	}()
+	select {
+	case <-acquired:
+		require.FailNow(t, "acquired over the concurrency limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+	c.observe(respWithWait(0))
+	require.Equal(t, 3, limit(c))
+	require.True(t, <-acquired)
+
+	// The concurrency doesn't exceed the max concurrency.
+	c.observe(&coprocessor.Response{})
+	c.observe(respWithWait(time.Millisecond))
+	require.Equal(t, 4, limit(c))
+	// The concurrency is kept when the wait time is moderate.
+	c.observe(respWithWait(10 * time.Millisecond))
+	require.Equal(t, 4, limit(c))
+	// The concurrency backs off when the tasks queue in TiKV.
+	c.observe(respWithWait(50 * time.Millisecond))
+	require.Equal(t, 2, limit(c))
+	c.observe(&coprocessor.Response{ExecDetails: &kvrpcpb.ExecDetails{
+		TimeDetail: &kvrpcpb.TimeDetail{WaitWallTimeMs: 100},
+	}})
+	c.observe(respWithWait(time.Second))
+	require.Equal(t, 1, limit(c))
+
+	history := c.getHistory()
+	concurrency := make([]int, 0, len(history))
+	for _, change := range history {
+		concurrency = append(concurrency, change.Concurrency)
+	}
+	require.Equal(t, []int{2, 3, 4, 2, 1}, concurrency)
+	require.Zero(t, history[0].Elapsed)
+
+	// The waiting workers exit when the iterator is closed.
+	for i := 0; i < 3; i++ {
+		c.release()
+	}
+	require.True(t, c.acquire())
+	go func() {
+		acquired <- c.acquire()
+	}()
+	c.close()
+	require.False(t, <-acquired)
+	require.False(t, c.acquire())
+
+	// Only the latest changes are kept besides the initial concurrency.
+	c = newAdaptiveConcurrency(100)
+	for i := 0; i < 2*maxConcurrencyHistory; i++ {
+		c.observe(respWithWait(0))
+	}
+	history = c.getHistory()
+	require.Len(t, history, maxConcurrencyHistory)
+	require.Equal(t, 2, history[0].Concurrency)
+	require.Equal(t, 2+2*maxConcurrencyHistory, history[maxConcurrencyHistory-1].Concurrency)
+}
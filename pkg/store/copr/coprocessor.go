@@ -667,6 +667,8 @@ type CopInfo interface {
 	GetStoreBatchInfo() (uint64, uint64)
 	// GetBuildTaskElapsed returns the duration of building task.
 	GetBuildTaskElapsed() time.Duration
+	// GetConcurrencyHistory returns the changes of the concurrency if it is adaptive.
+	GetConcurrencyHistory() []ConcurrencyChange
 }
 
 type copIterator struct {
@@ -713,6 +715,9 @@ type copIterator struct {
 
 	runawayChecker  *resourcegroup.RunawayChecker
 	unconsumedStats *unconsumedCopRuntimeStats
+
+	// adaptiveConcurrency is not nil if the concurrency is adapted to the queueing latency in TiKV.
+	adaptiveConcurrency *adaptiveConcurrency
 }
 
 // copIteratorWorker receives tasks from copIteratorTaskSender, handles tasks and sends the copResponse to respChan.
@@ -736,6 +741,7 @@ type copIteratorWorker struct {
 	storeBatchedNum         *atomic.Uint64
 	storeBatchedFallbackNum *atomic.Uint64
 	unconsumedStats         *unconsumedCopRuntimeStats
+	adaptiveConcurrency     *adaptiveConcurrency
 }
 
 // copIteratorTaskSender sends tasks to taskCh then wait for the workers to exit.
@@ -831,7 +837,15 @@ func (worker *copIteratorWorker) run(ctx context.Context) {
 		if respCh == nil {
 			respCh = task.respChan
 		}
+		if worker.adaptiveConcurrency != nil {
+			if !worker.adaptiveConcurrency.acquire() {
+				return
+			}
+		}
 		worker.handleTask(ctx, task, respCh)
+		if worker.adaptiveConcurrency != nil {
+			worker.adaptiveConcurrency.release()
+		}
 		if worker.respChan != nil {
 			// When a task is finished by the worker, send a finCopResp into channel to notify the copIterator that
 			// there is a task finished.
@@ -852,6 +866,14 @@ func (it *copIterator) open(ctx context.Context, enabledRateLimitAction, enableC
 	taskCh := make(chan *copTask, 1)
 	smallTaskCh := make(chan *copTask, 1)
 	it.unconsumedStats = &unconsumedCopRuntimeStats{}
+	// The keep order requests already run with a small concurrency, see BuildCopIterator.
+	if it.req.AdaptiveConcurrency && !it.req.KeepOrder && it.req.StoreType == kv.TiKV && it.concurrency > adaptiveInitConcurrency {
+		it.adaptiveConcurrency = newAdaptiveConcurrency(it.concurrency)
+		go func() {
+			<-it.finishCh
+			it.adaptiveConcurrency.close()
+		}()
+	}
 	it.wg.Add(it.concurrency + it.smallTaskConcurrency)
 	// Start it.concurrency number of workers to handle cop requests.
 	for i := 0; i < it.concurrency+it.smallTaskConcurrency; i++ {
@@ -878,6 +900,10 @@ func (it *copIterator) open(ctx context.Context, enabledRateLimitAction, enableC
 			storeBatchedFallbackNum:    &it.storeBatchedFallbackNum,
 			unconsumedStats:            it.unconsumedStats,
 		}
+		if i < it.concurrency {
+			// The workers for small tasks are not limited.
+			worker.adaptiveConcurrency = it.adaptiveConcurrency
+		}
 		go worker.run(ctx)
 	}
 	taskSender := &copIteratorTaskSender{
@@ -994,6 +1020,14 @@ func (it *copIterator) GetBuildTaskElapsed() time.Duration {
 	return it.buildTaskElapsed
 }
 
+// GetConcurrencyHistory returns the changes of the concurrency if it is adaptive.
+func (it *copIterator) GetConcurrencyHistory() []ConcurrencyChange {
+	if it.adaptiveConcurrency == nil {
+		return nil
+	}
+	return it.adaptiveConcurrency.getHistory()
+}
+
 // GetSendRate returns the rate-limit object.
 func (it *copIterator) GetSendRate() *util.RateLimit {
 	return it.sendRate
@@ -1257,6 +1291,9 @@ func (worker *copIteratorWorker) handleTaskOnce(bo *Backoffer, task *copTask, ch
 			return nil, err
 		}
 	}
+	if err := derr.CheckStmtRUQuota(bo.GetCtx(), worker.req.StmtRUQuota); err != nil {
+		return nil, err
+	}
 	req.StoreTp = getEndPointType(task.storeType)
 	startTime := time.Now()
 	if worker.kvclient.Stats == nil {
@@ -1493,6 +1530,9 @@ func (worker *copIteratorWorker) handleCopResponse(bo *Backoffer, rpcCtx *tikv.R
 	} else if task.ranges != nil && task.ranges.Len() > 0 {
 		resp.startKey = task.ranges.At(0).StartKey
 	}
+	if worker.adaptiveConcurrency != nil {
+		worker.adaptiveConcurrency.observe(resp.pbResp)
+	}
 	worker.handleCollectExecutionInfo(bo, rpcCtx, resp)
 	resp.respTime = costTime
 
@@ -15,6 +15,7 @@
 package error //nolint: predeclared
 
 import (
+	"context"
 	stderrs "errors"
 
 	"github.com/pingcap/errors"
@@ -25,6 +26,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/sqlkiller"
 	tikverr "github.com/tikv/client-go/v2/error"
+	"github.com/tikv/client-go/v2/util"
 	pderr "github.com/tikv/pd/client/errs"
 )
 
@@ -133,6 +135,10 @@ func ToTiDBErr(err error) error {
 		// connection id is unknown in client, which should be logged or filled by upper layers
 		return exeerrors.ErrMemoryExceedForInstance.GenWithStackByArgs(-1)
 	}
+	if stderrs.Is(err, tikverr.ErrQueryInterruptedWithSignal{Signal: sqlkiller.QueryDiskExceeded}) {
+		// connection id is unknown in client, which should be logged or filled by upper layers
+		return exeerrors.ErrDiskExceedForQuery.GenWithStackByArgs(-1)
+	}
 
 	if stderrs.Is(err, tikverr.ErrTiKVServerBusy) {
 		return ErrTiKVServerBusy
@@ -199,3 +205,21 @@ func ToTiDBErr(err error) error {
 
 	return errors.Trace(err)
 }
+
+// CheckStmtRUQuota returns an error if the RU consumed by the statement, which
+// is accumulated by the resource controller in the RUDetails of ctx, exceeds
+// the quota.
+func CheckStmtRUQuota(ctx context.Context, quota uint64) error {
+	if quota == 0 {
+		return nil
+	}
+	ruDetailsRaw := ctx.Value(util.RUDetailsCtxKey)
+	if ruDetailsRaw == nil {
+		return nil
+	}
+	ruDetails := ruDetailsRaw.(*util.RUDetails)
+	if ru := ruDetails.RRU() + ruDetails.WRU(); ru > float64(quota) {
+		return exeerrors.ErrStmtRUQuotaExceeded.GenWithStackByArgs(ru, quota)
+	}
+	return nil
+}
@@ -15,13 +15,16 @@
 package error //nolint: predeclared
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/testkit/testsetup"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/stretchr/testify/assert"
 	tikverr "github.com/tikv/client-go/v2/error"
+	"github.com/tikv/client-go/v2/util"
 	"go.uber.org/goleak"
 )
 
@@ -52,3 +55,14 @@ func TestConvertError(t *testing.T) {
 		assert.True(t, errors.ErrorEqual(tidbErr, terror.ErrResultUndetermined))
 	}
 }
+
+func TestCheckStmtRUQuota(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, CheckStmtRUQuota(ctx, 10))
+	ctx = context.WithValue(ctx, util.RUDetailsCtxKey, util.NewRUDetailsWith(6, 4, 0))
+	assert.NoError(t, CheckStmtRUQuota(ctx, 0))
+	assert.NoError(t, CheckStmtRUQuota(ctx, 10))
+	err := CheckStmtRUQuota(ctx, 9)
+	assert.True(t, exeerrors.ErrStmtRUQuotaExceeded.Equal(err))
+	assert.ErrorContains(t, err, "the statement consumed 10.00 RU which exceeds tidb_stmt_ru_quota(9)")
+}
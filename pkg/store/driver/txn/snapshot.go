@@ -34,16 +34,21 @@ type tikvSnapshot struct {
 	*txnsnapshot.KVSnapshot
 	// customRetrievers stores all custom retrievers, it is sorted
 	interceptor kv.SnapshotInterceptor
+	// stmtRUQuota is the max RU the statement can consume, see kv.StmtRUQuota.
+	stmtRUQuota uint64
 }
 
 // NewSnapshot creates a kv.Snapshot with txnsnapshot.KVSnapshot.
 func NewSnapshot(snapshot *txnsnapshot.KVSnapshot) kv.Snapshot {
-	return &tikvSnapshot{snapshot, nil}
+	return &tikvSnapshot{KVSnapshot: snapshot}
 }
 
 // BatchGet gets all the keys' value from kv-server and returns a map contains key/value pairs.
 // The map will not contain nonexistent keys.
 func (s *tikvSnapshot) BatchGet(ctx context.Context, keys []kv.Key) (map[string][]byte, error) {
+	if err := derr.CheckStmtRUQuota(ctx, s.stmtRUQuota); err != nil {
+		return nil, err
+	}
 	if s.interceptor != nil {
 		return s.interceptor.OnBatchGet(ctx, NewSnapshot(s.KVSnapshot), keys)
 	}
@@ -53,6 +58,9 @@ func (s *tikvSnapshot) BatchGet(ctx context.Context, keys []kv.Key) (map[string]
 
 // Get gets the value for key k from snapshot.
 func (s *tikvSnapshot) Get(ctx context.Context, k kv.Key) ([]byte, error) {
+	if err := derr.CheckStmtRUQuota(ctx, s.stmtRUQuota); err != nil {
+		return nil, err
+	}
 	if s.interceptor != nil {
 		return s.interceptor.OnGet(ctx, NewSnapshot(s.KVSnapshot), k)
 	}
@@ -96,6 +104,8 @@ func (s *tikvSnapshot) SetOption(opt int, val any) {
 		s.KVSnapshot.SetPriority(getTiKVPriority(val.(int)))
 	case kv.NotFillCache:
 		s.KVSnapshot.SetNotFillCache(val.(bool))
+	case kv.KeyOnly:
+		s.KVSnapshot.SetKeyOnly(val.(bool))
 	case kv.SnapshotTS:
 		s.KVSnapshot.SetSnapshotTS(val.(uint64))
 	case kv.ReplicaRead:
@@ -123,6 +133,8 @@ func (s *tikvSnapshot) SetOption(opt int, val any) {
 		s.KVSnapshot.SetReadReplicaScope(val.(string))
 	case kv.SnapInterceptor:
 		s.interceptor = val.(kv.SnapshotInterceptor)
+	case kv.StmtRUQuota:
+		s.stmtRUQuota = val.(uint64)
 	case kv.RPCInterceptor:
 		s.KVSnapshot.AddRPCInterceptor(val.(interceptor.RPCInterceptor))
 	case kv.RequestSourceInternal:
@@ -51,6 +51,8 @@ type tikvTxn struct {
 	columnMapsCache    any
 	isCommitterWorking atomic.Bool
 	memBuffer          *memBuffer
+	// stmtRUQuota is the max RU the statement can consume, see kv.StmtRUQuota.
+	stmtRUQuota uint64
 }
 
 // NewTiKVTxn returns a new Transaction.
@@ -63,8 +65,9 @@ func NewTiKVTxn(txn *tikv.KVTxn) kv.Transaction {
 	txn.GetUnionStore().SetEntrySizeLimit(entryLimit, totalLimit)
 
 	return &tikvTxn{
-		txn, make(map[int64]*model.TableInfo), nil, nil, atomic.Bool{},
-		newMemBuffer(txn.GetMemBuffer(), txn.IsPipelined()),
+		KVTxn:        txn,
+		idxNameCache: make(map[int64]*model.TableInfo),
+		memBuffer:    newMemBuffer(txn.GetMemBuffer(), txn.IsPipelined()),
 	}
 }
 
@@ -111,6 +114,9 @@ func (txn *tikvTxn) LockKeysFunc(ctx context.Context, lockCtx *kv.LockCtx, fn fu
 }
 
 func (txn *tikvTxn) Commit(ctx context.Context) error {
+	if err := derr.CheckStmtRUQuota(ctx, txn.stmtRUQuota); err != nil {
+		return err
+	}
 	if intest.InTest {
 		txn.isCommitterWorking.Store(true)
 	}
@@ -130,7 +136,11 @@ func (txn *tikvTxn) RollbackMemDBToCheckpoint(savepoint *tikv.MemDBCheckpoint) {
 
 // GetSnapshot returns the Snapshot binding to this transaction.
 func (txn *tikvTxn) GetSnapshot() kv.Snapshot {
-	return &tikvSnapshot{txn.KVTxn.GetSnapshot(), txn.snapshotInterceptor}
+	return &tikvSnapshot{
+		KVSnapshot:  txn.KVTxn.GetSnapshot(),
+		interceptor: txn.snapshotInterceptor,
+		stmtRUQuota: txn.stmtRUQuota,
+	}
 }
 
 // Iter creates an Iterator positioned on the first entry that k <= entry's key.
@@ -280,6 +290,8 @@ func (txn *tikvTxn) SetOption(opt int, val any) {
 		txn.KVTxn.SetKVFilter(val.(tikv.KVFilter))
 	case kv.SnapInterceptor:
 		txn.snapshotInterceptor = val.(kv.SnapshotInterceptor)
+	case kv.StmtRUQuota:
+		txn.stmtRUQuota = val.(uint64)
 	case kv.CommitTSUpperBoundCheck:
 		txn.KVTxn.SetCommitTSUpperBoundCheck(val.(func(commitTS uint64) bool))
 	case kv.RPCInterceptor:
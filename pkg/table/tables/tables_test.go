@@ -166,16 +166,11 @@ func TestBasic(t *testing.T) {
 }
 
 func countEntriesWithPrefix(ctx sessionctx.Context, prefix []byte) (int, error) {
-	cnt := 0
 	txn, err := ctx.Txn(true)
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
-	err = util.ScanMetaWithPrefix(txn, prefix, func(k kv.Key, v []byte) bool {
-		cnt++
-		return true
-	})
-	return cnt, err
+	return util.CountKeysWithPrefix(context.Background(), txn, prefix, 0)
 }
 
 func TestTypes(t *testing.T) {
@@ -0,0 +1,431 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/pingcap/tidb/pkg/ttl/metrics"
+	"github.com/pingcap/tidb/pkg/ttl/session"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// DistTaskMeta is the task meta of deleting expired rows of a physical table
+// on the distributed task framework.
+type DistTaskMeta struct {
+	Schema     string    `json:"schema"`
+	TableName  string    `json:"table_name"`
+	Partition  string    `json:"partition"`
+	TableID    int64     `json:"table_id"`
+	PhysicalID int64     `json:"physical_id"`
+	ExpireTime time.Time `json:"expire_time"`
+	// ScanRanges are encoded by codec.EncodeKey, each range is scanned and
+	// deleted in a separate subtask.
+	ScanRanges []DistScanRange `json:"scan_ranges"`
+}
+
+// DistScanRange is the subtask meta of proto.TTLStepDeleteExpired.
+type DistScanRange struct {
+	Start []byte `json:"start"`
+	End   []byte `json:"end"`
+}
+
+// DistTaskKey returns the task key of the TTL job.
+func DistTaskKey(jobID string) string {
+	return fmt.Sprintf("ttl/%s", jobID)
+}
+
+// SubmitDistTask splits the table into scan ranges, and submits a task to
+// delete the rows expired before expireTime.
+func SubmitDistTask(ctx context.Context, se session.Session, store kv.Storage,
+	tbl *cache.PhysicalTable, jobID string, expireTime time.Time) (*proto.Task, error) {
+	ranges, err := tbl.SplitScanRanges(ctx, store, splitScanCount)
+	if err != nil {
+		return nil, errors.Wrap(err, "split scan ranges")
+	}
+	taskMeta := &DistTaskMeta{
+		Schema:     tbl.Schema.O,
+		TableName:  tbl.Name.O,
+		Partition:  tbl.Partition.O,
+		TableID:    tbl.TableInfo.ID,
+		PhysicalID: tbl.ID,
+		ExpireTime: expireTime,
+		ScanRanges: make([]DistScanRange, 0, len(ranges)),
+	}
+	tz := se.GetSessionVars().StmtCtx.TimeZone()
+	for _, r := range ranges {
+		start, err := codec.EncodeKey(tz, []byte{}, r.Start...)
+		if err != nil {
+			return nil, errors.Wrap(err, "encode scan range")
+		}
+		end, err := codec.EncodeKey(tz, []byte{}, r.End...)
+		if err != nil {
+			return nil, errors.Wrap(err, "encode scan range")
+		}
+		taskMeta.ScanRanges = append(taskMeta.ScanRanges, DistScanRange{Start: start, End: end})
+	}
+	bs, err := json.Marshal(taskMeta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnTTL)
+	return handle.SubmitTask(ctx, DistTaskKey(jobID), proto.TTL, 1, "", bs)
+}
+
+func getDistTask(ctx context.Context, jobID string) (*proto.Task, error) {
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnTTL)
+	return taskManager.GetTaskByKeyWithHistory(ctx, DistTaskKey(jobID))
+}
+
+// distTaskExists returns whether the job runs on the distributed task framework.
+func distTaskExists(ctx context.Context, jobID string) (bool, error) {
+	_, err := getDistTask(ctx, jobID)
+	if err != nil {
+		if errors.ErrorEqual(err, storage.ErrTaskNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// summarizeDistTask summarizes the result of a finished task from its subtasks.
+func summarizeDistTask(ctx context.Context, task *proto.Task) (*TTLSummary, error) {
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnTTL)
+	subtasks, err := taskManager.GetSubtasksWithHistory(ctx, task.ID, proto.TTLStepDeleteExpired)
+	if err != nil {
+		return nil, err
+	}
+	summary := &TTLSummary{TotalScanTask: len(subtasks)}
+	for _, st := range subtasks {
+		if st.State != proto.SubtaskStatePending {
+			summary.ScheduledScanTask++
+		}
+		if st.State == proto.SubtaskStateSucceed {
+			summary.FinishedScanTask++
+		}
+		if st.Summary == "" {
+			continue
+		}
+		stSummary := struct {
+			RowCount uint64 `json:"row_count"`
+		}{}
+		if err := json.Unmarshal([]byte(st.Summary), &stSummary); err != nil {
+			return nil, errors.Trace(err)
+		}
+		// only the deleted rows are reported in the subtask summary.
+		summary.TotalRows += stSummary.RowCount
+		summary.SuccessRows += stSummary.RowCount
+	}
+	if task.Error != nil {
+		summary.ScanTaskErr = task.Error.Error()
+	} else if task.State != proto.TaskStateSucceed {
+		summary.ScanTaskErr = fmt.Sprintf("ttl dist task is %s", task.State)
+	}
+	buf, err := json.Marshal(summary)
+	if err != nil {
+		return nil, err
+	}
+	summary.SummaryText = string(buf)
+	return summary, nil
+}
+
+// RegisterDistTask registers the TTL task type to the distributed task framework.
+func RegisterDistTask(sessPool sessionPool) error {
+	if err := scheduler.RegisterSchedulerFactory(proto.TTL,
+		func(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+			sch := scheduler.NewBaseScheduler(ctx, task, param)
+			sch.Extension = &DistSchedulerExt{}
+			return sch
+		}); err != nil {
+		return err
+	}
+	taskexecutor.RegisterTaskType(proto.TTL,
+		func(ctx context.Context, id string, task *proto.Task, table taskexecutor.TaskTable) taskexecutor.TaskExecutor {
+			e := &distTaskExecutor{
+				BaseTaskExecutor: taskexecutor.NewBaseTaskExecutor(ctx, id, task, table),
+				sessPool:         sessPool,
+			}
+			e.BaseTaskExecutor.Extension = e
+			return e
+		})
+	return nil
+}
+
+// DistSchedulerExt is the scheduler extension of TTL, exported for test.
+type DistSchedulerExt struct{}
+
+var _ scheduler.Extension = (*DistSchedulerExt)(nil)
+
+// OnTick implements scheduler.Extension interface.
+func (*DistSchedulerExt) OnTick(context.Context, *proto.Task) {}
+
+// OnNextSubtasksBatch implements scheduler.Extension interface.
+func (*DistSchedulerExt) OnNextSubtasksBatch(
+	_ context.Context,
+	_ storage.TaskHandle,
+	task *proto.Task,
+	_ []string,
+	nextStep proto.Step,
+) ([][]byte, error) {
+	if nextStep != proto.TTLStepDeleteExpired {
+		return nil, errors.Errorf("unknown step %d for ttl task %d", nextStep, task.ID)
+	}
+	taskMeta := &DistTaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	metas := make([][]byte, 0, len(taskMeta.ScanRanges))
+	for _, r := range taskMeta.ScanRanges {
+		bs, err := json.Marshal(&r)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		metas = append(metas, bs)
+	}
+	return metas, nil
+}
+
+// OnDone implements scheduler.Extension interface.
+func (*DistSchedulerExt) OnDone(_ context.Context, _ storage.TaskHandle, task *proto.Task) error {
+	logutil.BgLogger().Info("ttl task done",
+		zap.Int64("task-id", task.ID),
+		zap.String("task-key", task.Key),
+		zap.Stringer("state", task.State),
+		zap.Error(task.Error))
+	return nil
+}
+
+// GetEligibleInstances implements scheduler.Extension interface.
+func (*DistSchedulerExt) GetEligibleInstances(context.Context, *proto.Task) ([]string, error) {
+	return nil, nil
+}
+
+// IsRetryableErr implements scheduler.Extension interface.
+func (*DistSchedulerExt) IsRetryableErr(error) bool {
+	return false
+}
+
+// GetNextStep implements scheduler.Extension interface.
+func (*DistSchedulerExt) GetNextStep(task *proto.TaskBase) proto.Step {
+	if task.Step == proto.StepInit {
+		return proto.TTLStepDeleteExpired
+	}
+	return proto.StepDone
+}
+
+type distTaskExecutor struct {
+	*taskexecutor.BaseTaskExecutor
+	sessPool sessionPool
+}
+
+func (*distTaskExecutor) IsIdempotent(*proto.Subtask) bool {
+	// rows are deleted only when they are still expired, so it's ok to
+	// delete the same range again.
+	return true
+}
+
+func (*distTaskExecutor) IsRetryableError(error) bool {
+	return false
+}
+
+func (e *distTaskExecutor) GetStepExecutor(task *proto.Task) (execute.StepExecutor, error) {
+	if task.Step != proto.TTLStepDeleteExpired {
+		return nil, errors.Errorf("unknown step %d for ttl task %d", task.Step, task.ID)
+	}
+	taskMeta := &DistTaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &deleteExpiredStepExecutor{
+		taskKey:    task.Key,
+		taskMeta:   taskMeta,
+		sessPool:   e.sessPool,
+		statistics: &ttlStatistics{},
+		logger: logutil.BgLogger().With(
+			zap.Stringer("type", proto.TTL),
+			zap.Int64("task-id", task.ID),
+			zap.String("step", proto.Step2Str(task.Type, task.Step)),
+		),
+	}, nil
+}
+
+// deleteExpiredStepExecutor scans the range of a subtask, and deletes the
+// expired rows with the same scan and delete logic of the ttl workers.
+type deleteExpiredStepExecutor struct {
+	taskexecutor.EmptyStepExecutor
+	taskKey    string
+	taskMeta   *DistTaskMeta
+	sessPool   sessionPool
+	logger     *zap.Logger
+	statistics *ttlStatistics
+}
+
+var _ execute.StepExecutor = &deleteExpiredStepExecutor{}
+
+func (e *deleteExpiredStepExecutor) getPhysicalTable(se session.Session) (*cache.PhysicalTable, error) {
+	m := e.taskMeta
+	tbl, err := se.SessionInfoSchema().TableByName(model.NewCIStr(m.Schema), model.NewCIStr(m.TableName))
+	if err != nil {
+		return nil, err
+	}
+	if tbl.Meta().ID != m.TableID {
+		return nil, errors.Errorf("table %s.%s is changed, expect id %d, got %d",
+			m.Schema, m.TableName, m.TableID, tbl.Meta().ID)
+	}
+	physicalTbl, err := cache.NewPhysicalTable(model.NewCIStr(m.Schema), tbl.Meta(), model.NewCIStr(m.Partition))
+	if err != nil {
+		return nil, err
+	}
+	if physicalTbl.ID != m.PhysicalID {
+		return nil, errors.Errorf("partition %s of table %s.%s is changed, expect id %d, got %d",
+			m.Partition, m.Schema, m.TableName, m.PhysicalID, physicalTbl.ID)
+	}
+	return physicalTbl, nil
+}
+
+func decodeScanRange(r DistScanRange) (start, end []types.Datum, err error) {
+	if len(r.Start) > 0 {
+		if start, err = codec.Decode(r.Start, len(r.Start)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(r.End) > 0 {
+		if end, err = codec.Decode(r.End, len(r.End)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return start, end, nil
+}
+
+func (e *deleteExpiredStepExecutor) RunSubtask(ctx context.Context, subtask *proto.Subtask) error {
+	scanRange := DistScanRange{}
+	if err := json.Unmarshal(subtask.Meta, &scanRange); err != nil {
+		return errors.Trace(err)
+	}
+	start, end, err := decodeScanRange(scanRange)
+	if err != nil {
+		return errors.Wrap(err, "decode scan range")
+	}
+	se, err := getSession(e.sessPool)
+	if err != nil {
+		return err
+	}
+	tbl, err := e.getPhysicalTable(se)
+	se.Close()
+	if err != nil {
+		return err
+	}
+
+	e.statistics.Reset()
+	scanTask := &ttlScanTask{
+		ctx: ctx,
+		TTLTask: &cache.TTLTask{
+			JobID:          e.taskKey,
+			TableID:        tbl.ID,
+			ScanID:         subtask.ID,
+			ScanRangeStart: start,
+			ScanRangeEnd:   end,
+			ExpireTime:     e.taskMeta.ExpireTime,
+		},
+		tbl:        tbl,
+		statistics: e.statistics,
+	}
+
+	delCh := make(chan *ttlDeleteTask)
+	delErrCh := make(chan error, 1)
+	go func() {
+		delErrCh <- e.deleteLoop(ctx, delCh)
+	}()
+	scanCtx := metrics.CtxWithPhaseTracer(ctx, metrics.NewScanWorkerPhaseTracer())
+	scanErr := scanTask.doScan(scanCtx, delCh, e.sessPool).err
+	close(delCh)
+	delErr := <-delErrCh
+
+	e.logger.Info("ttl subtask finished",
+		zap.Int64("subtask-id", subtask.ID),
+		zap.Stringer("statistics", e.statistics),
+		zap.Error(scanErr), zap.NamedError("delete-error", delErr))
+	if scanErr != nil {
+		return scanErr
+	}
+	return delErr
+}
+
+// deleteLoop deletes rows sent by the scan task, failed rows are retried
+// until delMaxRetry is reached.
+func (e *deleteExpiredStepExecutor) deleteLoop(ctx context.Context, delCh <-chan *ttlDeleteTask) error {
+	tracer := metrics.NewDeleteWorkerPhaseTracer()
+	defer tracer.EndPhase()
+	tracer.EnterPhase(metrics.PhaseOther)
+	se, err := getSession(e.sessPool)
+	if err != nil {
+		// drain the channel to unblock the scan task.
+		for range delCh {
+		}
+		return err
+	}
+	defer se.Close()
+
+	ctx = metrics.CtxWithPhaseTracer(ctx, tracer)
+	retryBuffer := newTTLDelRetryBuffer()
+	doRetry := func(task *ttlDeleteTask) [][]types.Datum {
+		return task.doDelete(ctx, se)
+	}
+	for task := range delCh {
+		retryBuffer.RecordTaskResult(task, task.doDelete(ctx, se))
+		retryBuffer.DoRetry(doRetry)
+	}
+	for retryBuffer.Len() > 0 {
+		interval := retryBuffer.DoRetry(doRetry)
+		if retryBuffer.Len() == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+	return nil
+}
+
+func (e *deleteExpiredStepExecutor) RealtimeSummary() *execute.SubtaskSummary {
+	return &execute.SubtaskSummary{RowCount: int64(e.statistics.SuccessRows.Load())}
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistSchedulerExt(t *testing.T) {
+	ext := &DistSchedulerExt{}
+	start, err := codec.EncodeKey(time.UTC, []byte{}, types.NewIntDatum(100))
+	require.NoError(t, err)
+	taskMeta := &DistTaskMeta{
+		Schema:     "test",
+		TableName:  "t",
+		TableID:    1,
+		PhysicalID: 1,
+		ExpireTime: time.Now(),
+		ScanRanges: []DistScanRange{{End: start}, {Start: start}},
+	}
+	bs, err := json.Marshal(taskMeta)
+	require.NoError(t, err)
+	task := &proto.Task{TaskBase: proto.TaskBase{ID: 1, Type: proto.TTL, Step: proto.StepInit}, Meta: bs}
+
+	require.Equal(t, proto.TTLStepDeleteExpired, ext.GetNextStep(&task.TaskBase))
+	metas, err := ext.OnNextSubtasksBatch(context.Background(), nil, task, nil, proto.TTLStepDeleteExpired)
+	require.NoError(t, err)
+	require.Len(t, metas, 2)
+
+	r := DistScanRange{}
+	require.NoError(t, json.Unmarshal(metas[0], &r))
+	s, e, err := decodeScanRange(r)
+	require.NoError(t, err)
+	require.Nil(t, s)
+	require.Len(t, e, 1)
+	require.Equal(t, int64(100), e[0].GetInt64())
+
+	require.NoError(t, json.Unmarshal(metas[1], &r))
+	s, e, err = decodeScanRange(r)
+	require.NoError(t, err)
+	require.Len(t, s, 1)
+	require.Equal(t, int64(100), s[0].GetInt64())
+	require.Nil(t, e)
+
+	task.Step = proto.TTLStepDeleteExpired
+	require.Equal(t, proto.StepDone, ext.GetNextStep(&task.TaskBase))
+	_, err = ext.OnNextSubtasksBatch(context.Background(), nil, task, nil, proto.StepDone)
+	require.ErrorContains(t, err, "unknown step")
+}
@@ -20,6 +20,8 @@ import (
 	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/ttl/cache"
 	"github.com/pingcap/tidb/pkg/ttl/session"
 	"github.com/pingcap/tidb/pkg/util/intest"
@@ -119,6 +121,9 @@ type ttlJob struct {
 	ttlExpireTime time.Time
 
 	tbl *cache.PhysicalTable
+	// distTask is true if the expired rows are deleted by a task on the
+	// distributed task framework instead of the scan tasks in tidb_ttl_task.
+	distTask bool
 
 	// status is the only field which should be protected by a mutex, as `Cancel` may be called at any time, and will
 	// change the status
@@ -129,6 +134,13 @@ type ttlJob struct {
 // finish turns current job into last job, and update the error message and statistics summary
 func (job *ttlJob) finish(se session.Session, now time.Time, summary *TTLSummary) {
 	intest.Assert(se.GetSessionVars().Location().String() == now.Location().String())
+	if job.distTask {
+		// it's a no-op if the task is already done.
+		ctx := kv.WithInternalSourceType(context.TODO(), kv.InternalTxnTTL)
+		if err := handle.CancelTask(ctx, DistTaskKey(job.id)); err != nil {
+			logutil.BgLogger().Warn("fail to cancel ttl dist task", zap.Error(err), zap.String("jobID", job.id))
+		}
+	}
 	// at this time, the job.ctx may have been canceled (to cancel this job)
 	// even when it's canceled, we'll need to update the states, so use another context
 	err := se.RunInTxn(context.TODO(), func() error {
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/terror"
@@ -526,6 +527,10 @@ func (m *JobManager) checkNotOwnJob() {
 func (m *JobManager) checkFinishedJob(se session.Session) {
 j:
 	for _, job := range m.runningJobs {
+		if job.distTask {
+			m.checkFinishedDistJob(se, job)
+			continue
+		}
 		timeoutJobCtx, cancel := context.WithTimeout(m.ctx, ttlInternalSQLTimeout)
 
 		sql, args := cache.SelectFromTTLTaskWithJobID(job.id)
@@ -564,6 +569,37 @@ j:
 	}
 }
 
+// checkFinishedDistJob finishes the job if its task on the distributed task
+// framework is done.
+func (m *JobManager) checkFinishedDistJob(se session.Session, job *ttlJob) {
+	ctx, cancel := context.WithTimeout(m.ctx, ttlInternalSQLTimeout)
+	defer cancel()
+	task, err := getDistTask(ctx, job.id)
+	if err != nil && !errors.ErrorEqual(err, storage.ErrTaskNotFound) {
+		logutil.Logger(m.ctx).Warn("fail to get ttl dist task", zap.String("jobID", job.id), zap.Error(err))
+		return
+	}
+
+	var summary *TTLSummary
+	if task == nil {
+		summary, err = summarizeErr(errors.New("ttl dist task is not found"))
+		if err != nil {
+			logutil.Logger(m.ctx).Info("fail to summarize job", zap.Error(err))
+		}
+	} else {
+		if !task.IsDone() {
+			return
+		}
+		if summary, err = summarizeDistTask(ctx, task); err != nil {
+			logutil.Logger(m.ctx).Warn("fail to summarize ttl dist task", zap.String("jobID", job.id), zap.Error(err))
+			return
+		}
+	}
+	logutil.Logger(m.ctx).Info("job has finished", zap.String("jobID", job.id))
+	m.removeJob(job)
+	job.finish(se, se.Now(), summary)
+}
+
 func (m *JobManager) rescheduleJobs(se session.Session, now time.Time) {
 	tz, err := se.GlobalTimeZone(m.ctx)
 	if err != nil {
@@ -731,12 +767,22 @@ func (m *JobManager) lockHBTimeoutJob(ctx context.Context, se session.Session, t
 		return nil, err
 	}
 
-	return m.appendLockedJob(jobID, se, jobStart, expireTime, table)
+	job, err := m.appendLockedJob(jobID, se, jobStart, expireTime, table)
+	if err != nil {
+		return nil, err
+	}
+	// the job may be created by another node on the distributed task framework.
+	job.distTask, err = distTaskExists(ctx, jobID)
+	if err != nil {
+		logutil.Logger(m.ctx).Warn("fail to check the ttl dist task", zap.String("jobID", jobID), zap.Error(err))
+	}
+	return job, nil
 }
 
 // lockNewJob locks a new job
 func (m *JobManager) lockNewJob(ctx context.Context, se session.Session, table *cache.PhysicalTable, now time.Time, jobID string, checkScheduleInterval bool) (*ttlJob, error) {
 	var expireTime time.Time
+	onDistTask := variable.TTLEnableDistTask.Load() && variable.EnableDistTask.Load()
 	err := se.RunInTxn(ctx, func() error {
 		tableStatus, err := m.getTableStatusForUpdateNotWait(ctx, se, table.ID, table.TableInfo.ID, true)
 		if err != nil {
@@ -767,6 +813,12 @@ func (m *JobManager) lockNewJob(ctx context.Context, se session.Session, table *
 			return errors.Wrapf(err, "execute sql: %s", sql)
 		}
 
+		if onDistTask {
+			// the scan ranges are split and submitted with the task after
+			// the job is locked.
+			return nil
+		}
+
 		ranges, err := table.SplitScanRanges(ctx, m.store, splitScanCount)
 		if err != nil {
 			return errors.Wrap(err, "split scan ranges")
@@ -788,7 +840,22 @@ func (m *JobManager) lockNewJob(ctx context.Context, se session.Session, table *
 		return nil, err
 	}
 
-	return m.appendLockedJob(jobID, se, now, expireTime, table)
+	job, err := m.appendLockedJob(jobID, se, now, expireTime, table)
+	if err != nil || !onDistTask {
+		return job, err
+	}
+	job.distTask = true
+	if _, err = SubmitDistTask(ctx, se, m.store, table, jobID, expireTime); err != nil {
+		logutil.Logger(m.ctx).Warn("fail to submit ttl dist task", zap.String("jobID", jobID), zap.Error(err))
+		summary, summaryErr := summarizeErr(errors.Wrap(err, "submit dist task"))
+		if summaryErr != nil {
+			logutil.Logger(m.ctx).Info("fail to summarize job", zap.Error(summaryErr))
+		}
+		m.removeJob(job)
+		job.finish(se, now, summary)
+		return nil, err
+	}
+	return job, nil
 }
 
 func (m *JobManager) getTableStatusForUpdateNotWait(ctx context.Context, se session.Session, physicalID int64, parentTableID int64, createIfNotExist bool) (*cache.TableStatus, error) {
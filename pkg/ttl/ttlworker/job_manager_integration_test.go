@@ -94,13 +94,13 @@ func TestGetSession(t *testing.T) {
 	require.Equal(t, "Europe/Berlin", tz.String())
 
 	// session variables should be set
-	tk.MustQuery("select @@time_zone, @@tidb_retry_limit, @@tidb_enable_1pc, @@tidb_enable_async_commit").
-		Check(testkit.Rows("UTC 0 1 1"))
+	tk.MustQuery("select @@time_zone, @@tidb_retry_limit, @@tidb_enable_1pc, @@tidb_enable_async_commit, @@tidb_request_source_type").
+		Check(testkit.Rows("UTC 0 1 1 background"))
 
 	// all session variables should be restored after close
 	se.Close()
-	tk.MustQuery("select @@time_zone, @@tidb_retry_limit, @@tidb_enable_1pc, @@tidb_enable_async_commit").
-		Check(testkit.Rows("Asia/Shanghai 1 0 0"))
+	tk.MustQuery("select @@time_zone, @@tidb_retry_limit, @@tidb_enable_1pc, @@tidb_enable_async_commit, @@tidb_request_source_type").
+		Check(testkit.Rows("Asia/Shanghai 1 0 0 "))
 }
 
 func TestParallelLockNewJob(t *testing.T) {
@@ -319,6 +319,51 @@ func TestTriggerTTLJob(t *testing.T) {
 	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("2", "4"))
 }
 
+func TestTriggerTTLJobOnDistTask(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Minute)
+	defer cancel()
+
+	store, do := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_ttl_enable_dist_task = on")
+	defer tk.MustExec("set @@global.tidb_ttl_enable_dist_task = default")
+	tk.MustExec("create table t(id int primary key, t timestamp) TTL=`t` + INTERVAL 1 DAY")
+	tbl, err := do.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblID := tbl.Meta().ID
+
+	timerStore := timertable.NewTableTimerStore(0, do.SysSessionPool(), "mysql", "tidb_timers", nil)
+	defer timerStore.Close()
+	timerCli := timerapi.NewDefaultTimerClient(timerStore)
+
+	// make sure the table had run a job one time to make the test stable
+	cli := do.TTLJobManager().GetCommandCli()
+	_, _ = client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	waitTTLJobFinished(t, tk, tblID, timerCli)
+
+	now := time.Now()
+	nowDateStr := now.Format("2006-01-02 15:04:05.999999")
+	expreDateStr := now.Add(-time.Hour * 25).Format("2006-01-02 15:04:05.999999")
+	tk.MustExec("insert into t values(1, ?), (2, ?), (3, ?), (4, ?)", expreDateStr, nowDateStr, expreDateStr, nowDateStr)
+
+	res, err := client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.TableResult))
+	jobID := res.TableResult[0].JobID
+	require.Equal(t, "", res.TableResult[0].ErrorMessage)
+
+	waitTTLJobFinished(t, tk, tblID, timerCli)
+	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("2", "4"))
+	// the rows are deleted by the task on the distributed task framework,
+	// rather than the scan tasks in tidb_ttl_task.
+	tk.MustQuery("select state from (select task_key, state from mysql.tidb_global_task union "+
+		"select task_key, state from mysql.tidb_global_task_history) t where task_key = ?",
+		ttlworker.DistTaskKey(jobID)).Check(testkit.Rows("succeed"))
+	tk.MustQuery("select status, deleted_rows from mysql.tidb_ttl_job_history where job_id = ?", jobID).
+		Check(testkit.Rows("finished 2"))
+}
+
 func TestTTLDeleteWithTimeZoneChange(t *testing.T) {
 	failpoint.Enable("github.com/pingcap/tidb/pkg/ttl/ttlworker/task-manager-loop-interval", fmt.Sprintf("return(%d)", time.Second))
 	defer failpoint.Disable("github.com/pingcap/tidb/pkg/ttl/ttlworker/task-manager-loop-interval")
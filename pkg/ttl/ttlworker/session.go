@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	kvutil "github.com/tikv/client-go/v2/util"
 	"go.uber.org/zap"
 )
 
@@ -81,6 +82,7 @@ func getSession(pool sessionPool) (session.Session, error) {
 	originalRetryLimit := sctx.GetSessionVars().RetryLimit
 	originalEnable1PC := sctx.GetSessionVars().Enable1PC
 	originalEnableAsyncCommit := sctx.GetSessionVars().EnableAsyncCommit
+	originalRequestSourceType := sctx.GetSessionVars().ExplicitRequestSourceType
 	originalTimeZone, restoreTimeZone := "", false
 
 	se := session.NewSession(sctx, exec, func(se session.Session) {
@@ -102,6 +104,10 @@ func getSession(pool sessionPool) (session.Session, error) {
 			terror.Log(err)
 		}
 
+		_, err = se.ExecuteSQL(context.Background(), "set tidb_request_source_type=%?", originalRequestSourceType)
+		intest.AssertNoError(err)
+		terror.Log(err)
+
 		if restoreTimeZone {
 			_, err = se.ExecuteSQL(context.Background(), "set @@time_zone=%?", originalTimeZone)
 			intest.AssertNoError(err)
@@ -136,6 +142,13 @@ func getSession(pool sessionPool) (session.Session, error) {
 		return nil, err
 	}
 
+	// mark the requests as background tasks, so they can be throttled by the resource group with background settings
+	_, err = se.ExecuteSQL(context.Background(), "set tidb_request_source_type=%?", kvutil.ExplicitTypeBackground)
+	if err != nil {
+		se.Close()
+		return nil, err
+	}
+
 	// Force rollback the session to guarantee the session is not in any explicit transaction
 	if _, err = se.ExecuteSQL(context.Background(), "ROLLBACK"); err != nil {
 		se.Close()
@@ -159,7 +159,10 @@ func (c *RowContainer) spillToDisk(preSpillError error) {
 	c.m.records.inDisk.diskTracker.AttachTo(c.diskTracker)
 	defer func() {
 		if r := recover(); r != nil {
-			err := fmt.Errorf("%v", r)
+			err, ok := r.(error)
+			if !ok {
+				err = fmt.Errorf("%v", r)
+			}
 			c.m.records.spillError = err
 			logutil.BgLogger().Error("spill to disk failed", zap.Stack("stack"), zap.Error(err))
 		}
@@ -53,6 +53,8 @@ var (
 	ErrMaxExecTimeExceeded                  = dbterror.ClassExecutor.NewStd(mysql.ErrMaxExecTimeExceeded)
 	ErrResourceGroupQueryRunawayInterrupted = dbterror.ClassExecutor.NewStd(mysql.ErrResourceGroupQueryRunawayInterrupted)
 	ErrResourceGroupQueryRunawayQuarantine  = dbterror.ClassExecutor.NewStd(mysql.ErrResourceGroupQueryRunawayQuarantine)
+	ErrResourceGroupAdmissionRejected       = dbterror.ClassExecutor.NewStd(mysql.ErrResourceGroupAdmissionRejected)
+	ErrStmtRUQuotaExceeded                  = dbterror.ClassExecutor.NewStd(mysql.ErrStmtRUQuotaExceeded)
 	ErrDynamicPrivilegeNotRegistered        = dbterror.ClassExecutor.NewStd(mysql.ErrDynamicPrivilegeNotRegistered)
 	ErrIllegalPrivilegeLevel                = dbterror.ClassExecutor.NewStd(mysql.ErrIllegalPrivilegeLevel)
 	ErrInvalidSplitRegionRanges             = dbterror.ClassExecutor.NewStd(mysql.ErrInvalidSplitRegionRanges)
@@ -62,6 +64,9 @@ var (
 	ErrLazyUniquenessCheckFailure           = dbterror.ClassExecutor.NewStd(mysql.ErrLazyUniquenessCheckFailure)
 	ErrMemoryExceedForQuery                 = dbterror.ClassExecutor.NewStd(mysql.ErrMemoryExceedForQuery)
 	ErrMemoryExceedForInstance              = dbterror.ClassExecutor.NewStd(mysql.ErrMemoryExceedForInstance)
+	ErrStmtMemoryQueueTimeout               = dbterror.ClassExecutor.NewStd(mysql.ErrStmtMemoryQueueTimeout)
+	ErrDiskExceedForQuery                   = dbterror.ClassExecutor.NewStd(mysql.ErrDiskExceedForQuery)
+	ErrHashAggPartialResult                 = dbterror.ClassExecutor.NewStd(mysql.ErrHashAggPartialResult)
 
 	ErrBRIEBackupFailed               = dbterror.ClassExecutor.NewStd(mysql.ErrBRIEBackupFailed)
 	ErrBRIERestoreFailed              = dbterror.ClassExecutor.NewStd(mysql.ErrBRIERestoreFailed)
@@ -99,5 +104,6 @@ var (
 	ErrLoadDataJobNotFound            = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataJobNotFound)
 	ErrLoadDataInvalidOperation       = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataInvalidOperation)
 	ErrLoadDataLocalUnsupportedOption = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataLocalUnsupportedOption)
+	ErrFileExists                     = dbterror.ClassExecutor.NewStd(mysql.ErrFileExists)
 	ErrLoadDataPreCheckFailed         = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataPreCheckFailed)
 )
@@ -15,7 +15,12 @@
 package disk
 
 import (
+	"sync"
+
+	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+	"go.uber.org/zap"
 )
 
 // Tracker is used to track the disk usage during query execution.
@@ -28,3 +33,31 @@ var NewTracker = memory.NewTracker
 
 // NewGlobalTrcaker creates a global disk tracker.
 var NewGlobalTrcaker = memory.NewGlobalTracker
+
+// PanicOnExceed panics when the disk usage of a query exceeds tidb_disk_quota_query.
+type PanicOnExceed struct {
+	memory.BaseOOMAction
+	Killer *sqlkiller.SQLKiller
+	mutex  sync.Mutex // For synchronization.
+	acted  bool
+}
+
+// Action panics when disk usage exceeds disk quota.
+func (a *PanicOnExceed) Action(t *Tracker) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if !a.acted {
+		logutil.BgLogger().Warn("disk exceeds quota",
+			zap.Uint64("conn", a.Killer.ConnID), zap.Int64("consumed", t.BytesConsumed()), zap.Int64("quota", t.GetBytesLimit()))
+	}
+	a.acted = true
+	a.Killer.SendKillSignal(sqlkiller.QueryDiskExceeded)
+	if err := a.Killer.HandleSignal(); err != nil {
+		panic(err)
+	}
+}
+
+// GetPriority get the priority of the Action
+func (*PanicOnExceed) GetPriority() int64 {
+	return memory.DefPanicPriority
+}
@@ -1239,6 +1239,75 @@ func (e *RootRuntimeStats) String() string {
 	return strings.Join(strs, ", ")
 }
 
+// StorageTime returns the sum of the StorageTime of the runtime stats which
+// implement StorageTimeStats.
+func (e *RootRuntimeStats) StorageTime() StorageTime {
+	var st StorageTime
+	for _, group := range e.groupRss {
+		if s, ok := group.(StorageTimeStats); ok {
+			st.Merge(s.StorageTime())
+		}
+	}
+	return st
+}
+
+// StorageTimeStats is implemented by the runtime stats of the executors which
+// send requests to the storage.
+type StorageTimeStats interface {
+	StorageTime() StorageTime
+}
+
+// StorageTime is the time spent by the requests sent to the storage.
+type StorageTime struct {
+	// RPC is the total wall time of the requests observed by TiDB.
+	RPC time.Duration
+	// Process and Wait are the total time spent to process and to wait (mostly
+	// queueing) in the storage.
+	Process time.Duration
+	Wait    time.Duration
+	// Blocked is the estimated wall time TiDB is blocked by the requests, it's
+	// the RPC time divided by the concurrency of the requests.
+	Blocked time.Duration
+}
+
+// Merge merges other into st.
+func (st *StorageTime) Merge(other StorageTime) {
+	st.RPC += other.RPC
+	st.Process += other.Process
+	st.Wait += other.Wait
+	st.Blocked += other.Blocked
+}
+
+// TimeBreakdown splits the time of an operator subtree into the time spent in
+// TiDB, in the storage and in between. The storage parts are summed up over
+// all the requests, so they may exceed the wall time if the requests are sent
+// concurrently.
+type TimeBreakdown struct {
+	TiDBCPU time.Duration
+	TiKVCPU time.Duration
+	Network time.Duration
+	Queue   time.Duration
+}
+
+// NewTimeBreakdown returns the TimeBreakdown of an operator subtree whose
+// wall time is wall and the requests sent to the storage take st. The TiDB
+// part is the wall time which isn't blocked by the storage, and the network
+// part is the RPC time which isn't spent in the storage.
+func NewTimeBreakdown(wall time.Duration, st StorageTime) TimeBreakdown {
+	return TimeBreakdown{
+		TiDBCPU: max(wall-st.Blocked, 0),
+		TiKVCPU: st.Process,
+		Network: max(st.RPC-st.Process-st.Wait, 0),
+		Queue:   st.Wait,
+	}
+}
+
+// String implements the fmt.Stringer interface.
+func (b TimeBreakdown) String() string {
+	return fmt.Sprintf("time_breakdown:{tidb_cpu:%v, tikv_cpu:%v, network:%v, queue:%v}",
+		FormatDuration(b.TiDBCPU), FormatDuration(b.TiKVCPU), FormatDuration(b.Network), FormatDuration(b.Queue))
+}
+
 // Record records executor's execution.
 func (e *BasicRuntimeStats) Record(d time.Duration, rowNum int) {
 	e.loop.Add(1)
@@ -418,6 +418,60 @@ func TestRootRuntimeStats(t *testing.T) {
 	require.Equal(t, expect, stats.String())
 }
 
+type mockStorageTimeStats struct {
+	BasicRuntimeStats
+	st StorageTime
+}
+
+func (s *mockStorageTimeStats) StorageTime() StorageTime {
+	return s.st
+}
+
+func (*mockStorageTimeStats) Tp() int {
+	return TpSelectResultRuntimeStats
+}
+
+func (s *mockStorageTimeStats) Clone() RuntimeStats {
+	return &mockStorageTimeStats{st: s.st}
+}
+
+func (s *mockStorageTimeStats) Merge(other RuntimeStats) {
+	s.st.Merge(other.(*mockStorageTimeStats).st)
+}
+
+func TestTimeBreakdown(t *testing.T) {
+	pid := 1
+	stmtStats := NewRuntimeStatsColl(nil)
+	stmtStats.RegisterStats(pid, &RuntimeStatsWithConcurrencyInfo{})
+	stmtStats.RegisterStats(pid, &mockStorageTimeStats{st: StorageTime{
+		RPC:     40 * time.Millisecond,
+		Process: 20 * time.Millisecond,
+		Wait:    5 * time.Millisecond,
+		Blocked: 10 * time.Millisecond,
+	}})
+	st := stmtStats.GetRootStats(pid).StorageTime()
+	st.Merge(StorageTime{RPC: time.Millisecond, Blocked: time.Millisecond})
+	require.Equal(t, StorageTime{
+		RPC:     41 * time.Millisecond,
+		Process: 20 * time.Millisecond,
+		Wait:    5 * time.Millisecond,
+		Blocked: 11 * time.Millisecond,
+	}, st)
+
+	breakdown := NewTimeBreakdown(30*time.Millisecond, st)
+	require.Equal(t, TimeBreakdown{
+		TiDBCPU: 19 * time.Millisecond,
+		TiKVCPU: 20 * time.Millisecond,
+		Network: 16 * time.Millisecond,
+		Queue:   5 * time.Millisecond,
+	}, breakdown)
+	require.Equal(t, "time_breakdown:{tidb_cpu:19ms, tikv_cpu:20ms, network:16ms, queue:5ms}", breakdown.String())
+
+	// the parts are never negative.
+	breakdown = NewTimeBreakdown(time.Millisecond, StorageTime{RPC: time.Millisecond, Process: 2 * time.Millisecond, Blocked: 2 * time.Millisecond})
+	require.Equal(t, TimeBreakdown{TiKVCPU: 2 * time.Millisecond}, breakdown)
+}
+
 func TestFormatDurationForExplain(t *testing.T) {
 	cases := []struct {
 		t string
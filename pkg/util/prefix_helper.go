@@ -20,13 +20,52 @@ package util
 
 import (
 	"bytes"
+	"context"
+	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
 )
 
+// SlowPrefixOpThreshold is the slow-prefix-op-threshold config item in
+// milliseconds, it's set when the config is loaded.
+var SlowPrefixOpThreshold = atomic.NewUint64(config.DefSlowPrefixOpThreshold)
+
+// observePrefixOp records the metrics of scanning or deleting keys with the
+// prefix, and logs it if it's slower than SlowPrefixOpThreshold, to help
+// diagnose runaway scans.
+func observePrefixOp(op string, prefix kv.Key, start time.Time, keys int) {
+	elapsed := time.Since(start)
+	metrics.PrefixHelperHistogram.WithLabelValues(op).Observe(elapsed.Seconds())
+	metrics.PrefixHelperKeysCounter.WithLabelValues(op).Add(float64(keys))
+	threshold := SlowPrefixOpThreshold.Load()
+	if threshold > 0 && elapsed > time.Duration(threshold)*time.Millisecond {
+		logutil.BgLogger().Warn("slow prefix operation",
+			zap.String("type", op),
+			zap.Stringer("prefix", prefix),
+			zap.Int("keys", keys),
+			zap.Duration("elapsed", elapsed))
+	}
+}
+
 // ScanMetaWithPrefix scans metadata with the prefix.
 func ScanMetaWithPrefix(retriever kv.Retriever, prefix kv.Key, filter func(kv.Key, []byte) bool) error {
+	return ScanMetaWithPrefixWithCtx(context.Background(), retriever, prefix, filter)
+}
+
+// ScanMetaWithPrefixWithCtx is like ScanMetaWithPrefix, but it checks ctx
+// between iterations and returns the error of ctx once it's done, so long
+// scans can be aborted.
+func ScanMetaWithPrefixWithCtx(ctx context.Context, retriever kv.Retriever, prefix kv.Key, filter func(kv.Key, []byte) bool) error {
+	start, scanned := time.Now(), 0
+	defer func() {
+		observePrefixOp(metrics.PrefixScan, prefix, start, scanned)
+	}()
 	iter, err := retriever.Iter(prefix, prefix.PrefixNext())
 	if err != nil {
 		return errors.Trace(err)
@@ -34,9 +73,13 @@ func ScanMetaWithPrefix(retriever kv.Retriever, prefix kv.Key, filter func(kv.Ke
 	defer iter.Close()
 
 	for {
+		if err = ctx.Err(); err != nil {
+			return errors.Trace(err)
+		}
 		if !(iter.Valid() && iter.Key().HasPrefix(prefix)) {
 			break
 		}
+		scanned++
 		if !filter(iter.Key(), iter.Value()) {
 			break
 		}
@@ -49,8 +92,24 @@ func ScanMetaWithPrefix(retriever kv.Retriever, prefix kv.Key, filter func(kv.Ke
 	return nil
 }
 
+// CountKeysWithPrefix counts the keys with the prefix. If limit is positive,
+// it stops counting once limit keys are found, which is enough to check
+// whether there are too many keys.
+func CountKeysWithPrefix(ctx context.Context, retriever kv.Retriever, prefix kv.Key, limit int) (int, error) {
+	cnt := 0
+	err := ScanMetaWithPrefixWithCtx(ctx, retriever, prefix, func(kv.Key, []byte) bool {
+		cnt++
+		return limit <= 0 || cnt < limit
+	})
+	return cnt, err
+}
+
 // DelKeyWithPrefix deletes keys with prefix.
 func DelKeyWithPrefix(rm kv.RetrieverMutator, prefix kv.Key) error {
+	start, deleted := time.Now(), 0
+	defer func() {
+		observePrefixOp(metrics.PrefixDelete, prefix, start, deleted)
+	}()
 	var keys []kv.Key
 	iter, err := rm.Iter(prefix, prefix.PrefixNext())
 	if err != nil {
@@ -74,6 +133,7 @@ func DelKeyWithPrefix(rm kv.RetrieverMutator, prefix kv.Key) error {
 		if err != nil {
 			return errors.Trace(err)
 		}
+		deleted++
 	}
 
 	return nil
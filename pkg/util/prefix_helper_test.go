@@ -18,13 +18,19 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/store/mockstore"
 	"github.com/pingcap/tidb/pkg/util"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 const (
@@ -73,6 +79,137 @@ func TestPrefix(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPrefixWithCtx(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, txn.Rollback())
+	}()
+	prefix := kv.Key("prefix")
+	for i := 0; i < 10; i++ {
+		require.NoError(t, txn.Set(append(prefix.Clone(), byte(i)), []byte("v")))
+	}
+
+	// cancel the scan in the middle.
+	ctx, cancel := context.WithCancel(context.Background())
+	cnt := 0
+	err = util.ScanMetaWithPrefixWithCtx(ctx, txn, prefix, func(kv.Key, []byte) bool {
+		cnt++
+		if cnt == 3 {
+			cancel()
+		}
+		return true
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 3, cnt)
+
+	cnt, err = util.CountKeysWithPrefix(context.Background(), txn, prefix, 0)
+	require.NoError(t, err)
+	require.Equal(t, 10, cnt)
+	cnt, err = util.CountKeysWithPrefix(context.Background(), txn, prefix, 4)
+	require.NoError(t, err)
+	require.Equal(t, 4, cnt)
+	cnt, err = util.CountKeysWithPrefix(context.Background(), txn, prefix, 20)
+	require.NoError(t, err)
+	require.Equal(t, 10, cnt)
+}
+
+func TestPrefixMetrics(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, txn.Rollback())
+	}()
+	prefix := kv.Key("prefix")
+	for i := 0; i < 10; i++ {
+		require.NoError(t, txn.Set(append(prefix.Clone(), byte(i)), []byte("v")))
+	}
+
+	getKeys := func(op string) float64 {
+		pb := &dto.Metric{}
+		require.NoError(t, metrics.PrefixHelperKeysCounter.WithLabelValues(op).Write(pb))
+		return pb.GetCounter().GetValue()
+	}
+	scanned, deleted := getKeys(metrics.PrefixScan), getKeys(metrics.PrefixDelete)
+	_, err = util.CountKeysWithPrefix(context.Background(), txn, prefix, 3)
+	require.NoError(t, err)
+	require.NoError(t, util.DelKeyWithPrefix(txn, prefix))
+	require.Equal(t, scanned+3, getKeys(metrics.PrefixScan))
+	require.Equal(t, deleted+10, getKeys(metrics.PrefixDelete))
+}
+
+// slowRetriever is a kv.Retriever whose iterators take 1ms to move to the
+// next key.
+type slowRetriever struct {
+	kv.Retriever
+}
+
+func (r slowRetriever) Iter(k kv.Key, upperBound kv.Key) (kv.Iterator, error) {
+	iter, err := r.Retriever.Iter(k, upperBound)
+	return slowIterator{iter}, err
+}
+
+type slowIterator struct {
+	kv.Iterator
+}
+
+func (it slowIterator) Next() error {
+	time.Sleep(time.Millisecond)
+	return it.Iterator.Next()
+}
+
+func TestSlowPrefixOpLog(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, txn.Rollback())
+	}()
+	prefix := kv.Key("prefix")
+	for i := 0; i < 10; i++ {
+		require.NoError(t, txn.Set(append(prefix.Clone(), byte(i)), []byte("v")))
+	}
+
+	core, logs := observer.New(zap.WarnLevel)
+	restore := log.ReplaceGlobals(zap.New(core), nil)
+	defer restore()
+	threshold := util.SlowPrefixOpThreshold.Load()
+	defer util.SlowPrefixOpThreshold.Store(threshold)
+	scanSlowly := func() {
+		_, err := util.CountKeysWithPrefix(context.Background(), slowRetriever{txn}, prefix, 0)
+		require.NoError(t, err)
+	}
+
+	// the scan is slower than the threshold.
+	util.SlowPrefixOpThreshold.Store(1)
+	scanSlowly()
+	entries := logs.FilterMessage("slow prefix operation").AllUntimed()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	require.Equal(t, metrics.PrefixScan, fields["type"])
+	require.Equal(t, prefix.String(), fields["prefix"])
+	require.Equal(t, int64(10), fields["keys"])
+
+	// the log is disabled.
+	util.SlowPrefixOpThreshold.Store(0)
+	scanSlowly()
+	require.Equal(t, 1, logs.FilterMessage("slow prefix operation").Len())
+}
+
 func TestPrefixFilter(t *testing.T) {
 	rowKey := []byte(`test@#$%l(le[0]..prefix) 2uio`)
 	rowKey[8] = 0x00
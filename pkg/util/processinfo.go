@@ -130,6 +130,8 @@ func (pi *ProcessInfo) txnStartTs(tz *time.Location) (txnStart string) {
 func (pi *ProcessInfo) ToRow(tz *time.Location) []any {
 	bytesConsumed := int64(0)
 	diskConsumed := int64(0)
+	ru := float64(0)
+	throttled := false
 	if pi.StmtCtx != nil {
 		if pi.MemTracker != nil {
 			bytesConsumed = pi.MemTracker.BytesConsumed()
@@ -137,8 +139,13 @@ func (pi *ProcessInfo) ToRow(tz *time.Location) []any {
 		if pi.DiskTracker != nil {
 			diskConsumed = pi.DiskTracker.BytesConsumed()
 		}
+		if ruDetails := pi.StmtCtx.RUDetails; ruDetails != nil {
+			ru = ruDetails.RRU() + ruDetails.WRU()
+			// The statement is regarded as throttled once it waited for the RU tokens of its resource group.
+			throttled = ruDetails.RUWaitDuration() > 0
+		}
 	}
-	return append(pi.ToRowForShow(true), pi.Digest, bytesConsumed, diskConsumed, pi.txnStartTs(tz), pi.ResourceGroupName, pi.SessionAlias)
+	return append(pi.ToRowForShow(true), pi.Digest, bytesConsumed, diskConsumed, pi.txnStartTs(tz), pi.ResourceGroupName, pi.SessionAlias, ru, throttled)
 }
 
 // ascServerStatus is a slice of all defined server status in ascending order.
@@ -64,6 +64,9 @@ type ExecOption struct {
 	TrackSysProcID     uint64
 	IgnoreWarning      bool
 	UseCurSession      bool
+	// ExplicitRequestSourceType is the explicit request source type of the requests, it's used by the
+	// resource group to identify background tasks.
+	ExplicitRequestSourceType string
 }
 
 // OptionFuncAlias is defined for the optional parameter of ExecRestrictedStmt/SQL.
@@ -99,6 +102,13 @@ func GetAnalyzeSnapshotOption(analyzeSnapshot bool) OptionFuncAlias {
 	}
 }
 
+// GetExplicitRequestSourceTypeOption returns a function which tells ExecRestrictedStmt/SQL to run with the explicit request source type.
+func GetExplicitRequestSourceTypeOption(tp string) OptionFuncAlias {
+	return func(option *ExecOption) {
+		option.ExplicitRequestSourceType = tp
+	}
+}
+
 // ExecOptionUseCurSession tells ExecRestrictedStmt/SQL to use current session.
 var ExecOptionUseCurSession = func(option *ExecOption) {
 	option.UseCurSession = true
@@ -0,0 +1,171 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+const (
+	// NUMANodeRoot is the sysfs directory of the NUMA nodes.
+	NUMANodeRoot = "/sys/devices/system/node"
+	// BlockDeviceRoot is the sysfs directory of the block devices.
+	BlockDeviceRoot = "/sys/block"
+)
+
+// NUMANode is a NUMA node of the machine.
+type NUMANode struct {
+	ID int
+	// CPUList is the cpus of the node in the kernel list format, e.g. 0-15,32-47.
+	CPUList  string
+	CPUCount int
+	// MemTotal is the memory capacity of the node in bytes.
+	MemTotal uint64
+}
+
+// ReadNUMANodes reads the NUMA topology from root, which is usually
+// NUMANodeRoot. It returns nil if root doesn't exist, e.g. on the systems
+// other than Linux or the kernels built without NUMA support.
+func ReadNUMANodes(root string) ([]NUMANode, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Trace(err)
+	}
+	var nodes []NUMANode
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "node") {
+			continue
+		}
+		id, err := strconv.Atoi(name[len("node"):])
+		if err != nil {
+			continue
+		}
+		dir := filepath.Join(root, name)
+		cpuList, err := readTrimmedFile(filepath.Join(dir, "cpulist"))
+		if err != nil {
+			return nil, err
+		}
+		cpuCount, err := CPUListCount(cpuList)
+		if err != nil {
+			return nil, err
+		}
+		memTotal, err := readNodeMemTotal(filepath.Join(dir, "meminfo"))
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, NUMANode{ID: id, CPUList: cpuList, CPUCount: cpuCount, MemTotal: memTotal})
+	}
+	slices.SortFunc(nodes, func(a, b NUMANode) int { return a.ID - b.ID })
+	return nodes, nil
+}
+
+// CPUListCount returns the number of cpus in a list of the kernel list
+// format, e.g. 0-3,8 contains 5 cpus.
+func CPUListCount(list string) (int, error) {
+	var count int
+	if len(list) == 0 {
+		return 0, nil
+	}
+	for _, part := range strings.Split(list, ",") {
+		lo, hi, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(lo)
+		if err != nil {
+			return 0, errors.Errorf("invalid cpu list %q", list)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(hi); err != nil || end < start {
+				return 0, errors.Errorf("invalid cpu list %q", list)
+			}
+		}
+		count += end - start + 1
+	}
+	return count, nil
+}
+
+// readNodeMemTotal reads the MemTotal of a node meminfo file, whose lines
+// are like "Node 0 MemTotal:       32768 kB".
+func readNodeMemTotal(path string) (uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[2] != "MemTotal:" {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		return kb * 1024, nil
+	}
+	return 0, errors.Errorf("MemTotal is not found in %s", path)
+}
+
+// BlockQueue is the request queue attributes of a block device.
+type BlockQueue struct {
+	Rotational       bool
+	QueueDepth       uint64
+	LogicalBlockSize uint64
+	// MaxIOSize is the max size of a request in bytes.
+	MaxIOSize uint64
+}
+
+// ReadBlockQueue reads the queue attributes of the block device from root,
+// which is usually BlockDeviceRoot.
+func ReadBlockQueue(root, device string) (BlockQueue, error) {
+	dir := filepath.Join(root, device, "queue")
+	var (
+		q      BlockQueue
+		values [4]uint64
+	)
+	for i, name := range []string{"rotational", "nr_requests", "logical_block_size", "max_sectors_kb"} {
+		s, err := readTrimmedFile(filepath.Join(dir, name))
+		if err != nil {
+			return q, err
+		}
+		if values[i], err = strconv.ParseUint(s, 10, 64); err != nil {
+			return q, errors.Trace(err)
+		}
+	}
+	q.Rotational = values[0] == 1
+	q.QueueDepth = values[1]
+	q.LogicalBlockSize = values[2]
+	q.MaxIOSize = values[3] * 1024
+	return q, nil
+}
+
+func readTrimmedFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -14,6 +14,9 @@
 package linux_test
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/pingcap/tidb/pkg/util/sys/linux"
@@ -25,3 +28,62 @@ func TestGetOSVersion(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, osRelease)
 }
+
+func TestCPUListCount(t *testing.T) {
+	for list, expected := range map[string]int{
+		"":           0,
+		"0":          1,
+		"0-3,8":      5,
+		"0-15,32-47": 32,
+		"1,3,5,7-8":  5,
+	} {
+		count, err := CPUListCount(list)
+		require.NoError(t, err)
+		require.Equal(t, expected, count, list)
+	}
+	for _, list := range []string{"a", "3-1", "0-", "0,,1"} {
+		_, err := CPUListCount(list)
+		require.Error(t, err, list)
+	}
+}
+
+func TestReadNUMANodes(t *testing.T) {
+	root := t.TempDir()
+	nodes, err := ReadNUMANodes(filepath.Join(root, "not-exist"))
+	require.NoError(t, err)
+	require.Nil(t, nodes)
+
+	for id, cpus := range []string{"0-3,8-11", "4-7,12-15"} {
+		dir := filepath.Join(root, fmt.Sprintf("node%d", id))
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cpulist"), []byte(cpus+"\n"), 0o644))
+		meminfo := fmt.Sprintf("Node %d MemTotal:       %d kB\nNode %d MemFree:        1024 kB\n", id, (id+1)*1024, id)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "meminfo"), []byte(meminfo), 0o644))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "power"), 0o755))
+	nodes, err = ReadNUMANodes(root)
+	require.NoError(t, err)
+	require.Equal(t, []NUMANode{
+		{ID: 0, CPUList: "0-3,8-11", CPUCount: 8, MemTotal: 1024 * 1024},
+		{ID: 1, CPUList: "4-7,12-15", CPUCount: 8, MemTotal: 2048 * 1024},
+	}, nodes)
+}
+
+func TestReadBlockQueue(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "nvme0n1", "queue")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	for name, val := range map[string]string{
+		"rotational":         "0",
+		"nr_requests":        "1023",
+		"logical_block_size": "512",
+		"max_sectors_kb":     "128",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(val+"\n"), 0o644))
+	}
+	q, err := ReadBlockQueue(root, "nvme0n1")
+	require.NoError(t, err)
+	require.Equal(t, BlockQueue{QueueDepth: 1023, LogicalBlockSize: 512, MaxIOSize: 128 * 1024}, q)
+	_, err = ReadBlockQueue(root, "sda")
+	require.Error(t, err)
+}
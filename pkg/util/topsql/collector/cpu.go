@@ -32,6 +32,8 @@ import (
 const (
 	labelSQLDigest  = "sql_digest"
 	labelPlanDigest = "plan_digest"
+	// labelResourceGroup is the resource group of the SQL, it's set alongside the digests.
+	labelResourceGroup = "resource_group"
 )
 
 // Collector uses to collect SQL execution cpu time.
@@ -51,6 +53,19 @@ type SQLCPUTimeRecord struct {
 	CPUTimeMs  uint32
 }
 
+// ResourceGroupCollector uses to collect the cpu time of each resource group.
+// The Collector can implement it optionally.
+type ResourceGroupCollector interface {
+	// CollectResourceGroupCPU uses to collect the cpu time of each resource group.
+	CollectResourceGroupCPU(stats []ResourceGroupCPUTimeRecord)
+}
+
+// ResourceGroupCPUTimeRecord represents how much cpu time the SQLs of a resource group consume in one second.
+type ResourceGroupCPUTimeRecord struct {
+	ResourceGroupName string
+	CPUTimeMs         uint32
+}
+
 // SQLCPUCollector uses to consume cpu profile from globalCPUProfiler, then parse the SQL CPU usage from the cpu profile data.
 // It is not thread-safe, should only be used in one goroutine.
 type SQLCPUCollector struct {
@@ -139,6 +154,9 @@ func (sp *SQLCPUCollector) handleProfileData(data *cpuprofile.ProfileData) {
 	}
 	stats := sp.parseCPUProfileBySQLLabels(p)
 	sp.collector.Collect(stats)
+	if rc, ok := sp.collector.(ResourceGroupCollector); ok {
+		rc.CollectResourceGroupCPU(parseCPUProfileByResourceGroupLabel(p))
+	}
 }
 
 func (sp *SQLCPUCollector) doRegister(profileConsumer cpuprofile.ProfileConsumer) {
@@ -219,6 +237,27 @@ func (*SQLCPUCollector) createSQLStats(sqlMap map[string]*sqlStats) []SQLCPUTime
 	return stats
 }
 
+// parseCPUProfileByResourceGroupLabel uses to aggregate the cpu-profile sample data by resource_group label.
+// The resource_group label is set by `CtxWithResourceGroup` along with the sql_digest label, so the samples
+// without it are ignored.
+func parseCPUProfileByResourceGroupLabel(p *profile.Profile) []ResourceGroupCPUTimeRecord {
+	groupMap := make(map[string]int64)
+	idx := len(p.SampleType) - 1
+	for _, s := range p.Sample {
+		for _, group := range s.Label[labelResourceGroup] {
+			groupMap[group] += s.Value[idx]
+		}
+	}
+	stats := make([]ResourceGroupCPUTimeRecord, 0, len(groupMap))
+	for group, val := range groupMap {
+		stats = append(stats, ResourceGroupCPUTimeRecord{
+			ResourceGroupName: group,
+			CPUTimeMs:         uint32(time.Duration(val).Milliseconds()),
+		})
+	}
+	return stats
+}
+
 type sqlStats struct {
 	plans map[string]int64
 	total int64
@@ -277,3 +316,8 @@ func CtxWithSQLAndPlanDigest(ctx context.Context, sqlDigest, planDigest string)
 	return pprof.WithLabels(ctx, pprof.Labels(labelSQLDigest, sqlDigest,
 		labelPlanDigest, planDigest))
 }
+
+// CtxWithResourceGroup wrap the ctx with resource group name.
+func CtxWithResourceGroup(ctx context.Context, resourceGroupName string) context.Context {
+	return pprof.WithLabels(ctx, pprof.Labels(labelResourceGroup, resourceGroupName))
+}
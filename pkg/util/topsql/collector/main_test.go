@@ -16,9 +16,12 @@ package collector
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/pprof/profile"
 	"github.com/pingcap/tidb/pkg/testkit/testsetup"
 	"github.com/pingcap/tidb/pkg/util/cpuprofile"
 	"github.com/pingcap/tidb/pkg/util/cpuprofile/testutil"
@@ -101,6 +104,29 @@ func TestSQLStatsTune(t *testing.T) {
 	require.Equal(t, int64(40), s.plans[""])
 }
 
+func TestParseCPUProfileByResourceGroupLabel(t *testing.T) {
+	ms := int64(time.Millisecond)
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "samples"}, {Type: "cpu"}},
+		Sample: []*profile.Sample{
+			{Value: []int64{1, 10 * ms}, Label: map[string][]string{labelSQLDigest: {"s1"}, labelResourceGroup: {"rg1"}}},
+			{Value: []int64{1, 20 * ms}, Label: map[string][]string{labelSQLDigest: {"s2"}, labelResourceGroup: {"rg1"}}},
+			{Value: []int64{1, 30 * ms}, Label: map[string][]string{labelSQLDigest: {"s3"}, labelResourceGroup: {"rg2"}}},
+			// the samples without the resource group are ignored.
+			{Value: []int64{1, 40 * ms}, Label: map[string][]string{labelSQLDigest: {"s4"}}},
+			{Value: []int64{1, 50 * ms}},
+		},
+	}
+	stats := parseCPUProfileByResourceGroupLabel(p)
+	slices.SortFunc(stats, func(a, b ResourceGroupCPUTimeRecord) int {
+		return strings.Compare(a.ResourceGroupName, b.ResourceGroupName)
+	})
+	require.Equal(t, []ResourceGroupCPUTimeRecord{
+		{ResourceGroupName: "rg1", CPUTimeMs: 30},
+		{ResourceGroupName: "rg2", CPUTimeMs: 30},
+	}, stats)
+}
+
 type mockCollector struct {
 	dataCh chan []SQLCPUTimeRecord
 }
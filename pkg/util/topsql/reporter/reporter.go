@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/topsql/collector"
@@ -60,6 +61,7 @@ type TopSQLReporter interface {
 }
 
 var _ TopSQLReporter = &RemoteTopSQLReporter{}
+var _ collector.ResourceGroupCollector = &RemoteTopSQLReporter{}
 var _ DataSinkRegisterer = &RemoteTopSQLReporter{}
 
 // RemoteTopSQLReporter implements TopSQLReporter that sends data to a remote agent.
@@ -128,6 +130,16 @@ func (tsr *RemoteTopSQLReporter) Collect(data []collector.SQLCPUTimeRecord) {
 	}
 }
 
+// CollectResourceGroupCPU implements collector.ResourceGroupCollector.
+//
+// The cpu time of the resource groups is not a part of the protocol of the agent,
+// so it's exposed as metrics instead.
+func (*RemoteTopSQLReporter) CollectResourceGroupCPU(data []collector.ResourceGroupCPUTimeRecord) {
+	for _, r := range data {
+		metrics.TopSQLResourceGroupCPUCounter.WithLabelValues(r.ResourceGroupName).Add(float64(r.CPUTimeMs))
+	}
+}
+
 // CollectStmtStatsMap implements stmtstats.Collector.
 //
 // WARN: It will drop the DataRecords if the processing is not in time.
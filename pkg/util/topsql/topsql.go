@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/plancodec"
@@ -146,6 +147,25 @@ func AttachSQLAndPlanInfo(ctx context.Context, sqlDigest *parser.Digest, planDig
 	return ctx
 }
 
+// AttachResourceGroup attach the resource group of the SQL into Top SQL, so the cpu time can be attributed to
+// the resource group as well as the SQL digest.
+func AttachResourceGroup(ctx context.Context, resourceGroupName string) context.Context {
+	if len(resourceGroupName) == 0 {
+		return ctx
+	}
+	ctx = collector.CtxWithResourceGroup(ctx, resourceGroupName)
+	pprof.SetGoroutineLabels(ctx)
+	return ctx
+}
+
+// ObserveResourceGroupRU records the request units consumed by a SQL of the resource group.
+func ObserveResourceGroupRU(resourceGroupName string, ru float64) {
+	if len(resourceGroupName) == 0 || ru <= 0 {
+		return
+	}
+	metrics.TopSQLResourceGroupRUCounter.WithLabelValues(resourceGroupName).Add(ru)
+}
+
 // MockHighCPULoad mocks high cpu load, only use in failpoint test.
 func MockHighCPULoad(sql string, sqlPrefixs []string, load int64) bool {
 	lowerSQL := strings.ToLower(sql)